@@ -2,20 +2,40 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"sort"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"wingedapp/pgtester/internal/wingedapp/apprepo"
+	economyBiz "wingedapp/pgtester/internal/wingedapp/business/domain/economy"
 	"wingedapp/pgtester/internal/wingedapp/db"
+	"wingedapp/pgtester/internal/wingedapp/lib/agentlog"
+	agentlogStore "wingedapp/pgtester/internal/wingedapp/lib/agentlog/store"
 	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/chat"
+	chatStore "wingedapp/pgtester/internal/wingedapp/lib/chat/store"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy"
+	economyStore "wingedapp/pgtester/internal/wingedapp/lib/economy/store"
+	"wingedapp/pgtester/internal/wingedapp/lib/jobqueue"
+	jobqueueStore "wingedapp/pgtester/internal/wingedapp/lib/jobqueue/store"
 	"wingedapp/pgtester/internal/wingedapp/lib/matching"
 	"wingedapp/pgtester/internal/wingedapp/lib/matching/extmatcher"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching/localmatcher"
 	"wingedapp/pgtester/internal/wingedapp/lib/matching/store"
+	"wingedapp/pgtester/internal/wingedapp/sysparam"
 
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
 	"github.com/robfig/cron/v3"
 )
 
@@ -23,6 +43,15 @@ func main() {
 	// CLI flags for manual triggering
 	runDrop := flag.Bool("drop", false, "Run DropOneMatchPerUser once and exit")
 	runMatch := flag.Bool("match", false, "Run RunMatchForUnmatchedUsers once and exit")
+	runExpire := flag.Bool("expire", false, "Run ExpireStaleMatches once and exit")
+	runNudge := flag.Bool("nudge", false, "Run NudgeStaleChats once and exit")
+	dropSchedule := flag.Bool("drop-schedule", false, "Print the upcoming match drops per timezone and exit (dry run)")
+	simulate := flag.String("simulate", "", "Simulate the hard qualifiers against a proposed match config update from a JSON file, print a report and exit (dry run)")
+	simulateUsers := flag.String("simulate-users", "all", "Users to simulate: all, test or real")
+	reconcileWings := flag.Bool("reconcile-wings", false, "Report wings balances that drifted from their transaction ledger and exit")
+	repairWings := flag.Bool("repair-wings", false, "With -reconcile-wings, move drifted balances back onto their ledger")
+	replayRevenueCat := flag.String("replay-revenuecat", "", "Replay the stored RevenueCat events with this status (Received, Failed, Ignored, Processed or all) and exit")
+	replayEventID := flag.String("replay-event", "", "With -replay-revenuecat, replay only the RevenueCat event with this id")
 	populateCSV := flag.String("populate", "", "Populate test users from CSV file path")
 	depopulate := flag.Bool("depopulate", false, "Delete all test users (is_test_user=true)")
 	flag.Parse()
@@ -75,6 +104,12 @@ func main() {
 		log.Fatalf("connect to supabase auth db: %v", err)
 	}
 
+	qualifier, err := newQualitativeQuantifier(cfg, logger)
+	if err != nil {
+		log.Fatalf("create qualitative matcher: %v", err)
+	}
+	log.Printf("qualitative matcher: %s", cfg.QualitativeMatcher)
+
 	// Create matching logic with minimal dependencies
 	stores := store.NewMatchingStores(logger)
 	userDeleter := &apprepo.Store{}
@@ -84,7 +119,7 @@ func main() {
 		stores.UserDatingPrefsStore,
 		stores.MatchSetStore,
 		stores.MatchResultStore,
-		extmatcher.NewQualitativeMatcher(logger),
+		qualifier,
 		stores.ProfileStore,
 		stores.SupabaseStore,
 		stores.UserMatchActionsStore,
@@ -98,8 +133,39 @@ func main() {
 	if err != nil {
 		log.Fatalf("create matching logic: %v", err)
 	}
+	matchLogic.SetQualitativeCacher(stores.QualitativeCacheStore)
+	matchLogic.SetBatchWorkers(cfg.BatchWorkers)
+	matchLogic.SetQualitativeRateLimit(cfg.QualitativeQPS, cfg.QualitativeBurst)
+	if cfg.QualitativeQPS > 0 {
+		log.Printf("batch matching: %d workers, qualitative matcher limited to %.2f calls/s (burst %d)",
+			cfg.BatchWorkers, cfg.QualitativeQPS, cfg.QualitativeBurst)
+	} else {
+		log.Printf("batch matching: %d workers, qualitative matcher unlimited", cfg.BatchWorkers)
+	}
+
+	agentLogLogic, err := agentlog.NewLogic(agentlogStore.NewAgentLogStores(logger).AgentLogStore)
+	if err != nil {
+		log.Fatalf("create agent log logic: %v", err)
+	}
+	nudgeLogic, err := chat.NewNudgeLogic(chatStore.NewChatStores(logger).ChatNudgeStore, agentLogLogic)
+	if err != nil {
+		log.Fatalf("create chat nudge logic: %v", err)
+	}
+
+	wingsReconciler, err := economy.NewWingsReconciler(economyStore.NewEconomyStores(logger).UserTotalsStore)
+	if err != nil {
+		log.Fatalf("create wings reconciler: %v", err)
+	}
+
+	economyBusiness, err := newEconomyBusiness(cfg, logger, backendDB)
+	if err != nil {
+		log.Fatalf("create economy business: %v", err)
+	}
+
+	// SIGTERM stops new work, and lets the pairs and jobs in flight finish
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
 
-	ctx := context.Background()
 	dbExec := backendDB.DB()
 	aiExec := aiBackendDB.DB()
 	supabaseExec := supabaseDB.DB()
@@ -235,6 +301,77 @@ func main() {
 		return
 	}
 
+	if *dropSchedule {
+		now := time.Now()
+		buckets, err := matchLogic.DropSchedule(ctx, dbExec, now)
+		if err != nil {
+			log.Fatalf("error building drop schedule: %v", err)
+		}
+		log.Printf("drop schedule for the next 24h from %s:", now.UTC().Format(time.RFC3339))
+		for _, b := range buckets {
+			drops := make([]string, len(b.NextDrops))
+			for i, d := range b.NextDrops {
+				drops[i] = fmt.Sprintf("%s (%s UTC)", d.Format("Mon 15:04"), d.UTC().Format("15:04"))
+			}
+			log.Printf("  UTC%+d: %d users, drops at %s", b.UTCOffset, b.Users, strings.Join(drops, ", "))
+		}
+		return
+	}
+
+	if *simulate != "" {
+		params, err := simulateParams(*simulate, *simulateUsers)
+		if err != nil {
+			log.Fatalf("error reading simulation params: %v", err)
+		}
+		report, err := matchLogic.Simulate(ctx, dbExec, params)
+		if err != nil {
+			log.Fatalf("error simulating config: %v", err)
+		}
+		printSimulationReport(report)
+		return
+	}
+
+	if *reconcileWings {
+		log.Printf("manually triggering ReconcileWings (repair: %t)...", *repairWings)
+		report, err := reconcileWingsBalances(ctx, wingsReconciler, backendDB, *repairWings)
+		if err != nil {
+			log.Fatalf("error reconciling wings: %v", err)
+		}
+		printWingsReconciliation(report)
+		return
+	}
+
+	if *replayRevenueCat != "" {
+		log.Printf("manually replaying RevenueCat events (status: %s)...", *replayRevenueCat)
+		replay, err := economyBusiness.ReplayRevenueCatEvents(ctx, replayFilter(*replayRevenueCat, *replayEventID))
+		if err != nil {
+			log.Fatalf("error replaying revenuecat events: %v", err)
+		}
+		printRevenueCatReplay(replay)
+		return
+	}
+
+	if *runExpire {
+		log.Println("manually triggering ExpireStaleMatches...")
+		expired, err := matchLogic.ExpireStaleMatches(ctx, dbExec)
+		if err != nil {
+			log.Fatalf("error expiring matches: %v", err)
+		}
+		log.Printf("ExpireStaleMatches completed successfully, expired %d matches", len(expired))
+		return
+	}
+
+	if *runNudge {
+		log.Println("manually triggering NudgeStaleChats...")
+		summary, err := nudgeStaleChats(ctx, matchLogic, nudgeLogic, backendDB)
+		if err != nil {
+			log.Fatalf("error nudging stale chats: %v", err)
+		}
+		log.Printf("NudgeStaleChats completed successfully, %d stale chats, %d nudged, %d offered agent setup",
+			summary.StaleChats, summary.Nudged, summary.AgentSetupOffered)
+		return
+	}
+
 	if *runMatch {
 		log.Println("=== MATCH MODE START ===")
 		log.Println("step 1: calling RunMatchForUnmatchedUsers...")
@@ -250,47 +387,146 @@ func main() {
 		log.Printf("step 1 done: created match set %s", matchSet.ID)
 
 		log.Println("step 2: calling RunIngestionSet to process matches...")
-		if err := matchLogic.RunIngestionSet(ctx, dbExec, aiExec, matchSet.ID); err != nil {
+		summary, err := matchLogic.RunIngestionSet(ctx, dbExec, aiExec, matchSet.ID)
+		if err != nil && !errors.Is(err, matching.ErrIngestionIncomplete) {
 			log.Fatalf("error running ingestion set: %v", err)
 		}
-		log.Println("step 2 done: RunIngestionSet completed")
+		log.Printf("step 2 done: RunIngestionSet run %s %s, %d pairs, %d processed, %d failed, %d skipped, qualitative cache %d hits / %d misses",
+			summary.RunID, summary.Status, summary.Pairs, summary.Processed, summary.Failed, summary.Skipped,
+			summary.QualitativeCacheHits, summary.QualitativeCacheMisses)
+		if err != nil {
+			log.Printf("WARNING: %v (rerun the batch match job for set %s to retry them)", err, matchSet.ID)
+		}
 		log.Println("=== MATCH MODE END ===")
 		return
 	}
 
-	// Daemon mode - start cron jobs
-	if err := startMatchingCrons(matchLogic, backendDB, aiBackendDB); err != nil {
+	// Daemon mode - start the job worker and cron jobs
+	queue, err := newJobQueue(logger, matchLogic, dbExec, aiExec)
+	if err != nil {
+		log.Fatalf("create job queue: %v", err)
+	}
+	workerDone := make(chan struct{})
+	go func() {
+		defer close(workerDone)
+		if err := queue.Work(ctx, dbExec, jobqueue.DefaultPollInterval); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("job worker stopped: %v", err)
+		}
+	}()
+
+	crons, err := startMatchingCrons(ctx, matchLogic, nudgeLogic, wingsReconciler, queue, backendDB)
+	if err != nil {
 		log.Fatalf("start matching crons: %v", err)
 	}
 
 	log.Println("matching runner started")
-	select {} // block forever
+	<-ctx.Done()
+
+	log.Println("shutting down, draining in-flight work...")
+	<-crons.Stop().Done()
+	<-workerDone
+	log.Println("matching runner stopped")
 }
 
-func startMatchingCrons(matchLogic *matching.Logic, backendDB, aiBackendDB *db.Transactor) error {
+// newJobQueue creates the job queue, with handlers for the jobs this runner processes.
+func newJobQueue(logger applog.Logger, matchLogic *matching.Logic, dbExec, aiExec boil.ContextExecutor) (*jobqueue.Logic, error) {
+	stores := jobqueueStore.NewJobQueueStores(logger)
+	queue, err := jobqueue.NewLogic(logger, stores.JobStore)
+	if err != nil {
+		return nil, fmt.Errorf("new job queue logic: %w", err)
+	}
+
+	if err = jobqueue.Register(queue, jobqueue.JobTypeBatchMatch,
+		func(ctx context.Context, p jobqueue.BatchMatchPayload) (any, error) {
+			matchSetID, err := uuid.Parse(p.MatchSetID)
+			if err != nil {
+				return nil, fmt.Errorf("parse match set id: %w", err)
+			}
+			summary, err := matchLogic.RunIngestionSet(ctx, dbExec, aiExec, matchSetID)
+			if err != nil {
+				return nil, fmt.Errorf("run ingestion set: %w", err)
+			}
+			return summary, nil
+		},
+	); err != nil {
+		return nil, fmt.Errorf("register %s handler: %w", jobqueue.JobTypeBatchMatch, err)
+	}
+
+	return queue, nil
+}
+
+// startMatchingCrons schedules the matching crons, and returns the started
+// scheduler, for the caller to stop. Runs in flight are cancelled with ctx.
+func startMatchingCrons(
+	ctx context.Context,
+	matchLogic *matching.Logic,
+	nudgeLogic *chat.NudgeLogic,
+	wingsReconciler *economy.WingsReconciler,
+	queue *jobqueue.Logic,
+	backendDB *db.Transactor,
+) (*cron.Cron, error) {
 	c := cron.New()
-	ctx := context.Background()
 	dbExec := backendDB.DB()
-	aiExec := aiBackendDB.DB()
 
 	// Load match config from DB
 	matchCfg, err := matchLogic.MatchConfig(ctx, dbExec, &matching.QueryFilterMatchConfig{})
 	if err != nil {
-		return fmt.Errorf("load match config: %v", err)
+		return nil, fmt.Errorf("load match config: %v", err)
 	}
 
-	// Match drops - drop one match per user at configured hours
-	for _, dropHour := range matchCfg.DropHours {
-		hour := dropHour
-		cronExpr := fmt.Sprintf("0 %v * * *", hour)
-		_, _ = c.AddFunc(cronExpr, func() {
-			log.Printf("running match drops for hour %s", hour)
-			if err := matchLogic.DropOneMatchPerUser(ctx, dbExec); err != nil {
-				log.Printf("error dropping matches: %v", err)
-			}
-		})
-		log.Printf("scheduled match drops at hour %s", hour)
-	}
+	// Match drops - every minute, drop for the timezones at a configured local hour
+	_, _ = c.AddFunc("* * * * *", func() {
+		buckets, err := matchLogic.RunDueDrops(ctx, dbExec, time.Now())
+		if err != nil {
+			log.Printf("error dropping matches: %v", err)
+			return
+		}
+		if len(buckets) > 0 {
+			log.Printf("ran match drops for UTC offsets %v", buckets)
+		}
+	})
+	log.Printf("scheduled match drops at local hours %v", matchCfg.DropHours)
+
+	// Expire dropped matches left undecided - hourly
+	_, _ = c.AddFunc("0 * * * *", func() {
+		expired, err := matchLogic.ExpireStaleMatches(ctx, dbExec)
+		if err != nil {
+			log.Printf("error expiring matches: %v", err)
+			return
+		}
+		if len(expired) > 0 {
+			log.Printf("expired %d stale matches", len(expired))
+		}
+	})
+	log.Println("scheduled stale match expiry hourly")
+
+	// Nudge quiet match chats - every 15 minutes
+	_, _ = c.AddFunc("*/15 * * * *", func() {
+		summary, err := nudgeStaleChats(ctx, matchLogic, nudgeLogic, backendDB)
+		if err != nil {
+			log.Printf("error nudging stale chats: %v", err)
+			return
+		}
+		if summary.Nudged+summary.AgentSetupOffered > 0 {
+			log.Printf("nudged %d quiet chat users, offered agent setup to %d",
+				summary.Nudged, summary.AgentSetupOffered)
+		}
+	})
+	log.Printf("scheduled stale chat nudges after %dh, agent setup after %dh",
+		matchCfg.StaleChatNudge, matchCfg.StaleChatAgentSetup)
+
+	// Report wings balances drifted from their ledger - daily, repairs are manual
+	_, _ = c.AddFunc("30 3 * * *", func() {
+		report, err := reconcileWingsBalances(ctx, wingsReconciler, backendDB, false)
+		if err != nil {
+			log.Printf("error reconciling wings: %v", err)
+			return
+		}
+		if len(report.Drifted) > 0 {
+			printWingsReconciliation(report)
+		}
+	})
+	log.Println("scheduled wings reconciliation report daily at 03:30")
 
 	// Run matching for unmatched users - daily
 	matchHourExpr := fmt.Sprintf("0 %v * * *", matchCfg.MatchExpirationHours)
@@ -305,17 +541,240 @@ func startMatchingCrons(matchLogic *matching.Logic, backendDB, aiBackendDB *db.T
 			log.Println("no match set created (not enough users or no new pairs)")
 			return
 		}
-		log.Printf("created match set: %s, running matching algorithm...", matchSet.ID)
-		if err := matchLogic.RunIngestionSet(ctx, dbExec, aiExec, matchSet.ID); err != nil {
-			log.Printf("error running ingestion set: %v", err)
+		job, err := queue.EnqueueBatchMatch(ctx, dbExec, matchSet.ID.String(), nil)
+		if err != nil {
+			log.Printf("error enqueueing batch match: %v", err)
 			return
 		}
-		log.Printf("match set %s processed successfully", matchSet.ID)
+		log.Printf("created match set: %s, enqueued batch match job %s", matchSet.ID, job.ID)
 	})
 	log.Printf("scheduled unmatched users matching at hour %s", matchCfg.MatchExpirationHours)
 
 	c.Start()
-	return nil
+	return c, nil
+}
+
+// nudgeStaleChats nudges quiet chats with the current match config's
+// windows, in one transaction.
+func nudgeStaleChats(
+	ctx context.Context,
+	matchLogic *matching.Logic,
+	nudgeLogic *chat.NudgeLogic,
+	backendDB *db.Transactor,
+) (*chat.NudgeStaleChatsSummary, error) {
+	matchCfg, err := matchLogic.MatchConfig(ctx, backendDB.DB(), &matching.QueryFilterMatchConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("load match config: %w", err)
+	}
+
+	tx, err := backendDB.TX()
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer backendDB.Rollback(tx)
+
+	summary, err := nudgeLogic.NudgeStaleChats(ctx, tx, &chat.NudgeStaleChatsParams{
+		NudgeAfter:      time.Duration(matchCfg.StaleChatNudge) * time.Hour,
+		AgentSetupAfter: time.Duration(matchCfg.StaleChatAgentSetup) * time.Hour,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("nudge stale chats: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return summary, nil
+}
+
+// reconcileWingsBalances reconciles wings balances with their ledger, in one
+// transaction when repairing.
+func reconcileWingsBalances(
+	ctx context.Context,
+	reconciler *economy.WingsReconciler,
+	backendDB *db.Transactor,
+	repair bool,
+) (*economy.WingsReconciliation, error) {
+	params := &economy.ReconcileWingsParams{Repair: repair}
+	if !repair {
+		return reconciler.ReconcileWings(ctx, backendDB.DB(), params)
+	}
+
+	tx, err := backendDB.TX()
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer backendDB.Rollback(tx)
+
+	report, err := reconciler.ReconcileWings(ctx, tx, params)
+	if err != nil {
+		return nil, fmt.Errorf("reconcile wings: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return report, nil
+}
+
+func printWingsReconciliation(r *economy.WingsReconciliation) {
+	log.Printf("=== WINGS RECONCILIATION: %d users, %d drifted, %d repaired ===",
+		r.Users, len(r.Drifted), r.Repaired)
+	for _, b := range r.Drifted {
+		log.Printf("  user %s: balance %d, ledger %d, drift %+d", b.UserID, b.Wings, b.LedgerWings, b.Drift())
+	}
+}
+
+// newEconomyBusiness creates the economy business, for replaying RevenueCat events.
+func newEconomyBusiness(cfg *Config, logger applog.Logger, backendDB *db.Transactor) (*economyBiz.Business, error) {
+	if cfg.RevenueCatWebhookSecret == "" {
+		return nil, fmt.Errorf("REVENUECAT_WEBHOOK_SECRET is required")
+	}
+
+	stores := economyStore.NewEconomyStores(logger)
+
+	actionLogger, err := economy.NewActionLogger(
+		logger,
+		&noopSettingGetter{}, // not needed for payments
+		stores.MessageStore,
+		stores.UserTotalsStore,
+		stores.ActionLogStore,
+		stores.SubscriptionStore,
+		stores.TransactionStore,
+		stores.InviteCodeStore,
+		stores.UserStore,
+		stores.ActionPriceStore,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create action logger: %w", err)
+	}
+
+	checkinLogic, err := economy.NewDailyCheckinLogic(logger, stores.UserTotalsStore, stores.ActionLogStore, stores.TransactionStore)
+	if err != nil {
+		return nil, fmt.Errorf("create daily checkin logic: %w", err)
+	}
+
+	eventLog, err := economy.NewRevenueCatEventLog(stores.RevenueCatEventStore)
+	if err != nil {
+		return nil, fmt.Errorf("create revenuecat event log: %w", err)
+	}
+
+	b, err := economyBiz.NewBusiness(backendDB, checkinLogic, actionLogger, eventLog)
+	if err != nil {
+		return nil, err
+	}
+	b.SetRevenueCatWebhookSecret(cfg.RevenueCatWebhookSecret)
+	b.SetProcessSandboxEvents(cfg.ProcessSandboxEvents)
+
+	return b, nil
+}
+
+// replayFilter picks the stored RevenueCat events to replay.
+func replayFilter(status, eventID string) *economy.QueryFilterRevenueCatEvent {
+	f := &economy.QueryFilterRevenueCatEvent{}
+	if status != "all" {
+		f.Status = null.StringFrom(status)
+	}
+	if eventID != "" {
+		f.EventID = null.StringFrom(eventID)
+	}
+	return f
+}
+
+func printRevenueCatReplay(r *economyBiz.ReplayRevenueCatEventsResponse) {
+	log.Printf("=== REVENUECAT REPLAY: %d events, %d processed, %d ignored, %d failed ===",
+		r.Events, r.Processed, r.Ignored, r.Failed)
+	for _, res := range r.Results {
+		switch {
+		case res.Error != "":
+			log.Printf("  event %s: failed: %s", res.EventID, res.Error)
+		case res.Reason != "":
+			log.Printf("  event %s: %s (%s)", res.EventID, res.Action, res.Reason)
+		default:
+			log.Printf("  event %s: %s", res.EventID, res.Action)
+		}
+	}
+}
+
+// simulateParams reads the proposed match config update from a JSON file,
+// e.g. {"location_radius_km": 150}, and picks the users to simulate.
+func simulateParams(path, users string) (*matching.SimulateParams, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var update matching.UpdateMatchConfig
+	if err = json.Unmarshal(b, &update); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	filter := &matching.QueryFilterUser{}
+	switch users {
+	case "all":
+	case "test":
+		filter.IsTestUser = null.BoolFrom(true)
+	case "real":
+		filter.IsTestUser = null.BoolFrom(false)
+	default:
+		return nil, fmt.Errorf("unknown -simulate-users %q, want all, test or real", users)
+	}
+
+	return &matching.SimulateParams{Config: &update, Users: filter}, nil
+}
+
+func printSimulationReport(r *matching.SimulationReport) {
+	log.Printf("=== SIMULATION: %d users, %d pairs ===", r.Users, r.Pairs)
+
+	log.Printf("config changes:")
+	if len(r.Changes) == 0 {
+		log.Printf("  none, the proposed config is the current one")
+	}
+	for _, c := range r.Changes {
+		log.Printf("  %s: %v -> %v", c.Field, c.From, c.To)
+	}
+
+	log.Printf("pairs passing all hard qualifiers: %d current, %d proposed (%d newly passing, %d newly failing)",
+		r.Current.Passed, r.Proposed.Passed, r.NewlyPassing, r.NewlyFailing)
+
+	names := make([]string, 0, len(r.Proposed.Qualifiers))
+	for name := range r.Current.Qualifiers {
+		names = append(names, string(name))
+	}
+	for name := range r.Proposed.Qualifiers {
+		if _, ok := r.Current.Qualifiers[name]; !ok {
+			names = append(names, string(name))
+		}
+	}
+	sort.Strings(names)
+
+	log.Printf("per qualifier, passed/failed:")
+	for _, name := range names {
+		current, proposed := "disabled", "disabled"
+		if q, ok := r.Current.Qualifiers[matching.QualifierType(name)]; ok {
+			current = fmt.Sprintf("%d/%d", q.Passed, q.Failed)
+		}
+		if q, ok := r.Proposed.Qualifiers[matching.QualifierType(name)]; ok {
+			proposed = fmt.Sprintf("%d/%d", q.Passed, q.Failed)
+		}
+		log.Printf("  %s: %s current, %s proposed", name, current, proposed)
+	}
+
+	printDistribution("distance (km)", &r.DistancesKM)
+	printDistribution("age gap (years)", &r.AgeGaps)
+}
+
+func printDistribution(name string, d *matching.Distribution) {
+	log.Printf("%s over %d pairs: min %.1f, mean %.1f, max %.1f", name, d.Pairs, d.Min, d.Mean, d.Max)
+	for _, b := range d.Buckets {
+		to := "+"
+		if b.To.Valid {
+			to = fmt.Sprintf("-%g", b.To.Float64)
+		}
+		log.Printf("  %g%s: %d", b.From, to, b.Pairs)
+	}
 }
 
 // Config for the matching runner
@@ -328,6 +787,26 @@ type Config struct {
 	DBSchema         string
 	DBSchemaAI       string
 	DBSchemaSupabase string
+
+	// QualitativeMatcher picks the qualitative matcher: "ext" calls the
+	// AI backend, "local" scores profiles offline.
+	QualitativeMatcher string
+	// ExtMatcher configures the AI backend, used when QualitativeMatcher is "ext".
+	ExtMatcher extmatcher.QualitativeMatcherCfg
+
+	// BatchWorkers is how many pairs a batch match processes concurrently.
+	BatchWorkers int
+	// QualitativeQPS limits calls to the qualitative matcher per second,
+	// in bursts of up to QualitativeBurst. Zero or less is unlimited.
+	QualitativeQPS   float64
+	QualitativeBurst int
+
+	// ProcessSandboxEvents lets RevenueCat sandbox events move wings and
+	// premium access when replayed, e.g. on a staging database.
+	ProcessSandboxEvents bool
+	// RevenueCatWebhookSecret is the secret RevenueCat sends in the
+	// Authorization header of its webhooks. Required.
+	RevenueCatWebhookSecret string
 }
 
 func loadConfig() *Config {
@@ -344,6 +823,51 @@ func loadConfig() *Config {
 		DBSchema:         getEnv("DB_SCHEMA", "backend_app"),
 		DBSchemaAI:       getEnv("DB_SCHEMA_AI", "ai_backend"),
 		DBSchemaSupabase: getEnv("DB_SCHEMA_SUPABASE", "auth"),
+
+		QualitativeMatcher: getEnv("QUALITATIVE_MATCHER", qualitativeMatcherExt),
+		ExtMatcher: extmatcher.QualitativeMatcherCfg{
+			BaseURL:                 getEnv("EXTMATCHER_BASE_URL", ""),
+			APIKey:                  getEnv("EXTMATCHER_API_KEY", ""),
+			Path:                    getEnv("EXTMATCHER_PATH", ""),
+			RequestTimeoutDuration:  getEnvInt("EXTMATCHER_REQUEST_TIMEOUT_DURATION", 120),
+			BreakerThreshold:        getEnvInt("EXTMATCHER_BREAKER_THRESHOLD", 5),
+			BreakerCooldownDuration: getEnvInt("EXTMATCHER_BREAKER_COOLDOWN_DURATION", 60),
+			HttpClientCfg: extmatcher.HttpClientCfg{
+				GeneralTimeoutDuration: getEnvInt("CLIENT_GENERAL_TIMEOUT_DURATION", 120),
+				ReadTimeoutDuration:    getEnvInt("CLIENT_READ_TIMEOUT_DURATION", 120),
+				RetryDuration:          getEnvInt("CLIENT_RETRY_TIMEOUT_DURATION", 60),
+				MaxRetries:             getEnvInt("CLIENT_MAX_RETRIES", 3),
+			},
+		},
+
+		BatchWorkers:     getEnvInt("MATCH_BATCH_WORKERS", 50),
+		QualitativeQPS:   getEnvFloat("MATCH_QUALITATIVE_QPS", 0),
+		QualitativeBurst: getEnvInt("MATCH_QUALITATIVE_BURST", 10),
+
+		ProcessSandboxEvents:    getEnv("REVENUECAT_PROCESS_SANDBOX", "false") == "true",
+		RevenueCatWebhookSecret: getEnv("REVENUECAT_WEBHOOK_SECRET", ""),
+	}
+}
+
+const (
+	qualitativeMatcherExt   = "ext"
+	qualitativeMatcherLocal = "local"
+)
+
+// qualitativeQuantifier mirrors the matching lib's qualitative matcher adapter.
+type qualitativeQuantifier interface {
+	Qualify(ctx context.Context, req *matching.QualitativeMatchRequest) (*matching.MatchCompatibilityResult, error)
+}
+
+func newQualitativeQuantifier(cfg *Config, logger applog.Logger) (qualitativeQuantifier, error) {
+	switch kind := cfg.QualitativeMatcher; kind {
+	case qualitativeMatcherExt:
+		return extmatcher.NewQualitativeMatcher(&cfg.ExtMatcher, logger)
+	case qualitativeMatcherLocal:
+		return localmatcher.NewQualitativeMatcher(), nil
+	default:
+		return nil, fmt.Errorf("unknown QUALITATIVE_MATCHER %q, want %q or %q",
+			kind, qualitativeMatcherExt, qualitativeMatcherLocal)
 	}
 }
 
@@ -354,6 +878,20 @@ func getEnv(key, defaultVal string) string {
 	return defaultVal
 }
 
+func getEnvInt(key string, defaultVal int) int {
+	if val, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return val
+	}
+	return defaultVal
+}
+
+func getEnvFloat(key string, defaultVal float64) float64 {
+	if val, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return val
+	}
+	return defaultVal
+}
+
 func loadDotEnv() {
 	data, err := os.ReadFile(".env")
 	if err != nil {
@@ -382,3 +920,10 @@ type noopPublicURLer struct{}
 func (n *noopPublicURLer) PublicURL(_ context.Context, key string) (string, error) {
 	return key, nil // just return the key as-is
 }
+
+// noopSettingGetter is a no-op implementation for the economy settingGetter interface
+type noopSettingGetter struct{}
+
+func (n *noopSettingGetter) Settings(_ context.Context) (*sysparam.Settings, error) {
+	return &sysparam.Settings{}, nil
+}
@@ -2,13 +2,18 @@ package economy
 
 import (
 	"context"
+	"time"
 
 	economyLib "wingedapp/pgtester/internal/wingedapp/lib/economy"
 
 	"github.com/aarondl/sqlboiler/v4/boil"
 )
 
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
 // transactor is an interface for handling transactions.
+//
+//counterfeiter:generate . transactor
 type transactor interface {
 	TX() (boil.ContextTransactor, error)
 	Rollback(boil.ContextTransactor)
@@ -16,12 +21,32 @@ type transactor interface {
 }
 
 // checkinPerformer handles daily check-in operations.
+//
+//counterfeiter:generate . checkinPerformer
 type checkinPerformer interface {
 	PerformCheckin(ctx context.Context, exec boil.ContextExecutor, userID string) (*economyLib.CheckinResult, error)
 	GetStatus(ctx context.Context, exec boil.ContextExecutor, userID string) (*economyLib.CheckinStatus, error)
 }
 
-// actionLogger handles action log creation (payments, referrals, etc).
+// actionLogger handles action log creation (payments, referrals, etc),
+// the subscription lifecycle after a payment, and what it entitles to.
+//
+//counterfeiter:generate . actionLogger
 type actionLogger interface {
 	CreateActionLog(ctx context.Context, exec boil.ContextExecutor, inserter *economyLib.InsertActionLog) error
+	SetPremiumExpiry(ctx context.Context, exec boil.ContextExecutor, userID string, expiresAt, at time.Time) (bool, error)
+	TransferPremium(ctx context.Context, exec boil.ContextExecutor, fromUserIDs, toUserIDs []string, at time.Time) error
+	VoidPayment(ctx context.Context, exec boil.ContextExecutor, refID string) (int, error)
+	SubscriptionHistory(ctx context.Context, exec boil.ContextExecutor, userID string) ([]economyLib.SubscriptionPeriod, error)
+	Entitlement(ctx context.Context, exec boil.ContextExecutor, userID string) (*economyLib.Entitlement, error)
+}
+
+// revenueCatEventLogger stores RevenueCat webhooks, for audit and replay.
+//
+//counterfeiter:generate . revenueCatEventLogger
+type revenueCatEventLogger interface {
+	RecordEvent(ctx context.Context, exec boil.ContextExecutor, inserter *economyLib.InsertRevenueCatEvent) (*economyLib.RevenueCatEvent, error)
+	Events(ctx context.Context, exec boil.ContextExecutor, f *economyLib.QueryFilterRevenueCatEvent) ([]economyLib.RevenueCatEvent, error)
+	Event(ctx context.Context, exec boil.ContextExecutor, f *economyLib.QueryFilterRevenueCatEvent) (*economyLib.RevenueCatEvent, error)
+	MarkEvent(ctx context.Context, exec boil.ContextExecutor, updater *economyLib.UpdateRevenueCatEvent) error
 }
@@ -4,20 +4,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	economyLib "wingedapp/pgtester/internal/wingedapp/lib/economy"
 )
 
 type Business struct {
-	transactor       transactor
-	checkinPerformer checkinPerformer
-	actionLogger     actionLogger
+	transactor         transactor
+	checkinPerformer   checkinPerformer
+	actionLogger       actionLogger
+	revenueCatEventLog revenueCatEventLogger
+
+	revenueCatWebhookSecret string
+	revenueCatEventMaxAge   time.Duration
+	processSandboxEvents    bool
 }
 
 func NewBusiness(
 	transactor transactor,
 	checkinPerformer checkinPerformer,
 	actionLogger actionLogger,
+	revenueCatEventLog revenueCatEventLogger,
 ) (*Business, error) {
 	if transactor == nil {
 		return nil, errors.New("transactor is required")
@@ -28,14 +35,38 @@ func NewBusiness(
 	if actionLogger == nil {
 		return nil, errors.New("actionLogger is required")
 	}
+	if revenueCatEventLog == nil {
+		return nil, errors.New("revenueCatEventLog is required")
+	}
 
 	return &Business{
-		transactor:       transactor,
-		checkinPerformer: checkinPerformer,
-		actionLogger:     actionLogger,
+		transactor:         transactor,
+		checkinPerformer:   checkinPerformer,
+		actionLogger:       actionLogger,
+		revenueCatEventLog: revenueCatEventLog,
+
+		revenueCatEventMaxAge: DefaultRevenueCatEventMaxAge,
 	}, nil
 }
 
+// SetRevenueCatWebhookSecret sets the secret RevenueCat sends in the
+// Authorization header of its webhooks. Webhooks are refused until it's set.
+func (b *Business) SetRevenueCatWebhookSecret(secret string) {
+	b.revenueCatWebhookSecret = secret
+}
+
+// SetRevenueCatEventMaxAge sets how old a delivered event may be, older ones
+// are stored and ignored. Defaults to DefaultRevenueCatEventMaxAge.
+func (b *Business) SetRevenueCatEventMaxAge(maxAge time.Duration) {
+	b.revenueCatEventMaxAge = maxAge
+}
+
+// SetProcessSandboxEvents sets whether RevenueCat sandbox events move wings
+// and premium access. They're stored, and ignored, otherwise.
+func (b *Business) SetProcessSandboxEvents(process bool) {
+	b.processSandboxEvents = process
+}
+
 // DailyCheckin performs a daily check-in for the user.
 // Per spec: Check-in is a UI action only. No wings granted directly.
 // Wings are awarded ONLY at streak milestones (7-day: +2, 30-day: +6).
@@ -98,58 +129,3 @@ func (b *Business) GetCheckinStatus(ctx context.Context, userID string) (*Checki
 		MilestoneWings:    status.MilestoneWings,
 	}, nil
 }
-
-// ProcessRevenueCatEvent processes a RevenueCat webhook event.
-// Uses existing ActionLogger.CreateActionLog flow - no duplicate logic.
-func (b *Business) ProcessRevenueCatEvent(ctx context.Context, req *RevenueCatWebhookRequest) (*RevenueCatWebhookResponse, error) {
-	event := req.Event
-
-	// Only handle purchase/renewal events
-	if event.Type != "INITIAL_PURCHASE" && event.Type != "RENEWAL" {
-		return &RevenueCatWebhookResponse{
-			Success: true,
-			EventID: event.ID,
-			Action:  "ignored",
-			Reason:  "event_type_not_handled",
-		}, nil
-	}
-
-	// Map product_id to action type
-	actionType, ok := economyLib.ProductIDToActionType[event.ProductID]
-	if !ok {
-		return &RevenueCatWebhookResponse{
-			Success: true,
-			EventID: event.ID,
-			Action:  "ignored",
-			Reason:  "unknown_product_id",
-		}, nil
-	}
-
-	// Process payment using existing CreateActionLog flow
-	// Idempotency is handled inside the handler - duplicate RefID is a no-op
-	tx, err := b.transactor.TX()
-	if err != nil {
-		return nil, fmt.Errorf("begin tx: %w", err)
-	}
-	defer b.transactor.Rollback(tx)
-
-	// Create action log - handles action log → transaction → user totals
-	// Handler has built-in idempotency (checks RefID, returns nil if exists)
-	if err := b.actionLogger.CreateActionLog(ctx, tx, &economyLib.InsertActionLog{
-		UserID: event.AppUserID,
-		RefID:  event.ID, // webhook event ID for idempotency
-		Type:   actionType,
-	}); err != nil {
-		return nil, fmt.Errorf("create action log: %w", err)
-	}
-
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("commit tx: %w", err)
-	}
-
-	return &RevenueCatWebhookResponse{
-		Success: true,
-		EventID: event.ID,
-		Action:  "processed",
-	}, nil
-}
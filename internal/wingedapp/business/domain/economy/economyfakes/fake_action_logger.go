@@ -0,0 +1,532 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package economyfakes
+
+import (
+	"context"
+	"sync"
+	"time"
+	economya "wingedapp/pgtester/internal/wingedapp/lib/economy"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+type FakeActionLogger struct {
+	CreateActionLogStub        func(context.Context, boil.ContextExecutor, *economya.InsertActionLog) error
+	createActionLogMutex       sync.RWMutex
+	createActionLogArgsForCall []struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 *economya.InsertActionLog
+	}
+	createActionLogReturns struct {
+		result1 error
+	}
+	createActionLogReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EntitlementStub        func(context.Context, boil.ContextExecutor, string) (*economya.Entitlement, error)
+	entitlementMutex       sync.RWMutex
+	entitlementArgsForCall []struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 string
+	}
+	entitlementReturns struct {
+		result1 *economya.Entitlement
+		result2 error
+	}
+	entitlementReturnsOnCall map[int]struct {
+		result1 *economya.Entitlement
+		result2 error
+	}
+	SetPremiumExpiryStub        func(context.Context, boil.ContextExecutor, string, time.Time, time.Time) (bool, error)
+	setPremiumExpiryMutex       sync.RWMutex
+	setPremiumExpiryArgsForCall []struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 string
+		arg4 time.Time
+		arg5 time.Time
+	}
+	setPremiumExpiryReturns struct {
+		result1 bool
+		result2 error
+	}
+	setPremiumExpiryReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	SubscriptionHistoryStub        func(context.Context, boil.ContextExecutor, string) ([]economya.SubscriptionPeriod, error)
+	subscriptionHistoryMutex       sync.RWMutex
+	subscriptionHistoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 string
+	}
+	subscriptionHistoryReturns struct {
+		result1 []economya.SubscriptionPeriod
+		result2 error
+	}
+	subscriptionHistoryReturnsOnCall map[int]struct {
+		result1 []economya.SubscriptionPeriod
+		result2 error
+	}
+	TransferPremiumStub        func(context.Context, boil.ContextExecutor, []string, []string, time.Time) error
+	transferPremiumMutex       sync.RWMutex
+	transferPremiumArgsForCall []struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 []string
+		arg4 []string
+		arg5 time.Time
+	}
+	transferPremiumReturns struct {
+		result1 error
+	}
+	transferPremiumReturnsOnCall map[int]struct {
+		result1 error
+	}
+	VoidPaymentStub        func(context.Context, boil.ContextExecutor, string) (int, error)
+	voidPaymentMutex       sync.RWMutex
+	voidPaymentArgsForCall []struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 string
+	}
+	voidPaymentReturns struct {
+		result1 int
+		result2 error
+	}
+	voidPaymentReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeActionLogger) CreateActionLog(arg1 context.Context, arg2 boil.ContextExecutor, arg3 *economya.InsertActionLog) error {
+	fake.createActionLogMutex.Lock()
+	ret, specificReturn := fake.createActionLogReturnsOnCall[len(fake.createActionLogArgsForCall)]
+	fake.createActionLogArgsForCall = append(fake.createActionLogArgsForCall, struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 *economya.InsertActionLog
+	}{arg1, arg2, arg3})
+	stub := fake.CreateActionLogStub
+	fakeReturns := fake.createActionLogReturns
+	fake.recordInvocation("CreateActionLog", []interface{}{arg1, arg2, arg3})
+	fake.createActionLogMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeActionLogger) CreateActionLogCallCount() int {
+	fake.createActionLogMutex.RLock()
+	defer fake.createActionLogMutex.RUnlock()
+	return len(fake.createActionLogArgsForCall)
+}
+
+func (fake *FakeActionLogger) CreateActionLogCalls(stub func(context.Context, boil.ContextExecutor, *economya.InsertActionLog) error) {
+	fake.createActionLogMutex.Lock()
+	defer fake.createActionLogMutex.Unlock()
+	fake.CreateActionLogStub = stub
+}
+
+func (fake *FakeActionLogger) CreateActionLogArgsForCall(i int) (context.Context, boil.ContextExecutor, *economya.InsertActionLog) {
+	fake.createActionLogMutex.RLock()
+	defer fake.createActionLogMutex.RUnlock()
+	argsForCall := fake.createActionLogArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeActionLogger) CreateActionLogReturns(result1 error) {
+	fake.createActionLogMutex.Lock()
+	defer fake.createActionLogMutex.Unlock()
+	fake.CreateActionLogStub = nil
+	fake.createActionLogReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeActionLogger) CreateActionLogReturnsOnCall(i int, result1 error) {
+	fake.createActionLogMutex.Lock()
+	defer fake.createActionLogMutex.Unlock()
+	fake.CreateActionLogStub = nil
+	if fake.createActionLogReturnsOnCall == nil {
+		fake.createActionLogReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createActionLogReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeActionLogger) Entitlement(arg1 context.Context, arg2 boil.ContextExecutor, arg3 string) (*economya.Entitlement, error) {
+	fake.entitlementMutex.Lock()
+	ret, specificReturn := fake.entitlementReturnsOnCall[len(fake.entitlementArgsForCall)]
+	fake.entitlementArgsForCall = append(fake.entitlementArgsForCall, struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.EntitlementStub
+	fakeReturns := fake.entitlementReturns
+	fake.recordInvocation("Entitlement", []interface{}{arg1, arg2, arg3})
+	fake.entitlementMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeActionLogger) EntitlementCallCount() int {
+	fake.entitlementMutex.RLock()
+	defer fake.entitlementMutex.RUnlock()
+	return len(fake.entitlementArgsForCall)
+}
+
+func (fake *FakeActionLogger) EntitlementCalls(stub func(context.Context, boil.ContextExecutor, string) (*economya.Entitlement, error)) {
+	fake.entitlementMutex.Lock()
+	defer fake.entitlementMutex.Unlock()
+	fake.EntitlementStub = stub
+}
+
+func (fake *FakeActionLogger) EntitlementArgsForCall(i int) (context.Context, boil.ContextExecutor, string) {
+	fake.entitlementMutex.RLock()
+	defer fake.entitlementMutex.RUnlock()
+	argsForCall := fake.entitlementArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeActionLogger) EntitlementReturns(result1 *economya.Entitlement, result2 error) {
+	fake.entitlementMutex.Lock()
+	defer fake.entitlementMutex.Unlock()
+	fake.EntitlementStub = nil
+	fake.entitlementReturns = struct {
+		result1 *economya.Entitlement
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActionLogger) EntitlementReturnsOnCall(i int, result1 *economya.Entitlement, result2 error) {
+	fake.entitlementMutex.Lock()
+	defer fake.entitlementMutex.Unlock()
+	fake.EntitlementStub = nil
+	if fake.entitlementReturnsOnCall == nil {
+		fake.entitlementReturnsOnCall = make(map[int]struct {
+			result1 *economya.Entitlement
+			result2 error
+		})
+	}
+	fake.entitlementReturnsOnCall[i] = struct {
+		result1 *economya.Entitlement
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActionLogger) SetPremiumExpiry(arg1 context.Context, arg2 boil.ContextExecutor, arg3 string, arg4 time.Time, arg5 time.Time) (bool, error) {
+	fake.setPremiumExpiryMutex.Lock()
+	ret, specificReturn := fake.setPremiumExpiryReturnsOnCall[len(fake.setPremiumExpiryArgsForCall)]
+	fake.setPremiumExpiryArgsForCall = append(fake.setPremiumExpiryArgsForCall, struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 string
+		arg4 time.Time
+		arg5 time.Time
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.SetPremiumExpiryStub
+	fakeReturns := fake.setPremiumExpiryReturns
+	fake.recordInvocation("SetPremiumExpiry", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.setPremiumExpiryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeActionLogger) SetPremiumExpiryCallCount() int {
+	fake.setPremiumExpiryMutex.RLock()
+	defer fake.setPremiumExpiryMutex.RUnlock()
+	return len(fake.setPremiumExpiryArgsForCall)
+}
+
+func (fake *FakeActionLogger) SetPremiumExpiryCalls(stub func(context.Context, boil.ContextExecutor, string, time.Time, time.Time) (bool, error)) {
+	fake.setPremiumExpiryMutex.Lock()
+	defer fake.setPremiumExpiryMutex.Unlock()
+	fake.SetPremiumExpiryStub = stub
+}
+
+func (fake *FakeActionLogger) SetPremiumExpiryArgsForCall(i int) (context.Context, boil.ContextExecutor, string, time.Time, time.Time) {
+	fake.setPremiumExpiryMutex.RLock()
+	defer fake.setPremiumExpiryMutex.RUnlock()
+	argsForCall := fake.setPremiumExpiryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeActionLogger) SetPremiumExpiryReturns(result1 bool, result2 error) {
+	fake.setPremiumExpiryMutex.Lock()
+	defer fake.setPremiumExpiryMutex.Unlock()
+	fake.SetPremiumExpiryStub = nil
+	fake.setPremiumExpiryReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActionLogger) SetPremiumExpiryReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.setPremiumExpiryMutex.Lock()
+	defer fake.setPremiumExpiryMutex.Unlock()
+	fake.SetPremiumExpiryStub = nil
+	if fake.setPremiumExpiryReturnsOnCall == nil {
+		fake.setPremiumExpiryReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.setPremiumExpiryReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActionLogger) SubscriptionHistory(arg1 context.Context, arg2 boil.ContextExecutor, arg3 string) ([]economya.SubscriptionPeriod, error) {
+	fake.subscriptionHistoryMutex.Lock()
+	ret, specificReturn := fake.subscriptionHistoryReturnsOnCall[len(fake.subscriptionHistoryArgsForCall)]
+	fake.subscriptionHistoryArgsForCall = append(fake.subscriptionHistoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.SubscriptionHistoryStub
+	fakeReturns := fake.subscriptionHistoryReturns
+	fake.recordInvocation("SubscriptionHistory", []interface{}{arg1, arg2, arg3})
+	fake.subscriptionHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeActionLogger) SubscriptionHistoryCallCount() int {
+	fake.subscriptionHistoryMutex.RLock()
+	defer fake.subscriptionHistoryMutex.RUnlock()
+	return len(fake.subscriptionHistoryArgsForCall)
+}
+
+func (fake *FakeActionLogger) SubscriptionHistoryCalls(stub func(context.Context, boil.ContextExecutor, string) ([]economya.SubscriptionPeriod, error)) {
+	fake.subscriptionHistoryMutex.Lock()
+	defer fake.subscriptionHistoryMutex.Unlock()
+	fake.SubscriptionHistoryStub = stub
+}
+
+func (fake *FakeActionLogger) SubscriptionHistoryArgsForCall(i int) (context.Context, boil.ContextExecutor, string) {
+	fake.subscriptionHistoryMutex.RLock()
+	defer fake.subscriptionHistoryMutex.RUnlock()
+	argsForCall := fake.subscriptionHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeActionLogger) SubscriptionHistoryReturns(result1 []economya.SubscriptionPeriod, result2 error) {
+	fake.subscriptionHistoryMutex.Lock()
+	defer fake.subscriptionHistoryMutex.Unlock()
+	fake.SubscriptionHistoryStub = nil
+	fake.subscriptionHistoryReturns = struct {
+		result1 []economya.SubscriptionPeriod
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActionLogger) SubscriptionHistoryReturnsOnCall(i int, result1 []economya.SubscriptionPeriod, result2 error) {
+	fake.subscriptionHistoryMutex.Lock()
+	defer fake.subscriptionHistoryMutex.Unlock()
+	fake.SubscriptionHistoryStub = nil
+	if fake.subscriptionHistoryReturnsOnCall == nil {
+		fake.subscriptionHistoryReturnsOnCall = make(map[int]struct {
+			result1 []economya.SubscriptionPeriod
+			result2 error
+		})
+	}
+	fake.subscriptionHistoryReturnsOnCall[i] = struct {
+		result1 []economya.SubscriptionPeriod
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActionLogger) TransferPremium(arg1 context.Context, arg2 boil.ContextExecutor, arg3 []string, arg4 []string, arg5 time.Time) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.transferPremiumMutex.Lock()
+	ret, specificReturn := fake.transferPremiumReturnsOnCall[len(fake.transferPremiumArgsForCall)]
+	fake.transferPremiumArgsForCall = append(fake.transferPremiumArgsForCall, struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 []string
+		arg4 []string
+		arg5 time.Time
+	}{arg1, arg2, arg3Copy, arg4Copy, arg5})
+	stub := fake.TransferPremiumStub
+	fakeReturns := fake.transferPremiumReturns
+	fake.recordInvocation("TransferPremium", []interface{}{arg1, arg2, arg3Copy, arg4Copy, arg5})
+	fake.transferPremiumMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeActionLogger) TransferPremiumCallCount() int {
+	fake.transferPremiumMutex.RLock()
+	defer fake.transferPremiumMutex.RUnlock()
+	return len(fake.transferPremiumArgsForCall)
+}
+
+func (fake *FakeActionLogger) TransferPremiumCalls(stub func(context.Context, boil.ContextExecutor, []string, []string, time.Time) error) {
+	fake.transferPremiumMutex.Lock()
+	defer fake.transferPremiumMutex.Unlock()
+	fake.TransferPremiumStub = stub
+}
+
+func (fake *FakeActionLogger) TransferPremiumArgsForCall(i int) (context.Context, boil.ContextExecutor, []string, []string, time.Time) {
+	fake.transferPremiumMutex.RLock()
+	defer fake.transferPremiumMutex.RUnlock()
+	argsForCall := fake.transferPremiumArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeActionLogger) TransferPremiumReturns(result1 error) {
+	fake.transferPremiumMutex.Lock()
+	defer fake.transferPremiumMutex.Unlock()
+	fake.TransferPremiumStub = nil
+	fake.transferPremiumReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeActionLogger) TransferPremiumReturnsOnCall(i int, result1 error) {
+	fake.transferPremiumMutex.Lock()
+	defer fake.transferPremiumMutex.Unlock()
+	fake.TransferPremiumStub = nil
+	if fake.transferPremiumReturnsOnCall == nil {
+		fake.transferPremiumReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.transferPremiumReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeActionLogger) VoidPayment(arg1 context.Context, arg2 boil.ContextExecutor, arg3 string) (int, error) {
+	fake.voidPaymentMutex.Lock()
+	ret, specificReturn := fake.voidPaymentReturnsOnCall[len(fake.voidPaymentArgsForCall)]
+	fake.voidPaymentArgsForCall = append(fake.voidPaymentArgsForCall, struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.VoidPaymentStub
+	fakeReturns := fake.voidPaymentReturns
+	fake.recordInvocation("VoidPayment", []interface{}{arg1, arg2, arg3})
+	fake.voidPaymentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeActionLogger) VoidPaymentCallCount() int {
+	fake.voidPaymentMutex.RLock()
+	defer fake.voidPaymentMutex.RUnlock()
+	return len(fake.voidPaymentArgsForCall)
+}
+
+func (fake *FakeActionLogger) VoidPaymentCalls(stub func(context.Context, boil.ContextExecutor, string) (int, error)) {
+	fake.voidPaymentMutex.Lock()
+	defer fake.voidPaymentMutex.Unlock()
+	fake.VoidPaymentStub = stub
+}
+
+func (fake *FakeActionLogger) VoidPaymentArgsForCall(i int) (context.Context, boil.ContextExecutor, string) {
+	fake.voidPaymentMutex.RLock()
+	defer fake.voidPaymentMutex.RUnlock()
+	argsForCall := fake.voidPaymentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeActionLogger) VoidPaymentReturns(result1 int, result2 error) {
+	fake.voidPaymentMutex.Lock()
+	defer fake.voidPaymentMutex.Unlock()
+	fake.VoidPaymentStub = nil
+	fake.voidPaymentReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActionLogger) VoidPaymentReturnsOnCall(i int, result1 int, result2 error) {
+	fake.voidPaymentMutex.Lock()
+	defer fake.voidPaymentMutex.Unlock()
+	fake.VoidPaymentStub = nil
+	if fake.voidPaymentReturnsOnCall == nil {
+		fake.voidPaymentReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.voidPaymentReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActionLogger) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeActionLogger) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
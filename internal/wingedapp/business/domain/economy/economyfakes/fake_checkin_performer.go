@@ -0,0 +1,199 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package economyfakes
+
+import (
+	"context"
+	"sync"
+	economya "wingedapp/pgtester/internal/wingedapp/lib/economy"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+type FakeCheckinPerformer struct {
+	GetStatusStub        func(context.Context, boil.ContextExecutor, string) (*economya.CheckinStatus, error)
+	getStatusMutex       sync.RWMutex
+	getStatusArgsForCall []struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 string
+	}
+	getStatusReturns struct {
+		result1 *economya.CheckinStatus
+		result2 error
+	}
+	getStatusReturnsOnCall map[int]struct {
+		result1 *economya.CheckinStatus
+		result2 error
+	}
+	PerformCheckinStub        func(context.Context, boil.ContextExecutor, string) (*economya.CheckinResult, error)
+	performCheckinMutex       sync.RWMutex
+	performCheckinArgsForCall []struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 string
+	}
+	performCheckinReturns struct {
+		result1 *economya.CheckinResult
+		result2 error
+	}
+	performCheckinReturnsOnCall map[int]struct {
+		result1 *economya.CheckinResult
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCheckinPerformer) GetStatus(arg1 context.Context, arg2 boil.ContextExecutor, arg3 string) (*economya.CheckinStatus, error) {
+	fake.getStatusMutex.Lock()
+	ret, specificReturn := fake.getStatusReturnsOnCall[len(fake.getStatusArgsForCall)]
+	fake.getStatusArgsForCall = append(fake.getStatusArgsForCall, struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetStatusStub
+	fakeReturns := fake.getStatusReturns
+	fake.recordInvocation("GetStatus", []interface{}{arg1, arg2, arg3})
+	fake.getStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCheckinPerformer) GetStatusCallCount() int {
+	fake.getStatusMutex.RLock()
+	defer fake.getStatusMutex.RUnlock()
+	return len(fake.getStatusArgsForCall)
+}
+
+func (fake *FakeCheckinPerformer) GetStatusCalls(stub func(context.Context, boil.ContextExecutor, string) (*economya.CheckinStatus, error)) {
+	fake.getStatusMutex.Lock()
+	defer fake.getStatusMutex.Unlock()
+	fake.GetStatusStub = stub
+}
+
+func (fake *FakeCheckinPerformer) GetStatusArgsForCall(i int) (context.Context, boil.ContextExecutor, string) {
+	fake.getStatusMutex.RLock()
+	defer fake.getStatusMutex.RUnlock()
+	argsForCall := fake.getStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCheckinPerformer) GetStatusReturns(result1 *economya.CheckinStatus, result2 error) {
+	fake.getStatusMutex.Lock()
+	defer fake.getStatusMutex.Unlock()
+	fake.GetStatusStub = nil
+	fake.getStatusReturns = struct {
+		result1 *economya.CheckinStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckinPerformer) GetStatusReturnsOnCall(i int, result1 *economya.CheckinStatus, result2 error) {
+	fake.getStatusMutex.Lock()
+	defer fake.getStatusMutex.Unlock()
+	fake.GetStatusStub = nil
+	if fake.getStatusReturnsOnCall == nil {
+		fake.getStatusReturnsOnCall = make(map[int]struct {
+			result1 *economya.CheckinStatus
+			result2 error
+		})
+	}
+	fake.getStatusReturnsOnCall[i] = struct {
+		result1 *economya.CheckinStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckinPerformer) PerformCheckin(arg1 context.Context, arg2 boil.ContextExecutor, arg3 string) (*economya.CheckinResult, error) {
+	fake.performCheckinMutex.Lock()
+	ret, specificReturn := fake.performCheckinReturnsOnCall[len(fake.performCheckinArgsForCall)]
+	fake.performCheckinArgsForCall = append(fake.performCheckinArgsForCall, struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.PerformCheckinStub
+	fakeReturns := fake.performCheckinReturns
+	fake.recordInvocation("PerformCheckin", []interface{}{arg1, arg2, arg3})
+	fake.performCheckinMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCheckinPerformer) PerformCheckinCallCount() int {
+	fake.performCheckinMutex.RLock()
+	defer fake.performCheckinMutex.RUnlock()
+	return len(fake.performCheckinArgsForCall)
+}
+
+func (fake *FakeCheckinPerformer) PerformCheckinCalls(stub func(context.Context, boil.ContextExecutor, string) (*economya.CheckinResult, error)) {
+	fake.performCheckinMutex.Lock()
+	defer fake.performCheckinMutex.Unlock()
+	fake.PerformCheckinStub = stub
+}
+
+func (fake *FakeCheckinPerformer) PerformCheckinArgsForCall(i int) (context.Context, boil.ContextExecutor, string) {
+	fake.performCheckinMutex.RLock()
+	defer fake.performCheckinMutex.RUnlock()
+	argsForCall := fake.performCheckinArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCheckinPerformer) PerformCheckinReturns(result1 *economya.CheckinResult, result2 error) {
+	fake.performCheckinMutex.Lock()
+	defer fake.performCheckinMutex.Unlock()
+	fake.PerformCheckinStub = nil
+	fake.performCheckinReturns = struct {
+		result1 *economya.CheckinResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckinPerformer) PerformCheckinReturnsOnCall(i int, result1 *economya.CheckinResult, result2 error) {
+	fake.performCheckinMutex.Lock()
+	defer fake.performCheckinMutex.Unlock()
+	fake.PerformCheckinStub = nil
+	if fake.performCheckinReturnsOnCall == nil {
+		fake.performCheckinReturnsOnCall = make(map[int]struct {
+			result1 *economya.CheckinResult
+			result2 error
+		})
+	}
+	fake.performCheckinReturnsOnCall[i] = struct {
+		result1 *economya.CheckinResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckinPerformer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCheckinPerformer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,356 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package economyfakes
+
+import (
+	"context"
+	"sync"
+	economya "wingedapp/pgtester/internal/wingedapp/lib/economy"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+type FakeRevenueCatEventLogger struct {
+	EventStub        func(context.Context, boil.ContextExecutor, *economya.QueryFilterRevenueCatEvent) (*economya.RevenueCatEvent, error)
+	eventMutex       sync.RWMutex
+	eventArgsForCall []struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 *economya.QueryFilterRevenueCatEvent
+	}
+	eventReturns struct {
+		result1 *economya.RevenueCatEvent
+		result2 error
+	}
+	eventReturnsOnCall map[int]struct {
+		result1 *economya.RevenueCatEvent
+		result2 error
+	}
+	EventsStub        func(context.Context, boil.ContextExecutor, *economya.QueryFilterRevenueCatEvent) ([]economya.RevenueCatEvent, error)
+	eventsMutex       sync.RWMutex
+	eventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 *economya.QueryFilterRevenueCatEvent
+	}
+	eventsReturns struct {
+		result1 []economya.RevenueCatEvent
+		result2 error
+	}
+	eventsReturnsOnCall map[int]struct {
+		result1 []economya.RevenueCatEvent
+		result2 error
+	}
+	MarkEventStub        func(context.Context, boil.ContextExecutor, *economya.UpdateRevenueCatEvent) error
+	markEventMutex       sync.RWMutex
+	markEventArgsForCall []struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 *economya.UpdateRevenueCatEvent
+	}
+	markEventReturns struct {
+		result1 error
+	}
+	markEventReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RecordEventStub        func(context.Context, boil.ContextExecutor, *economya.InsertRevenueCatEvent) (*economya.RevenueCatEvent, error)
+	recordEventMutex       sync.RWMutex
+	recordEventArgsForCall []struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 *economya.InsertRevenueCatEvent
+	}
+	recordEventReturns struct {
+		result1 *economya.RevenueCatEvent
+		result2 error
+	}
+	recordEventReturnsOnCall map[int]struct {
+		result1 *economya.RevenueCatEvent
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRevenueCatEventLogger) Event(arg1 context.Context, arg2 boil.ContextExecutor, arg3 *economya.QueryFilterRevenueCatEvent) (*economya.RevenueCatEvent, error) {
+	fake.eventMutex.Lock()
+	ret, specificReturn := fake.eventReturnsOnCall[len(fake.eventArgsForCall)]
+	fake.eventArgsForCall = append(fake.eventArgsForCall, struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 *economya.QueryFilterRevenueCatEvent
+	}{arg1, arg2, arg3})
+	stub := fake.EventStub
+	fakeReturns := fake.eventReturns
+	fake.recordInvocation("Event", []interface{}{arg1, arg2, arg3})
+	fake.eventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRevenueCatEventLogger) EventCallCount() int {
+	fake.eventMutex.RLock()
+	defer fake.eventMutex.RUnlock()
+	return len(fake.eventArgsForCall)
+}
+
+func (fake *FakeRevenueCatEventLogger) EventCalls(stub func(context.Context, boil.ContextExecutor, *economya.QueryFilterRevenueCatEvent) (*economya.RevenueCatEvent, error)) {
+	fake.eventMutex.Lock()
+	defer fake.eventMutex.Unlock()
+	fake.EventStub = stub
+}
+
+func (fake *FakeRevenueCatEventLogger) EventArgsForCall(i int) (context.Context, boil.ContextExecutor, *economya.QueryFilterRevenueCatEvent) {
+	fake.eventMutex.RLock()
+	defer fake.eventMutex.RUnlock()
+	argsForCall := fake.eventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRevenueCatEventLogger) EventReturns(result1 *economya.RevenueCatEvent, result2 error) {
+	fake.eventMutex.Lock()
+	defer fake.eventMutex.Unlock()
+	fake.EventStub = nil
+	fake.eventReturns = struct {
+		result1 *economya.RevenueCatEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRevenueCatEventLogger) EventReturnsOnCall(i int, result1 *economya.RevenueCatEvent, result2 error) {
+	fake.eventMutex.Lock()
+	defer fake.eventMutex.Unlock()
+	fake.EventStub = nil
+	if fake.eventReturnsOnCall == nil {
+		fake.eventReturnsOnCall = make(map[int]struct {
+			result1 *economya.RevenueCatEvent
+			result2 error
+		})
+	}
+	fake.eventReturnsOnCall[i] = struct {
+		result1 *economya.RevenueCatEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRevenueCatEventLogger) Events(arg1 context.Context, arg2 boil.ContextExecutor, arg3 *economya.QueryFilterRevenueCatEvent) ([]economya.RevenueCatEvent, error) {
+	fake.eventsMutex.Lock()
+	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
+	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 *economya.QueryFilterRevenueCatEvent
+	}{arg1, arg2, arg3})
+	stub := fake.EventsStub
+	fakeReturns := fake.eventsReturns
+	fake.recordInvocation("Events", []interface{}{arg1, arg2, arg3})
+	fake.eventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRevenueCatEventLogger) EventsCallCount() int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return len(fake.eventsArgsForCall)
+}
+
+func (fake *FakeRevenueCatEventLogger) EventsCalls(stub func(context.Context, boil.ContextExecutor, *economya.QueryFilterRevenueCatEvent) ([]economya.RevenueCatEvent, error)) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = stub
+}
+
+func (fake *FakeRevenueCatEventLogger) EventsArgsForCall(i int) (context.Context, boil.ContextExecutor, *economya.QueryFilterRevenueCatEvent) {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	argsForCall := fake.eventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRevenueCatEventLogger) EventsReturns(result1 []economya.RevenueCatEvent, result2 error) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = nil
+	fake.eventsReturns = struct {
+		result1 []economya.RevenueCatEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRevenueCatEventLogger) EventsReturnsOnCall(i int, result1 []economya.RevenueCatEvent, result2 error) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = nil
+	if fake.eventsReturnsOnCall == nil {
+		fake.eventsReturnsOnCall = make(map[int]struct {
+			result1 []economya.RevenueCatEvent
+			result2 error
+		})
+	}
+	fake.eventsReturnsOnCall[i] = struct {
+		result1 []economya.RevenueCatEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRevenueCatEventLogger) MarkEvent(arg1 context.Context, arg2 boil.ContextExecutor, arg3 *economya.UpdateRevenueCatEvent) error {
+	fake.markEventMutex.Lock()
+	ret, specificReturn := fake.markEventReturnsOnCall[len(fake.markEventArgsForCall)]
+	fake.markEventArgsForCall = append(fake.markEventArgsForCall, struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 *economya.UpdateRevenueCatEvent
+	}{arg1, arg2, arg3})
+	stub := fake.MarkEventStub
+	fakeReturns := fake.markEventReturns
+	fake.recordInvocation("MarkEvent", []interface{}{arg1, arg2, arg3})
+	fake.markEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRevenueCatEventLogger) MarkEventCallCount() int {
+	fake.markEventMutex.RLock()
+	defer fake.markEventMutex.RUnlock()
+	return len(fake.markEventArgsForCall)
+}
+
+func (fake *FakeRevenueCatEventLogger) MarkEventCalls(stub func(context.Context, boil.ContextExecutor, *economya.UpdateRevenueCatEvent) error) {
+	fake.markEventMutex.Lock()
+	defer fake.markEventMutex.Unlock()
+	fake.MarkEventStub = stub
+}
+
+func (fake *FakeRevenueCatEventLogger) MarkEventArgsForCall(i int) (context.Context, boil.ContextExecutor, *economya.UpdateRevenueCatEvent) {
+	fake.markEventMutex.RLock()
+	defer fake.markEventMutex.RUnlock()
+	argsForCall := fake.markEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRevenueCatEventLogger) MarkEventReturns(result1 error) {
+	fake.markEventMutex.Lock()
+	defer fake.markEventMutex.Unlock()
+	fake.MarkEventStub = nil
+	fake.markEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRevenueCatEventLogger) MarkEventReturnsOnCall(i int, result1 error) {
+	fake.markEventMutex.Lock()
+	defer fake.markEventMutex.Unlock()
+	fake.MarkEventStub = nil
+	if fake.markEventReturnsOnCall == nil {
+		fake.markEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRevenueCatEventLogger) RecordEvent(arg1 context.Context, arg2 boil.ContextExecutor, arg3 *economya.InsertRevenueCatEvent) (*economya.RevenueCatEvent, error) {
+	fake.recordEventMutex.Lock()
+	ret, specificReturn := fake.recordEventReturnsOnCall[len(fake.recordEventArgsForCall)]
+	fake.recordEventArgsForCall = append(fake.recordEventArgsForCall, struct {
+		arg1 context.Context
+		arg2 boil.ContextExecutor
+		arg3 *economya.InsertRevenueCatEvent
+	}{arg1, arg2, arg3})
+	stub := fake.RecordEventStub
+	fakeReturns := fake.recordEventReturns
+	fake.recordInvocation("RecordEvent", []interface{}{arg1, arg2, arg3})
+	fake.recordEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRevenueCatEventLogger) RecordEventCallCount() int {
+	fake.recordEventMutex.RLock()
+	defer fake.recordEventMutex.RUnlock()
+	return len(fake.recordEventArgsForCall)
+}
+
+func (fake *FakeRevenueCatEventLogger) RecordEventCalls(stub func(context.Context, boil.ContextExecutor, *economya.InsertRevenueCatEvent) (*economya.RevenueCatEvent, error)) {
+	fake.recordEventMutex.Lock()
+	defer fake.recordEventMutex.Unlock()
+	fake.RecordEventStub = stub
+}
+
+func (fake *FakeRevenueCatEventLogger) RecordEventArgsForCall(i int) (context.Context, boil.ContextExecutor, *economya.InsertRevenueCatEvent) {
+	fake.recordEventMutex.RLock()
+	defer fake.recordEventMutex.RUnlock()
+	argsForCall := fake.recordEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRevenueCatEventLogger) RecordEventReturns(result1 *economya.RevenueCatEvent, result2 error) {
+	fake.recordEventMutex.Lock()
+	defer fake.recordEventMutex.Unlock()
+	fake.RecordEventStub = nil
+	fake.recordEventReturns = struct {
+		result1 *economya.RevenueCatEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRevenueCatEventLogger) RecordEventReturnsOnCall(i int, result1 *economya.RevenueCatEvent, result2 error) {
+	fake.recordEventMutex.Lock()
+	defer fake.recordEventMutex.Unlock()
+	fake.RecordEventStub = nil
+	if fake.recordEventReturnsOnCall == nil {
+		fake.recordEventReturnsOnCall = make(map[int]struct {
+			result1 *economya.RevenueCatEvent
+			result2 error
+		})
+	}
+	fake.recordEventReturnsOnCall[i] = struct {
+		result1 *economya.RevenueCatEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRevenueCatEventLogger) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeRevenueCatEventLogger) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,203 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package economyfakes
+
+import (
+	"sync"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+type FakeTransactor struct {
+	DBStub        func() boil.ContextExecutor
+	dBMutex       sync.RWMutex
+	dBArgsForCall []struct {
+	}
+	dBReturns struct {
+		result1 boil.ContextExecutor
+	}
+	dBReturnsOnCall map[int]struct {
+		result1 boil.ContextExecutor
+	}
+	RollbackStub        func(boil.ContextTransactor)
+	rollbackMutex       sync.RWMutex
+	rollbackArgsForCall []struct {
+		arg1 boil.ContextTransactor
+	}
+	TXStub        func() (boil.ContextTransactor, error)
+	tXMutex       sync.RWMutex
+	tXArgsForCall []struct {
+	}
+	tXReturns struct {
+		result1 boil.ContextTransactor
+		result2 error
+	}
+	tXReturnsOnCall map[int]struct {
+		result1 boil.ContextTransactor
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTransactor) DB() boil.ContextExecutor {
+	fake.dBMutex.Lock()
+	ret, specificReturn := fake.dBReturnsOnCall[len(fake.dBArgsForCall)]
+	fake.dBArgsForCall = append(fake.dBArgsForCall, struct {
+	}{})
+	stub := fake.DBStub
+	fakeReturns := fake.dBReturns
+	fake.recordInvocation("DB", []interface{}{})
+	fake.dBMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTransactor) DBCallCount() int {
+	fake.dBMutex.RLock()
+	defer fake.dBMutex.RUnlock()
+	return len(fake.dBArgsForCall)
+}
+
+func (fake *FakeTransactor) DBCalls(stub func() boil.ContextExecutor) {
+	fake.dBMutex.Lock()
+	defer fake.dBMutex.Unlock()
+	fake.DBStub = stub
+}
+
+func (fake *FakeTransactor) DBReturns(result1 boil.ContextExecutor) {
+	fake.dBMutex.Lock()
+	defer fake.dBMutex.Unlock()
+	fake.DBStub = nil
+	fake.dBReturns = struct {
+		result1 boil.ContextExecutor
+	}{result1}
+}
+
+func (fake *FakeTransactor) DBReturnsOnCall(i int, result1 boil.ContextExecutor) {
+	fake.dBMutex.Lock()
+	defer fake.dBMutex.Unlock()
+	fake.DBStub = nil
+	if fake.dBReturnsOnCall == nil {
+		fake.dBReturnsOnCall = make(map[int]struct {
+			result1 boil.ContextExecutor
+		})
+	}
+	fake.dBReturnsOnCall[i] = struct {
+		result1 boil.ContextExecutor
+	}{result1}
+}
+
+func (fake *FakeTransactor) Rollback(arg1 boil.ContextTransactor) {
+	fake.rollbackMutex.Lock()
+	fake.rollbackArgsForCall = append(fake.rollbackArgsForCall, struct {
+		arg1 boil.ContextTransactor
+	}{arg1})
+	stub := fake.RollbackStub
+	fake.recordInvocation("Rollback", []interface{}{arg1})
+	fake.rollbackMutex.Unlock()
+	if stub != nil {
+		fake.RollbackStub(arg1)
+	}
+}
+
+func (fake *FakeTransactor) RollbackCallCount() int {
+	fake.rollbackMutex.RLock()
+	defer fake.rollbackMutex.RUnlock()
+	return len(fake.rollbackArgsForCall)
+}
+
+func (fake *FakeTransactor) RollbackCalls(stub func(boil.ContextTransactor)) {
+	fake.rollbackMutex.Lock()
+	defer fake.rollbackMutex.Unlock()
+	fake.RollbackStub = stub
+}
+
+func (fake *FakeTransactor) RollbackArgsForCall(i int) boil.ContextTransactor {
+	fake.rollbackMutex.RLock()
+	defer fake.rollbackMutex.RUnlock()
+	argsForCall := fake.rollbackArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTransactor) TX() (boil.ContextTransactor, error) {
+	fake.tXMutex.Lock()
+	ret, specificReturn := fake.tXReturnsOnCall[len(fake.tXArgsForCall)]
+	fake.tXArgsForCall = append(fake.tXArgsForCall, struct {
+	}{})
+	stub := fake.TXStub
+	fakeReturns := fake.tXReturns
+	fake.recordInvocation("TX", []interface{}{})
+	fake.tXMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransactor) TXCallCount() int {
+	fake.tXMutex.RLock()
+	defer fake.tXMutex.RUnlock()
+	return len(fake.tXArgsForCall)
+}
+
+func (fake *FakeTransactor) TXCalls(stub func() (boil.ContextTransactor, error)) {
+	fake.tXMutex.Lock()
+	defer fake.tXMutex.Unlock()
+	fake.TXStub = stub
+}
+
+func (fake *FakeTransactor) TXReturns(result1 boil.ContextTransactor, result2 error) {
+	fake.tXMutex.Lock()
+	defer fake.tXMutex.Unlock()
+	fake.TXStub = nil
+	fake.tXReturns = struct {
+		result1 boil.ContextTransactor
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransactor) TXReturnsOnCall(i int, result1 boil.ContextTransactor, result2 error) {
+	fake.tXMutex.Lock()
+	defer fake.tXMutex.Unlock()
+	fake.TXStub = nil
+	if fake.tXReturnsOnCall == nil {
+		fake.tXReturnsOnCall = make(map[int]struct {
+			result1 boil.ContextTransactor
+			result2 error
+		})
+	}
+	fake.tXReturnsOnCall[i] = struct {
+		result1 boil.ContextTransactor
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransactor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTransactor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
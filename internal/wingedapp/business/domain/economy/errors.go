@@ -0,0 +1,10 @@
+package economy
+
+import (
+	"errors"
+)
+
+var (
+	ErrWebhookSecretNotSet = errors.New("webhook secret not set")
+	ErrUnauthorizedWebhook = errors.New("unauthorized webhook")
+)
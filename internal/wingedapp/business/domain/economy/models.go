@@ -1,5 +1,7 @@
 package economy
 
+import "time"
+
 // CheckinResponse is the response for daily check-in.
 type CheckinResponse struct {
 	Success            bool   `json:"success"`
@@ -48,6 +50,14 @@ type RevenueCatEvent struct {
 	EntitlementIDs    []string `json:"entitlement_ids"`
 	OfferCode         *string  `json:"offer_code"`
 	IsFamilyShare     bool     `json:"is_family_share"`
+
+	TransactionID         string   `json:"transaction_id"`
+	OriginalTransactionID string   `json:"original_transaction_id"`
+	NewProductID          string   `json:"new_product_id"`    // PRODUCT_CHANGE only
+	CancelReason          string   `json:"cancel_reason"`     // CANCELLATION only
+	ExpirationReason      string   `json:"expiration_reason"` // EXPIRATION only
+	TransferredFrom       []string `json:"transferred_from"`  // TRANSFER only
+	TransferredTo         []string `json:"transferred_to"`    // TRANSFER only
 }
 
 // RevenueCatWebhookResponse is the response for the RevenueCat webhook.
@@ -58,3 +68,39 @@ type RevenueCatWebhookResponse struct {
 	Reason  string `json:"reason,omitempty"`
 	Error   string `json:"error,omitempty"`
 }
+
+// ReplayRevenueCatEventsResponse sums up a replay of stored RevenueCat events.
+type ReplayRevenueCatEventsResponse struct {
+	Events    int                         `json:"events"`
+	Processed int                         `json:"processed"`
+	Ignored   int                         `json:"ignored"`
+	Failed    int                         `json:"failed"`
+	Results   []RevenueCatWebhookResponse `json:"results"`
+}
+
+// SubscriptionPeriodResponse is a subscription period a payment bought.
+type SubscriptionPeriodResponse struct {
+	ID               string    `json:"id"`
+	SubscriptionType string    `json:"subscription_type"`
+	Plan             string    `json:"plan"`
+	Price            string    `json:"price"`
+	WingsGranted     int       `json:"wings_granted"`
+	StartDate        time.Time `json:"start_date"`
+	EndDate          time.Time `json:"end_date"`
+	EndReason        string    `json:"end_reason,omitempty"` // Upgrade or Downgrade, when it ended early
+	Voided           bool      `json:"voided"`               // refunded
+}
+
+// SubscriptionHistoryResponse is the response for a user's subscription history.
+type SubscriptionHistoryResponse struct {
+	Periods []SubscriptionPeriodResponse `json:"periods"`
+}
+
+// EntitlementResponse is the response for what a user is entitled to right now.
+type EntitlementResponse struct {
+	Tier             string                      `json:"tier"` // Free, Winged+ or WingedX
+	Period           *SubscriptionPeriodResponse `json:"period,omitempty"`
+	IsPremium        bool                        `json:"is_premium"`
+	PremiumExpiresAt *time.Time                  `json:"premium_expires_at,omitempty"`
+	Wings            int                         `json:"wings"`
+}
@@ -0,0 +1,519 @@
+package economy
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	economyLib "wingedapp/pgtester/internal/wingedapp/lib/economy"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+// RevenueCat event types.
+const (
+	revenueCatInitialPurchase = "INITIAL_PURCHASE"
+	revenueCatRenewal         = "RENEWAL"
+	revenueCatCancellation    = "CANCELLATION"
+	revenueCatUncancellation  = "UNCANCELLATION"
+	revenueCatExpiration      = "EXPIRATION"
+	revenueCatBillingIssue    = "BILLING_ISSUE"
+	revenueCatProductChange   = "PRODUCT_CHANGE"
+	revenueCatRefund          = "REFUND"
+	revenueCatTransfer        = "TRANSFER"
+
+	revenueCatSandbox = "SANDBOX"
+
+	// a CANCELLATION for this reason is a refund
+	revenueCatCancelReasonCustomerSupport = "CUSTOMER_SUPPORT"
+
+	// revenueCatMaxClockSkew is how far ahead of our clock an event may be.
+	revenueCatMaxClockSkew = 5 * time.Minute
+)
+
+// DefaultRevenueCatEventMaxAge is how old a delivered event may be. RevenueCat
+// retries a failed delivery for a few hours.
+const DefaultRevenueCatEventMaxAge = 24 * time.Hour
+
+// Actions of a processed RevenueCat event.
+const (
+	RevenueCatActionProcessed        = "processed"
+	RevenueCatActionPremiumUpdated   = "premium_updated"
+	RevenueCatActionRefunded         = "refunded"
+	RevenueCatActionTransferred      = "transferred"
+	RevenueCatActionIgnored          = "ignored"
+	RevenueCatActionAlreadyProcessed = "already_processed"
+)
+
+// ProcessRevenueCatWebhook processes a RevenueCat webhook body, which is
+// stored as received so the event can be replayed. authorization is the
+// request's Authorization header.
+func (b *Business) ProcessRevenueCatWebhook(
+	ctx context.Context,
+	authorization string,
+	body []byte,
+) (*RevenueCatWebhookResponse, error) {
+	if err := b.verifyRevenueCatAuthorization(authorization); err != nil {
+		return nil, err
+	}
+
+	var req RevenueCatWebhookRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, fmt.Errorf("unmarshal webhook: %w", err)
+	}
+
+	return b.processRevenueCatWebhook(ctx, &req, body)
+}
+
+// ProcessRevenueCatEvent processes a RevenueCat webhook event.
+// Uses existing ActionLogger.CreateActionLog flow - no duplicate logic.
+func (b *Business) ProcessRevenueCatEvent(
+	ctx context.Context,
+	authorization string,
+	req *RevenueCatWebhookRequest,
+) (*RevenueCatWebhookResponse, error) {
+	if err := b.verifyRevenueCatAuthorization(authorization); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshal webhook: %w", err)
+	}
+
+	return b.processRevenueCatWebhook(ctx, req, payload)
+}
+
+// verifyRevenueCatAuthorization checks the Authorization header against the
+// webhook secret configured in RevenueCat, sent as is or as a bearer token.
+func (b *Business) verifyRevenueCatAuthorization(authorization string) error {
+	if b.revenueCatWebhookSecret == "" {
+		return ErrWebhookSecretNotSet
+	}
+
+	token := strings.TrimPrefix(strings.TrimSpace(authorization), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(b.revenueCatWebhookSecret)) != 1 {
+		return ErrUnauthorizedWebhook
+	}
+
+	return nil
+}
+
+// processRevenueCatWebhook stores the event, then processes it unless it was
+// processed or ignored already: the stored events are the ledger of the
+// events seen, by event ID.
+func (b *Business) processRevenueCatWebhook(
+	ctx context.Context,
+	req *RevenueCatWebhookRequest,
+	payload []byte,
+) (*RevenueCatWebhookResponse, error) {
+	event := req.Event
+
+	stored, err := b.revenueCatEventLog.RecordEvent(ctx, b.transactor.DB(), &economyLib.InsertRevenueCatEvent{
+		EventID:       event.ID,
+		Type:          event.Type,
+		AppUserID:     event.AppUserID,
+		ProductID:     event.ProductID,
+		TransactionID: event.TransactionID,
+		Environment:   event.Environment,
+		Payload:       payload,
+		EventAt:       null.NewTime(eventTime(&event), event.EventTimestampMs > 0),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("record event: %w", err)
+	}
+
+	if isFinalRevenueCatEvent(stored) {
+		return alreadyProcessedRevenueCatEvent(&event), nil
+	}
+
+	// a delivery far from the event's time is a replayed or forged webhook,
+	// kept for review: a legit one can be replayed from the store
+	if reason := b.revenueCatTimestampReason(&event); reason != "" {
+		resp := ignoredRevenueCatEvent(&event, reason)
+		if err = b.markRevenueCatEvent(ctx, b.transactor.DB(), stored.ID, resp, nil); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	return b.processStoredRevenueCatEvent(ctx, stored, &event, false)
+}
+
+// revenueCatTimestampReason returns why the event's timestamp is out of the
+// accepted window, or "" when it's in it.
+func (b *Business) revenueCatTimestampReason(event *RevenueCatEvent) string {
+	if event.EventTimestampMs <= 0 {
+		return "missing_timestamp"
+	}
+
+	at := time.UnixMilli(event.EventTimestampMs)
+	now := time.Now()
+	switch {
+	case at.After(now.Add(revenueCatMaxClockSkew)):
+		return "timestamp_in_future"
+	case at.Before(now.Add(-b.revenueCatEventMaxAge)):
+		return "timestamp_too_old"
+	default:
+		return ""
+	}
+}
+
+// ReplayRevenueCatEvents processes the stored events again, in the order they
+// happened, e.g. the ones that failed, or sandbox events once they're enabled.
+// Replaying is safe: payments are idempotent by event ID, a payment is voided
+// once, and premium changes are ordered by event time. Stored events were
+// authenticated on receipt, and their timestamps aren't checked again.
+func (b *Business) ReplayRevenueCatEvents(
+	ctx context.Context,
+	f *economyLib.QueryFilterRevenueCatEvent,
+) (*ReplayRevenueCatEventsResponse, error) {
+	events, err := b.revenueCatEventLog.Events(ctx, b.transactor.DB(), f)
+	if err != nil {
+		return nil, fmt.Errorf("events: %w", err)
+	}
+
+	replay := &ReplayRevenueCatEventsResponse{
+		Events:  len(events),
+		Results: make([]RevenueCatWebhookResponse, 0, len(events)),
+	}
+	for i := range events {
+		var req RevenueCatWebhookRequest
+		if err = json.Unmarshal(events[i].Payload, &req); err != nil {
+			return nil, fmt.Errorf("unmarshal event %s: %w", events[i].EventID, err)
+		}
+
+		resp, err := b.processStoredRevenueCatEvent(ctx, &events[i], &req.Event, true)
+		if err != nil {
+			replay.Failed++
+			replay.Results = append(replay.Results, RevenueCatWebhookResponse{
+				EventID: events[i].EventID,
+				Error:   err.Error(),
+			})
+			continue
+		}
+
+		if resp.Action == RevenueCatActionIgnored {
+			replay.Ignored++
+		} else {
+			replay.Processed++
+		}
+		replay.Results = append(replay.Results, *resp)
+	}
+
+	return replay, nil
+}
+
+// processStoredRevenueCatEvent handles the event, and records the outcome on
+// its stored row, in one transaction holding the row: a concurrent delivery
+// of the event waits for it, then finds it processed. A failed event is
+// returned as an error, so RevenueCat redelivers it.
+func (b *Business) processStoredRevenueCatEvent(
+	ctx context.Context,
+	stored *economyLib.RevenueCatEvent,
+	event *RevenueCatEvent,
+	replay bool,
+) (*RevenueCatWebhookResponse, error) {
+	tx, err := b.transactor.TX()
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer b.transactor.Rollback(tx)
+
+	locked, err := b.revenueCatEventLog.Event(ctx, tx, &economyLib.QueryFilterRevenueCatEvent{
+		ID:        null.StringFrom(stored.ID),
+		ForUpdate: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lock event: %w", err)
+	}
+	if !replay && isFinalRevenueCatEvent(locked) {
+		return alreadyProcessedRevenueCatEvent(event), nil
+	}
+
+	resp, err := b.handleRevenueCatEvent(ctx, tx, event)
+	if err != nil {
+		b.transactor.Rollback(tx)
+		if markErr := b.markRevenueCatEvent(ctx, b.transactor.DB(), stored.ID, nil, err); markErr != nil {
+			return nil, errors.Join(err, markErr)
+		}
+		return nil, fmt.Errorf("handle %s event %s: %w", event.Type, event.ID, err)
+	}
+
+	if err = b.markRevenueCatEvent(ctx, tx, stored.ID, resp, nil); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return resp, nil
+}
+
+// markRevenueCatEvent records the outcome of handling a stored event.
+func (b *Business) markRevenueCatEvent(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	id string,
+	resp *RevenueCatWebhookResponse,
+	handleErr error,
+) error {
+	updater := &economyLib.UpdateRevenueCatEvent{
+		ID:     id,
+		Status: enums.RevenueCatEventStatusProcessed.String(),
+	}
+	switch {
+	case handleErr != nil:
+		updater.Status = enums.RevenueCatEventStatusFailed.String()
+		updater.Error = null.StringFrom(handleErr.Error())
+	case resp.Action == RevenueCatActionIgnored:
+		updater.Status = enums.RevenueCatEventStatusIgnored.String()
+	}
+	if resp != nil {
+		updater.Action = null.StringFrom(resp.Action)
+		updater.Reason = null.NewString(resp.Reason, resp.Reason != "")
+	}
+
+	if err := b.revenueCatEventLog.MarkEvent(ctx, exec, updater); err != nil {
+		return fmt.Errorf("mark event: %w", err)
+	}
+
+	return nil
+}
+
+// isFinalRevenueCatEvent reports whether the stored event needs no more
+// processing. Failed events are retried, on redelivery or replay.
+func isFinalRevenueCatEvent(event *economyLib.RevenueCatEvent) bool {
+	switch enums.RevenueCatEventStatus(event.Status) {
+	case enums.RevenueCatEventStatusProcessed, enums.RevenueCatEventStatusIgnored:
+		return true
+	}
+	return false
+}
+
+// handleRevenueCatEvent applies the event to the user's wings and premium access.
+func (b *Business) handleRevenueCatEvent(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	event *RevenueCatEvent,
+) (*RevenueCatWebhookResponse, error) {
+	if event.Environment == revenueCatSandbox && !b.processSandboxEvents {
+		return ignoredRevenueCatEvent(event, "sandbox_environment"), nil
+	}
+
+	if event.Type == revenueCatTransfer {
+		return b.handleRevenueCatTransfer(ctx, exec, event)
+	}
+
+	// anonymous store users haven't signed in to an account of ours
+	if uuid.Validate(event.AppUserID) != nil {
+		return ignoredRevenueCatEvent(event, "unknown_app_user_id"), nil
+	}
+
+	switch event.Type {
+	case revenueCatInitialPurchase, revenueCatRenewal:
+		return b.handleRevenueCatPayment(ctx, exec, event)
+	case revenueCatRefund:
+		return b.handleRevenueCatRefund(ctx, exec, event)
+	case revenueCatCancellation:
+		if event.CancelReason == revenueCatCancelReasonCustomerSupport {
+			return b.handleRevenueCatRefund(ctx, exec, event)
+		}
+		return b.syncRevenueCatPremium(ctx, exec, event)
+	case revenueCatUncancellation, revenueCatExpiration, revenueCatBillingIssue, revenueCatProductChange:
+		return b.syncRevenueCatPremium(ctx, exec, event)
+	default:
+		return ignoredRevenueCatEvent(event, "event_type_not_handled"), nil
+	}
+}
+
+// handleRevenueCatPayment credits a purchase or renewal, wings for Winged+,
+// premium access until the store's expiration for WingedX.
+func (b *Business) handleRevenueCatPayment(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	event *RevenueCatEvent,
+) (*RevenueCatWebhookResponse, error) {
+	// Map product_id to action type
+	actionType, ok := economyLib.ProductIDToActionType[event.ProductID]
+	if !ok {
+		return ignoredRevenueCatEvent(event, "unknown_product_id"), nil
+	}
+
+	inserter := &economyLib.InsertActionLog{
+		UserID: event.AppUserID,
+		RefID:  event.ID, // webhook event ID for idempotency
+		Type:   actionType,
+	}
+	if economyLib.IsPremiumAction(actionType) && event.ExpirationAtMs > 0 {
+		inserter.PremiumExpiresAt = null.TimeFrom(time.UnixMilli(event.ExpirationAtMs))
+		inserter.PremiumEventAt = null.TimeFrom(eventTime(event))
+	}
+
+	// Process payment using existing CreateActionLog flow
+	// Idempotency is handled inside the handler - duplicate RefID is a no-op
+	if err := b.actionLogger.CreateActionLog(ctx, exec, inserter); err != nil {
+		return nil, fmt.Errorf("create action log: %w", err)
+	}
+
+	return &RevenueCatWebhookResponse{
+		Success: true,
+		EventID: event.ID,
+		Action:  RevenueCatActionProcessed,
+	}, nil
+}
+
+// syncRevenueCatPremium sets the user's premium expiry to the store's
+// expiration for the event. Winged+ plans grant wings, not premium access,
+// so their lifecycle events change nothing: the wings were credited with
+// the payment, and expire on their own. An event older than the one the
+// current expiry came from is ignored.
+func (b *Business) syncRevenueCatPremium(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	event *RevenueCatEvent,
+) (*RevenueCatWebhookResponse, error) {
+	productID := event.ProductID
+	if event.Type == revenueCatProductChange && event.NewProductID != "" {
+		productID = event.NewProductID
+	}
+
+	actionType, ok := economyLib.ProductIDToActionType[productID]
+	if !ok {
+		return ignoredRevenueCatEvent(event, "unknown_product_id"), nil
+	}
+	if !economyLib.IsPremiumAction(actionType) {
+		return ignoredRevenueCatEvent(event, "product_not_premium"), nil
+	}
+	if event.ExpirationAtMs == 0 {
+		return ignoredRevenueCatEvent(event, "missing_expiration"), nil
+	}
+
+	applied, err := b.actionLogger.SetPremiumExpiry(ctx, exec,
+		event.AppUserID, time.UnixMilli(event.ExpirationAtMs), eventTime(event))
+	if err != nil {
+		return nil, fmt.Errorf("set premium expiry: %w", err)
+	}
+	if !applied {
+		return ignoredRevenueCatEvent(event, "out_of_order"), nil
+	}
+
+	return &RevenueCatWebhookResponse{
+		Success: true,
+		EventID: event.ID,
+		Action:  RevenueCatActionPremiumUpdated,
+	}, nil
+}
+
+// handleRevenueCatRefund voids the refunded payment, found by its store
+// transaction among the stored events, which claws back the wings it
+// credited. A refunded WingedX plan ends premium access at the refund,
+// unless it was renewed after it.
+func (b *Business) handleRevenueCatRefund(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	event *RevenueCatEvent,
+) (*RevenueCatWebhookResponse, error) {
+	actionType, ok := economyLib.ProductIDToActionType[event.ProductID]
+	if !ok {
+		return ignoredRevenueCatEvent(event, "unknown_product_id"), nil
+	}
+	if event.TransactionID == "" {
+		return ignoredRevenueCatEvent(event, "missing_transaction_id"), nil
+	}
+
+	payments, err := b.revenueCatEventLog.Events(ctx, exec, &economyLib.QueryFilterRevenueCatEvent{
+		TransactionID: null.StringFrom(event.TransactionID),
+		Types:         []string{revenueCatInitialPurchase, revenueCatRenewal},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("refunded payment events: %w", err)
+	}
+
+	voided := 0
+	for _, payment := range payments {
+		n, err := b.actionLogger.VoidPayment(ctx, exec, payment.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("void payment %s: %w", payment.EventID, err)
+		}
+		voided += n
+	}
+
+	premium := economyLib.IsPremiumAction(actionType)
+	if premium {
+		if _, err = b.actionLogger.SetPremiumExpiry(ctx, exec, event.AppUserID, eventTime(event), eventTime(event)); err != nil {
+			return nil, fmt.Errorf("end premium: %w", err)
+		}
+	}
+
+	if voided == 0 && !premium {
+		return ignoredRevenueCatEvent(event, "payment_not_found"), nil
+	}
+
+	return &RevenueCatWebhookResponse{
+		Success: true,
+		EventID: event.ID,
+		Action:  RevenueCatActionRefunded,
+	}, nil
+}
+
+// handleRevenueCatTransfer moves premium access along with a subscription
+// transferred between app users. Wings stay with the user who earned them.
+func (b *Business) handleRevenueCatTransfer(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	event *RevenueCatEvent,
+) (*RevenueCatWebhookResponse, error) {
+	toUserIDs := make([]string, 0, len(event.TransferredTo))
+	for _, userID := range event.TransferredTo {
+		if uuid.Validate(userID) == nil {
+			toUserIDs = append(toUserIDs, userID)
+		}
+	}
+	if len(toUserIDs) == 0 {
+		return ignoredRevenueCatEvent(event, "unknown_app_user_id"), nil
+	}
+
+	if err := b.actionLogger.TransferPremium(ctx, exec, event.TransferredFrom, toUserIDs, eventTime(event)); err != nil {
+		return nil, fmt.Errorf("transfer premium: %w", err)
+	}
+
+	return &RevenueCatWebhookResponse{
+		Success: true,
+		EventID: event.ID,
+		Action:  RevenueCatActionTransferred,
+	}, nil
+}
+
+func alreadyProcessedRevenueCatEvent(event *RevenueCatEvent) *RevenueCatWebhookResponse {
+	return &RevenueCatWebhookResponse{
+		Success: true,
+		EventID: event.ID,
+		Action:  RevenueCatActionAlreadyProcessed,
+	}
+}
+
+func ignoredRevenueCatEvent(event *RevenueCatEvent, reason string) *RevenueCatWebhookResponse {
+	return &RevenueCatWebhookResponse{
+		Success: true,
+		EventID: event.ID,
+		Action:  RevenueCatActionIgnored,
+		Reason:  reason,
+	}
+}
+
+// eventTime is when the event happened, or now for an event without a timestamp.
+func eventTime(event *RevenueCatEvent) time.Time {
+	if event.EventTimestampMs > 0 {
+		return time.UnixMilli(event.EventTimestampMs).UTC()
+	}
+	return time.Now().UTC()
+}
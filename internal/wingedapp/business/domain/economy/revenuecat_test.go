@@ -0,0 +1,348 @@
+package economy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/business/domain/economy"
+	"wingedapp/pgtester/internal/wingedapp/business/domain/economy/economyfakes"
+	economyLib "wingedapp/pgtester/internal/wingedapp/lib/economy"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRevenueCatSecret = "test-webhook-secret"
+
+// fakeTx is a transaction that commits and rolls back nothing.
+type fakeTx struct {
+	boil.ContextTransactor
+}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type revenueCatFakes struct {
+	transactor   *economyfakes.FakeTransactor
+	actionLogger *economyfakes.FakeActionLogger
+	eventLog     *economyfakes.FakeRevenueCatEventLogger
+}
+
+type testCaseRevenueCatEvent struct {
+	name string
+
+	authorization string                    // defaults to the secret
+	configure     func(b *economy.Business) // e.g. process sandbox events
+	storedStatus  enums.RevenueCatEventStatus
+	event         economy.RevenueCatEvent
+	setup         func(f *revenueCatFakes)
+	assertions    func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error)
+}
+
+func TestBusiness_ProcessRevenueCatEvent(t *testing.T) {
+	now := time.Now()
+	userID := uuid.NewString()
+
+	event := func(eventType, productID string) economy.RevenueCatEvent {
+		return economy.RevenueCatEvent{
+			ID:               uuid.NewString(),
+			Type:             eventType,
+			EventTimestampMs: now.UnixMilli(),
+			AppUserID:        userID,
+			ProductID:        productID,
+			TransactionID:    "store-transaction-1",
+			ExpirationAtMs:   now.AddDate(0, 1, 0).UnixMilli(),
+			Environment:      "PRODUCTION",
+		}
+	}
+	withTimestamp := func(e economy.RevenueCatEvent, at time.Time) economy.RevenueCatEvent {
+		e.EventTimestampMs = at.UnixMilli()
+		return e
+	}
+	withEnvironment := func(e economy.RevenueCatEvent, environment string) economy.RevenueCatEvent {
+		e.Environment = environment
+		return e
+	}
+	withCancelReason := func(e economy.RevenueCatEvent, reason string) economy.RevenueCatEvent {
+		e.CancelReason = reason
+		return e
+	}
+	markedStatus := func(t *testing.T, f *revenueCatFakes) string {
+		require.Equal(t, 1, f.eventLog.MarkEventCallCount(), "the outcome is recorded once")
+		_, _, updater := f.eventLog.MarkEventArgsForCall(0)
+		return updater.Status
+	}
+
+	testCases := []testCaseRevenueCatEvent{
+		{
+			name:  "error-secret-not-set",
+			event: event("INITIAL_PURCHASE", "com.app.wingedplus_monthly"),
+			configure: func(b *economy.Business) {
+				b.SetRevenueCatWebhookSecret("")
+			},
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.ErrorIs(t, err, economy.ErrWebhookSecretNotSet)
+				assert.Nil(t, resp)
+				assert.Zero(t, f.eventLog.RecordEventCallCount(), "an unverified webhook isn't stored")
+			},
+		},
+		{
+			name:          "error-wrong-authorization",
+			authorization: "Bearer not-the-secret",
+			event:         event("INITIAL_PURCHASE", "com.app.wingedplus_monthly"),
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.ErrorIs(t, err, economy.ErrUnauthorizedWebhook)
+				assert.Nil(t, resp)
+				assert.Zero(t, f.eventLog.RecordEventCallCount(), "an unverified webhook isn't stored")
+			},
+		},
+		{
+			name:          "success-bearer-authorization-credits-payment",
+			authorization: "Bearer " + testRevenueCatSecret,
+			event:         event("INITIAL_PURCHASE", "com.app.wingedplus_monthly"),
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, economy.RevenueCatActionProcessed, resp.Action)
+
+				require.Equal(t, 1, f.actionLogger.CreateActionLogCallCount())
+				_, _, inserter := f.actionLogger.CreateActionLogArgsForCall(0)
+				assert.Equal(t, userID, inserter.UserID)
+				assert.Equal(t, resp.EventID, inserter.RefID, "the event ID makes the payment idempotent")
+				assert.Equal(t, economyLib.ActionWingedPlusMonthlyPayment, inserter.Type)
+				assert.Equal(t, enums.RevenueCatEventStatusProcessed.String(), markedStatus(t, f))
+			},
+		},
+		{
+			name:  "ignored-timestamp-too-old",
+			event: withTimestamp(event("INITIAL_PURCHASE", "com.app.wingedplus_monthly"), now.Add(-48*time.Hour)),
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, economy.RevenueCatActionIgnored, resp.Action)
+				assert.Equal(t, "timestamp_too_old", resp.Reason)
+				assert.Equal(t, 1, f.eventLog.RecordEventCallCount(), "the event is kept for review")
+				assert.Zero(t, f.actionLogger.CreateActionLogCallCount())
+				assert.Equal(t, enums.RevenueCatEventStatusIgnored.String(), markedStatus(t, f))
+			},
+		},
+		{
+			name:  "ignored-timestamp-in-future",
+			event: withTimestamp(event("INITIAL_PURCHASE", "com.app.wingedplus_monthly"), now.Add(time.Hour)),
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "timestamp_in_future", resp.Reason)
+				assert.Zero(t, f.actionLogger.CreateActionLogCallCount())
+			},
+		},
+		{
+			name:  "ignored-missing-timestamp",
+			event: withTimestamp(event("INITIAL_PURCHASE", "com.app.wingedplus_monthly"), time.UnixMilli(0)),
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "missing_timestamp", resp.Reason)
+				assert.Zero(t, f.actionLogger.CreateActionLogCallCount())
+			},
+		},
+		{
+			name:         "success-processed-event-not-processed-again",
+			storedStatus: enums.RevenueCatEventStatusProcessed,
+			event:        event("INITIAL_PURCHASE", "com.app.wingedplus_monthly"),
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, economy.RevenueCatActionAlreadyProcessed, resp.Action)
+				assert.Zero(t, f.transactor.TXCallCount())
+				assert.Zero(t, f.actionLogger.CreateActionLogCallCount())
+				assert.Zero(t, f.eventLog.MarkEventCallCount())
+			},
+		},
+		{
+			name:         "success-ignored-event-not-processed-again",
+			storedStatus: enums.RevenueCatEventStatusIgnored,
+			event:        event("INITIAL_PURCHASE", "com.app.wingedplus_monthly"),
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, economy.RevenueCatActionAlreadyProcessed, resp.Action)
+				assert.Zero(t, f.actionLogger.CreateActionLogCallCount())
+			},
+		},
+		{
+			name:         "success-failed-event-retried",
+			storedStatus: enums.RevenueCatEventStatusFailed,
+			event:        event("RENEWAL", "com.app.wingedplus_monthly"),
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, economy.RevenueCatActionProcessed, resp.Action)
+				assert.Equal(t, 1, f.actionLogger.CreateActionLogCallCount())
+				assert.Equal(t, enums.RevenueCatEventStatusProcessed.String(), markedStatus(t, f))
+			},
+		},
+		{
+			name:  "error-handling-failure-marks-event-failed",
+			event: event("INITIAL_PURCHASE", "com.app.wingedplus_monthly"),
+			setup: func(f *revenueCatFakes) {
+				f.actionLogger.CreateActionLogReturns(errors.New("db down"))
+			},
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.Error(t, err, "RevenueCat redelivers a failed event")
+				assert.Nil(t, resp)
+				assert.Equal(t, enums.RevenueCatEventStatusFailed.String(), markedStatus(t, f))
+			},
+		},
+		{
+			name:  "ignored-sandbox-event",
+			event: withEnvironment(event("INITIAL_PURCHASE", "com.app.wingedplus_monthly"), "SANDBOX"),
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, economy.RevenueCatActionIgnored, resp.Action)
+				assert.Equal(t, "sandbox_environment", resp.Reason)
+				assert.Zero(t, f.actionLogger.CreateActionLogCallCount())
+				assert.Equal(t, enums.RevenueCatEventStatusIgnored.String(), markedStatus(t, f))
+			},
+		},
+		{
+			name:  "success-sandbox-event-processed-when-enabled",
+			event: withEnvironment(event("INITIAL_PURCHASE", "com.app.wingedplus_monthly"), "SANDBOX"),
+			configure: func(b *economy.Business) {
+				b.SetProcessSandboxEvents(true)
+			},
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, economy.RevenueCatActionProcessed, resp.Action)
+				assert.Equal(t, 1, f.actionLogger.CreateActionLogCallCount())
+			},
+		},
+		{
+			name:  "success-refund-voids-payment-by-transaction-id",
+			event: event("REFUND", "com.app.wingedplus_monthly"),
+			setup: func(f *revenueCatFakes) {
+				f.eventLog.EventsReturns([]economyLib.RevenueCatEvent{{EventID: "payment-event-1"}}, nil)
+				f.actionLogger.VoidPaymentReturns(1, nil)
+			},
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, economy.RevenueCatActionRefunded, resp.Action)
+
+				require.Equal(t, 1, f.eventLog.EventsCallCount())
+				_, _, filter := f.eventLog.EventsArgsForCall(0)
+				assert.Equal(t, "store-transaction-1", filter.TransactionID.String)
+				assert.ElementsMatch(t, []string{"INITIAL_PURCHASE", "RENEWAL"}, filter.Types)
+
+				require.Equal(t, 1, f.actionLogger.VoidPaymentCallCount())
+				_, _, refID := f.actionLogger.VoidPaymentArgsForCall(0)
+				assert.Equal(t, "payment-event-1", refID)
+				assert.Zero(t, f.actionLogger.SetPremiumExpiryCallCount(), "Winged+ grants no premium to end")
+			},
+		},
+		{
+			name:  "ignored-refund-of-unknown-payment",
+			event: event("REFUND", "com.app.wingedplus_monthly"),
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, economy.RevenueCatActionIgnored, resp.Action)
+				assert.Equal(t, "payment_not_found", resp.Reason)
+				assert.Zero(t, f.actionLogger.VoidPaymentCallCount())
+			},
+		},
+		{
+			name:  "success-customer-support-cancellation-is-refund",
+			event: withCancelReason(event("CANCELLATION", "com.app.wingedx_monthly"), "CUSTOMER_SUPPORT"),
+			setup: func(f *revenueCatFakes) {
+				f.eventLog.EventsReturns([]economyLib.RevenueCatEvent{{EventID: "payment-event-1"}}, nil)
+				f.actionLogger.VoidPaymentReturns(1, nil)
+			},
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, economy.RevenueCatActionRefunded, resp.Action)
+				assert.Equal(t, 1, f.actionLogger.VoidPaymentCallCount())
+
+				require.Equal(t, 1, f.actionLogger.SetPremiumExpiryCallCount())
+				_, _, premiumUserID, expiresAt, _ := f.actionLogger.SetPremiumExpiryArgsForCall(0)
+				assert.Equal(t, userID, premiumUserID)
+				assert.WithinDuration(t, now, expiresAt, time.Second, "premium ends at the refund")
+			},
+		},
+		{
+			name:  "success-cancellation-syncs-premium",
+			event: withCancelReason(event("CANCELLATION", "com.app.wingedx_monthly"), "UNSUBSCRIBE"),
+			setup: func(f *revenueCatFakes) {
+				f.actionLogger.SetPremiumExpiryReturns(true, nil)
+			},
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, economy.RevenueCatActionPremiumUpdated, resp.Action)
+				assert.Zero(t, f.actionLogger.VoidPaymentCallCount(), "a cancellation isn't a refund")
+
+				require.Equal(t, 1, f.actionLogger.SetPremiumExpiryCallCount())
+				_, _, _, expiresAt, _ := f.actionLogger.SetPremiumExpiryArgsForCall(0)
+				assert.WithinDuration(t, now.AddDate(0, 1, 0), expiresAt, time.Second, "premium lasts until the store's expiration")
+			},
+		},
+		{
+			name: "success-transfer-to-every-recipient",
+			event: func() economy.RevenueCatEvent {
+				e := event("TRANSFER", "")
+				e.TransferredFrom = []string{uuid.NewString()}
+				e.TransferredTo = []string{userID, "$RCAnonymousID:skipped", "5c2a6ad7-6c39-4bb4-9f5a-0a2cbe4d3e11"}
+				return e
+			}(),
+			assertions: func(t *testing.T, f *revenueCatFakes, resp *economy.RevenueCatWebhookResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, economy.RevenueCatActionTransferred, resp.Action)
+
+				require.Equal(t, 1, f.actionLogger.TransferPremiumCallCount(), "the premium is worked out once for all recipients")
+				_, _, _, toUserIDs, _ := f.actionLogger.TransferPremiumArgsForCall(0)
+				assert.Equal(t, []string{userID, "5c2a6ad7-6c39-4bb4-9f5a-0a2cbe4d3e11"}, toUserIDs)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := &revenueCatFakes{
+				transactor:   &economyfakes.FakeTransactor{},
+				actionLogger: &economyfakes.FakeActionLogger{},
+				eventLog:     &economyfakes.FakeRevenueCatEventLogger{},
+			}
+			f.transactor.TXReturns(fakeTx{}, nil)
+
+			storedStatus := tc.storedStatus
+			if storedStatus == "" {
+				storedStatus = enums.RevenueCatEventStatusReceived
+			}
+			stored := &economyLib.RevenueCatEvent{
+				ID:      uuid.NewString(),
+				EventID: tc.event.ID,
+				Status:  storedStatus.String(),
+			}
+			f.eventLog.RecordEventReturns(stored, nil)
+			f.eventLog.EventReturns(stored, nil)
+			if tc.setup != nil {
+				tc.setup(f)
+			}
+
+			b, err := economy.NewBusiness(f.transactor, &economyfakes.FakeCheckinPerformer{}, f.actionLogger, f.eventLog)
+			require.NoError(t, err)
+			b.SetRevenueCatWebhookSecret(testRevenueCatSecret)
+			if tc.configure != nil {
+				tc.configure(b)
+			}
+
+			authorization := tc.authorization
+			if authorization == "" {
+				authorization = testRevenueCatSecret
+			}
+
+			resp, err := b.ProcessRevenueCatEvent(context.Background(), authorization, &economy.RevenueCatWebhookRequest{
+				APIVersion: "1.0",
+				Event:      tc.event,
+			})
+
+			tc.assertions(t, f, resp, err)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package economy
+
+import (
+	"context"
+	"fmt"
+
+	economyLib "wingedapp/pgtester/internal/wingedapp/lib/economy"
+)
+
+// TierFree is the tier of users without a subscription or premium access.
+const TierFree = economyLib.PricingTierFree
+
+// SubscriptionHistory returns the subscription periods the user bought, the
+// latest first.
+func (b *Business) SubscriptionHistory(ctx context.Context, userID string) (*SubscriptionHistoryResponse, error) {
+	periods, err := b.actionLogger.SubscriptionHistory(ctx, b.transactor.DB(), userID)
+	if err != nil {
+		return nil, fmt.Errorf("subscription history: %w", err)
+	}
+
+	resp := &SubscriptionHistoryResponse{
+		Periods: make([]SubscriptionPeriodResponse, 0, len(periods)),
+	}
+	for i := range periods {
+		resp.Periods = append(resp.Periods, *toSubscriptionPeriodResponse(&periods[i]))
+	}
+
+	return resp, nil
+}
+
+// CurrentEntitlement returns what the user is entitled to right now. Premium
+// access without a current period, e.g. transferred from another account,
+// is the WingedX tier.
+func (b *Business) CurrentEntitlement(ctx context.Context, userID string) (*EntitlementResponse, error) {
+	entitlement, err := b.actionLogger.Entitlement(ctx, b.transactor.DB(), userID)
+	if err != nil {
+		return nil, fmt.Errorf("entitlement: %w", err)
+	}
+
+	resp := &EntitlementResponse{
+		Tier:      TierFree,
+		IsPremium: entitlement.IsPremium,
+		Wings:     entitlement.Wings,
+	}
+	if entitlement.PremiumExpiresAt.Valid {
+		resp.PremiumExpiresAt = &entitlement.PremiumExpiresAt.Time
+	}
+
+	switch {
+	case entitlement.Period != nil:
+		resp.Tier = entitlement.Period.SubscriptionType
+		resp.Period = toSubscriptionPeriodResponse(entitlement.Period)
+	case entitlement.IsPremium:
+		resp.Tier = economyLib.SubscriptionTypeWingedX
+	}
+
+	return resp, nil
+}
+
+func toSubscriptionPeriodResponse(period *economyLib.SubscriptionPeriod) *SubscriptionPeriodResponse {
+	resp := &SubscriptionPeriodResponse{
+		ID:               period.ID,
+		SubscriptionType: period.SubscriptionType,
+		Plan:             period.PlanName,
+		WingsGranted:     period.WingsGranted,
+		StartDate:        period.StartDate,
+		EndDate:          period.EndDate,
+		EndReason:        period.EndReason.String,
+		Voided:           period.IsActive == 0,
+	}
+	if period.Price.Big != nil {
+		resp.Price = period.Price.String()
+	}
+
+	return resp
+}
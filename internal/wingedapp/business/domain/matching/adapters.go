@@ -22,6 +22,7 @@ type transactor interface {
 type matchGetter interface {
 	MatchResults(ctx context.Context, exec boil.ContextExecutor, aiExec boil.ContextExecutor, f *matchLib.QueryFilterMatchResult) (*matchLib.MatchResultPaginated, error)
 	MatchResult(ctx context.Context, exec boil.ContextExecutor, aiExec boil.ContextExecutor, f *matchLib.QueryFilterMatchResult) (*matchLib.MatchResult, error)
+	ExplainMatch(ctx context.Context, exec boil.ContextExecutor, matchResultID uuid.UUID) (*matchLib.MatchExplanation, error)
 }
 
 // approvalSetter controls is_approved flag on match results.
@@ -48,6 +49,8 @@ type expirySetter interface {
 // configGetter contains methods to get match configuration
 type configGetter interface {
 	Config(ctx context.Context, exec boil.ContextExecutor, f *matchLib.QueryFilterMatchConfig) (*matchLib.Config, error)
+	ConfigVersions(ctx context.Context, exec boil.ContextExecutor, f *matchLib.QueryFilterMatchConfigVersion) ([]matchLib.ConfigVersion, error)
+	DiffConfigVersions(ctx context.Context, exec boil.ContextExecutor, from, to int) (*matchLib.ConfigDiff, error)
 }
 
 // matchSetGetter contains methods to get match sets with pagination
@@ -58,7 +61,8 @@ type matchSetGetter interface {
 
 // configUpdater contains methods to update match configuration
 type configUpdater interface {
-	UpdateConfig(ctx context.Context, exec boil.ContextExecutor, updater *matchLib.UpdateMatchConfig) (*matchLib.Config, error)
+	UpdateConfig(ctx context.Context, tx boil.ContextTransactor, updater *matchLib.UpdateMatchConfig) (*matchLib.Config, error)
+	RollbackConfig(ctx context.Context, tx boil.ContextTransactor, version int) (*matchLib.ConfigVersion, error)
 }
 
 // ingester contains methods to run the matching ingestion process
@@ -69,7 +73,7 @@ type ingester interface {
 
 // matchRunner contains methods to run the matching algorithm on ingested sets
 type matchRunner interface {
-	RunIngestionSet(ctx context.Context, exec boil.ContextExecutor, aiExec boil.ContextExecutor, matchSetID uuid.UUID) error
+	RunIngestionSet(ctx context.Context, exec boil.ContextExecutor, aiExec boil.ContextExecutor, matchSetID uuid.UUID) (*matchLib.IngestionSetSummary, error)
 }
 
 // populator contains methods to populate users from CSV data
@@ -498,8 +498,8 @@ func (b *Business) MatchOverlaps(ctx context.Context, userID, matchID uuid.UUID)
 	}
 
 	// 2. Validate user is in match
-	userIDA := matchResult.UserAID.String()
-	userIDB := matchResult.UserBID.String()
+	userIDA := matchResult.InitiatorUserID.String()
+	userIDB := matchResult.ReceiverUserID.String()
 	userIDStr := userID.String()
 
 	if userIDStr != userIDA && userIDStr != userIDB {
@@ -509,7 +509,7 @@ func (b *Business) MatchOverlaps(ctx context.Context, userID, matchID uuid.UUID)
 	// 3. Get the UserMatch to check action status
 	// We need to fetch the user's match view to check both actions
 	userMatchA, err := b.userMatchGetter.UserMatch(ctx, exec, aiExec, &matchLib.QueryFilterUserMatch{
-		UserID:  matchResult.UserAID,
+		UserID:  matchResult.InitiatorUserID,
 		MatchID: null.StringFrom(matchID.String()),
 	})
 	if err != nil {
@@ -517,7 +517,7 @@ func (b *Business) MatchOverlaps(ctx context.Context, userID, matchID uuid.UUID)
 	}
 
 	userMatchB, err := b.userMatchGetter.UserMatch(ctx, exec, aiExec, &matchLib.QueryFilterUserMatch{
-		UserID:  matchResult.UserBID,
+		UserID:  matchResult.ReceiverUserID,
 		MatchID: null.StringFrom(matchID.String()),
 	})
 	if err != nil {
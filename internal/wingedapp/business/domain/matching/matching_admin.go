@@ -5,6 +5,8 @@ import (
 	"fmt"
 	jobqueueLib "wingedapp/pgtester/internal/wingedapp/lib/jobqueue"
 	matchLib "wingedapp/pgtester/internal/wingedapp/lib/matching"
+
+	"github.com/google/uuid"
 )
 
 /*
@@ -185,6 +187,20 @@ func (b *Business) AdminMatchResult(
 	return result, nil
 }
 
+// ExplainMatch breaks down how a match result was decided: each hard
+// qualifier's inputs, thresholds and outcome, and the AI sub-scores (admin only)
+func (b *Business) ExplainMatch(
+	ctx context.Context,
+	matchResultID uuid.UUID,
+) (*matchLib.MatchExplanation, error) {
+	explanation, err := b.matchGetter.ExplainMatch(ctx, b.transactor.DB(), matchResultID)
+	if err != nil {
+		return nil, fmt.Errorf("explain match: %w", err)
+	}
+
+	return explanation, nil
+}
+
 // AdminMatchSets returns match sets with pagination and filtering (admin only)
 func (b *Business) AdminMatchSets(
 	ctx context.Context,
@@ -235,6 +251,57 @@ func (b *Business) AdminUpdateConfig(
 	return config, nil
 }
 
+// AdminConfigVersions returns the match configuration versions, newest first (admin only)
+func (b *Business) AdminConfigVersions(
+	ctx context.Context,
+	f *matchLib.QueryFilterMatchConfigVersion,
+) ([]matchLib.ConfigVersion, error) {
+	versions, err := b.configGetter.ConfigVersions(ctx, b.transactor.DB(), f)
+	if err != nil {
+		return nil, fmt.Errorf("config versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// AdminDiffConfigVersions returns the fields that differ between two
+// match configuration versions (admin only)
+func (b *Business) AdminDiffConfigVersions(
+	ctx context.Context,
+	from, to int,
+) (*matchLib.ConfigDiff, error) {
+	diff, err := b.configGetter.DiffConfigVersions(ctx, b.transactor.DB(), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("diff config versions: %w", err)
+	}
+
+	return diff, nil
+}
+
+// AdminRollbackConfig restores the match configuration to an earlier version,
+// recorded as a new version (admin only)
+func (b *Business) AdminRollbackConfig(
+	ctx context.Context,
+	version int,
+) (*matchLib.ConfigVersion, error) {
+	tx, err := b.transactor.TX()
+	if err != nil {
+		return nil, fmt.Errorf("tx: %w", err)
+	}
+	defer b.transactor.Rollback(tx)
+
+	restored, err := b.configUpdater.RollbackConfig(ctx, tx, version)
+	if err != nil {
+		return nil, fmt.Errorf("rollback config: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return restored, nil
+}
+
 // ============================================================================
 // BATCH INGESTION
 // ============================================================================
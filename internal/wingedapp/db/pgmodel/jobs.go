@@ -35,6 +35,10 @@ type Job struct {
 	CreatedAt   time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	StartedAt   null.Time   `boil:"started_at" json:"started_at,omitempty" toml:"started_at" yaml:"started_at,omitempty"`
 	CompletedAt null.Time   `boil:"completed_at" json:"completed_at,omitempty" toml:"completed_at" yaml:"completed_at,omitempty"`
+	// Job is not claimable before this time (retry backoff)
+	RunAfter time.Time `boil:"run_after" json:"run_after" toml:"run_after" yaml:"run_after"`
+	// Last liveness signal of the worker running the job
+	HeartbeatAt null.Time `boil:"heartbeat_at" json:"heartbeat_at,omitempty" toml:"heartbeat_at" yaml:"heartbeat_at,omitempty"`
 
 	R *jobR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L jobL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -52,6 +56,8 @@ var JobColumns = struct {
 	CreatedAt   string
 	StartedAt   string
 	CompletedAt string
+	RunAfter    string
+	HeartbeatAt string
 }{
 	ID:          "id",
 	Type:        "type",
@@ -64,6 +70,8 @@ var JobColumns = struct {
 	CreatedAt:   "created_at",
 	StartedAt:   "started_at",
 	CompletedAt: "completed_at",
+	RunAfter:    "run_after",
+	HeartbeatAt: "heartbeat_at",
 }
 
 var JobTableColumns = struct {
@@ -78,6 +86,8 @@ var JobTableColumns = struct {
 	CreatedAt   string
 	StartedAt   string
 	CompletedAt string
+	RunAfter    string
+	HeartbeatAt string
 }{
 	ID:          "jobs.id",
 	Type:        "jobs.type",
@@ -90,6 +100,8 @@ var JobTableColumns = struct {
 	CreatedAt:   "jobs.created_at",
 	StartedAt:   "jobs.started_at",
 	CompletedAt: "jobs.completed_at",
+	RunAfter:    "jobs.run_after",
+	HeartbeatAt: "jobs.heartbeat_at",
 }
 
 // Generated where
@@ -129,6 +141,8 @@ var JobWhere = struct {
 	CreatedAt   whereHelpertime_Time
 	StartedAt   whereHelpernull_Time
 	CompletedAt whereHelpernull_Time
+	RunAfter    whereHelpertime_Time
+	HeartbeatAt whereHelpernull_Time
 }{
 	ID:          whereHelperstring{field: "\"jobs\".\"id\""},
 	Type:        whereHelperstring{field: "\"jobs\".\"type\""},
@@ -141,6 +155,8 @@ var JobWhere = struct {
 	CreatedAt:   whereHelpertime_Time{field: "\"jobs\".\"created_at\""},
 	StartedAt:   whereHelpernull_Time{field: "\"jobs\".\"started_at\""},
 	CompletedAt: whereHelpernull_Time{field: "\"jobs\".\"completed_at\""},
+	RunAfter:    whereHelpertime_Time{field: "\"jobs\".\"run_after\""},
+	HeartbeatAt: whereHelpernull_Time{field: "\"jobs\".\"heartbeat_at\""},
 }
 
 // JobRels is where relationship names are stored.
@@ -160,9 +176,9 @@ func (*jobR) NewStruct() *jobR {
 type jobL struct{}
 
 var (
-	jobAllColumns            = []string{"id", "type", "status", "payload", "result", "error", "attempts", "max_attempts", "created_at", "started_at", "completed_at"}
+	jobAllColumns            = []string{"id", "type", "status", "payload", "result", "error", "attempts", "max_attempts", "created_at", "started_at", "completed_at", "run_after", "heartbeat_at"}
 	jobColumnsWithoutDefault = []string{"type"}
-	jobColumnsWithDefault    = []string{"id", "status", "payload", "result", "error", "attempts", "max_attempts", "created_at", "started_at", "completed_at"}
+	jobColumnsWithDefault    = []string{"id", "status", "payload", "result", "error", "attempts", "max_attempts", "created_at", "started_at", "completed_at", "run_after", "heartbeat_at"}
 	jobPrimaryKeyColumns     = []string{"id"}
 	jobGeneratedColumns      = []string{}
 )
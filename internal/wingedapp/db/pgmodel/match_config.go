@@ -44,6 +44,18 @@ type MatchConfig struct {
 	MatchBlockClosed          int               `boil:"match_block_closed" json:"match_block_closed" toml:"match_block_closed" yaml:"match_block_closed"`
 	ScoreRangeStart           types.Decimal     `boil:"score_range_start" json:"score_range_start" toml:"score_range_start" yaml:"score_range_start"`
 	ScoreRangeEnd             types.Decimal     `boil:"score_range_end" json:"score_range_end" toml:"score_range_end" yaml:"score_range_end"`
+	// Enabled hard qualifiers, in execution order
+	Qualifiers          types.StringArray `boil:"qualifiers" json:"qualifiers" toml:"qualifiers" yaml:"qualifiers"`
+	ScoreWeightDistance types.Decimal     `boil:"score_weight_distance" json:"score_weight_distance" toml:"score_weight_distance" yaml:"score_weight_distance"`
+	ScoreWeightAge      types.Decimal     `boil:"score_weight_age" json:"score_weight_age" toml:"score_weight_age" yaml:"score_weight_age"`
+	ScoreWeightHeight   types.Decimal     `boil:"score_weight_height" json:"score_weight_height" toml:"score_weight_height" yaml:"score_weight_height"`
+	ScoreWeightTraits   types.Decimal     `boil:"score_weight_traits" json:"score_weight_traits" toml:"score_weight_traits" yaml:"score_weight_traits"`
+	// Share of the AI score in the final score, the soft score gets the rest (0..1)
+	ScoreWeightAi types.Decimal `boil:"score_weight_ai" json:"score_weight_ai" toml:"score_weight_ai" yaml:"score_weight_ai"`
+	// How long a cached qualitative result is reused, 0 disables the cache
+	QualitativeCacheTTLHours int `boil:"qualitative_cache_ttl_hours" json:"qualitative_cache_ttl_hours" toml:"qualitative_cache_ttl_hours" yaml:"qualitative_cache_ttl_hours"`
+	// Only pair users that can pass the distance and dating preference qualifiers
+	CandidatePrefilter bool `boil:"candidate_prefilter" json:"candidate_prefilter" toml:"candidate_prefilter" yaml:"candidate_prefilter"`
 
 	R *matchConfigR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L matchConfigL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -69,6 +81,14 @@ var MatchConfigColumns = struct {
 	MatchBlockClosed          string
 	ScoreRangeStart           string
 	ScoreRangeEnd             string
+	Qualifiers                string
+	ScoreWeightDistance       string
+	ScoreWeightAge            string
+	ScoreWeightHeight         string
+	ScoreWeightTraits         string
+	ScoreWeightAi             string
+	QualitativeCacheTTLHours  string
+	CandidatePrefilter        string
 }{
 	ID:                        "id",
 	AgeRangeStart:             "age_range_start",
@@ -89,6 +109,14 @@ var MatchConfigColumns = struct {
 	MatchBlockClosed:          "match_block_closed",
 	ScoreRangeStart:           "score_range_start",
 	ScoreRangeEnd:             "score_range_end",
+	Qualifiers:                "qualifiers",
+	ScoreWeightDistance:       "score_weight_distance",
+	ScoreWeightAge:            "score_weight_age",
+	ScoreWeightHeight:         "score_weight_height",
+	ScoreWeightTraits:         "score_weight_traits",
+	ScoreWeightAi:             "score_weight_ai",
+	QualitativeCacheTTLHours:  "qualitative_cache_ttl_hours",
+	CandidatePrefilter:        "candidate_prefilter",
 }
 
 var MatchConfigTableColumns = struct {
@@ -111,6 +139,14 @@ var MatchConfigTableColumns = struct {
 	MatchBlockClosed          string
 	ScoreRangeStart           string
 	ScoreRangeEnd             string
+	Qualifiers                string
+	ScoreWeightDistance       string
+	ScoreWeightAge            string
+	ScoreWeightHeight         string
+	ScoreWeightTraits         string
+	ScoreWeightAi             string
+	QualitativeCacheTTLHours  string
+	CandidatePrefilter        string
 }{
 	ID:                        "match_config.id",
 	AgeRangeStart:             "match_config.age_range_start",
@@ -131,6 +167,14 @@ var MatchConfigTableColumns = struct {
 	MatchBlockClosed:          "match_config.match_block_closed",
 	ScoreRangeStart:           "match_config.score_range_start",
 	ScoreRangeEnd:             "match_config.score_range_end",
+	Qualifiers:                "match_config.qualifiers",
+	ScoreWeightDistance:       "match_config.score_weight_distance",
+	ScoreWeightAge:            "match_config.score_weight_age",
+	ScoreWeightHeight:         "match_config.score_weight_height",
+	ScoreWeightTraits:         "match_config.score_weight_traits",
+	ScoreWeightAi:             "match_config.score_weight_ai",
+	QualitativeCacheTTLHours:  "match_config.qualitative_cache_ttl_hours",
+	CandidatePrefilter:        "match_config.candidate_prefilter",
 }
 
 // Generated where
@@ -218,6 +262,14 @@ var MatchConfigWhere = struct {
 	MatchBlockClosed          whereHelperint
 	ScoreRangeStart           whereHelpertypes_Decimal
 	ScoreRangeEnd             whereHelpertypes_Decimal
+	Qualifiers                whereHelpertypes_StringArray
+	ScoreWeightDistance       whereHelpertypes_Decimal
+	ScoreWeightAge            whereHelpertypes_Decimal
+	ScoreWeightHeight         whereHelpertypes_Decimal
+	ScoreWeightTraits         whereHelpertypes_Decimal
+	ScoreWeightAi             whereHelpertypes_Decimal
+	QualitativeCacheTTLHours  whereHelperint
+	CandidatePrefilter        whereHelperbool
 }{
 	ID:                        whereHelperstring{field: "\"match_config\".\"id\""},
 	AgeRangeStart:             whereHelpernull_Int{field: "\"match_config\".\"age_range_start\""},
@@ -238,6 +290,14 @@ var MatchConfigWhere = struct {
 	MatchBlockClosed:          whereHelperint{field: "\"match_config\".\"match_block_closed\""},
 	ScoreRangeStart:           whereHelpertypes_Decimal{field: "\"match_config\".\"score_range_start\""},
 	ScoreRangeEnd:             whereHelpertypes_Decimal{field: "\"match_config\".\"score_range_end\""},
+	Qualifiers:                whereHelpertypes_StringArray{field: "\"match_config\".\"qualifiers\""},
+	ScoreWeightDistance:       whereHelpertypes_Decimal{field: "\"match_config\".\"score_weight_distance\""},
+	ScoreWeightAge:            whereHelpertypes_Decimal{field: "\"match_config\".\"score_weight_age\""},
+	ScoreWeightHeight:         whereHelpertypes_Decimal{field: "\"match_config\".\"score_weight_height\""},
+	ScoreWeightTraits:         whereHelpertypes_Decimal{field: "\"match_config\".\"score_weight_traits\""},
+	ScoreWeightAi:             whereHelpertypes_Decimal{field: "\"match_config\".\"score_weight_ai\""},
+	QualitativeCacheTTLHours:  whereHelperint{field: "\"match_config\".\"qualitative_cache_ttl_hours\""},
+	CandidatePrefilter:        whereHelperbool{field: "\"match_config\".\"candidate_prefilter\""},
 }
 
 // MatchConfigRels is where relationship names are stored.
@@ -257,9 +317,9 @@ func (*matchConfigR) NewStruct() *matchConfigR {
 type matchConfigL struct{}
 
 var (
-	matchConfigAllColumns            = []string{"id", "age_range_start", "age_range_end", "age_range_woman_older_by", "age_range_man_older_by", "height_male_greater_by_cm", "location_radius_km", "location_adaptive_expansion", "match_hours", "drop_hours", "drop_hours_utc", "stale_chat_nudge", "stale_chat_agent_setup", "match_expiration_hours", "match_block_declined", "match_block_ignored", "match_block_closed", "score_range_start", "score_range_end"}
+	matchConfigAllColumns            = []string{"id", "age_range_start", "age_range_end", "age_range_woman_older_by", "age_range_man_older_by", "height_male_greater_by_cm", "location_radius_km", "location_adaptive_expansion", "match_hours", "drop_hours", "drop_hours_utc", "stale_chat_nudge", "stale_chat_agent_setup", "match_expiration_hours", "match_block_declined", "match_block_ignored", "match_block_closed", "score_range_start", "score_range_end", "qualifiers", "score_weight_distance", "score_weight_age", "score_weight_height", "score_weight_traits", "score_weight_ai", "qualitative_cache_ttl_hours", "candidate_prefilter"}
 	matchConfigColumnsWithoutDefault = []string{}
-	matchConfigColumnsWithDefault    = []string{"id", "age_range_start", "age_range_end", "age_range_woman_older_by", "age_range_man_older_by", "height_male_greater_by_cm", "location_radius_km", "location_adaptive_expansion", "match_hours", "drop_hours", "drop_hours_utc", "stale_chat_nudge", "stale_chat_agent_setup", "match_expiration_hours", "match_block_declined", "match_block_ignored", "match_block_closed", "score_range_start", "score_range_end"}
+	matchConfigColumnsWithDefault    = []string{"id", "age_range_start", "age_range_end", "age_range_woman_older_by", "age_range_man_older_by", "height_male_greater_by_cm", "location_radius_km", "location_adaptive_expansion", "match_hours", "drop_hours", "drop_hours_utc", "stale_chat_nudge", "stale_chat_agent_setup", "match_expiration_hours", "match_block_declined", "match_block_ignored", "match_block_closed", "score_range_start", "score_range_end", "qualifiers", "score_weight_distance", "score_weight_age", "score_weight_height", "score_weight_traits", "score_weight_ai", "qualitative_cache_ttl_hours", "candidate_prefilter"}
 	matchConfigPrimaryKeyColumns     = []string{"id"}
 	matchConfigGeneratedColumns      = []string{}
 )
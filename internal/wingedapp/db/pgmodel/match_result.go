@@ -24,152 +24,207 @@ import (
 
 // MatchResult is an object representing the database table.
 type MatchResult struct {
-	ID                    string      `boil:"id" json:"id" toml:"id" yaml:"id"`
-	MatchSetRefID         string      `boil:"match_set_ref_id" json:"match_set_ref_id" toml:"match_set_ref_id" yaml:"match_set_ref_id"`
-	InitiatorUserRefID    string      `boil:"initiator_user_ref_id" json:"initiator_user_ref_id" toml:"initiator_user_ref_id" yaml:"initiator_user_ref_id"`
-	ReceiverUserRefID     string      `boil:"receiver_user_ref_id" json:"receiver_user_ref_id" toml:"receiver_user_ref_id" yaml:"receiver_user_ref_id"`
-	MatchStatus           string      `boil:"match_status" json:"match_status" toml:"match_status" yaml:"match_status"`
-	MatchLifecycleStatus  null.String `boil:"match_lifecycle_status" json:"match_lifecycle_status,omitempty" toml:"match_lifecycle_status" yaml:"match_lifecycle_status,omitempty"`
-	CurrentDateInstanceID null.String `boil:"current_date_instance_id" json:"current_date_instance_id,omitempty" toml:"current_date_instance_id" yaml:"current_date_instance_id,omitempty"`
-	InitiatorAction           string      `boil:"initiator_action" json:"initiator_action" toml:"initiator_action" yaml:"initiator_action"`
-	InitiatorActionAt         null.Time   `boil:"initiator_action_at" json:"initiator_action_at,omitempty" toml:"initiator_action_at" yaml:"initiator_action_at,omitempty"`
-	InitiatorSeenAt           null.Time   `boil:"initiator_seen_at" json:"initiator_seen_at,omitempty" toml:"initiator_seen_at" yaml:"initiator_seen_at,omitempty"`
-	ReceiverAction           string      `boil:"receiver_action" json:"receiver_action" toml:"receiver_action" yaml:"receiver_action"`
-	ReceiverActionAt         null.Time   `boil:"receiver_action_at" json:"receiver_action_at,omitempty" toml:"receiver_action_at" yaml:"receiver_action_at,omitempty"`
-	ReceiverSeenAt           null.Time   `boil:"receiver_seen_at" json:"receiver_seen_at,omitempty" toml:"receiver_seen_at" yaml:"receiver_seen_at,omitempty"`
-	QualifierResults      null.JSON   `boil:"qualifier_results" json:"qualifier_results,omitempty" toml:"qualifier_results" yaml:"qualifier_results,omitempty"`
-	MatchedQualitatively  bool        `boil:"matched_qualitatively" json:"matched_qualitatively" toml:"matched_qualitatively" yaml:"matched_qualitatively"`
-	DeliveredToUserAt     null.Time   `boil:"delivered_to_user_at" json:"delivered_to_user_at,omitempty" toml:"delivered_to_user_at" yaml:"delivered_to_user_at,omitempty"`
-	LastProposerUserRefID null.String `boil:"last_proposer_user_ref_id" json:"last_proposer_user_ref_id,omitempty" toml:"last_proposer_user_ref_id" yaml:"last_proposer_user_ref_id,omitempty"`
-	LastProposedAt        null.Time   `boil:"last_proposed_at" json:"last_proposed_at,omitempty" toml:"last_proposed_at" yaml:"last_proposed_at,omitempty"`
-	ChatUnlockedAt        null.Time   `boil:"chat_unlocked_at" json:"chat_unlocked_at,omitempty" toml:"chat_unlocked_at" yaml:"chat_unlocked_at,omitempty"`
-	IsApproved            bool        `boil:"is_approved" json:"is_approved" toml:"is_approved" yaml:"is_approved"`
-	IsDropped             bool        `boil:"is_dropped" json:"is_dropped" toml:"is_dropped" yaml:"is_dropped"`
-	DroppedTS             null.Time   `boil:"dropped_ts" json:"dropped_ts,omitempty" toml:"dropped_ts" yaml:"dropped_ts,omitempty"`
-	IsPossibleMatch       bool        `boil:"is_possible_match" json:"is_possible_match" toml:"is_possible_match" yaml:"is_possible_match"`
-	IsExpired             bool        `boil:"is_expired" json:"is_expired" toml:"is_expired" yaml:"is_expired"`
-	ExpiresAt             null.Time   `boil:"expires_at" json:"expires_at,omitempty" toml:"expires_at" yaml:"expires_at,omitempty"`
-	CreatedAt             time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt             null.Time   `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	ID                    string       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	MatchSetRefID         string       `boil:"match_set_ref_id" json:"match_set_ref_id" toml:"match_set_ref_id" yaml:"match_set_ref_id"`
+	InitiatorUserRefID    string       `boil:"initiator_user_ref_id" json:"initiator_user_ref_id" toml:"initiator_user_ref_id" yaml:"initiator_user_ref_id"`
+	ReceiverUserRefID     string       `boil:"receiver_user_ref_id" json:"receiver_user_ref_id" toml:"receiver_user_ref_id" yaml:"receiver_user_ref_id"`
+	MatchStatus           string       `boil:"match_status" json:"match_status" toml:"match_status" yaml:"match_status"`
+	MatchLifecycleStatus  null.String  `boil:"match_lifecycle_status" json:"match_lifecycle_status,omitempty" toml:"match_lifecycle_status" yaml:"match_lifecycle_status,omitempty"`
+	CurrentDateInstanceID null.String  `boil:"current_date_instance_id" json:"current_date_instance_id,omitempty" toml:"current_date_instance_id" yaml:"current_date_instance_id,omitempty"`
+	InitiatorAction       string       `boil:"initiator_action" json:"initiator_action" toml:"initiator_action" yaml:"initiator_action"`
+	InitiatorActionAt     null.Time    `boil:"initiator_action_at" json:"initiator_action_at,omitempty" toml:"initiator_action_at" yaml:"initiator_action_at,omitempty"`
+	InitiatorSeenAt       null.Time    `boil:"initiator_seen_at" json:"initiator_seen_at,omitempty" toml:"initiator_seen_at" yaml:"initiator_seen_at,omitempty"`
+	ReceiverAction        string       `boil:"receiver_action" json:"receiver_action" toml:"receiver_action" yaml:"receiver_action"`
+	ReceiverActionAt      null.Time    `boil:"receiver_action_at" json:"receiver_action_at,omitempty" toml:"receiver_action_at" yaml:"receiver_action_at,omitempty"`
+	ReceiverSeenAt        null.Time    `boil:"receiver_seen_at" json:"receiver_seen_at,omitempty" toml:"receiver_seen_at" yaml:"receiver_seen_at,omitempty"`
+	QualifierResults      null.JSON    `boil:"qualifier_results" json:"qualifier_results,omitempty" toml:"qualifier_results" yaml:"qualifier_results,omitempty"`
+	MatchedQualitatively  bool         `boil:"matched_qualitatively" json:"matched_qualitatively" toml:"matched_qualitatively" yaml:"matched_qualitatively"`
+	DeliveredToUserAt     null.Time    `boil:"delivered_to_user_at" json:"delivered_to_user_at,omitempty" toml:"delivered_to_user_at" yaml:"delivered_to_user_at,omitempty"`
+	LastProposerUserRefID null.String  `boil:"last_proposer_user_ref_id" json:"last_proposer_user_ref_id,omitempty" toml:"last_proposer_user_ref_id" yaml:"last_proposer_user_ref_id,omitempty"`
+	LastProposedAt        null.Time    `boil:"last_proposed_at" json:"last_proposed_at,omitempty" toml:"last_proposed_at" yaml:"last_proposed_at,omitempty"`
+	ChatUnlockedAt        null.Time    `boil:"chat_unlocked_at" json:"chat_unlocked_at,omitempty" toml:"chat_unlocked_at" yaml:"chat_unlocked_at,omitempty"`
+	IsApproved            bool         `boil:"is_approved" json:"is_approved" toml:"is_approved" yaml:"is_approved"`
+	IsDropped             bool         `boil:"is_dropped" json:"is_dropped" toml:"is_dropped" yaml:"is_dropped"`
+	DroppedTS             null.Time    `boil:"dropped_ts" json:"dropped_ts,omitempty" toml:"dropped_ts" yaml:"dropped_ts,omitempty"`
+	IsPossibleMatch       bool         `boil:"is_possible_match" json:"is_possible_match" toml:"is_possible_match" yaml:"is_possible_match"`
+	IsExpired             bool         `boil:"is_expired" json:"is_expired" toml:"is_expired" yaml:"is_expired"`
+	ExpiresAt             null.Time    `boil:"expires_at" json:"expires_at,omitempty" toml:"expires_at" yaml:"expires_at,omitempty"`
+	CreatedAt             time.Time    `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt             null.Time    `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	SoftScore             null.Float64 `boil:"soft_score" json:"soft_score,omitempty" toml:"soft_score" yaml:"soft_score,omitempty"`
+	AiScore               null.Float64 `boil:"ai_score" json:"ai_score,omitempty" toml:"ai_score" yaml:"ai_score,omitempty"`
+	FinalScore            null.Float64 `boil:"final_score" json:"final_score,omitempty" toml:"final_score" yaml:"final_score,omitempty"`
+	// What the initiator did by expiry: Ignored (no action), Passed or Proposed
+	InitiatorExpiryOutcome null.String `boil:"initiator_expiry_outcome" json:"initiator_expiry_outcome,omitempty" toml:"initiator_expiry_outcome" yaml:"initiator_expiry_outcome,omitempty"`
+	// What the receiver did by expiry: Ignored (no action), Passed or Proposed
+	ReceiverExpiryOutcome null.String `boil:"receiver_expiry_outcome" json:"receiver_expiry_outcome,omitempty" toml:"receiver_expiry_outcome" yaml:"receiver_expiry_outcome,omitempty"`
+	// When match_lifecycle_status became Closed
+	ClosedAt        null.Time `boil:"closed_at" json:"closed_at,omitempty" toml:"closed_at" yaml:"closed_at,omitempty"`
+	IngestionStatus string    `boil:"ingestion_status" json:"ingestion_status" toml:"ingestion_status" yaml:"ingestion_status"`
+	// Error of the last failed attempt to process the pair
+	IngestionError null.String `boil:"ingestion_error" json:"ingestion_error,omitempty" toml:"ingestion_error" yaml:"ingestion_error,omitempty"`
+	IngestedAt     null.Time   `boil:"ingested_at" json:"ingested_at,omitempty" toml:"ingested_at" yaml:"ingested_at,omitempty"`
+	// Outcome and telemetry of each hard qualifier, keyed by qualifier name
+	HardQualifierResults null.JSON `boil:"hard_qualifier_results" json:"hard_qualifier_results,omitempty" toml:"hard_qualifier_results" yaml:"hard_qualifier_results,omitempty"`
 
 	R *matchResultR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L matchResultL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var MatchResultColumns = struct {
-	ID                    string
-	MatchSetRefID         string
-	InitiatorUserRefID    string
-	ReceiverUserRefID     string
-	MatchStatus           string
-	MatchLifecycleStatus  string
-	CurrentDateInstanceID string
-	InitiatorAction           string
-	InitiatorActionAt         string
-	InitiatorSeenAt           string
-	ReceiverAction           string
-	ReceiverActionAt         string
-	ReceiverSeenAt           string
-	QualifierResults      string
-	MatchedQualitatively  string
-	DeliveredToUserAt     string
-	LastProposerUserRefID string
-	LastProposedAt        string
-	ChatUnlockedAt        string
-	IsApproved            string
-	IsDropped             string
-	DroppedTS             string
-	IsPossibleMatch       string
-	IsExpired             string
-	ExpiresAt             string
-	CreatedAt             string
-	UpdatedAt             string
+	ID                     string
+	MatchSetRefID          string
+	InitiatorUserRefID     string
+	ReceiverUserRefID      string
+	MatchStatus            string
+	MatchLifecycleStatus   string
+	CurrentDateInstanceID  string
+	InitiatorAction        string
+	InitiatorActionAt      string
+	InitiatorSeenAt        string
+	ReceiverAction         string
+	ReceiverActionAt       string
+	ReceiverSeenAt         string
+	QualifierResults       string
+	MatchedQualitatively   string
+	DeliveredToUserAt      string
+	LastProposerUserRefID  string
+	LastProposedAt         string
+	ChatUnlockedAt         string
+	IsApproved             string
+	IsDropped              string
+	DroppedTS              string
+	IsPossibleMatch        string
+	IsExpired              string
+	ExpiresAt              string
+	CreatedAt              string
+	UpdatedAt              string
+	SoftScore              string
+	AiScore                string
+	FinalScore             string
+	InitiatorExpiryOutcome string
+	ReceiverExpiryOutcome  string
+	ClosedAt               string
+	IngestionStatus        string
+	IngestionError         string
+	IngestedAt             string
+	HardQualifierResults   string
 }{
-	ID:                    "id",
-	MatchSetRefID:         "match_set_ref_id",
-	InitiatorUserRefID:    "initiator_user_ref_id",
-	ReceiverUserRefID:     "receiver_user_ref_id",
-	MatchStatus:           "match_status",
-	MatchLifecycleStatus:  "match_lifecycle_status",
-	CurrentDateInstanceID: "current_date_instance_id",
-	InitiatorAction:           "initiator_action",
-	InitiatorActionAt:         "initiator_action_at",
-	InitiatorSeenAt:           "initiator_seen_at",
-	ReceiverAction:           "receiver_action",
-	ReceiverActionAt:         "receiver_action_at",
-	ReceiverSeenAt:           "receiver_seen_at",
-	QualifierResults:      "qualifier_results",
-	MatchedQualitatively:  "matched_qualitatively",
-	DeliveredToUserAt:     "delivered_to_user_at",
-	LastProposerUserRefID: "last_proposer_user_ref_id",
-	LastProposedAt:        "last_proposed_at",
-	ChatUnlockedAt:        "chat_unlocked_at",
-	IsApproved:            "is_approved",
-	IsDropped:             "is_dropped",
-	DroppedTS:             "dropped_ts",
-	IsPossibleMatch:       "is_possible_match",
-	IsExpired:             "is_expired",
-	ExpiresAt:             "expires_at",
-	CreatedAt:             "created_at",
-	UpdatedAt:             "updated_at",
+	ID:                     "id",
+	MatchSetRefID:          "match_set_ref_id",
+	InitiatorUserRefID:     "initiator_user_ref_id",
+	ReceiverUserRefID:      "receiver_user_ref_id",
+	MatchStatus:            "match_status",
+	MatchLifecycleStatus:   "match_lifecycle_status",
+	CurrentDateInstanceID:  "current_date_instance_id",
+	InitiatorAction:        "initiator_action",
+	InitiatorActionAt:      "initiator_action_at",
+	InitiatorSeenAt:        "initiator_seen_at",
+	ReceiverAction:         "receiver_action",
+	ReceiverActionAt:       "receiver_action_at",
+	ReceiverSeenAt:         "receiver_seen_at",
+	QualifierResults:       "qualifier_results",
+	MatchedQualitatively:   "matched_qualitatively",
+	DeliveredToUserAt:      "delivered_to_user_at",
+	LastProposerUserRefID:  "last_proposer_user_ref_id",
+	LastProposedAt:         "last_proposed_at",
+	ChatUnlockedAt:         "chat_unlocked_at",
+	IsApproved:             "is_approved",
+	IsDropped:              "is_dropped",
+	DroppedTS:              "dropped_ts",
+	IsPossibleMatch:        "is_possible_match",
+	IsExpired:              "is_expired",
+	ExpiresAt:              "expires_at",
+	CreatedAt:              "created_at",
+	UpdatedAt:              "updated_at",
+	SoftScore:              "soft_score",
+	AiScore:                "ai_score",
+	FinalScore:             "final_score",
+	InitiatorExpiryOutcome: "initiator_expiry_outcome",
+	ReceiverExpiryOutcome:  "receiver_expiry_outcome",
+	ClosedAt:               "closed_at",
+	IngestionStatus:        "ingestion_status",
+	IngestionError:         "ingestion_error",
+	IngestedAt:             "ingested_at",
+	HardQualifierResults:   "hard_qualifier_results",
 }
 
 var MatchResultTableColumns = struct {
-	ID                    string
-	MatchSetRefID         string
-	InitiatorUserRefID    string
-	ReceiverUserRefID     string
-	MatchStatus           string
-	MatchLifecycleStatus  string
-	CurrentDateInstanceID string
-	InitiatorAction           string
-	InitiatorActionAt         string
-	InitiatorSeenAt           string
-	ReceiverAction           string
-	ReceiverActionAt         string
-	ReceiverSeenAt           string
-	QualifierResults      string
-	MatchedQualitatively  string
-	DeliveredToUserAt     string
-	LastProposerUserRefID string
-	LastProposedAt        string
-	ChatUnlockedAt        string
-	IsApproved            string
-	IsDropped             string
-	DroppedTS             string
-	IsPossibleMatch       string
-	IsExpired             string
-	ExpiresAt             string
-	CreatedAt             string
-	UpdatedAt             string
+	ID                     string
+	MatchSetRefID          string
+	InitiatorUserRefID     string
+	ReceiverUserRefID      string
+	MatchStatus            string
+	MatchLifecycleStatus   string
+	CurrentDateInstanceID  string
+	InitiatorAction        string
+	InitiatorActionAt      string
+	InitiatorSeenAt        string
+	ReceiverAction         string
+	ReceiverActionAt       string
+	ReceiverSeenAt         string
+	QualifierResults       string
+	MatchedQualitatively   string
+	DeliveredToUserAt      string
+	LastProposerUserRefID  string
+	LastProposedAt         string
+	ChatUnlockedAt         string
+	IsApproved             string
+	IsDropped              string
+	DroppedTS              string
+	IsPossibleMatch        string
+	IsExpired              string
+	ExpiresAt              string
+	CreatedAt              string
+	UpdatedAt              string
+	SoftScore              string
+	AiScore                string
+	FinalScore             string
+	InitiatorExpiryOutcome string
+	ReceiverExpiryOutcome  string
+	ClosedAt               string
+	IngestionStatus        string
+	IngestionError         string
+	IngestedAt             string
+	HardQualifierResults   string
 }{
-	ID:                    "match_result.id",
-	MatchSetRefID:         "match_result.match_set_ref_id",
-	InitiatorUserRefID:    "match_result.initiator_user_ref_id",
-	ReceiverUserRefID:     "match_result.receiver_user_ref_id",
-	MatchStatus:           "match_result.match_status",
-	MatchLifecycleStatus:  "match_result.match_lifecycle_status",
-	CurrentDateInstanceID: "match_result.current_date_instance_id",
-	InitiatorAction:           "match_result.initiator_action",
-	InitiatorActionAt:         "match_result.initiator_action_at",
-	InitiatorSeenAt:           "match_result.initiator_seen_at",
-	ReceiverAction:           "match_result.receiver_action",
-	ReceiverActionAt:         "match_result.receiver_action_at",
-	ReceiverSeenAt:           "match_result.receiver_seen_at",
-	QualifierResults:      "match_result.qualifier_results",
-	MatchedQualitatively:  "match_result.matched_qualitatively",
-	DeliveredToUserAt:     "match_result.delivered_to_user_at",
-	LastProposerUserRefID: "match_result.last_proposer_user_ref_id",
-	LastProposedAt:        "match_result.last_proposed_at",
-	ChatUnlockedAt:        "match_result.chat_unlocked_at",
-	IsApproved:            "match_result.is_approved",
-	IsDropped:             "match_result.is_dropped",
-	DroppedTS:             "match_result.dropped_ts",
-	IsPossibleMatch:       "match_result.is_possible_match",
-	IsExpired:             "match_result.is_expired",
-	ExpiresAt:             "match_result.expires_at",
-	CreatedAt:             "match_result.created_at",
-	UpdatedAt:             "match_result.updated_at",
+	ID:                     "match_result.id",
+	MatchSetRefID:          "match_result.match_set_ref_id",
+	InitiatorUserRefID:     "match_result.initiator_user_ref_id",
+	ReceiverUserRefID:      "match_result.receiver_user_ref_id",
+	MatchStatus:            "match_result.match_status",
+	MatchLifecycleStatus:   "match_result.match_lifecycle_status",
+	CurrentDateInstanceID:  "match_result.current_date_instance_id",
+	InitiatorAction:        "match_result.initiator_action",
+	InitiatorActionAt:      "match_result.initiator_action_at",
+	InitiatorSeenAt:        "match_result.initiator_seen_at",
+	ReceiverAction:         "match_result.receiver_action",
+	ReceiverActionAt:       "match_result.receiver_action_at",
+	ReceiverSeenAt:         "match_result.receiver_seen_at",
+	QualifierResults:       "match_result.qualifier_results",
+	MatchedQualitatively:   "match_result.matched_qualitatively",
+	DeliveredToUserAt:      "match_result.delivered_to_user_at",
+	LastProposerUserRefID:  "match_result.last_proposer_user_ref_id",
+	LastProposedAt:         "match_result.last_proposed_at",
+	ChatUnlockedAt:         "match_result.chat_unlocked_at",
+	IsApproved:             "match_result.is_approved",
+	IsDropped:              "match_result.is_dropped",
+	DroppedTS:              "match_result.dropped_ts",
+	IsPossibleMatch:        "match_result.is_possible_match",
+	IsExpired:              "match_result.is_expired",
+	ExpiresAt:              "match_result.expires_at",
+	CreatedAt:              "match_result.created_at",
+	UpdatedAt:              "match_result.updated_at",
+	SoftScore:              "match_result.soft_score",
+	AiScore:                "match_result.ai_score",
+	FinalScore:             "match_result.final_score",
+	InitiatorExpiryOutcome: "match_result.initiator_expiry_outcome",
+	ReceiverExpiryOutcome:  "match_result.receiver_expiry_outcome",
+	ClosedAt:               "match_result.closed_at",
+	IngestionStatus:        "match_result.ingestion_status",
+	IngestionError:         "match_result.ingestion_error",
+	IngestedAt:             "match_result.ingested_at",
+	HardQualifierResults:   "match_result.hard_qualifier_results",
 }
 
 // Generated where
@@ -184,61 +239,81 @@ func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field
 func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
 var MatchResultWhere = struct {
-	ID                    whereHelperstring
-	MatchSetRefID         whereHelperstring
-	InitiatorUserRefID    whereHelperstring
-	ReceiverUserRefID     whereHelperstring
-	MatchStatus           whereHelperstring
-	MatchLifecycleStatus  whereHelpernull_String
-	CurrentDateInstanceID whereHelpernull_String
-	InitiatorAction           whereHelperstring
-	InitiatorActionAt         whereHelpernull_Time
-	InitiatorSeenAt           whereHelpernull_Time
-	ReceiverAction           whereHelperstring
-	ReceiverActionAt         whereHelpernull_Time
-	ReceiverSeenAt           whereHelpernull_Time
-	QualifierResults      whereHelpernull_JSON
-	MatchedQualitatively  whereHelperbool
-	DeliveredToUserAt     whereHelpernull_Time
-	LastProposerUserRefID whereHelpernull_String
-	LastProposedAt        whereHelpernull_Time
-	ChatUnlockedAt        whereHelpernull_Time
-	IsApproved            whereHelperbool
-	IsDropped             whereHelperbool
-	DroppedTS             whereHelpernull_Time
-	IsPossibleMatch       whereHelperbool
-	IsExpired             whereHelperbool
-	ExpiresAt             whereHelpernull_Time
-	CreatedAt             whereHelpertime_Time
-	UpdatedAt             whereHelpernull_Time
+	ID                     whereHelperstring
+	MatchSetRefID          whereHelperstring
+	InitiatorUserRefID     whereHelperstring
+	ReceiverUserRefID      whereHelperstring
+	MatchStatus            whereHelperstring
+	MatchLifecycleStatus   whereHelpernull_String
+	CurrentDateInstanceID  whereHelpernull_String
+	InitiatorAction        whereHelperstring
+	InitiatorActionAt      whereHelpernull_Time
+	InitiatorSeenAt        whereHelpernull_Time
+	ReceiverAction         whereHelperstring
+	ReceiverActionAt       whereHelpernull_Time
+	ReceiverSeenAt         whereHelpernull_Time
+	QualifierResults       whereHelpernull_JSON
+	MatchedQualitatively   whereHelperbool
+	DeliveredToUserAt      whereHelpernull_Time
+	LastProposerUserRefID  whereHelpernull_String
+	LastProposedAt         whereHelpernull_Time
+	ChatUnlockedAt         whereHelpernull_Time
+	IsApproved             whereHelperbool
+	IsDropped              whereHelperbool
+	DroppedTS              whereHelpernull_Time
+	IsPossibleMatch        whereHelperbool
+	IsExpired              whereHelperbool
+	ExpiresAt              whereHelpernull_Time
+	CreatedAt              whereHelpertime_Time
+	UpdatedAt              whereHelpernull_Time
+	SoftScore              whereHelpernull_Float64
+	AiScore                whereHelpernull_Float64
+	FinalScore             whereHelpernull_Float64
+	InitiatorExpiryOutcome whereHelpernull_String
+	ReceiverExpiryOutcome  whereHelpernull_String
+	ClosedAt               whereHelpernull_Time
+	IngestionStatus        whereHelperstring
+	IngestionError         whereHelpernull_String
+	IngestedAt             whereHelpernull_Time
+	HardQualifierResults   whereHelpernull_JSON
 }{
-	ID:                    whereHelperstring{field: "\"match_result\".\"id\""},
-	MatchSetRefID:         whereHelperstring{field: "\"match_result\".\"match_set_ref_id\""},
-	InitiatorUserRefID:    whereHelperstring{field: "\"match_result\".\"initiator_user_ref_id\""},
-	ReceiverUserRefID:     whereHelperstring{field: "\"match_result\".\"receiver_user_ref_id\""},
-	MatchStatus:           whereHelperstring{field: "\"match_result\".\"match_status\""},
-	MatchLifecycleStatus:  whereHelpernull_String{field: "\"match_result\".\"match_lifecycle_status\""},
-	CurrentDateInstanceID: whereHelpernull_String{field: "\"match_result\".\"current_date_instance_id\""},
-	InitiatorAction:           whereHelperstring{field: "\"match_result\".\"initiator_action\""},
-	InitiatorActionAt:         whereHelpernull_Time{field: "\"match_result\".\"initiator_action_at\""},
-	InitiatorSeenAt:           whereHelpernull_Time{field: "\"match_result\".\"initiator_seen_at\""},
-	ReceiverAction:           whereHelperstring{field: "\"match_result\".\"receiver_action\""},
-	ReceiverActionAt:         whereHelpernull_Time{field: "\"match_result\".\"receiver_action_at\""},
-	ReceiverSeenAt:           whereHelpernull_Time{field: "\"match_result\".\"receiver_seen_at\""},
-	QualifierResults:      whereHelpernull_JSON{field: "\"match_result\".\"qualifier_results\""},
-	MatchedQualitatively:  whereHelperbool{field: "\"match_result\".\"matched_qualitatively\""},
-	DeliveredToUserAt:     whereHelpernull_Time{field: "\"match_result\".\"delivered_to_user_at\""},
-	LastProposerUserRefID: whereHelpernull_String{field: "\"match_result\".\"last_proposer_user_ref_id\""},
-	LastProposedAt:        whereHelpernull_Time{field: "\"match_result\".\"last_proposed_at\""},
-	ChatUnlockedAt:        whereHelpernull_Time{field: "\"match_result\".\"chat_unlocked_at\""},
-	IsApproved:            whereHelperbool{field: "\"match_result\".\"is_approved\""},
-	IsDropped:             whereHelperbool{field: "\"match_result\".\"is_dropped\""},
-	DroppedTS:             whereHelpernull_Time{field: "\"match_result\".\"dropped_ts\""},
-	IsPossibleMatch:       whereHelperbool{field: "\"match_result\".\"is_possible_match\""},
-	IsExpired:             whereHelperbool{field: "\"match_result\".\"is_expired\""},
-	ExpiresAt:             whereHelpernull_Time{field: "\"match_result\".\"expires_at\""},
-	CreatedAt:             whereHelpertime_Time{field: "\"match_result\".\"created_at\""},
-	UpdatedAt:             whereHelpernull_Time{field: "\"match_result\".\"updated_at\""},
+	ID:                     whereHelperstring{field: "\"match_result\".\"id\""},
+	MatchSetRefID:          whereHelperstring{field: "\"match_result\".\"match_set_ref_id\""},
+	InitiatorUserRefID:     whereHelperstring{field: "\"match_result\".\"initiator_user_ref_id\""},
+	ReceiverUserRefID:      whereHelperstring{field: "\"match_result\".\"receiver_user_ref_id\""},
+	MatchStatus:            whereHelperstring{field: "\"match_result\".\"match_status\""},
+	MatchLifecycleStatus:   whereHelpernull_String{field: "\"match_result\".\"match_lifecycle_status\""},
+	CurrentDateInstanceID:  whereHelpernull_String{field: "\"match_result\".\"current_date_instance_id\""},
+	InitiatorAction:        whereHelperstring{field: "\"match_result\".\"initiator_action\""},
+	InitiatorActionAt:      whereHelpernull_Time{field: "\"match_result\".\"initiator_action_at\""},
+	InitiatorSeenAt:        whereHelpernull_Time{field: "\"match_result\".\"initiator_seen_at\""},
+	ReceiverAction:         whereHelperstring{field: "\"match_result\".\"receiver_action\""},
+	ReceiverActionAt:       whereHelpernull_Time{field: "\"match_result\".\"receiver_action_at\""},
+	ReceiverSeenAt:         whereHelpernull_Time{field: "\"match_result\".\"receiver_seen_at\""},
+	QualifierResults:       whereHelpernull_JSON{field: "\"match_result\".\"qualifier_results\""},
+	MatchedQualitatively:   whereHelperbool{field: "\"match_result\".\"matched_qualitatively\""},
+	DeliveredToUserAt:      whereHelpernull_Time{field: "\"match_result\".\"delivered_to_user_at\""},
+	LastProposerUserRefID:  whereHelpernull_String{field: "\"match_result\".\"last_proposer_user_ref_id\""},
+	LastProposedAt:         whereHelpernull_Time{field: "\"match_result\".\"last_proposed_at\""},
+	ChatUnlockedAt:         whereHelpernull_Time{field: "\"match_result\".\"chat_unlocked_at\""},
+	IsApproved:             whereHelperbool{field: "\"match_result\".\"is_approved\""},
+	IsDropped:              whereHelperbool{field: "\"match_result\".\"is_dropped\""},
+	DroppedTS:              whereHelpernull_Time{field: "\"match_result\".\"dropped_ts\""},
+	IsPossibleMatch:        whereHelperbool{field: "\"match_result\".\"is_possible_match\""},
+	IsExpired:              whereHelperbool{field: "\"match_result\".\"is_expired\""},
+	ExpiresAt:              whereHelpernull_Time{field: "\"match_result\".\"expires_at\""},
+	CreatedAt:              whereHelpertime_Time{field: "\"match_result\".\"created_at\""},
+	UpdatedAt:              whereHelpernull_Time{field: "\"match_result\".\"updated_at\""},
+	SoftScore:              whereHelpernull_Float64{field: "\"match_result\".\"soft_score\""},
+	AiScore:                whereHelpernull_Float64{field: "\"match_result\".\"ai_score\""},
+	FinalScore:             whereHelpernull_Float64{field: "\"match_result\".\"final_score\""},
+	InitiatorExpiryOutcome: whereHelpernull_String{field: "\"match_result\".\"initiator_expiry_outcome\""},
+	ReceiverExpiryOutcome:  whereHelpernull_String{field: "\"match_result\".\"receiver_expiry_outcome\""},
+	ClosedAt:               whereHelpernull_Time{field: "\"match_result\".\"closed_at\""},
+	IngestionStatus:        whereHelperstring{field: "\"match_result\".\"ingestion_status\""},
+	IngestionError:         whereHelpernull_String{field: "\"match_result\".\"ingestion_error\""},
+	IngestedAt:             whereHelpernull_Time{field: "\"match_result\".\"ingested_at\""},
+	HardQualifierResults:   whereHelpernull_JSON{field: "\"match_result\".\"hard_qualifier_results\""},
 }
 
 // MatchResultRels is where relationship names are stored.
@@ -411,9 +486,9 @@ func (r *matchResultR) GetMatchChatMessages() MatchChatMessageSlice {
 type matchResultL struct{}
 
 var (
-	matchResultAllColumns            = []string{"id", "match_set_ref_id", "initiator_user_ref_id", "receiver_user_ref_id", "match_status", "match_lifecycle_status", "current_date_instance_id", "initiator_action", "initiator_action_at", "initiator_seen_at", "receiver_action", "receiver_action_at", "receiver_seen_at", "qualifier_results", "matched_qualitatively", "delivered_to_user_at", "last_proposer_user_ref_id", "last_proposed_at", "chat_unlocked_at", "is_approved", "is_dropped", "dropped_ts", "is_possible_match", "is_expired", "expires_at", "created_at", "updated_at"}
+	matchResultAllColumns            = []string{"id", "match_set_ref_id", "initiator_user_ref_id", "receiver_user_ref_id", "match_status", "match_lifecycle_status", "current_date_instance_id", "initiator_action", "initiator_action_at", "initiator_seen_at", "receiver_action", "receiver_action_at", "receiver_seen_at", "qualifier_results", "matched_qualitatively", "delivered_to_user_at", "last_proposer_user_ref_id", "last_proposed_at", "chat_unlocked_at", "is_approved", "is_dropped", "dropped_ts", "is_possible_match", "is_expired", "expires_at", "created_at", "updated_at", "soft_score", "ai_score", "final_score", "initiator_expiry_outcome", "receiver_expiry_outcome", "closed_at", "ingestion_status", "ingestion_error", "ingested_at", "hard_qualifier_results"}
 	matchResultColumnsWithoutDefault = []string{"match_set_ref_id", "initiator_user_ref_id", "receiver_user_ref_id"}
-	matchResultColumnsWithDefault    = []string{"id", "match_status", "match_lifecycle_status", "current_date_instance_id", "initiator_action", "initiator_action_at", "initiator_seen_at", "receiver_action", "receiver_action_at", "receiver_seen_at", "qualifier_results", "matched_qualitatively", "delivered_to_user_at", "last_proposer_user_ref_id", "last_proposed_at", "chat_unlocked_at", "is_approved", "is_dropped", "dropped_ts", "is_possible_match", "is_expired", "expires_at", "created_at", "updated_at"}
+	matchResultColumnsWithDefault    = []string{"id", "match_status", "match_lifecycle_status", "current_date_instance_id", "initiator_action", "initiator_action_at", "initiator_seen_at", "receiver_action", "receiver_action_at", "receiver_seen_at", "qualifier_results", "matched_qualitatively", "delivered_to_user_at", "last_proposer_user_ref_id", "last_proposed_at", "chat_unlocked_at", "is_approved", "is_dropped", "dropped_ts", "is_possible_match", "is_expired", "expires_at", "created_at", "updated_at", "soft_score", "ai_score", "final_score", "initiator_expiry_outcome", "receiver_expiry_outcome", "closed_at", "ingestion_status", "ingestion_error", "ingested_at", "hard_qualifier_results"}
 	matchResultPrimaryKeyColumns     = []string{"id"}
 	matchResultGeneratedColumns      = []string{}
 )
@@ -33,49 +33,61 @@ type MatchSet struct {
 	TimeEnd              null.Time  `boil:"time_end" json:"time_end,omitempty" toml:"time_end" yaml:"time_end,omitempty"`
 	CreatedAt            null.Time  `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
 	UpdatedAt            null.Time  `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	// Status of the latest ingestion run; Failed when some pairs failed and a rerun is due
+	IngestionStatus string `boil:"ingestion_status" json:"ingestion_status" toml:"ingestion_status" yaml:"ingestion_status"`
+	// Config version the set was created with; NULL for sets created before versions
+	MatchConfigVersionRefID null.String `boil:"match_config_version_ref_id" json:"match_config_version_ref_id,omitempty" toml:"match_config_version_ref_id" yaml:"match_config_version_ref_id,omitempty"`
 
 	R *matchSetR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L matchSetL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var MatchSetColumns = struct {
-	ID                   string
-	Name                 string
-	NumberOfParticipants string
-	MatchConfiguration   string
-	TimeStart            string
-	TimeEnd              string
-	CreatedAt            string
-	UpdatedAt            string
+	ID                      string
+	Name                    string
+	NumberOfParticipants    string
+	MatchConfiguration      string
+	TimeStart               string
+	TimeEnd                 string
+	CreatedAt               string
+	UpdatedAt               string
+	IngestionStatus         string
+	MatchConfigVersionRefID string
 }{
-	ID:                   "id",
-	Name:                 "name",
-	NumberOfParticipants: "number_of_participants",
-	MatchConfiguration:   "match_configuration",
-	TimeStart:            "time_start",
-	TimeEnd:              "time_end",
-	CreatedAt:            "created_at",
-	UpdatedAt:            "updated_at",
+	ID:                      "id",
+	Name:                    "name",
+	NumberOfParticipants:    "number_of_participants",
+	MatchConfiguration:      "match_configuration",
+	TimeStart:               "time_start",
+	TimeEnd:                 "time_end",
+	CreatedAt:               "created_at",
+	UpdatedAt:               "updated_at",
+	IngestionStatus:         "ingestion_status",
+	MatchConfigVersionRefID: "match_config_version_ref_id",
 }
 
 var MatchSetTableColumns = struct {
-	ID                   string
-	Name                 string
-	NumberOfParticipants string
-	MatchConfiguration   string
-	TimeStart            string
-	TimeEnd              string
-	CreatedAt            string
-	UpdatedAt            string
+	ID                      string
+	Name                    string
+	NumberOfParticipants    string
+	MatchConfiguration      string
+	TimeStart               string
+	TimeEnd                 string
+	CreatedAt               string
+	UpdatedAt               string
+	IngestionStatus         string
+	MatchConfigVersionRefID string
 }{
-	ID:                   "match_set.id",
-	Name:                 "match_set.name",
-	NumberOfParticipants: "match_set.number_of_participants",
-	MatchConfiguration:   "match_set.match_configuration",
-	TimeStart:            "match_set.time_start",
-	TimeEnd:              "match_set.time_end",
-	CreatedAt:            "match_set.created_at",
-	UpdatedAt:            "match_set.updated_at",
+	ID:                      "match_set.id",
+	Name:                    "match_set.name",
+	NumberOfParticipants:    "match_set.number_of_participants",
+	MatchConfiguration:      "match_set.match_configuration",
+	TimeStart:               "match_set.time_start",
+	TimeEnd:                 "match_set.time_end",
+	CreatedAt:               "match_set.created_at",
+	UpdatedAt:               "match_set.updated_at",
+	IngestionStatus:         "match_set.ingestion_status",
+	MatchConfigVersionRefID: "match_set.match_config_version_ref_id",
 }
 
 // Generated where
@@ -102,23 +114,27 @@ func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
 }
 
 var MatchSetWhere = struct {
-	ID                   whereHelperstring
-	Name                 whereHelperstring
-	NumberOfParticipants whereHelperint
-	MatchConfiguration   whereHelpertypes_JSON
-	TimeStart            whereHelpernull_Time
-	TimeEnd              whereHelpernull_Time
-	CreatedAt            whereHelpernull_Time
-	UpdatedAt            whereHelpernull_Time
+	ID                      whereHelperstring
+	Name                    whereHelperstring
+	NumberOfParticipants    whereHelperint
+	MatchConfiguration      whereHelpertypes_JSON
+	TimeStart               whereHelpernull_Time
+	TimeEnd                 whereHelpernull_Time
+	CreatedAt               whereHelpernull_Time
+	UpdatedAt               whereHelpernull_Time
+	IngestionStatus         whereHelperstring
+	MatchConfigVersionRefID whereHelpernull_String
 }{
-	ID:                   whereHelperstring{field: "\"match_set\".\"id\""},
-	Name:                 whereHelperstring{field: "\"match_set\".\"name\""},
-	NumberOfParticipants: whereHelperint{field: "\"match_set\".\"number_of_participants\""},
-	MatchConfiguration:   whereHelpertypes_JSON{field: "\"match_set\".\"match_configuration\""},
-	TimeStart:            whereHelpernull_Time{field: "\"match_set\".\"time_start\""},
-	TimeEnd:              whereHelpernull_Time{field: "\"match_set\".\"time_end\""},
-	CreatedAt:            whereHelpernull_Time{field: "\"match_set\".\"created_at\""},
-	UpdatedAt:            whereHelpernull_Time{field: "\"match_set\".\"updated_at\""},
+	ID:                      whereHelperstring{field: "\"match_set\".\"id\""},
+	Name:                    whereHelperstring{field: "\"match_set\".\"name\""},
+	NumberOfParticipants:    whereHelperint{field: "\"match_set\".\"number_of_participants\""},
+	MatchConfiguration:      whereHelpertypes_JSON{field: "\"match_set\".\"match_configuration\""},
+	TimeStart:               whereHelpernull_Time{field: "\"match_set\".\"time_start\""},
+	TimeEnd:                 whereHelpernull_Time{field: "\"match_set\".\"time_end\""},
+	CreatedAt:               whereHelpernull_Time{field: "\"match_set\".\"created_at\""},
+	UpdatedAt:               whereHelpernull_Time{field: "\"match_set\".\"updated_at\""},
+	IngestionStatus:         whereHelperstring{field: "\"match_set\".\"ingestion_status\""},
+	MatchConfigVersionRefID: whereHelpernull_String{field: "\"match_set\".\"match_config_version_ref_id\""},
 }
 
 // MatchSetRels is where relationship names are stored.
@@ -158,9 +174,9 @@ func (r *matchSetR) GetMatchSetRefMatchResults() MatchResultSlice {
 type matchSetL struct{}
 
 var (
-	matchSetAllColumns            = []string{"id", "name", "number_of_participants", "match_configuration", "time_start", "time_end", "created_at", "updated_at"}
+	matchSetAllColumns            = []string{"id", "name", "number_of_participants", "match_configuration", "time_start", "time_end", "created_at", "updated_at", "ingestion_status", "match_config_version_ref_id"}
 	matchSetColumnsWithoutDefault = []string{"name", "number_of_participants", "match_configuration"}
-	matchSetColumnsWithDefault    = []string{"id", "time_start", "time_end", "created_at", "updated_at"}
+	matchSetColumnsWithDefault    = []string{"id", "time_start", "time_end", "created_at", "updated_at", "ingestion_status", "match_config_version_ref_id"}
 	matchSetPrimaryKeyColumns     = []string{"id"}
 	matchSetGeneratedColumns      = []string{}
 )
@@ -122,17 +122,20 @@ var WingsEcnActionLogWhere = struct {
 
 // WingsEcnActionLogRels is where relationship names are stored.
 var WingsEcnActionLogRels = struct {
-	UserRef                          string
-	ActionLogRefWingsEcnTransactions string
+	UserRef                                   string
+	ActionLogRefWingsEcnTransactions          string
+	ActionLogRefWingsEcnUserSubscriptionPlans string
 }{
 	UserRef:                          "UserRef",
 	ActionLogRefWingsEcnTransactions: "ActionLogRefWingsEcnTransactions",
+	ActionLogRefWingsEcnUserSubscriptionPlans: "ActionLogRefWingsEcnUserSubscriptionPlans",
 }
 
 // wingsEcnActionLogR is where relationships are stored.
 type wingsEcnActionLogR struct {
-	UserRef                          *User                    `boil:"UserRef" json:"UserRef" toml:"UserRef" yaml:"UserRef"`
-	ActionLogRefWingsEcnTransactions WingsEcnTransactionSlice `boil:"ActionLogRefWingsEcnTransactions" json:"ActionLogRefWingsEcnTransactions" toml:"ActionLogRefWingsEcnTransactions" yaml:"ActionLogRefWingsEcnTransactions"`
+	UserRef                                   *User                             `boil:"UserRef" json:"UserRef" toml:"UserRef" yaml:"UserRef"`
+	ActionLogRefWingsEcnTransactions          WingsEcnTransactionSlice          `boil:"ActionLogRefWingsEcnTransactions" json:"ActionLogRefWingsEcnTransactions" toml:"ActionLogRefWingsEcnTransactions" yaml:"ActionLogRefWingsEcnTransactions"`
+	ActionLogRefWingsEcnUserSubscriptionPlans WingsEcnUserSubscriptionPlanSlice `boil:"ActionLogRefWingsEcnUserSubscriptionPlans" json:"ActionLogRefWingsEcnUserSubscriptionPlans" toml:"ActionLogRefWingsEcnUserSubscriptionPlans" yaml:"ActionLogRefWingsEcnUserSubscriptionPlans"`
 }
 
 // NewStruct creates a new relationship struct
@@ -172,6 +175,22 @@ func (r *wingsEcnActionLogR) GetActionLogRefWingsEcnTransactions() WingsEcnTrans
 	return r.ActionLogRefWingsEcnTransactions
 }
 
+func (o *WingsEcnActionLog) GetActionLogRefWingsEcnUserSubscriptionPlans() WingsEcnUserSubscriptionPlanSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetActionLogRefWingsEcnUserSubscriptionPlans()
+}
+
+func (r *wingsEcnActionLogR) GetActionLogRefWingsEcnUserSubscriptionPlans() WingsEcnUserSubscriptionPlanSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.ActionLogRefWingsEcnUserSubscriptionPlans
+}
+
 // wingsEcnActionLogL is where Load methods for each relationship are stored.
 type wingsEcnActionLogL struct{}
 
@@ -513,6 +532,20 @@ func (o *WingsEcnActionLog) ActionLogRefWingsEcnTransactions(mods ...qm.QueryMod
 	return WingsEcnTransactions(queryMods...)
 }
 
+// ActionLogRefWingsEcnUserSubscriptionPlans retrieves all the wings_ecn_user_subscription_plan's WingsEcnUserSubscriptionPlans with an executor via action_log_ref_id column.
+func (o *WingsEcnActionLog) ActionLogRefWingsEcnUserSubscriptionPlans(mods ...qm.QueryMod) wingsEcnUserSubscriptionPlanQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"wings_ecn_user_subscription_plan\".\"action_log_ref_id\"=?", o.ID),
+	)
+
+	return WingsEcnUserSubscriptionPlans(queryMods...)
+}
+
 // LoadUserRef allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (wingsEcnActionLogL) LoadUserRef(ctx context.Context, e boil.ContextExecutor, singular bool, maybeWingsEcnActionLog interface{}, mods queries.Applicator) error {
@@ -746,6 +779,119 @@ func (wingsEcnActionLogL) LoadActionLogRefWingsEcnTransactions(ctx context.Conte
 	return nil
 }
 
+// LoadActionLogRefWingsEcnUserSubscriptionPlans allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (wingsEcnActionLogL) LoadActionLogRefWingsEcnUserSubscriptionPlans(ctx context.Context, e boil.ContextExecutor, singular bool, maybeWingsEcnActionLog interface{}, mods queries.Applicator) error {
+	var slice []*WingsEcnActionLog
+	var object *WingsEcnActionLog
+
+	if singular {
+		var ok bool
+		object, ok = maybeWingsEcnActionLog.(*WingsEcnActionLog)
+		if !ok {
+			object = new(WingsEcnActionLog)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeWingsEcnActionLog)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeWingsEcnActionLog))
+			}
+		}
+	} else {
+		s, ok := maybeWingsEcnActionLog.(*[]*WingsEcnActionLog)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeWingsEcnActionLog)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeWingsEcnActionLog))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &wingsEcnActionLogR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &wingsEcnActionLogR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`wings_ecn_user_subscription_plan`),
+		qm.WhereIn(`wings_ecn_user_subscription_plan.action_log_ref_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load wings_ecn_user_subscription_plan")
+	}
+
+	var resultSlice []*WingsEcnUserSubscriptionPlan
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice wings_ecn_user_subscription_plan")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on wings_ecn_user_subscription_plan")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for wings_ecn_user_subscription_plan")
+	}
+
+	if len(wingsEcnUserSubscriptionPlanAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ActionLogRefWingsEcnUserSubscriptionPlans = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &wingsEcnUserSubscriptionPlanR{}
+			}
+			foreign.R.ActionLogRef = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.ActionLogRefID) {
+				local.R.ActionLogRefWingsEcnUserSubscriptionPlans = append(local.R.ActionLogRefWingsEcnUserSubscriptionPlans, foreign)
+				if foreign.R == nil {
+					foreign.R = &wingsEcnUserSubscriptionPlanR{}
+				}
+				foreign.R.ActionLogRef = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // SetUserRef of the wingsEcnActionLog to the related item.
 // Sets o.R.UserRef to related.
 // Adds o to related.R.UserRefWingsEcnActionLogs.
@@ -846,6 +992,133 @@ func (o *WingsEcnActionLog) AddActionLogRefWingsEcnTransactions(ctx context.Cont
 	return nil
 }
 
+// AddActionLogRefWingsEcnUserSubscriptionPlans adds the given related objects to the existing relationships
+// of the wings_ecn_action_log, optionally inserting them as new records.
+// Appends related to o.R.ActionLogRefWingsEcnUserSubscriptionPlans.
+// Sets related.R.ActionLogRef appropriately.
+func (o *WingsEcnActionLog) AddActionLogRefWingsEcnUserSubscriptionPlans(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*WingsEcnUserSubscriptionPlan) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.ActionLogRefID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"wings_ecn_user_subscription_plan\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"action_log_ref_id"}),
+				strmangle.WhereClause("\"", "\"", 2, wingsEcnUserSubscriptionPlanPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.ActionLogRefID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &wingsEcnActionLogR{
+			ActionLogRefWingsEcnUserSubscriptionPlans: related,
+		}
+	} else {
+		o.R.ActionLogRefWingsEcnUserSubscriptionPlans = append(o.R.ActionLogRefWingsEcnUserSubscriptionPlans, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &wingsEcnUserSubscriptionPlanR{
+				ActionLogRef: o,
+			}
+		} else {
+			rel.R.ActionLogRef = o
+		}
+	}
+	return nil
+}
+
+// SetActionLogRefWingsEcnUserSubscriptionPlans removes all previously related items of the
+// wings_ecn_action_log replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.ActionLogRef's ActionLogRefWingsEcnUserSubscriptionPlans accordingly.
+// Replaces o.R.ActionLogRefWingsEcnUserSubscriptionPlans with related.
+// Sets related.R.ActionLogRef's ActionLogRefWingsEcnUserSubscriptionPlans accordingly.
+func (o *WingsEcnActionLog) SetActionLogRefWingsEcnUserSubscriptionPlans(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*WingsEcnUserSubscriptionPlan) error {
+	query := "update \"wings_ecn_user_subscription_plan\" set \"action_log_ref_id\" = null where \"action_log_ref_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.ActionLogRefWingsEcnUserSubscriptionPlans {
+			queries.SetScanner(&rel.ActionLogRefID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.ActionLogRef = nil
+		}
+		o.R.ActionLogRefWingsEcnUserSubscriptionPlans = nil
+	}
+
+	return o.AddActionLogRefWingsEcnUserSubscriptionPlans(ctx, exec, insert, related...)
+}
+
+// RemoveActionLogRefWingsEcnUserSubscriptionPlans relationships from objects passed in.
+// Removes related items from R.ActionLogRefWingsEcnUserSubscriptionPlans (uses pointer comparison, removal does not keep order)
+// Sets related.R.ActionLogRef.
+func (o *WingsEcnActionLog) RemoveActionLogRefWingsEcnUserSubscriptionPlans(ctx context.Context, exec boil.ContextExecutor, related ...*WingsEcnUserSubscriptionPlan) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.ActionLogRefID, nil)
+		if rel.R != nil {
+			rel.R.ActionLogRef = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("action_log_ref_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.ActionLogRefWingsEcnUserSubscriptionPlans {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.ActionLogRefWingsEcnUserSubscriptionPlans)
+			if ln > 1 && i < ln-1 {
+				o.R.ActionLogRefWingsEcnUserSubscriptionPlans[i] = o.R.ActionLogRefWingsEcnUserSubscriptionPlans[ln-1]
+			}
+			o.R.ActionLogRefWingsEcnUserSubscriptionPlans = o.R.ActionLogRefWingsEcnUserSubscriptionPlans[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // WingsEcnActionLogs retrieves all the records using an executor.
 func WingsEcnActionLogs(mods ...qm.QueryMod) wingsEcnActionLogQuery {
 	mods = append(mods, qm.From("\"wings_ecn_action_log\""))
@@ -32,6 +32,12 @@ type WingsEcnUserSubscriptionPlan struct {
 	IsActive           null.Int  `boil:"is_active" json:"is_active,omitempty" toml:"is_active" yaml:"is_active,omitempty"`
 	CreatedDate        null.Time `boil:"created_date" json:"created_date,omitempty" toml:"created_date" yaml:"created_date,omitempty"`
 	LastUpdated        null.Time `boil:"last_updated" json:"last_updated,omitempty" toml:"last_updated" yaml:"last_updated,omitempty"`
+	// The payment that bought the period
+	ActionLogRefID null.String `boil:"action_log_ref_id" json:"action_log_ref_id,omitempty" toml:"action_log_ref_id" yaml:"action_log_ref_id,omitempty"`
+	// Wings credited for the period, after proration
+	WingsGranted int `boil:"wings_granted" json:"wings_granted" toml:"wings_granted" yaml:"wings_granted"`
+	// Why the period ended before its end_date was originally due
+	EndReason null.String `boil:"end_reason" json:"end_reason,omitempty" toml:"end_reason" yaml:"end_reason,omitempty"`
 
 	R *wingsEcnUserSubscriptionPlanR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L wingsEcnUserSubscriptionPlanL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -46,6 +52,9 @@ var WingsEcnUserSubscriptionPlanColumns = struct {
 	IsActive           string
 	CreatedDate        string
 	LastUpdated        string
+	ActionLogRefID     string
+	WingsGranted       string
+	EndReason          string
 }{
 	ID:                 "id",
 	UserID:             "user_id",
@@ -55,6 +64,9 @@ var WingsEcnUserSubscriptionPlanColumns = struct {
 	IsActive:           "is_active",
 	CreatedDate:        "created_date",
 	LastUpdated:        "last_updated",
+	ActionLogRefID:     "action_log_ref_id",
+	WingsGranted:       "wings_granted",
+	EndReason:          "end_reason",
 }
 
 var WingsEcnUserSubscriptionPlanTableColumns = struct {
@@ -66,6 +78,9 @@ var WingsEcnUserSubscriptionPlanTableColumns = struct {
 	IsActive           string
 	CreatedDate        string
 	LastUpdated        string
+	ActionLogRefID     string
+	WingsGranted       string
+	EndReason          string
 }{
 	ID:                 "wings_ecn_user_subscription_plan.id",
 	UserID:             "wings_ecn_user_subscription_plan.user_id",
@@ -75,6 +90,9 @@ var WingsEcnUserSubscriptionPlanTableColumns = struct {
 	IsActive:           "wings_ecn_user_subscription_plan.is_active",
 	CreatedDate:        "wings_ecn_user_subscription_plan.created_date",
 	LastUpdated:        "wings_ecn_user_subscription_plan.last_updated",
+	ActionLogRefID:     "wings_ecn_user_subscription_plan.action_log_ref_id",
+	WingsGranted:       "wings_ecn_user_subscription_plan.wings_granted",
+	EndReason:          "wings_ecn_user_subscription_plan.end_reason",
 }
 
 // Generated where
@@ -88,6 +106,9 @@ var WingsEcnUserSubscriptionPlanWhere = struct {
 	IsActive           whereHelpernull_Int
 	CreatedDate        whereHelpernull_Time
 	LastUpdated        whereHelpernull_Time
+	ActionLogRefID     whereHelpernull_String
+	WingsGranted       whereHelperint
+	EndReason          whereHelpernull_String
 }{
 	ID:                 whereHelperstring{field: "\"wings_ecn_user_subscription_plan\".\"id\""},
 	UserID:             whereHelperstring{field: "\"wings_ecn_user_subscription_plan\".\"user_id\""},
@@ -97,19 +118,25 @@ var WingsEcnUserSubscriptionPlanWhere = struct {
 	IsActive:           whereHelpernull_Int{field: "\"wings_ecn_user_subscription_plan\".\"is_active\""},
 	CreatedDate:        whereHelpernull_Time{field: "\"wings_ecn_user_subscription_plan\".\"created_date\""},
 	LastUpdated:        whereHelpernull_Time{field: "\"wings_ecn_user_subscription_plan\".\"last_updated\""},
+	ActionLogRefID:     whereHelpernull_String{field: "\"wings_ecn_user_subscription_plan\".\"action_log_ref_id\""},
+	WingsGranted:       whereHelperint{field: "\"wings_ecn_user_subscription_plan\".\"wings_granted\""},
+	EndReason:          whereHelpernull_String{field: "\"wings_ecn_user_subscription_plan\".\"end_reason\""},
 }
 
 // WingsEcnUserSubscriptionPlanRels is where relationship names are stored.
 var WingsEcnUserSubscriptionPlanRels = struct {
+	ActionLogRef     string
 	SubscriptionPlan string
 	User             string
 }{
+	ActionLogRef:     "ActionLogRef",
 	SubscriptionPlan: "SubscriptionPlan",
 	User:             "User",
 }
 
 // wingsEcnUserSubscriptionPlanR is where relationships are stored.
 type wingsEcnUserSubscriptionPlanR struct {
+	ActionLogRef     *WingsEcnActionLog        `boil:"ActionLogRef" json:"ActionLogRef" toml:"ActionLogRef" yaml:"ActionLogRef"`
 	SubscriptionPlan *WingsEcnSubscriptionPlan `boil:"SubscriptionPlan" json:"SubscriptionPlan" toml:"SubscriptionPlan" yaml:"SubscriptionPlan"`
 	User             *User                     `boil:"User" json:"User" toml:"User" yaml:"User"`
 }
@@ -119,6 +146,22 @@ func (*wingsEcnUserSubscriptionPlanR) NewStruct() *wingsEcnUserSubscriptionPlanR
 	return &wingsEcnUserSubscriptionPlanR{}
 }
 
+func (o *WingsEcnUserSubscriptionPlan) GetActionLogRef() *WingsEcnActionLog {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetActionLogRef()
+}
+
+func (r *wingsEcnUserSubscriptionPlanR) GetActionLogRef() *WingsEcnActionLog {
+	if r == nil {
+		return nil
+	}
+
+	return r.ActionLogRef
+}
+
 func (o *WingsEcnUserSubscriptionPlan) GetSubscriptionPlan() *WingsEcnSubscriptionPlan {
 	if o == nil {
 		return nil
@@ -155,9 +198,9 @@ func (r *wingsEcnUserSubscriptionPlanR) GetUser() *User {
 type wingsEcnUserSubscriptionPlanL struct{}
 
 var (
-	wingsEcnUserSubscriptionPlanAllColumns            = []string{"id", "user_id", "subscription_plan_id", "start_date", "end_date", "is_active", "created_date", "last_updated"}
+	wingsEcnUserSubscriptionPlanAllColumns            = []string{"id", "user_id", "subscription_plan_id", "start_date", "end_date", "is_active", "created_date", "last_updated", "action_log_ref_id", "wings_granted", "end_reason"}
 	wingsEcnUserSubscriptionPlanColumnsWithoutDefault = []string{"user_id", "subscription_plan_id", "start_date", "end_date"}
-	wingsEcnUserSubscriptionPlanColumnsWithDefault    = []string{"id", "is_active", "created_date", "last_updated"}
+	wingsEcnUserSubscriptionPlanColumnsWithDefault    = []string{"id", "is_active", "created_date", "last_updated", "action_log_ref_id", "wings_granted", "end_reason"}
 	wingsEcnUserSubscriptionPlanPrimaryKeyColumns     = []string{"id"}
 	wingsEcnUserSubscriptionPlanGeneratedColumns      = []string{}
 )
@@ -467,6 +510,17 @@ func (q wingsEcnUserSubscriptionPlanQuery) Exists(ctx context.Context, exec boil
 	return count > 0, nil
 }
 
+// ActionLogRef pointed to by the foreign key.
+func (o *WingsEcnUserSubscriptionPlan) ActionLogRef(mods ...qm.QueryMod) wingsEcnActionLogQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ActionLogRefID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return WingsEcnActionLogs(queryMods...)
+}
+
 // SubscriptionPlan pointed to by the foreign key.
 func (o *WingsEcnUserSubscriptionPlan) SubscriptionPlan(mods ...qm.QueryMod) wingsEcnSubscriptionPlanQuery {
 	queryMods := []qm.QueryMod{
@@ -489,6 +543,130 @@ func (o *WingsEcnUserSubscriptionPlan) User(mods ...qm.QueryMod) userQuery {
 	return Users(queryMods...)
 }
 
+// LoadActionLogRef allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (wingsEcnUserSubscriptionPlanL) LoadActionLogRef(ctx context.Context, e boil.ContextExecutor, singular bool, maybeWingsEcnUserSubscriptionPlan interface{}, mods queries.Applicator) error {
+	var slice []*WingsEcnUserSubscriptionPlan
+	var object *WingsEcnUserSubscriptionPlan
+
+	if singular {
+		var ok bool
+		object, ok = maybeWingsEcnUserSubscriptionPlan.(*WingsEcnUserSubscriptionPlan)
+		if !ok {
+			object = new(WingsEcnUserSubscriptionPlan)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeWingsEcnUserSubscriptionPlan)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeWingsEcnUserSubscriptionPlan))
+			}
+		}
+	} else {
+		s, ok := maybeWingsEcnUserSubscriptionPlan.(*[]*WingsEcnUserSubscriptionPlan)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeWingsEcnUserSubscriptionPlan)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeWingsEcnUserSubscriptionPlan))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &wingsEcnUserSubscriptionPlanR{}
+		}
+		if !queries.IsNil(object.ActionLogRefID) {
+			args[object.ActionLogRefID] = struct{}{}
+		}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &wingsEcnUserSubscriptionPlanR{}
+			}
+
+			if !queries.IsNil(obj.ActionLogRefID) {
+				args[obj.ActionLogRefID] = struct{}{}
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`wings_ecn_action_log`),
+		qm.WhereIn(`wings_ecn_action_log.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load WingsEcnActionLog")
+	}
+
+	var resultSlice []*WingsEcnActionLog
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice WingsEcnActionLog")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for wings_ecn_action_log")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for wings_ecn_action_log")
+	}
+
+	if len(wingsEcnActionLogAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.ActionLogRef = foreign
+		if foreign.R == nil {
+			foreign.R = &wingsEcnActionLogR{}
+		}
+		foreign.R.ActionLogRefWingsEcnUserSubscriptionPlans = append(foreign.R.ActionLogRefWingsEcnUserSubscriptionPlans, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.ActionLogRefID, foreign.ID) {
+				local.R.ActionLogRef = foreign
+				if foreign.R == nil {
+					foreign.R = &wingsEcnActionLogR{}
+				}
+				foreign.R.ActionLogRefWingsEcnUserSubscriptionPlans = append(foreign.R.ActionLogRefWingsEcnUserSubscriptionPlans, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadSubscriptionPlan allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (wingsEcnUserSubscriptionPlanL) LoadSubscriptionPlan(ctx context.Context, e boil.ContextExecutor, singular bool, maybeWingsEcnUserSubscriptionPlan interface{}, mods queries.Applicator) error {
@@ -729,6 +907,86 @@ func (wingsEcnUserSubscriptionPlanL) LoadUser(ctx context.Context, e boil.Contex
 	return nil
 }
 
+// SetActionLogRef of the wingsEcnUserSubscriptionPlan to the related item.
+// Sets o.R.ActionLogRef to related.
+// Adds o to related.R.ActionLogRefWingsEcnUserSubscriptionPlans.
+func (o *WingsEcnUserSubscriptionPlan) SetActionLogRef(ctx context.Context, exec boil.ContextExecutor, insert bool, related *WingsEcnActionLog) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"wings_ecn_user_subscription_plan\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"action_log_ref_id"}),
+		strmangle.WhereClause("\"", "\"", 2, wingsEcnUserSubscriptionPlanPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.ActionLogRefID, related.ID)
+	if o.R == nil {
+		o.R = &wingsEcnUserSubscriptionPlanR{
+			ActionLogRef: related,
+		}
+	} else {
+		o.R.ActionLogRef = related
+	}
+
+	if related.R == nil {
+		related.R = &wingsEcnActionLogR{
+			ActionLogRefWingsEcnUserSubscriptionPlans: WingsEcnUserSubscriptionPlanSlice{o},
+		}
+	} else {
+		related.R.ActionLogRefWingsEcnUserSubscriptionPlans = append(related.R.ActionLogRefWingsEcnUserSubscriptionPlans, o)
+	}
+
+	return nil
+}
+
+// RemoveActionLogRef relationship.
+// Sets o.R.ActionLogRef to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *WingsEcnUserSubscriptionPlan) RemoveActionLogRef(ctx context.Context, exec boil.ContextExecutor, related *WingsEcnActionLog) error {
+	var err error
+
+	queries.SetScanner(&o.ActionLogRefID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("action_log_ref_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.ActionLogRef = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.ActionLogRefWingsEcnUserSubscriptionPlans {
+		if queries.Equal(o.ActionLogRefID, ri.ActionLogRefID) {
+			continue
+		}
+
+		ln := len(related.R.ActionLogRefWingsEcnUserSubscriptionPlans)
+		if ln > 1 && i < ln-1 {
+			related.R.ActionLogRefWingsEcnUserSubscriptionPlans[i] = related.R.ActionLogRefWingsEcnUserSubscriptionPlans[ln-1]
+		}
+		related.R.ActionLogRefWingsEcnUserSubscriptionPlans = related.R.ActionLogRefWingsEcnUserSubscriptionPlans[:ln-1]
+		break
+	}
+	return nil
+}
+
 // SetSubscriptionPlan of the wingsEcnUserSubscriptionPlan to the related item.
 // Sets o.R.SubscriptionPlan to related.
 // Adds o to related.R.SubscriptionPlanWingsEcnUserSubscriptionPlans.
@@ -41,6 +41,8 @@ type WingsEcnUserTotal struct {
 	StreakCurrentDays int `boil:"streak_current_days" json:"streak_current_days" toml:"streak_current_days" yaml:"streak_current_days"`
 	// Longest streak ever achieved by user
 	StreakLongestDays int `boil:"streak_longest_days" json:"streak_longest_days" toml:"streak_longest_days" yaml:"streak_longest_days"`
+	// Time of the store event premium_expires_in was last set from
+	PremiumSyncedAt null.Time `boil:"premium_synced_at" json:"premium_synced_at,omitempty" toml:"premium_synced_at" yaml:"premium_synced_at,omitempty"`
 
 	R *wingsEcnUserTotalR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L wingsEcnUserTotalL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -61,6 +63,7 @@ var WingsEcnUserTotalColumns = struct {
 	StreakLastDate      string
 	StreakCurrentDays   string
 	StreakLongestDays   string
+	PremiumSyncedAt     string
 }{
 	ID:                  "id",
 	UserRefID:           "user_ref_id",
@@ -76,6 +79,7 @@ var WingsEcnUserTotalColumns = struct {
 	StreakLastDate:      "streak_last_date",
 	StreakCurrentDays:   "streak_current_days",
 	StreakLongestDays:   "streak_longest_days",
+	PremiumSyncedAt:     "premium_synced_at",
 }
 
 var WingsEcnUserTotalTableColumns = struct {
@@ -93,6 +97,7 @@ var WingsEcnUserTotalTableColumns = struct {
 	StreakLastDate      string
 	StreakCurrentDays   string
 	StreakLongestDays   string
+	PremiumSyncedAt     string
 }{
 	ID:                  "wings_ecn_user_totals.id",
 	UserRefID:           "wings_ecn_user_totals.user_ref_id",
@@ -108,6 +113,7 @@ var WingsEcnUserTotalTableColumns = struct {
 	StreakLastDate:      "wings_ecn_user_totals.streak_last_date",
 	StreakCurrentDays:   "wings_ecn_user_totals.streak_current_days",
 	StreakLongestDays:   "wings_ecn_user_totals.streak_longest_days",
+	PremiumSyncedAt:     "wings_ecn_user_totals.premium_synced_at",
 }
 
 // Generated where
@@ -127,6 +133,7 @@ var WingsEcnUserTotalWhere = struct {
 	StreakLastDate      whereHelpernull_Time
 	StreakCurrentDays   whereHelperint
 	StreakLongestDays   whereHelperint
+	PremiumSyncedAt     whereHelpernull_Time
 }{
 	ID:                  whereHelperstring{field: "\"wings_ecn_user_totals\".\"id\""},
 	UserRefID:           whereHelperstring{field: "\"wings_ecn_user_totals\".\"user_ref_id\""},
@@ -142,6 +149,7 @@ var WingsEcnUserTotalWhere = struct {
 	StreakLastDate:      whereHelpernull_Time{field: "\"wings_ecn_user_totals\".\"streak_last_date\""},
 	StreakCurrentDays:   whereHelperint{field: "\"wings_ecn_user_totals\".\"streak_current_days\""},
 	StreakLongestDays:   whereHelperint{field: "\"wings_ecn_user_totals\".\"streak_longest_days\""},
+	PremiumSyncedAt:     whereHelpernull_Time{field: "\"wings_ecn_user_totals\".\"premium_synced_at\""},
 }
 
 // WingsEcnUserTotalRels is where relationship names are stored.
@@ -181,9 +189,9 @@ func (r *wingsEcnUserTotalR) GetUserRef() *User {
 type wingsEcnUserTotalL struct{}
 
 var (
-	wingsEcnUserTotalAllColumns            = []string{"id", "user_ref_id", "total_wings", "counter_sent_messages", "counter_daily_check_in", "premium_expires_in", "is_active", "created_by", "created_date", "last_updated", "updated_by", "streak_last_date", "streak_current_days", "streak_longest_days"}
+	wingsEcnUserTotalAllColumns            = []string{"id", "user_ref_id", "total_wings", "counter_sent_messages", "counter_daily_check_in", "premium_expires_in", "is_active", "created_by", "created_date", "last_updated", "updated_by", "streak_last_date", "streak_current_days", "streak_longest_days", "premium_synced_at"}
 	wingsEcnUserTotalColumnsWithoutDefault = []string{"user_ref_id"}
-	wingsEcnUserTotalColumnsWithDefault    = []string{"id", "total_wings", "counter_sent_messages", "counter_daily_check_in", "premium_expires_in", "is_active", "created_by", "created_date", "last_updated", "updated_by", "streak_last_date", "streak_current_days", "streak_longest_days"}
+	wingsEcnUserTotalColumnsWithDefault    = []string{"id", "total_wings", "counter_sent_messages", "counter_daily_check_in", "premium_expires_in", "is_active", "created_by", "created_date", "last_updated", "updated_by", "streak_last_date", "streak_current_days", "streak_longest_days", "premium_synced_at"}
 	wingsEcnUserTotalPrimaryKeyColumns     = []string{"id"}
 	wingsEcnUserTotalGeneratedColumns      = []string{}
 )
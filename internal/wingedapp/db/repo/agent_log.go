@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+type InsertAgentLog struct {
+	UserRefID string
+	Log       string
+	DisplayBy null.Time // defaults to CURRENT_TIMESTAMP
+}
+
+func (s *Store) InsertAgentLog(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *InsertAgentLog,
+) (*pgmodel.AgentLog, error) {
+	agentLog := pgmodel.AgentLog{
+		UserRefID: inserter.UserRefID,
+		Log:       inserter.Log,
+	}
+	if inserter.DisplayBy.Valid {
+		agentLog.DisplayBy = inserter.DisplayBy.Time
+	}
+
+	if err := agentLog.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("insert agent log: %w", err)
+	}
+
+	return &agentLog, nil
+}
+
+// InsertAgentLogs inserts agent logs in a single statement.
+func (s *Store) InsertAgentLogs(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserters []InsertAgentLog,
+) (int64, error) {
+	if len(inserters) == 0 {
+		return 0, nil
+	}
+
+	cols := pgmodel.AgentLogColumns
+
+	values := make([]string, len(inserters))
+	args := make([]interface{}, 0, len(inserters)*3)
+	for i, in := range inserters {
+		n := i * 3
+		values[i] = fmt.Sprintf("($%d, $%d, COALESCE($%d::timestamptz, CURRENT_TIMESTAMP))", n+1, n+2, n+3)
+		args = append(args, in.UserRefID, in.Log, in.DisplayBy)
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s, %s, %s) VALUES %s",
+		pgmodel.TableNames.AgentLog,
+		cols.UserRefID, cols.Log, cols.DisplayBy,
+		strings.Join(values, ", "),
+	)
+
+	result, err := exec.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("insert agent logs: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("rows affected: %w", err)
+	}
+
+	return count, nil
+}
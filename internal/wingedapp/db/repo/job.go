@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+type InsertJob struct {
+	Type        string
+	Payload     json.RawMessage
+	MaxAttempts int
+	RunAfter    null.Time // defaults to NOW()
+}
+
+func (s *Store) InsertJob(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *InsertJob,
+) (*pgmodel.Job, error) {
+	if inserter.Type == "" {
+		return nil, fmt.Errorf("type is required")
+	}
+	if inserter.MaxAttempts <= 0 {
+		return nil, fmt.Errorf("max_attempts must be greater than 0")
+	}
+
+	job := pgmodel.Job{
+		Type:        inserter.Type,
+		MaxAttempts: inserter.MaxAttempts,
+	}
+	if len(inserter.Payload) > 0 {
+		job.Payload = null.JSONFrom(inserter.Payload)
+	}
+	if inserter.RunAfter.Valid {
+		job.RunAfter = inserter.RunAfter.Time
+	}
+
+	if err := job.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("insert job: %w", err)
+	}
+
+	return &job, nil
+}
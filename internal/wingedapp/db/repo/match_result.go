@@ -3,6 +3,7 @@ package repo
 import (
 	"context"
 	"fmt"
+	"time"
 	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
 	"wingedapp/pgtester/internal/wingedapp/lib/enums"
 
@@ -79,11 +80,14 @@ func (s *Store) UpdateMatchResult(
 	}
 
 	if updater.MatchLifecycleStatus.Valid {
-		matchResult.MatchLifecycleStatus = updater.MatchLifecycleStatus
+		setMatchLifecycleStatus(matchResult, updater.MatchLifecycleStatus.String)
 	}
 	if updater.QualifierResults.Valid {
 		matchResult.QualifierResults = updater.QualifierResults
 	}
+	if updater.HardQualifierResults.Valid {
+		matchResult.HardQualifierResults = updater.HardQualifierResults
+	}
 	if updater.MatchedQualitatively.Valid {
 		matchResult.MatchedQualitatively = updater.MatchedQualitatively.Bool
 	}
@@ -105,6 +109,15 @@ func (s *Store) UpdateMatchResult(
 	if updater.IsExpired.Valid {
 		matchResult.IsExpired = updater.IsExpired.Bool
 	}
+	if updater.SoftScore.Valid {
+		matchResult.SoftScore = updater.SoftScore
+	}
+	if updater.AiScore.Valid {
+		matchResult.AiScore = updater.AiScore
+	}
+	if updater.FinalScore.Valid {
+		matchResult.FinalScore = updater.FinalScore
+	}
 
 	// Per-user action fields (string enums)
 	if updater.InitiatorAction.Valid {
@@ -128,6 +141,15 @@ func (s *Store) UpdateMatchResult(
 	if updater.ExpiresAt.Valid {
 		matchResult.ExpiresAt = updater.ExpiresAt
 	}
+	if updater.IngestionStatus.Valid {
+		matchResult.IngestionStatus = updater.IngestionStatus.String
+	}
+	if updater.IngestionError.Valid {
+		matchResult.IngestionError = updater.IngestionError
+	}
+	if updater.IngestedAt.Valid {
+		matchResult.IngestedAt = updater.IngestedAt
+	}
 
 	if _, err := matchResult.Update(ctx, exec, boil.Infer()); err != nil {
 		return fmt.Errorf("update match result: %w", err)
@@ -141,6 +163,7 @@ type UpdateMatchResult struct {
 	ID                   string
 	MatchLifecycleStatus null.String // String enum
 	QualifierResults     null.JSON
+	HardQualifierResults null.JSON
 	MatchedQualitatively null.Bool
 	DeliveredToUserAt    null.Time
 	IsVerified           null.Bool
@@ -149,6 +172,9 @@ type UpdateMatchResult struct {
 	IsDropped            null.Bool
 	DroppedTS            null.Time
 	IsPossibleMatch      null.Bool
+	SoftScore            null.Float64
+	AiScore              null.Float64
+	FinalScore           null.Float64
 
 	// Per-user action fields (string enums)
 	InitiatorAction   null.String // String enum
@@ -158,6 +184,11 @@ type UpdateMatchResult struct {
 	ReceiverActionAt null.Time
 	ReceiverSeenAt   null.Time
 	ExpiresAt     null.Time
+
+	// Ingestion state (string enum)
+	IngestionStatus null.String
+	IngestionError  null.String
+	IngestedAt      null.Time
 }
 
 // UpdateMatchForDateInstance links a match to its date instance.
@@ -190,11 +221,12 @@ func (s *Store) UpdateMatchForDateInstance(
 	}
 
 	matchResult.CurrentDateInstanceID = null.StringFrom(updater.CurrentDateInstanceID)
-	matchResult.MatchLifecycleStatus = null.StringFrom(updater.MatchLifecycleStatus)
+	setMatchLifecycleStatus(matchResult, updater.MatchLifecycleStatus)
 
 	cols := []string{
 		pgmodel.MatchResultColumns.CurrentDateInstanceID,
 		pgmodel.MatchResultColumns.MatchLifecycleStatus,
+		pgmodel.MatchResultColumns.ClosedAt,
 	}
 
 	if _, err := matchResult.Update(ctx, exec, boil.Whitelist(cols...)); err != nil {
@@ -203,3 +235,16 @@ func (s *Store) UpdateMatchForDateInstance(
 
 	return nil
 }
+
+// setMatchLifecycleStatus sets a match's lifecycle status, stamping
+// closed_at when the match closes, for the re-match cooldown.
+func setMatchLifecycleStatus(matchResult *pgmodel.MatchResult, status string) {
+	closed := status == string(enums.MatchLifecycleStatusClosed)
+	switch {
+	case closed && !matchResult.ClosedAt.Valid:
+		matchResult.ClosedAt = null.TimeFrom(time.Now().UTC())
+	case !closed:
+		matchResult.ClosedAt = null.Time{} // reopened
+	}
+	matchResult.MatchLifecycleStatus = null.StringFrom(status)
+}
@@ -15,6 +15,7 @@ type InsertMatchSet struct {
 	Name                  string
 	NumberOfParticipants  int
 	MatchingConfiguration json.RawMessage // current config this set is run against
+	ConfigVersionID       null.String     // match_config_version the config was read from
 }
 
 type QueryFilterMatchSet struct {
@@ -34,9 +35,10 @@ func (s *Store) InsertMatchSet(
 	}
 
 	matchSet := pgmodel.MatchSet{
-		Name:                 inserter.Name,
-		NumberOfParticipants: inserter.NumberOfParticipants,
-		MatchConfiguration:   types.JSON(inserter.MatchingConfiguration),
+		Name:                    inserter.Name,
+		NumberOfParticipants:    inserter.NumberOfParticipants,
+		MatchConfiguration:      types.JSON(inserter.MatchingConfiguration),
+		MatchConfigVersionRefID: inserter.ConfigVersionID,
 	}
 
 	if err := matchSet.Insert(ctx, exec, boil.Infer()); err != nil {
@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+// InsertUserAvailability represents a user availability block to be inserted.
+// TimeBlock is a postgres TSTZRANGE literal, e.g. "[2025-01-01T10:00:00Z,2025-01-01T12:00:00Z)".
+type InsertUserAvailability struct {
+	UserID    string
+	TimeBlock string
+}
+
+// InsertUserAvailability inserts a new user availability block.
+func (s *Store) InsertUserAvailability(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *InsertUserAvailability,
+) (*pgmodel.UserAvailability, error) {
+	if inserter.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	if inserter.TimeBlock == "" {
+		return nil, fmt.Errorf("time_block is required")
+	}
+
+	ua := &pgmodel.UserAvailability{
+		UserID:    inserter.UserID,
+		TimeBlock: inserter.TimeBlock,
+	}
+
+	if err := ua.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("insert user availability: %w", err)
+	}
+
+	return ua, nil
+}
+
+// DeleteUserAvailabilities deletes all availability blocks of a user,
+// and returns the number of rows deleted.
+func (s *Store) DeleteUserAvailabilities(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	userID string,
+) (int64, error) {
+	if userID == "" {
+		return 0, fmt.Errorf("user_id is required")
+	}
+
+	n, err := pgmodel.UserAvailabilities(
+		qm.Where(pgmodel.UserAvailabilityColumns.UserID+" = ?", userID),
+	).DeleteAll(ctx, exec)
+	if err != nil {
+		return 0, fmt.Errorf("delete user availabilities: %w", err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,16 @@
+package agentlog
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// agentLogStorer persists agent logs.
+type agentLogStorer interface {
+	AgentLogs(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterAgentLog) (*AgentLogPaginated, error)
+	Count(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterAgentLog) (int, error)
+	Insert(ctx context.Context, exec boil.ContextExecutor, inserter *InsertAgentLog) (*AgentLog, error)
+	InsertBulk(ctx context.Context, exec boil.ContextExecutor, inserters []InsertAgentLog) (int64, error)
+	MarkSeen(ctx context.Context, exec boil.ContextExecutor, m *MarkAgentLogsSeen) ([]string, error)
+}
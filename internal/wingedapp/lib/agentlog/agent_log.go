@@ -0,0 +1,178 @@
+package agentlog
+
+import (
+	"context"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/business/sdk"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+// AgentLogs returns the user's unseen, displayable logs, and marks them seen.
+// A log is only returned by the read that marked it, so concurrent reads
+// never show the same log twice. Since read logs leave the unseen set, the
+// next unseen page is always the first one: pass the same pagination again.
+func (l *Logic) AgentLogs(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	userID uuid.UUID,
+	pagination *sdk.Pagination,
+) (*AgentLogPaginated, error) {
+	now := timeNow()
+
+	unseen, err := l.agentLogStorer.AgentLogs(ctx, exec, &QueryFilterAgentLog{
+		UserRefID:     null.StringFrom(userID.String()),
+		Unseen:        null.BoolFrom(true),
+		DisplayableAt: null.TimeFrom(now),
+		Pagination:    pagination,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("agent log storer agent logs: %w", err)
+	}
+
+	ids := make([]string, 0, len(unseen.Data))
+	for _, al := range unseen.Data {
+		ids = append(ids, al.ID.String())
+	}
+
+	marked, err := l.agentLogStorer.MarkSeen(ctx, exec, &MarkAgentLogsSeen{
+		UserRefID: userID.String(),
+		IDs:       ids,
+		SeenAt:    now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("agent log storer mark seen: %w", err)
+	}
+
+	// drop logs a concurrent read marked first
+	markedIDs := make(map[string]struct{}, len(marked))
+	for _, id := range marked {
+		markedIDs[id] = struct{}{}
+	}
+
+	data := make([]AgentLog, 0, len(marked))
+	for _, al := range unseen.Data {
+		if _, ok := markedIDs[al.ID.String()]; !ok {
+			continue
+		}
+		al.SeenAt = null.TimeFrom(now)
+		data = append(data, al)
+	}
+
+	return &AgentLogPaginated{
+		Data:       data,
+		Pagination: unseen.Pagination,
+	}, nil
+}
+
+// AgentLog returns one displayable log of the user, and marks it seen.
+func (l *Logic) AgentLog(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	userID uuid.UUID,
+	logID uuid.UUID,
+) (*AgentLog, error) {
+	now := timeNow()
+
+	res, err := l.agentLogStorer.AgentLogs(ctx, exec, &QueryFilterAgentLog{
+		ID:            null.StringFrom(logID.String()),
+		UserRefID:     null.StringFrom(userID.String()),
+		DisplayableAt: null.TimeFrom(now),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("agent log storer agent logs: %w", err)
+	}
+	if len(res.Data) != 1 {
+		return nil, fmt.Errorf("%w: %s", ErrAgentLogNotFound, logID)
+	}
+
+	al := res.Data[0]
+	if al.SeenAt.Valid {
+		return &al, nil
+	}
+
+	marked, err := l.agentLogStorer.MarkSeen(ctx, exec, &MarkAgentLogsSeen{
+		UserRefID: userID.String(),
+		IDs:       []string{al.ID.String()},
+		SeenAt:    now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("agent log storer mark seen: %w", err)
+	}
+	if len(marked) == 1 {
+		al.SeenAt = null.TimeFrom(now)
+	}
+
+	return &al, nil
+}
+
+// AllLogs returns the user's displayable logs, seen and unseen, newest first.
+// Unlike AgentLogs, it doesn't mark anything seen.
+func (l *Logic) AllLogs(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	userID uuid.UUID,
+	pagination *sdk.Pagination,
+) (*AgentLogPaginated, error) {
+	res, err := l.agentLogStorer.AgentLogs(ctx, exec, &QueryFilterAgentLog{
+		UserRefID:     null.StringFrom(userID.String()),
+		DisplayableAt: null.TimeFrom(timeNow()),
+		Pagination:    pagination,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("agent log storer agent logs: %w", err)
+	}
+
+	return res, nil
+}
+
+// UnseenCount returns the number of the user's unseen, displayable logs.
+func (l *Logic) UnseenCount(ctx context.Context, exec boil.ContextExecutor, userID uuid.UUID) (int, error) {
+	count, err := l.agentLogStorer.Count(ctx, exec, &QueryFilterAgentLog{
+		UserRefID:     null.StringFrom(userID.String()),
+		Unseen:        null.BoolFrom(true),
+		DisplayableAt: null.TimeFrom(timeNow()),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("agent log storer count: %w", err)
+	}
+
+	return count, nil
+}
+
+// Insert inserts an agent log.
+func (l *Logic) Insert(ctx context.Context, exec boil.ContextExecutor, inserter *InsertAgentLog) (*AgentLog, error) {
+	if err := inserter.Validate(); err != nil {
+		return nil, fmt.Errorf("validate inserter: %w", err)
+	}
+
+	al, err := l.agentLogStorer.Insert(ctx, exec, inserter)
+	if err != nil {
+		return nil, fmt.Errorf("agent log storer insert: %w", err)
+	}
+
+	return al, nil
+}
+
+// InsertBulk inserts agent logs in one statement, e.g. one per user of a match drop.
+// Returns the number of logs inserted.
+func (l *Logic) InsertBulk(ctx context.Context, exec boil.ContextExecutor, inserters []InsertAgentLog) (int64, error) {
+	if len(inserters) == 0 {
+		return 0, nil
+	}
+
+	for i := range inserters {
+		if err := inserters[i].Validate(); err != nil {
+			return 0, fmt.Errorf("validate inserter %d: %w", i, err)
+		}
+	}
+
+	count, err := l.agentLogStorer.InsertBulk(ctx, exec, inserters)
+	if err != nil {
+		return 0, fmt.Errorf("agent log storer insert bulk: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,170 @@
+package agentlog_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/db/factory"
+	wingedFactory "wingedapp/pgtester/internal/wingedapp/db/factory"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/agentlog"
+	"wingedapp/pgtester/internal/wingedapp/lib/agentlog/store"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/null/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestAgentLogLogic wires the agent log logic against the real stores.
+func createTestAgentLogLogic(t *testing.T) *agentlog.Logic {
+	t.Helper()
+	stores := store.NewAgentLogStores(applog.NewLogrus("test"))
+
+	logic, err := agentlog.NewLogic(stores.AgentLogStore)
+	require.NoError(t, err)
+	return logic
+}
+
+// persistAgentLog inserts an agent log for a user, displayed from displayBy.
+func persistAgentLog(th *testsuite.Helper, userID, log string, displayBy time.Time, seenAt null.Time) {
+	th.T.Helper()
+	factory.NewEntity[*wingedFactory.AgentLog](&wingedFactory.AgentLog{
+		Subject: &pgmodel.AgentLog{
+			UserRefID: userID,
+			Log:       log,
+			DisplayBy: displayBy,
+			SeenAt:    seenAt,
+		},
+	}).New(th.T, th.BackendAppDb())
+}
+
+func TestLogic_AgentLogs(t *testing.T) {
+	t.Run("returns-displayable-unseen-and-marks-them-seen", func(t *testing.T) {
+		t.Parallel()
+		testSuite := testsuite.New(t)
+		t.Cleanup(testSuite.UseBackendDB())
+
+		ctx := context.Background()
+		exec := testSuite.BackendAppDb()
+		logic := createTestAgentLogLogic(t)
+
+		user := testSuite.PersistRegisteredUser()
+		now := time.Now()
+		persistAgentLog(testSuite, user.ID, "displayable", now.Add(-time.Minute), null.Time{})
+		persistAgentLog(testSuite, user.ID, "delayed", now.Add(time.Hour), null.Time{})
+		persistAgentLog(testSuite, user.ID, "already-seen", now.Add(-time.Hour), null.TimeFrom(now.Add(-time.Minute)))
+
+		userID := uuid.MustParse(user.ID)
+
+		count, err := logic.UnseenCount(ctx, exec, userID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count, "delayed logs are not counted")
+
+		res, err := logic.AgentLogs(ctx, exec, userID, nil)
+		require.NoError(t, err)
+		require.Len(t, res.Data, 1)
+		assert.Equal(t, "displayable", res.Data[0].Log)
+		assert.True(t, res.Data[0].SeenAt.Valid)
+
+		res, err = logic.AgentLogs(ctx, exec, userID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, res.Data, "a read log is not returned again")
+
+		count, err = logic.UnseenCount(ctx, exec, userID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		all, err := logic.AllLogs(ctx, exec, userID, nil)
+		require.NoError(t, err)
+		assert.Len(t, all.Data, 2, "all logs excludes the delayed log")
+	})
+
+	t.Run("concurrent-reads-return-each-log-once", func(t *testing.T) {
+		t.Parallel()
+		testSuite := testsuite.New(t)
+		t.Cleanup(testSuite.UseBackendDB())
+
+		ctx := context.Background()
+		exec := testSuite.BackendAppDb()
+		logic := createTestAgentLogLogic(t)
+
+		user := testSuite.PersistRegisteredUser()
+		userID := uuid.MustParse(user.ID)
+
+		const logs, readers = 5, 4
+		inserters := make([]agentlog.InsertAgentLog, logs)
+		for i := range inserters {
+			inserters[i] = agentlog.InsertAgentLog{UserRefID: user.ID, Log: "log"}
+		}
+		inserted, err := logic.InsertBulk(ctx, exec, inserters)
+		require.NoError(t, err)
+		require.EqualValues(t, logs, inserted)
+
+		var (
+			mu   sync.Mutex
+			seen = map[uuid.UUID]int{}
+			wg   sync.WaitGroup
+		)
+		for i := 0; i < readers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, err := logic.AgentLogs(ctx, exec, userID, nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				for _, al := range res.Data {
+					seen[al.ID]++
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, seen, logs)
+		for id, n := range seen {
+			assert.Equal(t, 1, n, "log %s returned more than once", id)
+		}
+	})
+}
+
+func TestLogic_AgentLog(t *testing.T) {
+	t.Parallel()
+	testSuite := testsuite.New(t)
+	t.Cleanup(testSuite.UseBackendDB())
+
+	ctx := context.Background()
+	exec := testSuite.BackendAppDb()
+	logic := createTestAgentLogLogic(t)
+
+	user := testSuite.PersistRegisteredUser()
+	other := testSuite.PersistRegisteredUser()
+	userID := uuid.MustParse(user.ID)
+
+	al, err := logic.Insert(ctx, exec, &agentlog.InsertAgentLog{UserRefID: user.ID, Log: "your agent proposed a date"})
+	require.NoError(t, err)
+	delayed, err := logic.Insert(ctx, exec, &agentlog.InsertAgentLog{
+		UserRefID: user.ID,
+		Log:       "later",
+		DisplayBy: null.TimeFrom(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+
+	got, err := logic.AgentLog(ctx, exec, userID, al.ID)
+	require.NoError(t, err)
+	assert.True(t, got.SeenAt.Valid)
+
+	_, err = logic.AgentLog(ctx, exec, userID, delayed.ID)
+	require.ErrorIs(t, err, agentlog.ErrAgentLogNotFound, "delayed logs are not displayable yet")
+
+	_, err = logic.AgentLog(ctx, exec, uuid.MustParse(other.ID), al.ID)
+	require.ErrorIs(t, err, agentlog.ErrAgentLogNotFound, "users only see their own logs")
+
+	_, err = logic.Insert(ctx, exec, &agentlog.InsertAgentLog{UserRefID: user.ID, Log: "  "})
+	require.ErrorIs(t, err, agentlog.ErrEmptyLog)
+}
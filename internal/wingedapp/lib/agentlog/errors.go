@@ -0,0 +1,12 @@
+package agentlog
+
+import "errors"
+
+/* Agent log sentinel errors */
+
+var (
+	ErrMissingParams    = errors.New("missing params")
+	ErrInvalidUserID    = errors.New("invalid user id")
+	ErrEmptyLog         = errors.New("log message is required")
+	ErrAgentLogNotFound = errors.New("agent log not found")
+)
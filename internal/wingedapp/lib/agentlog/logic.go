@@ -0,0 +1,23 @@
+package agentlog
+
+import (
+	"errors"
+	"time"
+)
+
+// timeNow is a variable for testing purposes
+var timeNow = time.Now
+
+type Logic struct {
+	agentLogStorer agentLogStorer
+}
+
+func NewLogic(agentLogStorer agentLogStorer) (*Logic, error) {
+	if agentLogStorer == nil {
+		return nil, errors.New("agentLogStorer is required")
+	}
+
+	return &Logic{
+		agentLogStorer: agentLogStorer,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package agentlog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/business/sdk"
+
+	"github.com/aarondl/null/v8"
+	"github.com/google/uuid"
+)
+
+// AgentLog is a message telling a user what their agent did.
+// It's hidden until DisplayBy, so the agent doesn't look instant.
+type AgentLog struct {
+	ID        uuid.UUID `json:"id" boil:"id"`
+	UserRefID uuid.UUID `json:"user_ref_id" boil:"user_ref_id"`
+	Log       string    `json:"log" boil:"log"`
+	DisplayBy time.Time `json:"display_by" boil:"display_by"`
+	SeenAt    null.Time `json:"seen_at" boil:"seen_at"`
+	CreatedAt time.Time `json:"created_at" boil:"created_at"`
+}
+
+type AgentLogPaginated struct {
+	Data       []AgentLog      `json:"data"`
+	Pagination *sdk.Pagination `json:"pagination"`
+}
+
+// InsertAgentLog inserts an agent log, displayed from DisplayBy (defaults to now).
+type InsertAgentLog struct {
+	UserRefID string
+	Log       string
+	DisplayBy null.Time
+}
+
+func (i *InsertAgentLog) Validate() error {
+	if i == nil {
+		return ErrMissingParams
+	}
+	if _, err := uuid.Parse(i.UserRefID); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidUserID, i.UserRefID)
+	}
+	if strings.TrimSpace(i.Log) == "" {
+		return ErrEmptyLog
+	}
+	return nil
+}
+
+// QueryFilterAgentLog filters agent log reads.
+type QueryFilterAgentLog struct {
+	ID            null.String
+	UserRefID     null.String
+	Unseen        null.Bool // only logs with no seen_at
+	DisplayableAt null.Time // only logs whose display_by has passed at this time
+	Pagination    *sdk.Pagination
+}
+
+// MarkAgentLogsSeen marks the given unseen logs of a user as seen.
+type MarkAgentLogsSeen struct {
+	UserRefID string
+	IDs       []string
+	SeenAt    time.Time
+}
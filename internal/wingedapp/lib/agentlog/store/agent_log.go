@@ -0,0 +1,217 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"wingedapp/pgtester/internal/wingedapp/db/boilhelper"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/db/repo"
+	"wingedapp/pgtester/internal/wingedapp/lib/agentlog"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/google/uuid"
+)
+
+// AgentLogStore handles reads and writes of agent logs.
+type AgentLogStore struct {
+	l    applog.Logger
+	repo *repo.Store
+}
+
+// AgentLogs returns agent logs matching the filter, newest display_by first.
+func (s *AgentLogStore) AgentLogs(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	f *agentlog.QueryFilterAgentLog,
+) (*agentlog.AgentLogPaginated, error) {
+	cols := pgmodel.AgentLogColumns
+
+	qMods := append(
+		qModsAgentLog(f),
+		qm.Select(
+			cols.ID,
+			cols.UserRefID,
+			cols.Log,
+			cols.DisplayBy,
+			cols.SeenAt,
+			cols.CreatedAt,
+		),
+		qm.OrderBy(cols.DisplayBy+" DESC, "+cols.ID+" DESC"),
+	)
+	if f.Pagination != nil {
+		qMods = boilhelper.ApplyPagination(qMods, f.Pagination)
+	}
+
+	var rows []agentlog.AgentLog
+	if err := pgmodel.AgentLogs(qMods...).Bind(ctx, exec, &rows); err != nil {
+		return nil, fmt.Errorf("query agent logs: %w", err)
+	}
+
+	count := len(rows)
+	if f.Pagination != nil {
+		var err error
+		if count, err = s.Count(ctx, exec, f); err != nil {
+			return nil, fmt.Errorf("count: %w", err)
+		}
+	}
+
+	return &agentlog.AgentLogPaginated{
+		Data:       rows,
+		Pagination: f.Pagination.Recalculated(count),
+	}, nil
+}
+
+// Count returns the number of agent logs matching the filter.
+func (s *AgentLogStore) Count(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	f *agentlog.QueryFilterAgentLog,
+) (int, error) {
+	count, err := pgmodel.AgentLogs(qModsAgentLog(f)...).Count(ctx, exec)
+	if err != nil {
+		return 0, fmt.Errorf("count agent logs: %w", err)
+	}
+	return int(count), nil
+}
+
+// Insert inserts an agent log.
+func (s *AgentLogStore) Insert(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *agentlog.InsertAgentLog,
+) (*agentlog.AgentLog, error) {
+	al, err := s.repo.InsertAgentLog(ctx, exec, &repo.InsertAgentLog{
+		UserRefID: inserter.UserRefID,
+		Log:       inserter.Log,
+		DisplayBy: inserter.DisplayBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("insert agent log: %w", err)
+	}
+
+	id, err := uuid.Parse(al.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parse agent log id: %w", err)
+	}
+	userID, err := uuid.Parse(al.UserRefID)
+	if err != nil {
+		return nil, fmt.Errorf("parse user ref id: %w", err)
+	}
+
+	return &agentlog.AgentLog{
+		ID:        id,
+		UserRefID: userID,
+		Log:       al.Log,
+		DisplayBy: al.DisplayBy,
+		SeenAt:    al.SeenAt,
+		CreatedAt: al.CreatedAt,
+	}, nil
+}
+
+// InsertBulk inserts agent logs in a single statement.
+func (s *AgentLogStore) InsertBulk(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserters []agentlog.InsertAgentLog,
+) (int64, error) {
+	rows := make([]repo.InsertAgentLog, len(inserters))
+	for i, in := range inserters {
+		rows[i] = repo.InsertAgentLog{
+			UserRefID: in.UserRefID,
+			Log:       in.Log,
+			DisplayBy: in.DisplayBy,
+		}
+	}
+
+	count, err := s.repo.InsertAgentLogs(ctx, exec, rows)
+	if err != nil {
+		return 0, fmt.Errorf("insert agent logs: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkSeen sets seen_at on the given logs that are still unseen, and returns
+// the ids it marked. The seen_at IS NULL guard is re-checked under the row
+// lock, so of two concurrent marks only one gets each log back.
+func (s *AgentLogStore) MarkSeen(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	m *agentlog.MarkAgentLogsSeen,
+) ([]string, error) {
+	if len(m.IDs) == 0 {
+		return []string{}, nil
+	}
+
+	cols := pgmodel.AgentLogColumns
+
+	args := []interface{}{m.SeenAt, m.UserRefID}
+	placeholders := make([]string, len(m.IDs))
+	for i, id := range m.IDs {
+		args = append(args, id)
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET %s = $1, %s = $1
+		WHERE %s = $2
+		  AND %s IS NULL
+		  AND %s IN (%s)
+		RETURNING %s`,
+		pgmodel.TableNames.AgentLog,
+		cols.SeenAt, cols.UpdatedAt,
+		cols.UserRefID,
+		cols.SeenAt,
+		cols.ID, strings.Join(placeholders, ", "),
+		cols.ID,
+	)
+
+	rows, err := exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("mark agent logs seen: %w", err)
+	}
+	defer rows.Close()
+
+	marked := make([]string, 0, len(m.IDs))
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan marked id: %w", err)
+		}
+		marked = append(marked, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("marked rows: %w", err)
+	}
+
+	return marked, nil
+}
+
+// qModsAgentLog builds the filters shared by the rows and count queries.
+func qModsAgentLog(f *agentlog.QueryFilterAgentLog) []qm.QueryMod {
+	cols := pgmodel.AgentLogColumns
+
+	qMods := []qm.QueryMod{}
+	if f.ID.Valid {
+		qMods = append(qMods, qm.Where(cols.ID+" = ?", f.ID.String))
+	}
+	if f.UserRefID.Valid {
+		qMods = append(qMods, qm.Where(cols.UserRefID+" = ?", f.UserRefID.String))
+	}
+	if f.Unseen.Valid {
+		if f.Unseen.Bool {
+			qMods = append(qMods, qm.Where(cols.SeenAt+" IS NULL"))
+		} else {
+			qMods = append(qMods, qm.Where(cols.SeenAt+" IS NOT NULL"))
+		}
+	}
+	if f.DisplayableAt.Valid {
+		qMods = append(qMods, qm.Where(cols.DisplayBy+" <= ?", f.DisplayableAt.Time))
+	}
+
+	return qMods
+}
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"wingedapp/pgtester/internal/wingedapp/db/repo"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+)
+
+type AgentLogStores struct {
+	AgentLogStore *AgentLogStore
+}
+
+func NewAgentLogStores(l applog.Logger) *AgentLogStores {
+	r := &repo.Store{}
+	return &AgentLogStores{
+		AgentLogStore: &AgentLogStore{l, r},
+	}
+}
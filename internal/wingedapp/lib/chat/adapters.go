@@ -0,0 +1,43 @@
+package chat
+
+import (
+	"context"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/lib/agentlog"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+// chatMessageStorer persists chat messages.
+type chatMessageStorer interface {
+	ChatMessages(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterChatMessage) ([]ChatMessage, error)
+	Insert(ctx context.Context, exec boil.ContextExecutor, inserter *InsertChatMessage) (*ChatMessage, error)
+	Update(ctx context.Context, exec boil.ContextExecutor, updater *UpdateChatMessage) error
+	MarkSeen(ctx context.Context, exec boil.ContextExecutor, m *MarkChatMessagesSeen) (int64, error)
+	UnreadCounts(ctx context.Context, exec boil.ContextExecutor, userID uuid.UUID) ([]UnreadCount, error)
+}
+
+// chatMatchStorer reads the match a chat belongs to.
+type chatMatchStorer interface {
+	ChatMatch(ctx context.Context, exec boil.ContextExecutor, matchResultID uuid.UUID) (*ChatMatch, error)
+}
+
+// actionLogger charges wings for sent messages.
+type actionLogger interface {
+	CanPerformAction(ctx context.Context, exec boil.ContextExecutor, params *economy.CanPerformActionParams) (bool, error)
+	CreateActionLog(ctx context.Context, exec boil.ContextExecutor, inserter *economy.InsertActionLog) error
+}
+
+// chatNudgeStorer finds quiet chats, and records the nudges sent for them.
+type chatNudgeStorer interface {
+	StaleChats(ctx context.Context, exec boil.ContextExecutor, quietBefore time.Time) ([]StaleChat, error)
+	InsertNudge(ctx context.Context, exec boil.ContextExecutor, inserter *InsertChatNudge) (bool, error)
+	InsertNotification(ctx context.Context, exec boil.ContextExecutor, inserter *InsertNotification) error
+}
+
+// agentLogInserter writes the agent log entries users see in the app.
+type agentLogInserter interface {
+	Insert(ctx context.Context, exec boil.ContextExecutor, inserter *agentlog.InsertAgentLog) (*agentlog.AgentLog, error)
+}
@@ -0,0 +1,290 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+// SendMessage sends a message in a match's chat, once both users proposed.
+// Sent messages go through the economy like agent prompts do: every
+// SendMessageThreshold messages cost the Send Message price in the action
+// price catalog, which depends on the sender's tier.
+// Run it in a transaction, so a message whose price can't be charged is
+// rolled back with it.
+func (l *Logic) SendMessage(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	params *SendMessageParams,
+) (*ChatMessage, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("validate params: %w", err)
+	}
+
+	if _, err := l.openChatMatch(ctx, exec, params.MatchResultID, params.SenderID); err != nil {
+		return nil, err
+	}
+
+	canPerform, err := l.actionLogger.CanPerformAction(ctx, exec, &economy.CanPerformActionParams{
+		UserID:     params.SenderID.String(),
+		ActionType: economy.ActionSendMessage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("check can perform action: %w", err)
+	}
+	if !canPerform {
+		return nil, economy.ErrInsufficientWings
+	}
+
+	msg, err := l.chatMessageStorer.Insert(ctx, exec, &InsertChatMessage{
+		MatchResultID: params.MatchResultID,
+		SenderID:      params.SenderID,
+		Message:       params.Message,
+		CreatedAt:     timeNow(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("chat message storer insert: %w", err)
+	}
+
+	// log action for economy (deducts wings at threshold)
+	err = l.actionLogger.CreateActionLog(ctx, exec, &economy.InsertActionLog{
+		UserID: params.SenderID.String(),
+		RefID:  msg.ID.String(),
+		Type:   economy.ActionSendMessage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create action log: %w", err)
+	}
+
+	return msg, nil
+}
+
+// History returns a page of a match's chat, without deleted messages.
+// The first page holds the newest messages; pass its NextCursor to get the
+// ones before. Messages within a page are oldest first, as they're shown.
+func (l *Logic) History(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	params *HistoryParams,
+) (*ChatMessagePage, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("validate params: %w", err)
+	}
+
+	if _, err := l.chatMatch(ctx, exec, params.MatchResultID, params.UserID); err != nil {
+		return nil, err
+	}
+
+	f := &QueryFilterChatMessage{
+		MatchResultID: null.StringFrom(params.MatchResultID.String()),
+		Limit:         historyLimit(params.Limit) + 1, // one extra to know if there's an older page
+	}
+	if params.Cursor.Valid {
+		cursor, err := ParseCursor(params.Cursor.String)
+		if err != nil {
+			return nil, err
+		}
+		f.Before = &cursor
+	}
+
+	msgs, err := l.chatMessageStorer.ChatMessages(ctx, exec, f)
+	if err != nil {
+		return nil, fmt.Errorf("chat message storer chat messages: %w", err)
+	}
+
+	page := &ChatMessagePage{}
+	if len(msgs) == f.Limit {
+		msgs = msgs[:len(msgs)-1]
+		page.NextCursor = null.StringFrom(msgs[len(msgs)-1].Cursor().String())
+	}
+
+	// newest first from the store, oldest first on the page
+	page.Data = make([]ChatMessage, len(msgs))
+	for i, msg := range msgs {
+		page.Data[len(msgs)-1-i] = msg
+	}
+
+	return page, nil
+}
+
+// EditMessage replaces the text of one of the sender's messages, while the
+// chat is still open.
+func (l *Logic) EditMessage(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	params *EditMessageParams,
+) (*ChatMessage, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("validate params: %w", err)
+	}
+
+	msg, err := l.sendersMessage(ctx, exec, params.MessageID, params.SenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = l.openChatMatch(ctx, exec, msg.MatchResultID, params.SenderID); err != nil {
+		return nil, err
+	}
+
+	now := timeNow()
+	if err = l.chatMessageStorer.Update(ctx, exec, &UpdateChatMessage{
+		ID:        msg.ID,
+		Message:   null.StringFrom(params.Message),
+		EditedAt:  null.TimeFrom(now),
+		UpdatedAt: now,
+	}); err != nil {
+		return nil, fmt.Errorf("chat message storer update: %w", err)
+	}
+
+	msg.Message = params.Message
+	msg.EditedAt = null.TimeFrom(now)
+
+	return msg, nil
+}
+
+// DeleteMessage soft-deletes one of the sender's messages. Deleted messages
+// are left out of the history and unread counts.
+func (l *Logic) DeleteMessage(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	messageID uuid.UUID,
+	senderID uuid.UUID,
+) error {
+	msg, err := l.sendersMessage(ctx, exec, messageID, senderID)
+	if err != nil {
+		return err
+	}
+
+	now := timeNow()
+	if err = l.chatMessageStorer.Update(ctx, exec, &UpdateChatMessage{
+		ID:        msg.ID,
+		DeletedAt: null.TimeFrom(now),
+		UpdatedAt: now,
+	}); err != nil {
+		return fmt.Errorf("chat message storer update: %w", err)
+	}
+
+	return nil
+}
+
+// MarkSeen marks every message the user received in a match as seen,
+// and returns how many were unseen.
+func (l *Logic) MarkSeen(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	matchResultID uuid.UUID,
+	userID uuid.UUID,
+) (int64, error) {
+	if _, err := l.chatMatch(ctx, exec, matchResultID, userID); err != nil {
+		return 0, err
+	}
+
+	marked, err := l.chatMessageStorer.MarkSeen(ctx, exec, &MarkChatMessagesSeen{
+		MatchResultID: matchResultID,
+		RecipientID:   userID,
+		SeenAt:        timeNow(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("chat message storer mark seen: %w", err)
+	}
+
+	return marked, nil
+}
+
+// UnreadCounts returns, per match of the user, the number of messages they
+// received and haven't seen. Matches without unread messages are left out.
+func (l *Logic) UnreadCounts(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	userID uuid.UUID,
+) (map[uuid.UUID]int, error) {
+	counts, err := l.chatMessageStorer.UnreadCounts(ctx, exec, userID)
+	if err != nil {
+		return nil, fmt.Errorf("chat message storer unread counts: %w", err)
+	}
+
+	unread := make(map[uuid.UUID]int, len(counts))
+	for _, c := range counts {
+		unread[c.MatchResultID] = c.Count
+	}
+
+	return unread, nil
+}
+
+// chatMatch returns the match, if the user is part of it.
+func (l *Logic) chatMatch(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	matchResultID uuid.UUID,
+	userID uuid.UUID,
+) (*ChatMatch, error) {
+	match, err := l.chatMatchStorer.ChatMatch(ctx, exec, matchResultID)
+	if err != nil {
+		return nil, fmt.Errorf("chat match storer chat match: %w", err)
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMatchNotFound, matchResultID)
+	}
+	if !match.HasUser(userID) {
+		return nil, fmt.Errorf("%w: %s", ErrNotInMatch, matchResultID)
+	}
+
+	return match, nil
+}
+
+// openChatMatch returns the match, if the user is part of it and can chat.
+func (l *Logic) openChatMatch(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	matchResultID uuid.UUID,
+	userID uuid.UUID,
+) (*ChatMatch, error) {
+	match, err := l.chatMatch(ctx, exec, matchResultID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !match.Unlocked() {
+		return nil, fmt.Errorf("%w: %s", ErrChatLocked, matchResultID)
+	}
+
+	return match, nil
+}
+
+// sendersMessage returns a message that isn't deleted, if the user sent it.
+func (l *Logic) sendersMessage(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	messageID uuid.UUID,
+	senderID uuid.UUID,
+) (*ChatMessage, error) {
+	msgs, err := l.chatMessageStorer.ChatMessages(ctx, exec, &QueryFilterChatMessage{
+		ID: null.StringFrom(messageID.String()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("chat message storer chat messages: %w", err)
+	}
+	if len(msgs) != 1 {
+		return nil, fmt.Errorf("%w: %s", ErrMessageNotFound, messageID)
+	}
+	if msgs[0].SenderID != senderID {
+		return nil, fmt.Errorf("%w: %s", ErrNotSender, messageID)
+	}
+
+	return &msgs[0], nil
+}
+
+func historyLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return DefaultHistoryLimit
+	case limit > MaxHistoryLimit:
+		return MaxHistoryLimit
+	default:
+		return limit
+	}
+}
@@ -0,0 +1,362 @@
+package chat_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/db/factory"
+	wingedFactory "wingedapp/pgtester/internal/wingedapp/db/factory"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/db/repo"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/chat"
+	"wingedapp/pgtester/internal/wingedapp/lib/chat/store"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/null/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestChatLogic wires the chat logic against the real stores and economy.
+func createTestChatLogic(th *testsuite.Helper) *chat.Logic {
+	th.T.Helper()
+	stores := store.NewChatStores(applog.NewLogrus("test"))
+
+	logic, err := chat.NewLogic(stores.ChatMessageStore, stores.ChatMatchStore, th.FakeContainer().GetLibEconomy())
+	require.NoError(th.T, err)
+	return logic
+}
+
+// chatUsers are the two users of a match, each starting with wings.
+type chatUsers struct {
+	match     uuid.UUID
+	initiator uuid.UUID
+	receiver  uuid.UUID
+}
+
+// persistChatMatch creates a dropped match between two registered users
+// with the given actions, giving each user the wings.
+func persistChatMatch(th *testsuite.Helper, initiator, receiver enums.MatchUserAction, wings int) chatUsers {
+	th.T.Helper()
+	exec := th.BackendAppDb()
+
+	a := th.PersistRegisteredUser()
+	b := th.PersistRegisteredUser()
+	for _, u := range []*pgmodel.User{a, b} {
+		setWings(th, u.ID, wings)
+	}
+
+	mr := factory.NewEntity[*wingedFactory.MatchResult](&wingedFactory.MatchResult{
+		Subject: &pgmodel.MatchResult{
+			UserARefID:         a.ID,
+			UserBRefID:         b.ID,
+			InitiatorUserRefID: a.ID,
+			ReceiverUserRefID:  b.ID,
+			IsApproved:         true,
+			IsDropped:          true,
+			InitiatorAction:    string(initiator),
+			ReceiverAction:     string(receiver),
+		},
+	}).New(th.T, exec)
+
+	return chatUsers{
+		match:     uuid.MustParse(mr.Subject.ID),
+		initiator: uuid.MustParse(a.ID),
+		receiver:  uuid.MustParse(b.ID),
+	}
+}
+
+func setWings(th *testsuite.Helper, userID string, wings int) {
+	th.T.Helper()
+	beStore := repo.Store{}
+	userTotals, err := beStore.WingsEcnUserTotal(context.Background(), th.BackendAppDb(), &repo.QueryFilterWingsEcnUserTotal{
+		UserID: null.StringFrom(userID),
+	})
+	require.NoError(th.T, err, "fetch user totals")
+	require.NoError(th.T, beStore.UpdateWingsEcnUserTotals(context.Background(), th.BackendAppDb(), &repo.UpdateWingsEcnUserTotals{
+		ID:         userTotals.ID,
+		TotalWings: null.IntFrom(wings),
+	}), "set wings")
+}
+
+func wingsOf(th *testsuite.Helper, userID uuid.UUID) int {
+	th.T.Helper()
+	beStore := repo.Store{}
+	userTotals, err := beStore.WingsEcnUserTotal(context.Background(), th.BackendAppDb(), &repo.QueryFilterWingsEcnUserTotal{
+		UserID: null.StringFrom(userID.String()),
+	})
+	require.NoError(th.T, err, "fetch user totals")
+	return userTotals.TotalWings
+}
+
+func send(th *testsuite.Helper, logic *chat.Logic, match, sender uuid.UUID, message string) (*chat.ChatMessage, error) {
+	return logic.SendMessage(context.Background(), th.BackendAppDb(), &chat.SendMessageParams{
+		MatchResultID: match,
+		SenderID:      sender,
+		Message:       message,
+	})
+}
+
+type testCaseSendMessage struct {
+	name            string
+	initiator       enums.MatchUserAction
+	receiver        enums.MatchUserAction
+	wings           int
+	extraAssertions func(th *testsuite.Helper, logic *chat.Logic, users chatUsers)
+}
+
+func TestLogic_SendMessage(t *testing.T) {
+	testCases := []testCaseSendMessage{
+		{
+			name:      "success-mutual-proposal-unlocks-chat",
+			initiator: enums.MatchUserActionProposed,
+			receiver:  enums.MatchUserActionProposed,
+			wings:     10,
+			extraAssertions: func(th *testsuite.Helper, logic *chat.Logic, users chatUsers) {
+				msg, err := send(th, logic, users.match, users.initiator, "hi!")
+				require.NoError(th.T, err, "send should succeed")
+				assert.Equal(th.T, "hi!", msg.Message)
+				assert.Equal(th.T, users.initiator, msg.SenderID)
+
+				_, err = send(th, logic, users.match, users.receiver, "hey")
+				require.NoError(th.T, err, "both users can send")
+			},
+		},
+		{
+			name:      "error-one-sided-proposal-is-locked",
+			initiator: enums.MatchUserActionProposed,
+			receiver:  enums.MatchUserActionPending,
+			wings:     10,
+			extraAssertions: func(th *testsuite.Helper, logic *chat.Logic, users chatUsers) {
+				_, err := send(th, logic, users.match, users.initiator, "hi!")
+				require.ErrorIs(th.T, err, chat.ErrChatLocked)
+			},
+		},
+		{
+			name:      "error-user-outside-match",
+			initiator: enums.MatchUserActionProposed,
+			receiver:  enums.MatchUserActionProposed,
+			wings:     10,
+			extraAssertions: func(th *testsuite.Helper, logic *chat.Logic, users chatUsers) {
+				outsider := th.PersistRegisteredUser()
+				_, err := send(th, logic, users.match, uuid.MustParse(outsider.ID), "hi!")
+				require.ErrorIs(th.T, err, chat.ErrNotInMatch)
+			},
+		},
+		{
+			name:      "error-empty-message",
+			initiator: enums.MatchUserActionProposed,
+			receiver:  enums.MatchUserActionProposed,
+			wings:     10,
+			extraAssertions: func(th *testsuite.Helper, logic *chat.Logic, users chatUsers) {
+				_, err := send(th, logic, users.match, users.initiator, "   ")
+				require.ErrorIs(th.T, err, chat.ErrEmptyMessage)
+			},
+		},
+		{
+			name:      "success-every-fifth-message-costs-a-wing",
+			initiator: enums.MatchUserActionProposed,
+			receiver:  enums.MatchUserActionProposed,
+			wings:     10,
+			extraAssertions: func(th *testsuite.Helper, logic *chat.Logic, users chatUsers) {
+				for i := 1; i <= economy.SendMessageThreshold; i++ {
+					_, err := send(th, logic, users.match, users.initiator, "msg")
+					require.NoError(th.T, err, "send %d should succeed", i)
+
+					if i < economy.SendMessageThreshold {
+						assert.Equal(th.T, 10, wingsOf(th, users.initiator), "no wing charged after %d messages", i)
+					}
+				}
+				assert.Equal(th.T, 9, wingsOf(th, users.initiator), "a message costs the seeded catalog price, 1 wing")
+				assert.Equal(th.T, 10, wingsOf(th, users.receiver), "the receiver isn't charged")
+			},
+		},
+		{
+			name:      "error-no-wings",
+			initiator: enums.MatchUserActionProposed,
+			receiver:  enums.MatchUserActionProposed,
+			wings:     0,
+			extraAssertions: func(th *testsuite.Helper, logic *chat.Logic, users chatUsers) {
+				_, err := send(th, logic, users.match, users.initiator, "hi!")
+				require.ErrorIs(th.T, err, economy.ErrInsufficientWings)
+
+				page, err := logic.History(context.Background(), th.BackendAppDb(), &chat.HistoryParams{
+					MatchResultID: users.match,
+					UserID:        users.initiator,
+				})
+				require.NoError(th.T, err)
+				assert.Empty(th.T, page.Data, "no message is sent")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tSuite := testsuite.New(t)
+			t.Cleanup(tSuite.UseBackendDB())
+
+			users := persistChatMatch(tSuite, tc.initiator, tc.receiver, tc.wings)
+			tc.extraAssertions(tSuite, createTestChatLogic(tSuite), users)
+		})
+	}
+}
+
+func TestLogic_History(t *testing.T) {
+	t.Parallel()
+	tSuite := testsuite.New(t)
+	t.Cleanup(tSuite.UseBackendDB())
+
+	ctx := context.Background()
+	exec := tSuite.BackendAppDb()
+	logic := createTestChatLogic(tSuite)
+	users := persistChatMatch(tSuite, enums.MatchUserActionProposed, enums.MatchUserActionProposed, 10)
+
+	var sent []string
+	for _, text := range []string{"one", "two", "three", "four", "five"} {
+		msg, err := send(tSuite, logic, users.match, users.initiator, text)
+		require.NoError(t, err)
+		sent = append(sent, msg.Message)
+	}
+
+	newest, err := logic.History(ctx, exec, &chat.HistoryParams{
+		MatchResultID: users.match,
+		UserID:        users.receiver,
+		Limit:         2,
+	})
+	require.NoError(t, err)
+	require.Len(t, newest.Data, 2)
+	assert.Equal(t, "four", newest.Data[0].Message, "a page is oldest first")
+	assert.Equal(t, "five", newest.Data[1].Message)
+	require.True(t, newest.NextCursor.Valid)
+
+	var got []string
+	cursor := null.String{}
+	for {
+		page, err := logic.History(ctx, exec, &chat.HistoryParams{
+			MatchResultID: users.match,
+			UserID:        users.receiver,
+			Cursor:        cursor,
+			Limit:         2,
+		})
+		require.NoError(t, err)
+		for _, msg := range page.Data {
+			got = append([]string{msg.Message}, got...)
+		}
+		if !page.NextCursor.Valid {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	assert.ElementsMatch(t, sent, got, "paging back covers every message once")
+
+	_, err = logic.History(ctx, exec, &chat.HistoryParams{
+		MatchResultID: users.match,
+		UserID:        users.receiver,
+		Cursor:        null.StringFrom("not-a-cursor"),
+	})
+	require.ErrorIs(t, err, chat.ErrInvalidCursor)
+}
+
+func TestLogic_EditAndDeleteMessage(t *testing.T) {
+	t.Parallel()
+	tSuite := testsuite.New(t)
+	t.Cleanup(tSuite.UseBackendDB())
+
+	ctx := context.Background()
+	exec := tSuite.BackendAppDb()
+	logic := createTestChatLogic(tSuite)
+	users := persistChatMatch(tSuite, enums.MatchUserActionProposed, enums.MatchUserActionProposed, 10)
+
+	msg, err := send(tSuite, logic, users.match, users.initiator, "helo")
+	require.NoError(t, err)
+
+	_, err = logic.EditMessage(ctx, exec, &chat.EditMessageParams{
+		MessageID: msg.ID,
+		SenderID:  users.receiver,
+		Message:   "hello",
+	})
+	require.ErrorIs(t, err, chat.ErrNotSender, "only the sender can edit")
+
+	edited, err := logic.EditMessage(ctx, exec, &chat.EditMessageParams{
+		MessageID: msg.ID,
+		SenderID:  users.initiator,
+		Message:   "hello",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", edited.Message)
+	assert.True(t, edited.EditedAt.Valid)
+
+	page, err := logic.History(ctx, exec, &chat.HistoryParams{MatchResultID: users.match, UserID: users.receiver})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "hello", page.Data[0].Message)
+
+	require.ErrorIs(t, logic.DeleteMessage(ctx, exec, msg.ID, users.receiver), chat.ErrNotSender)
+	require.NoError(t, logic.DeleteMessage(ctx, exec, msg.ID, users.initiator))
+
+	page, err = logic.History(ctx, exec, &chat.HistoryParams{MatchResultID: users.match, UserID: users.receiver})
+	require.NoError(t, err)
+	assert.Empty(t, page.Data, "deleted messages leave the history")
+
+	require.ErrorIs(t, logic.DeleteMessage(ctx, exec, msg.ID, users.initiator), chat.ErrMessageNotFound)
+}
+
+func TestLogic_UnreadCountsAndMarkSeen(t *testing.T) {
+	t.Parallel()
+	tSuite := testsuite.New(t)
+	t.Cleanup(tSuite.UseBackendDB())
+
+	ctx := context.Background()
+	exec := tSuite.BackendAppDb()
+	logic := createTestChatLogic(tSuite)
+	users := persistChatMatch(tSuite, enums.MatchUserActionProposed, enums.MatchUserActionProposed, 10)
+
+	for range 3 {
+		_, err := send(tSuite, logic, users.match, users.initiator, "ping")
+		require.NoError(t, err)
+	}
+	deleted, err := send(tSuite, logic, users.match, users.initiator, "oops")
+	require.NoError(t, err)
+	require.NoError(t, logic.DeleteMessage(ctx, exec, deleted.ID, users.initiator))
+	_, err = send(tSuite, logic, users.match, users.receiver, "pong")
+	require.NoError(t, err)
+
+	unread, err := logic.UnreadCounts(ctx, exec, users.receiver)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{users.match: 3}, unread, "own and deleted messages aren't unread")
+
+	unread, err = logic.UnreadCounts(ctx, exec, users.initiator)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{users.match: 1}, unread)
+
+	marked, err := logic.MarkSeen(ctx, exec, users.match, users.receiver)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, marked)
+
+	unread, err = logic.UnreadCounts(ctx, exec, users.receiver)
+	require.NoError(t, err)
+	assert.Empty(t, unread)
+
+	unread, err = logic.UnreadCounts(ctx, exec, users.initiator)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{users.match: 1}, unread, "marking seen is per recipient")
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	c := chat.Cursor{CreatedAt: time.UnixMicro(time.Now().UnixMicro()), ID: uuid.New()}
+
+	got, err := chat.ParseCursor(c.String())
+	require.NoError(t, err)
+	assert.True(t, c.CreatedAt.Equal(got.CreatedAt))
+	assert.Equal(t, c.ID, got.ID)
+
+	for _, bad := range []string{"", "%%%", "bm8tc2VwYXJhdG9y"} {
+		_, err := chat.ParseCursor(bad)
+		assert.ErrorIs(t, err, chat.ErrInvalidCursor, "cursor %q", bad)
+	}
+}
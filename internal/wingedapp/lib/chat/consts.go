@@ -0,0 +1,17 @@
+package chat
+
+const (
+	// MaxMessageLength is the most characters a message can have.
+	MaxMessageLength = 2000
+
+	// DefaultHistoryLimit is the page size of History when no limit is given.
+	DefaultHistoryLimit = 50
+	// MaxHistoryLimit caps the page size of History.
+	MaxHistoryLimit = 200
+)
+
+// Notification types of stale chat nudges.
+const (
+	NotificationTypeStaleChatNudge      = "stale_chat_nudge"
+	NotificationTypeStaleChatAgentSetup = "stale_chat_agent_setup"
+)
@@ -0,0 +1,17 @@
+package chat
+
+import "errors"
+
+/* Chat sentinel errors */
+
+var (
+	ErrMissingParams   = errors.New("missing params")
+	ErrEmptyMessage    = errors.New("message is required")
+	ErrMessageTooLong  = errors.New("message is too long")
+	ErrInvalidCursor   = errors.New("invalid cursor")
+	ErrMatchNotFound   = errors.New("match not found")
+	ErrNotInMatch      = errors.New("user is not part of the match")
+	ErrChatLocked      = errors.New("chat is not unlocked for the match")
+	ErrMessageNotFound = errors.New("message not found")
+	ErrNotSender       = errors.New("only the sender can change a message")
+)
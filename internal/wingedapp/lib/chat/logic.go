@@ -0,0 +1,38 @@
+package chat
+
+import (
+	"errors"
+	"time"
+)
+
+// timeNow is a variable for testing purposes
+var timeNow = time.Now
+
+// Logic is the chat between the two users of a mutually proposed match.
+type Logic struct {
+	chatMessageStorer chatMessageStorer
+	chatMatchStorer   chatMatchStorer
+	actionLogger      actionLogger
+}
+
+func NewLogic(
+	chatMessageStorer chatMessageStorer,
+	chatMatchStorer chatMatchStorer,
+	actionLogger actionLogger,
+) (*Logic, error) {
+	if chatMessageStorer == nil {
+		return nil, errors.New("chatMessageStorer is required")
+	}
+	if chatMatchStorer == nil {
+		return nil, errors.New("chatMatchStorer is required")
+	}
+	if actionLogger == nil {
+		return nil, errors.New("actionLogger is required")
+	}
+
+	return &Logic{
+		chatMessageStorer: chatMessageStorer,
+		chatMatchStorer:   chatMatchStorer,
+		actionLogger:      actionLogger,
+	}, nil
+}
@@ -0,0 +1,282 @@
+package chat
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
+
+	"github.com/aarondl/null/v8"
+	"github.com/google/uuid"
+)
+
+// ChatMessage is a message one user of a match sent the other.
+type ChatMessage struct {
+	ID            uuid.UUID `json:"id" boil:"id"`
+	MatchResultID uuid.UUID `json:"match_result_id" boil:"match_result_id"`
+	SenderID      uuid.UUID `json:"sender_id" boil:"sender_id"`
+	Message       string    `json:"message" boil:"message"`
+	SeenAt        null.Time `json:"seen_at" boil:"seen_at"`
+	EditedAt      null.Time `json:"edited_at" boil:"edited_at"`
+	DeletedAt     null.Time `json:"deleted_at" boil:"deleted_at"`
+	CreatedAt     time.Time `json:"created_at" boil:"created_at"`
+}
+
+// Cursor is the position of a message in a chat's history,
+// ordered by created_at, then id.
+func (m *ChatMessage) Cursor() Cursor {
+	return Cursor{CreatedAt: m.CreatedAt, ID: m.ID}
+}
+
+// ChatMessagePage is a page of a chat's history, oldest message first.
+// NextCursor points at the page of older messages, and is unset on the
+// oldest page.
+type ChatMessagePage struct {
+	Data       []ChatMessage `json:"data"`
+	NextCursor null.String   `json:"next_cursor"`
+}
+
+// ChatMatch is the match a chat belongs to.
+type ChatMatch struct {
+	ID              uuid.UUID   `boil:"id"`
+	InitiatorUserID uuid.UUID   `boil:"initiator_user_ref_id"`
+	ReceiverUserID  uuid.UUID   `boil:"receiver_user_ref_id"`
+	InitiatorAction string      `boil:"initiator_action"`
+	ReceiverAction  string      `boil:"receiver_action"`
+	LifecycleStatus null.String `boil:"match_lifecycle_status"`
+	ChatUnlockedAt  null.Time   `boil:"chat_unlocked_at"`
+}
+
+// HasUser reports whether the user is one of the match's two users.
+func (m *ChatMatch) HasUser(userID uuid.UUID) bool {
+	return m.InitiatorUserID == userID || m.ReceiverUserID == userID
+}
+
+// Unlocked reports whether the users can chat: both proposed, and the match
+// isn't closed. Matches proposed before chat_unlocked_at was stamped only
+// have the two actions to go by.
+func (m *ChatMatch) Unlocked() bool {
+	if m.LifecycleStatus.String == string(enums.MatchLifecycleStatusClosed) {
+		return false
+	}
+	if m.ChatUnlockedAt.Valid {
+		return true
+	}
+	return m.InitiatorAction == string(enums.MatchUserActionProposed) &&
+		m.ReceiverAction == string(enums.MatchUserActionProposed)
+}
+
+// UnreadCount is the number of messages a user hasn't seen in a match.
+type UnreadCount struct {
+	MatchResultID uuid.UUID `boil:"match_result_id"`
+	Count         int       `boil:"count"`
+}
+
+// Cursor is an opaque position in a chat's history, used to page backwards.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// String encodes the cursor for the client.
+func (c Cursor) String() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixMicro(), 10) + "_" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes a cursor from Cursor.String.
+func ParseCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %s", ErrInvalidCursor, s)
+	}
+
+	micros, id, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return Cursor{}, fmt.Errorf("%w: %s", ErrInvalidCursor, s)
+	}
+
+	usec, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %s", ErrInvalidCursor, s)
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %s", ErrInvalidCursor, s)
+	}
+
+	return Cursor{CreatedAt: time.UnixMicro(usec), ID: uid}, nil
+}
+
+// SendMessageParams sends a message in a match's chat.
+type SendMessageParams struct {
+	MatchResultID uuid.UUID
+	SenderID      uuid.UUID
+	Message       string
+}
+
+func (p *SendMessageParams) Validate() error {
+	if p == nil || p.MatchResultID == uuid.Nil || p.SenderID == uuid.Nil {
+		return ErrMissingParams
+	}
+	return validateMessage(p.Message)
+}
+
+// HistoryParams pages through a match's chat, newest page first.
+type HistoryParams struct {
+	MatchResultID uuid.UUID
+	UserID        uuid.UUID
+	Cursor        null.String // from ChatMessagePage.NextCursor; unset for the newest page
+	Limit         int         // defaults to DefaultHistoryLimit, capped at MaxHistoryLimit
+}
+
+func (p *HistoryParams) Validate() error {
+	if p == nil || p.MatchResultID == uuid.Nil || p.UserID == uuid.Nil {
+		return ErrMissingParams
+	}
+	return nil
+}
+
+// EditMessageParams replaces the text of a message.
+type EditMessageParams struct {
+	MessageID uuid.UUID
+	SenderID  uuid.UUID
+	Message   string
+}
+
+func (p *EditMessageParams) Validate() error {
+	if p == nil || p.MessageID == uuid.Nil || p.SenderID == uuid.Nil {
+		return ErrMissingParams
+	}
+	return validateMessage(p.Message)
+}
+
+func validateMessage(message string) error {
+	if strings.TrimSpace(message) == "" {
+		return ErrEmptyMessage
+	}
+	if utf8.RuneCountInString(message) > MaxMessageLength {
+		return fmt.Errorf("%w: over %d characters", ErrMessageTooLong, MaxMessageLength)
+	}
+	return nil
+}
+
+// InsertChatMessage inserts a chat message.
+type InsertChatMessage struct {
+	MatchResultID uuid.UUID
+	SenderID      uuid.UUID
+	Message       string
+	CreatedAt     time.Time
+}
+
+// UpdateChatMessage updates the set fields of a chat message.
+type UpdateChatMessage struct {
+	ID        uuid.UUID
+	Message   null.String
+	EditedAt  null.Time
+	DeletedAt null.Time
+	UpdatedAt time.Time
+}
+
+// QueryFilterChatMessage filters chat message reads. Rows come newest first.
+type QueryFilterChatMessage struct {
+	ID             null.String
+	MatchResultID  null.String
+	IncludeDeleted bool    // soft-deleted messages are excluded unless set
+	Before         *Cursor // only messages older than the cursor
+	Limit          int
+}
+
+// MarkChatMessagesSeen marks the messages a user received in a match as seen.
+type MarkChatMessagesSeen struct {
+	MatchResultID uuid.UUID
+	RecipientID   uuid.UUID
+	SeenAt        time.Time
+}
+
+// NudgeType is the kind of nudge sent for a quiet chat.
+type NudgeType string
+
+const (
+	// NudgeTypeNudge reminds the quiet user to reply.
+	NudgeTypeNudge NudgeType = "Nudge"
+	// NudgeTypeAgentSetup offers to let the quiet user's agent schedule the date.
+	NudgeTypeAgentSetup NudgeType = "AgentSetup"
+)
+
+// StaleChat is an unlocked match chat that's been quiet since QuietSince:
+// the last message, or the chat unlock if nobody wrote yet.
+type StaleChat struct {
+	MatchResultID      uuid.UUID     `boil:"match_result_id"`
+	InitiatorUserID    uuid.UUID     `boil:"initiator_user_ref_id"`
+	ReceiverUserID     uuid.UUID     `boil:"receiver_user_ref_id"`
+	InitiatorFirstName null.String   `boil:"initiator_first_name"`
+	ReceiverFirstName  null.String   `boil:"receiver_first_name"`
+	LastSenderID       uuid.NullUUID `boil:"last_sender_id"`
+	QuietSince         time.Time     `boil:"quiet_since"`
+}
+
+// QuietUsers returns the users who owe the chat a message: the recipient of
+// the last message, or both users if nobody wrote yet.
+func (s *StaleChat) QuietUsers() []uuid.UUID {
+	switch {
+	case !s.LastSenderID.Valid:
+		return []uuid.UUID{s.InitiatorUserID, s.ReceiverUserID}
+	case s.LastSenderID.UUID == s.InitiatorUserID:
+		return []uuid.UUID{s.ReceiverUserID}
+	default:
+		return []uuid.UUID{s.InitiatorUserID}
+	}
+}
+
+// PartnerFirstName returns the first name of the user's match.
+func (s *StaleChat) PartnerFirstName(userID uuid.UUID) string {
+	name := s.InitiatorFirstName
+	if userID == s.InitiatorUserID {
+		name = s.ReceiverFirstName
+	}
+	if !name.Valid || name.String == "" {
+		return "your match"
+	}
+	return name.String
+}
+
+// NudgeStaleChatsParams sets how long a chat stays quiet before each nudge.
+type NudgeStaleChatsParams struct {
+	NudgeAfter      time.Duration
+	AgentSetupAfter time.Duration
+}
+
+func (p *NudgeStaleChatsParams) Validate() error {
+	if p == nil || p.NudgeAfter <= 0 || p.AgentSetupAfter <= 0 {
+		return ErrMissingParams
+	}
+	return nil
+}
+
+// NudgeStaleChatsSummary counts the nudges sent by a run.
+type NudgeStaleChatsSummary struct {
+	StaleChats        int
+	Nudged            int
+	AgentSetupOffered int
+}
+
+// InsertChatNudge records a nudge of a user for a chat's quiet window.
+type InsertChatNudge struct {
+	MatchResultID uuid.UUID
+	UserID        uuid.UUID
+	Type          NudgeType
+	QuietSince    time.Time
+}
+
+// InsertNotification inserts a notification for a user.
+type InsertNotification struct {
+	UserID  uuid.UUID
+	Type    string
+	Title   string
+	Message string
+	Payload null.JSON
+}
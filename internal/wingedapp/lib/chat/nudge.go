@@ -0,0 +1,145 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/lib/agentlog"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+// NudgeLogic nudges users whose match chat went quiet.
+type NudgeLogic struct {
+	chatNudgeStorer  chatNudgeStorer
+	agentLogInserter agentLogInserter
+}
+
+func NewNudgeLogic(
+	chatNudgeStorer chatNudgeStorer,
+	agentLogInserter agentLogInserter,
+) (*NudgeLogic, error) {
+	if chatNudgeStorer == nil {
+		return nil, errors.New("chatNudgeStorer is required")
+	}
+	if agentLogInserter == nil {
+		return nil, errors.New("agentLogInserter is required")
+	}
+
+	return &NudgeLogic{
+		chatNudgeStorer:  chatNudgeStorer,
+		agentLogInserter: agentLogInserter,
+	}, nil
+}
+
+// NudgeStaleChats nudges the quiet users of chats that have gone quiet:
+// after NudgeAfter they're reminded to reply, and after AgentSetupAfter
+// they're offered to let their agent schedule the date instead. Each user
+// gets each nudge once per quiet window; a new message starts a new one.
+// Run it in a transaction, so a nudge is only recorded with its
+// notification and agent log.
+func (n *NudgeLogic) NudgeStaleChats(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	params *NudgeStaleChatsParams,
+) (*NudgeStaleChatsSummary, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("validate params: %w", err)
+	}
+
+	now := timeNow()
+	stale, err := n.chatNudgeStorer.StaleChats(ctx, exec, now.Add(-params.NudgeAfter))
+	if err != nil {
+		return nil, fmt.Errorf("chat nudge storer stale chats: %w", err)
+	}
+
+	summary := &NudgeStaleChatsSummary{StaleChats: len(stale)}
+	for _, sc := range stale {
+		// only the latest step: a chat quiet past both gets the agent offer
+		nudgeType := NudgeTypeNudge
+		if now.Sub(sc.QuietSince) >= params.AgentSetupAfter {
+			nudgeType = NudgeTypeAgentSetup
+		}
+
+		for _, userID := range sc.QuietUsers() {
+			sent, err := n.nudge(ctx, exec, &sc, userID, nudgeType)
+			if err != nil {
+				return nil, fmt.Errorf("nudge user %s of match %s: %w", userID, sc.MatchResultID, err)
+			}
+			if !sent {
+				continue
+			}
+
+			if nudgeType == NudgeTypeAgentSetup {
+				summary.AgentSetupOffered++
+			} else {
+				summary.Nudged++
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// nudge records the nudge, and if it's new, notifies the user and writes
+// their agent log. Returns whether the nudge was new.
+func (n *NudgeLogic) nudge(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	sc *StaleChat,
+	userID uuid.UUID,
+	nudgeType NudgeType,
+) (bool, error) {
+	inserted, err := n.chatNudgeStorer.InsertNudge(ctx, exec, &InsertChatNudge{
+		MatchResultID: sc.MatchResultID,
+		UserID:        userID,
+		Type:          nudgeType,
+		QuietSince:    sc.QuietSince,
+	})
+	if err != nil {
+		return false, fmt.Errorf("chat nudge storer insert nudge: %w", err)
+	}
+	if !inserted {
+		return false, nil // already nudged for this window
+	}
+
+	partner := sc.PartnerFirstName(userID)
+	notification := &InsertNotification{
+		UserID:  userID,
+		Type:    NotificationTypeStaleChatNudge,
+		Title:   "Keep the conversation going",
+		Message: fmt.Sprintf("It's been quiet with %s. Send a message to keep things going.", partner),
+	}
+	log := fmt.Sprintf("I noticed your chat with %s went quiet, so I sent you a reminder.", partner)
+	if nudgeType == NudgeTypeAgentSetup {
+		notification.Type = NotificationTypeStaleChatAgentSetup
+		notification.Title = "Want a hand?"
+		notification.Message = fmt.Sprintf("Your agent can take over and plan the date with %s.", partner)
+		log = fmt.Sprintf("Your chat with %s has been quiet for a while. I can schedule the date for you.", partner)
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"match_result_id": sc.MatchResultID.String(),
+		"nudge_type":      string(nudgeType),
+	})
+	if err != nil {
+		return false, fmt.Errorf("marshal notification payload: %w", err)
+	}
+	notification.Payload = null.JSONFrom(payload)
+
+	if err = n.chatNudgeStorer.InsertNotification(ctx, exec, notification); err != nil {
+		return false, fmt.Errorf("chat nudge storer insert notification: %w", err)
+	}
+
+	if _, err = n.agentLogInserter.Insert(ctx, exec, &agentlog.InsertAgentLog{
+		UserRefID: userID.String(),
+		Log:       log,
+	}); err != nil {
+		return false, fmt.Errorf("agent log inserter insert: %w", err)
+	}
+
+	return true, nil
+}
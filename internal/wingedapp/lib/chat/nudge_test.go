@@ -0,0 +1,222 @@
+package chat_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/db/factory"
+	wingedFactory "wingedapp/pgtester/internal/wingedapp/db/factory"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/agentlog"
+	agentlogStore "wingedapp/pgtester/internal/wingedapp/lib/agentlog/store"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/chat"
+	"wingedapp/pgtester/internal/wingedapp/lib/chat/store"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/null/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var nudgeParams = &chat.NudgeStaleChatsParams{
+	NudgeAfter:      24 * time.Hour,
+	AgentSetupAfter: 84 * time.Hour,
+}
+
+func createTestNudgeLogic(th *testsuite.Helper) *chat.NudgeLogic {
+	th.T.Helper()
+	l := applog.NewLogrus("test")
+
+	agentLogLogic, err := agentlog.NewLogic(agentlogStore.NewAgentLogStores(l).AgentLogStore)
+	require.NoError(th.T, err)
+
+	logic, err := chat.NewNudgeLogic(store.NewChatStores(l).ChatNudgeStore, agentLogLogic)
+	require.NoError(th.T, err)
+	return logic
+}
+
+// persistQuietChat creates a match between two new users, unlocked at
+// unlockedAt with the given actions.
+func persistQuietChat(th *testsuite.Helper, unlockedAt time.Time, initiator, receiver enums.MatchUserAction) chatUsers {
+	th.T.Helper()
+	mr := factory.NewEntity[*wingedFactory.MatchResult](&wingedFactory.MatchResult{
+		Subject: &pgmodel.MatchResult{
+			IsApproved:      true,
+			IsDropped:       true,
+			InitiatorAction: string(initiator),
+			ReceiverAction:  string(receiver),
+			ChatUnlockedAt:  null.TimeFrom(unlockedAt),
+		},
+	}).New(th.T, th.BackendAppDb())
+
+	return chatUsers{
+		match:     uuid.MustParse(mr.Subject.ID),
+		initiator: uuid.MustParse(mr.Subject.InitiatorUserRefID),
+		receiver:  uuid.MustParse(mr.Subject.ReceiverUserRefID),
+	}
+}
+
+// persistMessageAt writes a chat message sent at sentAt, bypassing the economy.
+func persistMessageAt(th *testsuite.Helper, users chatUsers, sender uuid.UUID, sentAt time.Time) {
+	th.T.Helper()
+	_, err := store.NewChatStores(applog.NewLogrus("test")).ChatMessageStore.Insert(
+		context.Background(), th.BackendAppDb(), &chat.InsertChatMessage{
+			MatchResultID: users.match,
+			SenderID:      sender,
+			Message:       "hi",
+			CreatedAt:     sentAt,
+		})
+	require.NoError(th.T, err)
+}
+
+// nudgesOf returns the notification types and number of agent logs of a user.
+func nudgesOf(th *testsuite.Helper, userID uuid.UUID) ([]string, int64) {
+	th.T.Helper()
+	ctx := context.Background()
+
+	notifications, err := pgmodel.Notifications(
+		pgmodel.NotificationWhere.UserRefID.EQ(userID.String()),
+	).All(ctx, th.BackendAppDb())
+	require.NoError(th.T, err)
+
+	types := make([]string, 0, len(notifications))
+	for _, n := range notifications {
+		types = append(types, n.NotificationType.String)
+	}
+
+	logs, err := pgmodel.AgentLogs(pgmodel.AgentLogWhere.UserRefID.EQ(userID.String())).Count(ctx, th.BackendAppDb())
+	require.NoError(th.T, err)
+
+	return types, logs
+}
+
+type testCaseNudgeStaleChats struct {
+	name            string
+	setup           func(th *testsuite.Helper) chatUsers
+	extraAssertions func(th *testsuite.Helper, users chatUsers, summary *chat.NudgeStaleChatsSummary, err error)
+}
+
+func TestNudgeLogic_NudgeStaleChats(t *testing.T) {
+	now := time.Now()
+
+	testCases := []testCaseNudgeStaleChats{
+		{
+			name: "success-silent-chat-nudges-both-users",
+			setup: func(th *testsuite.Helper) chatUsers {
+				return persistQuietChat(th, now.Add(-30*time.Hour), enums.MatchUserActionProposed, enums.MatchUserActionProposed)
+			},
+			extraAssertions: func(th *testsuite.Helper, users chatUsers, summary *chat.NudgeStaleChatsSummary, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 2, summary.Nudged)
+
+				for _, u := range []uuid.UUID{users.initiator, users.receiver} {
+					types, logs := nudgesOf(th, u)
+					assert.Equal(th.T, []string{chat.NotificationTypeStaleChatNudge}, types)
+					assert.EqualValues(th.T, 1, logs)
+				}
+			},
+		},
+		{
+			name: "success-only-the-quiet-user-is-nudged",
+			setup: func(th *testsuite.Helper) chatUsers {
+				users := persistQuietChat(th, now.Add(-40*time.Hour), enums.MatchUserActionProposed, enums.MatchUserActionProposed)
+				persistMessageAt(th, users, users.initiator, now.Add(-30*time.Hour))
+				return users
+			},
+			extraAssertions: func(th *testsuite.Helper, users chatUsers, summary *chat.NudgeStaleChatsSummary, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 1, summary.Nudged)
+
+				types, _ := nudgesOf(th, users.receiver)
+				assert.Equal(th.T, []string{chat.NotificationTypeStaleChatNudge}, types, "the receiver owes a reply")
+				types, _ = nudgesOf(th, users.initiator)
+				assert.Empty(th.T, types, "the last sender isn't nudged")
+			},
+		},
+		{
+			name: "success-long-quiet-chat-offers-agent-setup",
+			setup: func(th *testsuite.Helper) chatUsers {
+				users := persistQuietChat(th, now.Add(-100*time.Hour), enums.MatchUserActionProposed, enums.MatchUserActionProposed)
+				persistMessageAt(th, users, users.receiver, now.Add(-90*time.Hour))
+				return users
+			},
+			extraAssertions: func(th *testsuite.Helper, users chatUsers, summary *chat.NudgeStaleChatsSummary, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 0, summary.Nudged)
+				assert.Equal(th.T, 1, summary.AgentSetupOffered)
+
+				types, logs := nudgesOf(th, users.initiator)
+				assert.Equal(th.T, []string{chat.NotificationTypeStaleChatAgentSetup}, types)
+				assert.EqualValues(th.T, 1, logs)
+			},
+		},
+		{
+			name: "success-recent-message-is-not-stale",
+			setup: func(th *testsuite.Helper) chatUsers {
+				users := persistQuietChat(th, now.Add(-40*time.Hour), enums.MatchUserActionProposed, enums.MatchUserActionProposed)
+				persistMessageAt(th, users, users.initiator, now.Add(-time.Hour))
+				return users
+			},
+			extraAssertions: func(th *testsuite.Helper, users chatUsers, summary *chat.NudgeStaleChatsSummary, err error) {
+				require.NoError(th.T, err)
+				assert.Zero(th.T, summary.StaleChats)
+			},
+		},
+		{
+			name: "success-locked-chat-is-skipped",
+			setup: func(th *testsuite.Helper) chatUsers {
+				return persistQuietChat(th, now.Add(-40*time.Hour), enums.MatchUserActionProposed, enums.MatchUserActionPending)
+			},
+			extraAssertions: func(th *testsuite.Helper, users chatUsers, summary *chat.NudgeStaleChatsSummary, err error) {
+				require.NoError(th.T, err)
+				assert.Zero(th.T, summary.StaleChats)
+			},
+		},
+		{
+			name: "success-rerun-is-idempotent-per-window",
+			setup: func(th *testsuite.Helper) chatUsers {
+				users := persistQuietChat(th, now.Add(-40*time.Hour), enums.MatchUserActionProposed, enums.MatchUserActionProposed)
+				persistMessageAt(th, users, users.initiator, now.Add(-30*time.Hour))
+
+				_, err := createTestNudgeLogic(th).NudgeStaleChats(context.Background(), th.BackendAppDb(), nudgeParams)
+				require.NoError(th.T, err, "first run")
+				return users
+			},
+			extraAssertions: func(th *testsuite.Helper, users chatUsers, summary *chat.NudgeStaleChatsSummary, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 1, summary.StaleChats)
+				assert.Zero(th.T, summary.Nudged, "already nudged for this window")
+
+				types, logs := nudgesOf(th, users.receiver)
+				assert.Len(th.T, types, 1)
+				assert.EqualValues(th.T, 1, logs)
+
+				// a reply, then quiet again, is a new window
+				persistMessageAt(th, users, users.receiver, now.Add(-25*time.Hour))
+				summary, err = createTestNudgeLogic(th).NudgeStaleChats(context.Background(), th.BackendAppDb(), nudgeParams)
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 1, summary.Nudged)
+
+				types, _ = nudgesOf(th, users.initiator)
+				assert.Len(th.T, types, 1, "the other user now owes a reply")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tSuite := testsuite.New(t)
+			t.Cleanup(tSuite.UseBackendDB())
+
+			users := tc.setup(tSuite)
+
+			summary, err := createTestNudgeLogic(tSuite).NudgeStaleChats(context.Background(), tSuite.BackendAppDb(), nudgeParams)
+
+			tc.extraAssertions(tSuite, users, summary, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/chat"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/google/uuid"
+)
+
+// ChatMatchStore reads the matches chats belong to.
+type ChatMatchStore struct {
+	l applog.Logger
+}
+
+// ChatMatch returns the match result, or nil if there's none with the id.
+func (s *ChatMatchStore) ChatMatch(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	matchResultID uuid.UUID,
+) (*chat.ChatMatch, error) {
+	cols := pgmodel.MatchResultColumns
+
+	var m chat.ChatMatch
+	err := pgmodel.MatchResults(
+		qm.Select(
+			cols.ID,
+			cols.InitiatorUserRefID,
+			cols.ReceiverUserRefID,
+			cols.InitiatorAction,
+			cols.ReceiverAction,
+			cols.MatchLifecycleStatus,
+			cols.ChatUnlockedAt,
+		),
+		pgmodel.MatchResultWhere.ID.EQ(matchResultID.String()),
+	).Bind(ctx, exec, &m)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query chat match: %w", err)
+	}
+
+	return &m, nil
+}
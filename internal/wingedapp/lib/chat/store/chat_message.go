@@ -0,0 +1,178 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/chat"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/google/uuid"
+)
+
+// ChatMessageStore handles reads and writes of match chat messages.
+type ChatMessageStore struct {
+	l applog.Logger
+}
+
+// ChatMessages returns chat messages matching the filter, newest first.
+func (s *ChatMessageStore) ChatMessages(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	f *chat.QueryFilterChatMessage,
+) ([]chat.ChatMessage, error) {
+	cols := pgmodel.MatchChatMessageColumns
+
+	qMods := []qm.QueryMod{
+		qm.Select(
+			cols.ID,
+			cols.MatchResultID,
+			cols.SenderID,
+			cols.Message,
+			cols.SeenAt,
+			cols.EditedAt,
+			cols.DeletedAt,
+			cols.CreatedAt,
+		),
+		qm.OrderBy(cols.CreatedAt + " DESC, " + cols.ID + " DESC"),
+	}
+	if f.ID.Valid {
+		qMods = append(qMods, pgmodel.MatchChatMessageWhere.ID.EQ(f.ID.String))
+	}
+	if f.MatchResultID.Valid {
+		qMods = append(qMods, pgmodel.MatchChatMessageWhere.MatchResultID.EQ(f.MatchResultID.String))
+	}
+	if !f.IncludeDeleted {
+		qMods = append(qMods, pgmodel.MatchChatMessageWhere.DeletedAt.IsNull())
+	}
+	if f.Before != nil {
+		qMods = append(qMods, qm.Where(
+			fmt.Sprintf("(%s, %s) < (?, ?)", cols.CreatedAt, cols.ID),
+			f.Before.CreatedAt, f.Before.ID.String(),
+		))
+	}
+	if f.Limit > 0 {
+		qMods = append(qMods, qm.Limit(f.Limit))
+	}
+
+	var rows []chat.ChatMessage
+	if err := pgmodel.MatchChatMessages(qMods...).Bind(ctx, exec, &rows); err != nil {
+		return nil, fmt.Errorf("query chat messages: %w", err)
+	}
+
+	return rows, nil
+}
+
+// Insert inserts a chat message.
+func (s *ChatMessageStore) Insert(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *chat.InsertChatMessage,
+) (*chat.ChatMessage, error) {
+	m := &pgmodel.MatchChatMessage{
+		MatchResultID: inserter.MatchResultID.String(),
+		SenderID:      inserter.SenderID.String(),
+		Message:       inserter.Message,
+		CreatedAt:     inserter.CreatedAt,
+	}
+	if err := m.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("insert chat message: %w", err)
+	}
+
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parse chat message id: %w", err)
+	}
+
+	return &chat.ChatMessage{
+		ID:            id,
+		MatchResultID: inserter.MatchResultID,
+		SenderID:      inserter.SenderID,
+		Message:       m.Message,
+		CreatedAt:     m.CreatedAt,
+	}, nil
+}
+
+// Update updates the set fields of a chat message.
+func (s *ChatMessageStore) Update(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	updater *chat.UpdateChatMessage,
+) error {
+	cols := pgmodel.MatchChatMessageColumns
+
+	set := pgmodel.M{cols.UpdatedAt: null.TimeFrom(updater.UpdatedAt)}
+	if updater.Message.Valid {
+		set[cols.Message] = updater.Message.String
+	}
+	if updater.EditedAt.Valid {
+		set[cols.EditedAt] = updater.EditedAt
+	}
+	if updater.DeletedAt.Valid {
+		set[cols.DeletedAt] = updater.DeletedAt
+	}
+
+	if _, err := pgmodel.MatchChatMessages(
+		pgmodel.MatchChatMessageWhere.ID.EQ(updater.ID.String()),
+	).UpdateAll(ctx, exec, set); err != nil {
+		return fmt.Errorf("update chat message: %w", err)
+	}
+
+	return nil
+}
+
+// MarkSeen sets seen_at on the unseen messages the recipient got in the
+// match, and returns how many it marked.
+func (s *ChatMessageStore) MarkSeen(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	m *chat.MarkChatMessagesSeen,
+) (int64, error) {
+	const query = `
+		UPDATE match_chat_message
+		SET seen_at = $1, updated_at = $1
+		WHERE match_result_id = $2
+		  AND sender_id <> $3
+		  AND seen_at IS NULL
+		  AND deleted_at IS NULL`
+
+	res, err := exec.ExecContext(ctx, query, m.SeenAt, m.MatchResultID.String(), m.RecipientID.String())
+	if err != nil {
+		return 0, fmt.Errorf("mark chat messages seen: %w", err)
+	}
+
+	marked, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("rows affected: %w", err)
+	}
+
+	return marked, nil
+}
+
+// UnreadCounts returns the number of unseen messages the user received,
+// per match that has any.
+func (s *ChatMessageStore) UnreadCounts(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	userID uuid.UUID,
+) ([]chat.UnreadCount, error) {
+	const query = `
+		SELECT m.match_result_id, COUNT(*) AS count
+		FROM match_chat_message m
+		JOIN match_result mr ON mr.id = m.match_result_id
+		WHERE (mr.initiator_user_ref_id = $1 OR mr.receiver_user_ref_id = $1)
+		  AND m.sender_id <> $1
+		  AND m.seen_at IS NULL
+		  AND m.deleted_at IS NULL
+		GROUP BY m.match_result_id`
+
+	var counts []chat.UnreadCount
+	if err := pgmodel.NewQuery(qm.SQL(query, userID.String())).Bind(ctx, exec, &counts); err != nil {
+		return nil, fmt.Errorf("query unread counts: %w", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,117 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/db/repo"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/chat"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+// ChatNudgeStore finds quiet chats, and records the nudges sent for them.
+type ChatNudgeStore struct {
+	l    applog.Logger
+	repo *repo.Store
+}
+
+// StaleChats returns the unlocked chats of open matches that have been quiet
+// since before quietBefore. A chat is quiet since its last message, or since
+// it unlocked if nobody wrote yet. Chats of closed matches, and of matches
+// with a date set, are left alone.
+func (s *ChatNudgeStore) StaleChats(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	quietBefore time.Time,
+) ([]chat.StaleChat, error) {
+	const query = `
+		SELECT *
+		FROM (
+			SELECT mr.id AS match_result_id,
+			       mr.initiator_user_ref_id,
+			       mr.receiver_user_ref_id,
+			       ui.first_name AS initiator_first_name,
+			       ur.first_name AS receiver_first_name,
+			       last.sender_id AS last_sender_id,
+			       COALESCE(last.created_at, mr.chat_unlocked_at,
+			                GREATEST(mr.initiator_action_at, mr.receiver_action_at)) AS quiet_since
+			FROM match_result mr
+			JOIN users ui ON ui.id = mr.initiator_user_ref_id
+			JOIN users ur ON ur.id = mr.receiver_user_ref_id
+			LEFT JOIN LATERAL (
+				SELECT m.sender_id, m.created_at
+				FROM match_chat_message m
+				WHERE m.match_result_id = mr.id
+				  AND m.deleted_at IS NULL
+				ORDER BY m.created_at DESC, m.id DESC
+				LIMIT 1
+			) last ON TRUE
+			WHERE mr.initiator_action = 'Proposed'
+			  AND mr.receiver_action = 'Proposed'
+			  AND mr.is_expired = FALSE
+			  AND COALESCE(mr.match_lifecycle_status, '') NOT IN ('Closed', 'Date Set')
+		) chats
+		WHERE quiet_since < $1
+		ORDER BY quiet_since`
+
+	var stale []chat.StaleChat
+	if err := pgmodel.NewQuery(qm.SQL(query, quietBefore)).Bind(ctx, exec, &stale); err != nil {
+		return nil, fmt.Errorf("query stale chats: %w", err)
+	}
+
+	return stale, nil
+}
+
+// InsertNudge records a nudge, and returns false if the user was already
+// nudged for the chat's quiet window.
+func (s *ChatNudgeStore) InsertNudge(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *chat.InsertChatNudge,
+) (bool, error) {
+	const query = `
+		INSERT INTO match_chat_nudge (match_result_id, user_ref_id, nudge_type, quiet_since)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (match_result_id, user_ref_id, nudge_type, quiet_since) DO NOTHING`
+
+	res, err := exec.ExecContext(ctx, query,
+		inserter.MatchResultID.String(),
+		inserter.UserID.String(),
+		string(inserter.Type),
+		inserter.QuietSince,
+	)
+	if err != nil {
+		return false, fmt.Errorf("insert chat nudge: %w", err)
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("rows affected: %w", err)
+	}
+
+	return inserted == 1, nil
+}
+
+// InsertNotification inserts a notification for the nudged user.
+func (s *ChatNudgeStore) InsertNotification(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *chat.InsertNotification,
+) error {
+	if _, err := s.repo.InsertNotification(ctx, exec, &repo.InsertNotification{
+		UserRefID:        inserter.UserID.String(),
+		NotificationType: null.StringFrom(inserter.Type),
+		Title:            null.StringFrom(inserter.Title),
+		Message:          inserter.Message,
+		Payload:          inserter.Payload,
+	}); err != nil {
+		return fmt.Errorf("insert notification: %w", err)
+	}
+
+	return nil
+}
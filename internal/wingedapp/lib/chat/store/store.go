@@ -0,0 +1,21 @@
+package store
+
+import (
+	"wingedapp/pgtester/internal/wingedapp/db/repo"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+)
+
+type ChatStores struct {
+	ChatMessageStore *ChatMessageStore
+	ChatMatchStore   *ChatMatchStore
+	ChatNudgeStore   *ChatNudgeStore
+}
+
+func NewChatStores(l applog.Logger) *ChatStores {
+	r := &repo.Store{}
+	return &ChatStores{
+		ChatMessageStore: &ChatMessageStore{l},
+		ChatMatchStore:   &ChatMatchStore{l},
+		ChatNudgeStore:   &ChatNudgeStore{l, r},
+	}
+}
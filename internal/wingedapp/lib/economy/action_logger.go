@@ -32,6 +32,7 @@ type ActionLogger struct {
 	subscriptionStorer subscriptionPlanStorer
 	transactionStorer  transactionStorer
 	inviteCodeStorer   inviteCodeStorer
+	actionPriceStorer  actionPriceStorer
 }
 
 func NewActionLogger(
@@ -44,6 +45,7 @@ func NewActionLogger(
 	transStorer transactionStorer,
 	inviteCodeStorer inviteCodeStorer,
 	userStorer userStorer,
+	actionPriceStorer actionPriceStorer,
 ) (*ActionLogger, error) {
 	if logger == nil {
 		return nil, errors.New("logger is required")
@@ -72,6 +74,9 @@ func NewActionLogger(
 	if userStorer == nil {
 		return nil, errors.New("userStorer is required")
 	}
+	if actionPriceStorer == nil {
+		return nil, errors.New("actionPriceStorer is required")
+	}
 
 	return &ActionLogger{
 		logger:             logger,
@@ -83,5 +88,6 @@ func NewActionLogger(
 		transactionStorer:  transStorer,
 		inviteCodeStorer:   inviteCodeStorer,
 		userStorer:         userStorer,
+		actionPriceStorer:  actionPriceStorer,
 	}, nil
 }
@@ -0,0 +1,175 @@
+package economy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"wingedapp/pgtester/internal/util/validationlib"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// ActionPrice returns the price of an action in effect now, or
+// ErrActionPriceNotFound when the action isn't priced, e.g. earning actions.
+func (a *ActionLogger) ActionPrice(ctx context.Context,
+	exec boil.ContextExecutor,
+	actionType ActionType,
+) (*ActionPrice, error) {
+	prices, err := a.actionPriceStorer.Prices(ctx, exec, &QueryFilterActionPrice{
+		ActionType:  null.StringFrom(string(actionType)),
+		EffectiveAt: null.TimeFrom(time.Now().UTC()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch action prices: %w", err)
+	}
+	if len(prices) == 0 {
+		return nil, ErrActionPriceNotFound
+	}
+
+	return &prices[0], nil
+}
+
+// ActionPrices returns the price of every priced action in effect now,
+// ordered by action type.
+func (a *ActionLogger) ActionPrices(ctx context.Context, exec boil.ContextExecutor) ([]ActionPrice, error) {
+	prices, err := a.actionPriceStorer.Prices(ctx, exec, &QueryFilterActionPrice{
+		EffectiveAt: null.TimeFrom(time.Now().UTC()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch action prices: %w", err)
+	}
+
+	return prices, nil
+}
+
+// ScheduleActionPrice adds a price for an action to the catalog, which
+// applies from its EffectiveFrom on.
+func (a *ActionLogger) ScheduleActionPrice(ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *InsertActionPrice,
+) (*ActionPrice, error) {
+	if err := validationlib.Validate(inserter); err != nil {
+		return nil, fmt.Errorf("param validation: %w", err)
+	}
+
+	price, err := a.actionPriceStorer.Insert(ctx, exec, inserter)
+	if err != nil {
+		return nil, fmt.Errorf("insert action price: %w", err)
+	}
+
+	return price, nil
+}
+
+// actionCost returns what an action costs the user, at the price for their
+// tier: WingedX while premium is active, Winged+ during a Winged+ period,
+// free otherwise. priced is false for actions without a price.
+func (a *ActionLogger) actionCost(ctx context.Context,
+	exec boil.ContextExecutor,
+	userID string,
+	userTotals *UserTotals,
+	actionType ActionType,
+) (cost int, priced bool, err error) {
+	price, err := a.ActionPrice(ctx, exec, actionType)
+	if errors.Is(err, ErrActionPriceNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("action price: %w", err)
+	}
+
+	if isPremiumActive(userTotals) {
+		return price.CostFor(SubscriptionTypeWingedX), true, nil
+	}
+
+	// only look the subscription up when Winged+ is priced differently
+	if price.WingedPlusCost.Valid {
+		periods, err := a.subscriptionStorer.Periods(ctx, exec, &QueryFilterSubscriptionPeriod{
+			UserID:   null.StringFrom(userID),
+			IsActive: null.IntFrom(1),
+			ActiveAt: null.TimeFrom(time.Now().UTC()),
+			Limit:    1,
+		})
+		if err != nil {
+			return 0, false, fmt.Errorf("fetch subscription periods: %w", err)
+		}
+		if len(periods) > 0 && periods[0].SubscriptionType == SubscriptionTypeWingedPlus {
+			return price.CostFor(SubscriptionTypeWingedPlus), true, nil
+		}
+	}
+
+	return price.CostFor(PricingTierFree), true, nil
+}
+
+// processSpendAction spends wings on an action, at the action's price for
+// the user's tier. Actions free for the tier are recorded without spending.
+// actionInserter.RefID identifies what was paid for, it's paid for once.
+func (a *ActionLogger) processSpendAction(ctx context.Context,
+	exec boil.ContextExecutor,
+	userTotals *UserTotals,
+	actionInserter *InsertActionLog,
+) error {
+	// 1. Check idempotency - skip if already paid for
+	existingLogs, err := a.actionLogStorer.ActionLogs(ctx, exec, &QueryFilterActionLog{
+		UserID:   null.StringFrom(actionInserter.UserID),
+		Category: null.StringFrom(string(actionInserter.Type)),
+		RefID:    null.StringFrom(actionInserter.RefID),
+		IsActive: null.IntFrom(1),
+	})
+	if err != nil {
+		return fmt.Errorf("check idempotency: %w", err)
+	}
+	if len(existingLogs) > 0 {
+		return nil // Already processed
+	}
+
+	// 2. Get or create user totals
+	if userTotals == nil {
+		userTotals, err = a.userTotalsStorer.Create(ctx, exec, actionInserter.UserID)
+		if err != nil {
+			return fmt.Errorf("create user totals: %w", err)
+		}
+	}
+
+	// 3. Price the action, and check the user can afford it
+	cost, _, err := a.actionCost(ctx, exec, actionInserter.UserID, userTotals, actionInserter.Type)
+	if err != nil {
+		return fmt.Errorf("action cost: %w", err)
+	}
+	if userTotals.Wings < cost {
+		return ErrInsufficientWings
+	}
+
+	// 4. Insert action log
+	actionLog, err := a.actionLogStorer.Insert(ctx, exec, string(actionInserter.Type), actionInserter)
+	if err != nil {
+		return fmt.Errorf("insert action log: %w", err)
+	}
+	if cost == 0 {
+		return nil
+	}
+
+	// 5. Deduct wings, failing if a concurrent spend got to them first
+	if _, err = a.userTotalsStorer.AdjustWings(ctx, exec, &AdjustWings{
+		UserID: actionInserter.UserID,
+		Delta:  -cost,
+	}); err != nil {
+		return fmt.Errorf("deduct wings: %w", err)
+	}
+
+	// 6. Insert debit transaction
+	if err = a.transactionStorer.Insert(ctx, exec, &InsertTransaction{
+		UserID:       actionInserter.UserID,
+		ActionTypeID: string(actionInserter.Type),
+		ActionRefID:  actionLog.ID,
+		WingsAmount:  cost,
+		Claimed:      true,
+		IsCredit:     false, // debit
+		ExtraInfo:    actionInserter.JSONDetails,
+	}); err != nil {
+		return fmt.Errorf("insert transaction: %w", err)
+	}
+
+	return nil
+}
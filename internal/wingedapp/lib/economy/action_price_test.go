@@ -0,0 +1,136 @@
+package economy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/db/repo"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/null/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCaseSpendAction struct {
+	name string
+
+	initialWings int
+	premium      bool // give the user active premium access
+	run          func(th *testsuite.Helper, e *economy.ActionLogger, userID string) error
+	assertions   func(th *testsuite.Helper, userID string, err error)
+}
+
+func TestActionLogger_SpendActions(t *testing.T) {
+	spend := func(th *testsuite.Helper, e *economy.ActionLogger, userID string, actionType economy.ActionType, refID string) error {
+		return e.CreateActionLog(context.Background(), th.BackendAppDb(), &economy.InsertActionLog{
+			UserID: userID,
+			RefID:  refID,
+			Type:   actionType,
+		})
+	}
+
+	testCases := []testCaseSpendAction{
+		{
+			name:         "success-propose-match-spends-once",
+			initialWings: 5,
+			run: func(th *testsuite.Helper, e *economy.ActionLogger, userID string) error {
+				matchID := uuid.NewString()
+				require.NoError(th.T, spend(th, e, userID, economy.ActionProposeMatch, matchID))
+				return spend(th, e, userID, economy.ActionProposeMatch, matchID)
+			},
+			assertions: func(th *testsuite.Helper, userID string, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 4, getTestUserTotals(th, userID).TotalWings, "a match is paid for once")
+				require.Len(th.T, getTestTransactionsByUser(th, userID), 1)
+			},
+		},
+		{
+			name:         "error-priority-drop-insufficient-wings",
+			initialWings: 1,
+			run: func(th *testsuite.Helper, e *economy.ActionLogger, userID string) error {
+				canPerform, err := e.CanPerformAction(context.Background(), th.BackendAppDb(), &economy.CanPerformActionParams{
+					UserID:     userID,
+					ActionType: economy.ActionPriorityDrop,
+				})
+				assert.False(th.T, canPerform)
+				require.ErrorIs(th.T, err, economy.ErrInsufficientWings)
+
+				return spend(th, e, userID, economy.ActionPriorityDrop, uuid.NewString())
+			},
+			assertions: func(th *testsuite.Helper, userID string, err error) {
+				require.ErrorIs(th.T, err, economy.ErrInsufficientWings)
+				assert.Equal(th.T, 1, getTestUserTotals(th, userID).TotalWings)
+			},
+		},
+		{
+			name:    "success-premium-spends-nothing",
+			premium: true,
+			run: func(th *testsuite.Helper, e *economy.ActionLogger, userID string) error {
+				return spend(th, e, userID, economy.ActionRescheduleDate, uuid.NewString())
+			},
+			assertions: func(th *testsuite.Helper, userID string, err error) {
+				require.NoError(th.T, err)
+				assert.Empty(th.T, getTestTransactionsByUser(th, userID), "WingedX is priced at 0")
+			},
+		},
+		{
+			name:         "success-catalog-price-change-applies",
+			initialWings: 10,
+			run: func(th *testsuite.Helper, e *economy.ActionLogger, userID string) error {
+				_, err := e.ScheduleActionPrice(context.Background(), th.BackendAppDb(), &economy.InsertActionPrice{
+					ActionType:    economy.ActionVenueChange,
+					WingsCost:     3,
+					EffectiveFrom: time.Now().Add(-time.Minute),
+				})
+				require.NoError(th.T, err)
+
+				_, err = e.ScheduleActionPrice(context.Background(), th.BackendAppDb(), &economy.InsertActionPrice{
+					ActionType:    economy.ActionVenueChange,
+					WingsCost:     7,
+					EffectiveFrom: time.Now().Add(time.Hour),
+				})
+				require.NoError(th.T, err)
+
+				return spend(th, e, userID, economy.ActionVenueChange, uuid.NewString())
+			},
+			assertions: func(th *testsuite.Helper, userID string, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 7, getTestUserTotals(th, userID).TotalWings, "the price in effect now applies, not the scheduled one")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tSuite := testsuite.New(t)
+			tSuite.FakeAPI().App() // init fakes
+			t.Cleanup(tSuite.UseBackendDB())
+
+			user := tSuite.PersistRegisteredUser()
+			e := tSuite.FakeContainer().GetLibEconomy()
+
+			beStore := repo.Store{}
+			userTotals, err := beStore.WingsEcnUserTotal(context.Background(), tSuite.BackendAppDb(), &repo.QueryFilterWingsEcnUserTotal{
+				UserID: null.StringFrom(user.ID),
+			})
+			require.NoError(t, err)
+			updater := &repo.UpdateWingsEcnUserTotals{
+				ID:         userTotals.ID,
+				TotalWings: null.IntFrom(tc.initialWings),
+			}
+			if tc.premium {
+				updater.PremiumExpiresIn = null.TimeFrom(time.Now().Add(24 * time.Hour))
+			}
+			require.NoError(t, beStore.UpdateWingsEcnUserTotals(context.Background(), tSuite.BackendAppDb(), updater))
+
+			err = tc.run(tSuite, e, user.ID)
+
+			tc.assertions(tSuite, user.ID, err)
+		})
+	}
+}
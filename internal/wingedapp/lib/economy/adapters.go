@@ -24,9 +24,20 @@ type inviteCodeStorer interface {
 	InviteCode(ctx context.Context, exec boil.ContextExecutor, filter *QueryFilterInviteCode) (*InviteCode, error)
 }
 
-// subscriptionPlanStorer fetches subscription info.
+// subscriptionPlanStorer fetches subscription info, and stores the
+// subscription periods users bought.
 type subscriptionPlanStorer interface {
 	SubscriptionPlan(ctx context.Context, exec boil.ContextExecutor, subscriptionType, subscriptionName string) (*SubscriptionPlan, error)
+	InsertPeriod(ctx context.Context, exec boil.ContextExecutor, inserter *InsertSubscriptionPeriod) (*SubscriptionPeriod, error)
+	Periods(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterSubscriptionPeriod) ([]SubscriptionPeriod, error)
+	EndPeriod(ctx context.Context, exec boil.ContextExecutor, updater *EndSubscriptionPeriod) error
+	VoidPeriods(ctx context.Context, exec boil.ContextExecutor, actionLogID string) (int, error)
+}
+
+// actionPriceStorer reads and schedules the prices of spending actions.
+type actionPriceStorer interface {
+	Prices(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterActionPrice) ([]ActionPrice, error)
+	Insert(ctx context.Context, exec boil.ContextExecutor, inserter *InsertActionPrice) (*ActionPrice, error)
 }
 
 type transactionStorer interface {
@@ -41,6 +52,10 @@ type userTotalsStorer interface {
 	Totals(ctx context.Context, exec boil.ContextExecutor, uuid string) (*UserTotals, error)
 	Create(ctx context.Context, exec boil.ContextExecutor, uuid string) (*UserTotals, error)
 	Update(ctx context.Context, exec boil.ContextExecutor, updater *UpdateUserTotals) error
+	AdjustWings(ctx context.Context, exec boil.ContextExecutor, adjuster *AdjustWings) (int, error)
+	SyncPremiumExpiry(ctx context.Context, exec boil.ContextExecutor, syncer *SyncPremiumExpiry) (bool, error)
+	IncrementSentMessages(ctx context.Context, exec boil.ContextExecutor, userID string) (int, error)
+	LedgerBalances(ctx context.Context, exec boil.ContextExecutor) ([]WingsLedgerBalance, error)
 }
 
 // actionLogStorer enables user action CRUD.
@@ -66,3 +81,10 @@ type dailyCheckinStorer interface {
 	Insert(ctx context.Context, exec boil.ContextExecutor, inserter *InsertCheckinTransaction) (*pgmodel.WingsEcnTransaction, error)
 	Update(ctx context.Context, exec boil.ContextExecutor, updater *UpdateCheckinTransaction) (int, error)
 }
+
+// revenueCatEventStorer stores received RevenueCat webhooks.
+type revenueCatEventStorer interface {
+	Insert(ctx context.Context, exec boil.ContextExecutor, inserter *InsertRevenueCatEvent) (*RevenueCatEvent, error)
+	Events(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterRevenueCatEvent) ([]RevenueCatEvent, error)
+	Update(ctx context.Context, exec boil.ContextExecutor, updater *UpdateRevenueCatEvent) error
+}
@@ -63,10 +63,9 @@ func (a *ActionLogger) processAttendDate(ctx context.Context,
 	}
 
 	// 5. Update user totals
-	newWings := userTotals.Wings + AttendDateWings
-	if err := a.userTotalsStorer.Update(ctx, exec, &UpdateUserTotals{
-		ID:    userTotals.ID,
-		Wings: null.IntFrom(newWings),
+	if _, err := a.userTotalsStorer.AdjustWings(ctx, exec, &AdjustWings{
+		UserID: actionInserter.UserID,
+		Delta:  AttendDateWings,
 	}); err != nil {
 		return fmt.Errorf("update user wings: %w", err)
 	}
@@ -8,15 +8,19 @@ const (
 
 	/* categories */
 
-	CategoryWingedxWeeklyPayment    = "WingedX - Weekly Payment"
-	CategoryWingedxMonthlyPayment   = "WingedX - Monthly Payment"
-	CategoryWingedPlusWeeklyPayment = "Winged+ - Weekly Payment"
-	CategoryTypeSubscriptionPlan    = "Wings Economy - Subscription Plan"
+	CategoryWingedxWeeklyPayment     = "WingedX - Weekly Payment"
+	CategoryWingedxMonthlyPayment    = "WingedX - Monthly Payment"
+	CategoryWingedxThreeMonthPayment = "WingedX - 3 Month Payment"
+	CategoryWingedxSixMonthPayment   = "WingedX - 6 Month Payment"
+	CategoryWingedPlusWeeklyPayment  = "Winged+ - Weekly Payment"
+	CategoryTypeSubscriptionPlan     = "Wings Economy - Subscription Plan"
 
 	/* TODO: deprecate - old economy no longer used */
 
-	CountWingedXPaymentWeek  = 7
-	CountWingedXPaymentMonth = 1
+	CountWingedXPaymentWeek       = 7
+	CountWingedXPaymentMonth      = 1
+	CountWingedXPaymentThreeMonth = 3
+	CountWingedXPaymentSixMonth   = 6
 
 	SubscriptionTypeWingedPlus    = "Winged+"
 	SubscriptionTypeWingedX       = "WingedX"
@@ -24,6 +28,11 @@ const (
 	SubscriptionPaymentMonthly    = "Monthly"
 	SubscriptionPaymentThreeMonth = "3 Months"
 	SubscriptionPaymentSixMonth   = "6 Months"
+
+	/* why a subscription period ended early */
+
+	SubscriptionEndReasonUpgrade   = "Upgrade"
+	SubscriptionEndReasonDowngrade = "Downgrade"
 )
 
 type incrementableCol string
@@ -39,8 +48,10 @@ const (
 		Maps 1:1 with categories.
 	*/
 
-	ActionWingedXWeeklyPayment  ActionType = "WingedX - Weekly Payment"
-	ActionWingedXMonthlyPayment ActionType = "WingedX - Monthly Payment"
+	ActionWingedXWeeklyPayment     ActionType = "WingedX - Weekly Payment"
+	ActionWingedXMonthlyPayment    ActionType = "WingedX - Monthly Payment"
+	ActionWingedXThreeMonthPayment ActionType = "WingedX - 3 Month Payment"
+	ActionWingedXSixMonthPayment   ActionType = "WingedX - 6 Month Payment"
 
 	ActionWingedPlusWeeklyPayment     ActionType = "Winged+ - Weekly Payment"
 	ActionWingedPlusMonthlyPayment    ActionType = "Winged+ - Monthly Payment"
@@ -71,8 +82,20 @@ const (
 
 	// When user sends messages (every 5 messages costs 1 wing)
 	ActionSendMessage ActionType = "Send Message"
+
+	/* Spends - priced by the action price catalog, RefID identifies what was paid for */
+
+	ActionProposeMatch   ActionType = "Propose Match"    // RefID: the match
+	ActionRescheduleDate ActionType = "Reschedule Date"  // RefID: the reschedule request
+	ActionVenueChange    ActionType = "Venue Change"     // RefID: the venue change request
+	ActionPriorityDrop   ActionType = "Priority Drop"    // RefID: the priority drop
+	ActionExtraAIMessage ActionType = "Extra AI Message" // RefID: the AI message
 )
 
+// PricingTierFree is the pricing tier of users without a subscription. The
+// subscription types are the other tiers.
+const PricingTierFree = "Free"
+
 // Streak milestone rewards - Per spec: check-in does NOT grant wings directly
 // Wings are granted ONLY through streak milestones
 const (
@@ -87,11 +110,9 @@ const ReferralBonusWings = 4
 // AttendDateWings is the amount of wings earned for attending a scheduled date
 const AttendDateWings = 1
 
-// SendMessageThreshold is the number of messages before 1 wing is deducted
+// SendMessageThreshold is the number of messages before 1 wing is deducted,
+// when WINGS_ECON_INCREMENT_THRESH_SEND_MESSAGE isn't set
 const SendMessageThreshold = 5
 
-// SendMessageWingsCost is the amount of wings deducted per threshold
-const SendMessageWingsCost = 1
-
 // EarnedWingsExpiryDays is the number of days before earned wings expire
 const EarnedWingsExpiryDays = 30
@@ -104,7 +104,7 @@ func (d *DailyCheckinLogic) PerformCheckin(
 
 	milestone, wings := d.checkMilestone(newStreak)
 	if milestone > 0 {
-		err = d.awardMilestoneWings(ctx, exec, userID, totals.ID, milestone, wings)
+		err = d.awardMilestoneWings(ctx, exec, userID, milestone, wings)
 		if err != nil {
 			return nil, fmt.Errorf("award milestone wings: %w", err)
 		}
@@ -155,7 +155,6 @@ func (d *DailyCheckinLogic) awardMilestoneWings(
 	ctx context.Context,
 	exec boil.ContextExecutor,
 	userID string,
-	totalsID string,
 	milestone int,
 	wings int,
 ) error {
@@ -196,16 +195,10 @@ func (d *DailyCheckinLogic) awardMilestoneWings(
 	}
 
 	// Update user's total wings
-	totals, err := d.userTotalsStore.Totals(ctx, exec, userID)
-	if err != nil {
-		return fmt.Errorf("get totals for wing update: %w", err)
-	}
-
-	err = d.userTotalsStore.Update(ctx, exec, &UpdateUserTotals{
-		ID:    totalsID,
-		Wings: null.IntFrom(totals.Wings + wings),
-	})
-	if err != nil {
+	if _, err = d.userTotalsStore.AdjustWings(ctx, exec, &AdjustWings{
+		UserID: userID,
+		Delta:  wings,
+	}); err != nil {
 		return fmt.Errorf("update wings balance: %w", err)
 	}
 
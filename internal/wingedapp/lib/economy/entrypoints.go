@@ -67,6 +67,8 @@ func (a *ActionLogger) CreateActionLog(ctx context.Context,
 	actionLoggerHandlers := map[ActionType]actLoggerHandlerFn{
 		ActionWingedXWeeklyPayment:        a.addWingedXWeeklyPayment,
 		ActionWingedXMonthlyPayment:       a.addWingedXMonthlyPayment,
+		ActionWingedXThreeMonthPayment:    a.addWingedXThreeMonthPayment,
+		ActionWingedXSixMonthPayment:      a.addWingedXSixMonthPayment,
 		ActionWingedPlusWeeklyPayment:     a.addWingedPlusWeeklyPayment,
 		ActionWingedPlusMonthlyPayment:    a.addWingedPlusMonthlyPayment,
 		ActionWingedPlusThreeMonthPayment: a.addWingedPlusThreeMonthlyPayment,
@@ -74,6 +76,11 @@ func (a *ActionLogger) CreateActionLog(ctx context.Context,
 		ActionReferralComplete:            a.processReferralBonus,  // Referrer gets 4 wings on invitee's first paid action
 		ActionAttendDate:                  a.processAttendDate,     // When user confirms they attended a date
 		ActionSendMessage:                 a.processSendMessage,    // Deduct 1 wing per 5 messages sent
+		ActionProposeMatch:                a.processSpendAction,    // Spends at the action price catalog's price
+		ActionRescheduleDate:              a.processSpendAction,
+		ActionVenueChange:                 a.processSpendAction,
+		ActionPriorityDrop:                a.processSpendAction,
+		ActionExtraAIMessage:              a.processSpendAction,
 	}
 
 	var handler actLoggerHandlerFn
@@ -89,7 +96,7 @@ func (a *ActionLogger) CreateActionLog(ctx context.Context,
 }
 
 // isPremiumActive checks if the user has an active premium subscription.
-// Used to bypass wings checks and short-circuit spend recording.
+// Used to price spending actions at the WingedX tier.
 func isPremiumActive(userTotals *UserTotals) bool {
 	return userTotals != nil &&
 		userTotals.PremiumExpiresIn.Valid &&
@@ -98,7 +105,8 @@ func isPremiumActive(userTotals *UserTotals) bool {
 
 // CanPerformAction checks if the action can be performed based on user's wings balance.
 // Returns true if action is allowed, false with appropriate error if not.
-// Premium subscribers with active subscription bypass wings requirements.
+// Spending actions cost what the action price catalog prices them at for the
+// user's tier, premium subscribers spend nothing by default.
 func (a *ActionLogger) CanPerformAction(ctx context.Context,
 	exec boil.ContextExecutor,
 	params *CanPerformActionParams,
@@ -108,32 +116,25 @@ func (a *ActionLogger) CanPerformAction(ctx context.Context,
 		return false, fmt.Errorf("get user totals: %w", err)
 	}
 
-	// User has no totals record yet - they have 0 wings
-	if userTotals == nil {
-		return a.canPerformWithBalance(params.ActionType, 0)
+	cost, priced, err := a.actionCost(ctx, exec, params.UserID, userTotals, params.ActionType)
+	if err != nil {
+		return false, fmt.Errorf("action cost: %w", err)
 	}
-
-	// Premium subscribers bypass wings check
-	if isPremiumActive(userTotals) {
+	if !priced {
+		// For earning actions (payments, referrals, etc.), always allow
 		return true, nil
 	}
 
-	return a.canPerformWithBalance(params.ActionType, userTotals.Wings)
-}
-
-// canPerformWithBalance checks if an action can be performed given a wings balance.
-func (a *ActionLogger) canPerformWithBalance(actionType ActionType, wings int) (bool, error) {
-	switch actionType {
-	case ActionSendMessage:
-		// Need at least 1 wing to send messages (will be deducted every 5 msgs)
-		if wings < SendMessageWingsCost {
-			return false, ErrInsufficientWings
-		}
-		return true, nil
-	default:
-		// For earning actions (payments, referrals, etc.), always allow
-		return true, nil
+	// User has no totals record yet - they have 0 wings
+	wings := 0
+	if userTotals != nil {
+		wings = userTotals.Wings
+	}
+	if wings < cost {
+		return false, ErrInsufficientWings
 	}
+
+	return true, nil
 }
 
 // actionTypeToSubscription maps an ActionType to subscription type and plan name.
@@ -151,6 +152,10 @@ func actionTypeToSubscription(actionType ActionType) (subscriptionType, planName
 		return SubscriptionTypeWingedX, SubscriptionPaymentWeekly
 	case ActionWingedXMonthlyPayment:
 		return SubscriptionTypeWingedX, SubscriptionPaymentMonthly
+	case ActionWingedXThreeMonthPayment:
+		return SubscriptionTypeWingedX, SubscriptionPaymentThreeMonth
+	case ActionWingedXSixMonthPayment:
+		return SubscriptionTypeWingedX, SubscriptionPaymentSixMonth
 	default:
 		return "", ""
 	}
@@ -188,6 +193,11 @@ func (a *ActionLogger) DeleteActionLog(ctx context.Context,
 		}
 	}
 
+	// void the subscription period it bought, periods it ended stay ended
+	if _, err = a.subscriptionStorer.VoidPeriods(ctx, exec, actionLog.ID); err != nil {
+		return fmt.Errorf("void subscription periods: %w", err)
+	}
+
 	/* to scale: keep adding more tables to void here */
 
 	return nil
@@ -203,20 +213,16 @@ func (a *ActionLogger) handleDeleteTransaction(ctx context.Context,
 		return fmt.Errorf("void transaction: %w", err)
 	}
 
-	// void (revert) user total wings balance
-	userTotals, err := a.userTotalsStorer.Totals(ctx, exec, transaction.UserID)
-	if err != nil {
-		return fmt.Errorf("fetch user totals: %w", err)
-	}
-	newBalance := userTotals.Wings
+	// void (revert) user total wings balance, floored at 0 as wings the
+	// user already spent can't be taken back
+	delta := transaction.Amount
 	if transaction.IsCredit {
-		newBalance -= transaction.Amount
-	} else {
-		newBalance += transaction.Amount
+		delta = -transaction.Amount
 	}
-	if err = a.userTotalsStorer.Update(ctx, exec, &UpdateUserTotals{
-		ID:    userTotals.ID,
-		Wings: null.IntFrom(newBalance),
+	if _, err := a.userTotalsStorer.AdjustWings(ctx, exec, &AdjustWings{
+		UserID:      transaction.UserID,
+		Delta:       delta,
+		FloorAtZero: true,
 	}); err != nil {
 		return fmt.Errorf("update user totals: %w", err)
 	}
@@ -6,16 +6,21 @@ import (
 )
 
 var (
-	ErrInvalidEntryType      = errors.New("invalid entry type")
-	ErrMissingJSONDetails    = errors.New("missing required JSON details")
-	ErrMissingSubscriptionID = errors.New("missing subscription ID")
-	ErrInsufficientWings     = errors.New("insufficient wings balance")
-	ErrWeeklyCapReached      = errors.New("weekly earning cap reached")
-	ErrBalanceCapReached     = errors.New("balance cap reached")
-	ErrReferralCapReached    = errors.New("referral monthly cap reached")
-	ErrAlreadyCheckedInToday = errors.New("already checked in today")
-	ErrAlreadyProcessed      = errors.New("payment already processed")
-	ErrUnknownProductID      = errors.New("unknown product ID")
+	ErrInvalidEntryType        = errors.New("invalid entry type")
+	ErrMissingJSONDetails      = errors.New("missing required JSON details")
+	ErrMissingSubscriptionID   = errors.New("missing subscription ID")
+	ErrInsufficientWings       = errors.New("insufficient wings balance")
+	ErrWeeklyCapReached        = errors.New("weekly earning cap reached")
+	ErrBalanceCapReached       = errors.New("balance cap reached")
+	ErrReferralCapReached      = errors.New("referral monthly cap reached")
+	ErrAlreadyCheckedInToday   = errors.New("already checked in today")
+	ErrAlreadyProcessed        = errors.New("payment already processed")
+	ErrUnknownProductID        = errors.New("unknown product ID")
+	ErrUserTotalsNotFound      = errors.New("user totals not found")
+	ErrRevenueCatEventNotFound = errors.New("revenuecat event not found")
+
+	ErrSubscriptionPeriodNotFound = errors.New("subscription period not found")
+	ErrActionPriceNotFound        = errors.New("action price not found")
 )
 
 // errInvalidAction formats an error for an invalid action type.
@@ -2,6 +2,7 @@ package economy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -71,25 +72,17 @@ func (e *ExpiryLogic) ExpireWings(
 		return 0, nil // nothing to expire
 	}
 
-	// 2. Decrement each user's wings
+	// 2. Decrement each user's wings, floored at 0
 	for _, eu := range expiredByUser {
-		totals, err := e.userTotalsStore.Totals(ctx, exec, eu.UserID)
-		if err != nil {
-			return 0, fmt.Errorf("fetch totals for user %s: %w", eu.UserID, err)
-		}
-		if totals == nil {
+		_, err := e.userTotalsStore.AdjustWings(ctx, exec, &AdjustWings{
+			UserID:      eu.UserID,
+			Delta:       -eu.Amount,
+			FloorAtZero: true,
+		})
+		if errors.Is(err, ErrUserTotalsNotFound) {
 			continue // user has no totals record, skip
 		}
-
-		newWings := totals.Wings - eu.Amount
-		if newWings < 0 {
-			newWings = 0 // floor at 0
-		}
-
-		if err := e.userTotalsStore.Update(ctx, exec, &UpdateUserTotals{
-			ID:    totals.ID,
-			Wings: null.IntFrom(newWings),
-		}); err != nil {
+		if err != nil {
 			return 0, fmt.Errorf("update totals for user %s: %w", eu.UserID, err)
 		}
 	}
@@ -15,6 +15,12 @@ type InsertActionLog struct {
 	RefID       string     `json:"ref_id"` // Optional for some actions (e.g., ActionReferralComplete looks it up)
 	Type        ActionType `json:"category" validate:"required"`
 	JSONDetails null.JSON  `json:"json_details"`
+	// PremiumExpiresAt sets a WingedX payment's premium expiry, e.g. from the
+	// store's own expiration, instead of extending the current one by the plan.
+	PremiumExpiresAt null.Time `json:"premium_expires_at"`
+	// PremiumEventAt is when the store reported PremiumExpiresAt, now if unset.
+	// An expiry reported before the current one was doesn't replace it.
+	PremiumEventAt null.Time `json:"premium_event_at"`
 }
 
 // QueryFilterActionLog represents filters for querying action logs.
@@ -43,6 +49,11 @@ var actionsRequiringRefID = map[ActionType]bool{
 	ActionWingedPlusSixMonthPayment:   true,
 	ActionAttendDate:                  true,
 	ActionSendMessage:                 true,
+	ActionProposeMatch:                true,
+	ActionRescheduleDate:              true,
+	ActionVenueChange:                 true,
+	ActionPriorityDrop:                true,
+	ActionExtraAIMessage:              true,
 	// ActionReferralComplete: false - RefID is looked up by processReferralBonus
 }
 
@@ -86,16 +97,62 @@ type UserTotals struct {
 	StreakLongestDays int       `boil:"streak_longest_days"`
 }
 
+// UpdateUserTotals updates the set fields of a user's totals. The wings
+// balance isn't one of them, it only moves through AdjustWings.
 type UpdateUserTotals struct {
 	ID                string
 	PremiumExpiresIn  null.Time
-	Wings             null.Int
 	SentMessages      null.Int
 	StreakLastDate    null.Time
 	StreakCurrentDays null.Int
 	StreakLongestDays null.Int
 }
 
+// SyncPremiumExpiry sets a user's premium expiry from a store event. It's
+// applied only when the event at At isn't older than the one the current
+// expiry came from, as the store can deliver events out of order.
+type SyncPremiumExpiry struct {
+	UserID    string
+	ExpiresAt time.Time
+	At        time.Time
+}
+
+// AdjustWings moves a user's wings balance by Delta, atomically.
+type AdjustWings struct {
+	UserID string
+	Delta  int // negative for a debit
+	// FloorAtZero clamps a debit larger than the balance at 0, instead of
+	// failing it with ErrInsufficientWings.
+	FloorAtZero bool
+}
+
+// WingsLedgerBalance is a user's wings balance next to the balance their
+// ledger adds up to: active credits that haven't expired, less active debits,
+// floored at 0 as expiry floors it.
+type WingsLedgerBalance struct {
+	UserID      string `boil:"user_id"`
+	Wings       int    `boil:"wings"`
+	LedgerWings int    `boil:"ledger_wings"`
+}
+
+// Drift is how many wings the balance is over its ledger, negative when under.
+func (b *WingsLedgerBalance) Drift() int {
+	return b.Wings - b.LedgerWings
+}
+
+// ReconcileWingsParams sets whether a reconciliation repairs the drift it finds.
+type ReconcileWingsParams struct {
+	Repair bool
+}
+
+// WingsReconciliation reports the balances a reconciliation found drifted
+// from their ledger.
+type WingsReconciliation struct {
+	Users    int
+	Drifted  []WingsLedgerBalance
+	Repaired int
+}
+
 type SubscriptionPlan struct {
 	ID    string        `boil:"id"`
 	Name  string        `boil:"name"`
@@ -234,4 +291,169 @@ var ProductIDToActionType = map[string]ActionType{
 	// WingedX products
 	"com.app.wingedx_weekly":  ActionWingedXWeeklyPayment,
 	"com.app.wingedx_monthly": ActionWingedXMonthlyPayment,
+	"com.app.wingedx_3month":  ActionWingedXThreeMonthPayment,
+	"com.app.wingedx_6month":  ActionWingedXSixMonthPayment,
+}
+
+// IsPremiumAction reports whether the action is a WingedX payment, whose
+// plan is premium access rather than wings.
+func IsPremiumAction(actionType ActionType) bool {
+	switch actionType {
+	case ActionWingedXWeeklyPayment, ActionWingedXMonthlyPayment,
+		ActionWingedXThreeMonthPayment, ActionWingedXSixMonthPayment:
+		return true
+	}
+	return false
+}
+
+// RevenueCatEvent is a RevenueCat webhook as it was received, and the
+// outcome of processing it.
+type RevenueCatEvent struct {
+	ID            string      `boil:"id" json:"id"`
+	EventID       string      `boil:"event_id" json:"event_id"`
+	Type          string      `boil:"event_type" json:"event_type"`
+	AppUserID     string      `boil:"app_user_id" json:"app_user_id"`
+	ProductID     string      `boil:"product_id" json:"product_id"`
+	TransactionID string      `boil:"transaction_id" json:"transaction_id"`
+	Environment   string      `boil:"environment" json:"environment"`
+	Payload       types.JSON  `boil:"payload" json:"payload"`
+	Status        string      `boil:"status" json:"status"`
+	Action        null.String `boil:"action" json:"action"`
+	Reason        null.String `boil:"reason" json:"reason"`
+	Error         null.String `boil:"error" json:"error"`
+	EventAt       null.Time   `boil:"event_at" json:"event_at"`
+	ProcessedAt   null.Time   `boil:"processed_at" json:"processed_at"`
+	CreatedAt     time.Time   `boil:"created_at" json:"created_at"`
+}
+
+// InsertRevenueCatEvent stores a received RevenueCat webhook.
+type InsertRevenueCatEvent struct {
+	EventID       string `validate:"required"`
+	Type          string `validate:"required"`
+	AppUserID     string
+	ProductID     string
+	TransactionID string
+	Environment   string
+	Payload       []byte `validate:"required"`
+	EventAt       null.Time
+}
+
+// UpdateRevenueCatEvent records the outcome of processing a stored event.
+type UpdateRevenueCatEvent struct {
+	ID     string `validate:"required"`
+	Status string `validate:"required"`
+	Action null.String
+	Reason null.String
+	Error  null.String
+}
+
+// QueryFilterRevenueCatEvent filters stored RevenueCat events.
+type QueryFilterRevenueCatEvent struct {
+	ID            null.String
+	EventID       null.String
+	Status        null.String
+	Environment   null.String
+	TransactionID null.String
+	Types         []string
+	Limit         int  // 0 for no limit
+	ForUpdate     bool // lock the events until the transaction ends
+}
+
+// SubscriptionPeriod is a period of a subscription plan a payment bought.
+type SubscriptionPeriod struct {
+	ID               string        `boil:"id" json:"id"`
+	UserID           string        `boil:"user_id" json:"user_id"`
+	PlanID           string        `boil:"subscription_plan_id" json:"subscription_plan_id"`
+	SubscriptionType string        `boil:"subscription_type" json:"subscription_type"`
+	PlanName         string        `boil:"name" json:"name"`
+	Price            types.Decimal `boil:"price" json:"price"`
+	ActionLogID      null.String   `boil:"action_log_ref_id" json:"action_log_ref_id"`
+	WingsGranted     int           `boil:"wings_granted" json:"wings_granted"`
+	StartDate        time.Time     `boil:"start_date" json:"start_date"`
+	EndDate          time.Time     `boil:"end_date" json:"end_date"`
+	EndReason        null.String   `boil:"end_reason" json:"end_reason"`
+	IsActive         int           `boil:"is_active" json:"is_active"`
+}
+
+// InsertSubscriptionPeriod records the period a payment bought.
+type InsertSubscriptionPeriod struct {
+	UserID       string `validate:"required"`
+	PlanID       string `validate:"required"`
+	ActionLogID  null.String
+	WingsGranted int
+	StartDate    time.Time `validate:"required"`
+	EndDate      time.Time `validate:"required"`
+}
+
+// EndSubscriptionPeriod ends a period before it was due to, on a plan change.
+type EndSubscriptionPeriod struct {
+	ID        string    `validate:"required"`
+	EndDate   time.Time `validate:"required"`
+	EndReason string    `validate:"required"`
+}
+
+// QueryFilterSubscriptionPeriod filters subscription periods.
+type QueryFilterSubscriptionPeriod struct {
+	UserID      null.String
+	ActionLogID null.String
+	IsActive    null.Int
+	ActiveAt    null.Time // periods that started at or before, and end after it
+	EndsAfter   null.Time // periods that end after it, including ones yet to start
+	Limit       int       // 0 for no limit
+}
+
+// Entitlement is what a user is entitled to right now.
+type Entitlement struct {
+	UserID           string
+	Period           *SubscriptionPeriod // the current subscription period, nil when none
+	IsPremium        bool
+	PremiumExpiresAt null.Time
+	Wings            int
+}
+
+// ActionPrice is what an action costs in wings, from EffectiveFrom on. The
+// tier overrides, when set, replace WingsCost for that tier.
+type ActionPrice struct {
+	ID             string    `boil:"id" json:"id"`
+	ActionType     string    `boil:"action_type" json:"action_type"`
+	WingsCost      int       `boil:"wings_cost" json:"wings_cost"`
+	FreeCost       null.Int  `boil:"free_cost" json:"free_cost"`
+	WingedPlusCost null.Int  `boil:"winged_plus_cost" json:"winged_plus_cost"`
+	WingedXCost    null.Int  `boil:"wingedx_cost" json:"wingedx_cost"`
+	EffectiveFrom  time.Time `boil:"effective_from" json:"effective_from"`
+}
+
+// CostFor returns what the action costs for a pricing tier: PricingTierFree,
+// or a subscription type.
+func (p *ActionPrice) CostFor(tier string) int {
+	override := null.Int{}
+	switch tier {
+	case PricingTierFree:
+		override = p.FreeCost
+	case SubscriptionTypeWingedPlus:
+		override = p.WingedPlusCost
+	case SubscriptionTypeWingedX:
+		override = p.WingedXCost
+	}
+
+	if override.Valid {
+		return override.Int
+	}
+	return p.WingsCost
+}
+
+// InsertActionPrice schedules a price for an action.
+type InsertActionPrice struct {
+	ActionType     ActionType `validate:"required"`
+	WingsCost      int        `validate:"gte=0"`
+	FreeCost       null.Int
+	WingedPlusCost null.Int
+	WingedXCost    null.Int
+	EffectiveFrom  time.Time `validate:"required"`
+}
+
+// QueryFilterActionPrice filters action prices.
+type QueryFilterActionPrice struct {
+	ActionType  null.String
+	EffectiveAt null.Time // only the price in effect at it, per action
 }
@@ -0,0 +1,70 @@
+package economy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// WingsReconciler checks wings balances against the transaction ledger.
+type WingsReconciler struct {
+	userTotalsStore userTotalsStorer
+}
+
+// NewWingsReconciler creates a new WingsReconciler.
+func NewWingsReconciler(userTotalsStore userTotalsStorer) (*WingsReconciler, error) {
+	if userTotalsStore == nil {
+		return nil, fmt.Errorf("userTotalsStore is required")
+	}
+	return &WingsReconciler{
+		userTotalsStore: userTotalsStore,
+	}, nil
+}
+
+// ReconcileWings recomputes each user's balance from their active
+// wings_ecn_transaction rows, and reports the balances that drifted from it.
+// With params.Repair, each drifted balance is moved back onto its ledger by
+// the drift, so a balance change committed meanwhile isn't lost.
+//
+// Expiry and voided credits floor a balance at 0 when the wings were already
+// spent, which the ledger can't tell apart: review the report before repairing.
+//
+// IMPORTANT: Caller must wrap in transaction when repairing.
+func (r *WingsReconciler) ReconcileWings(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	params *ReconcileWingsParams,
+) (*WingsReconciliation, error) {
+	balances, err := r.userTotalsStore.LedgerBalances(ctx, exec)
+	if err != nil {
+		return nil, fmt.Errorf("ledger balances: %w", err)
+	}
+
+	report := &WingsReconciliation{
+		Users:   len(balances),
+		Drifted: make([]WingsLedgerBalance, 0),
+	}
+	for _, b := range balances {
+		if b.Drift() != 0 {
+			report.Drifted = append(report.Drifted, b)
+		}
+	}
+
+	if params == nil || !params.Repair {
+		return report, nil
+	}
+
+	for _, b := range report.Drifted {
+		if _, err = r.userTotalsStore.AdjustWings(ctx, exec, &AdjustWings{
+			UserID:      b.UserID,
+			Delta:       -b.Drift(),
+			FloorAtZero: true,
+		}); err != nil {
+			return nil, fmt.Errorf("repair wings for user %s: %w", b.UserID, err)
+		}
+		report.Repaired++
+	}
+
+	return report, nil
+}
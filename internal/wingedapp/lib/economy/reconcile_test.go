@@ -0,0 +1,115 @@
+package economy_test
+
+import (
+	"context"
+	"testing"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy/store"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCaseReconcileWings struct {
+	name string
+
+	balance int // total_wings set by hand after earning AttendDateWings
+	params  *economy.ReconcileWingsParams
+
+	assertions func(th *testsuite.Helper, userID string, report *economy.WingsReconciliation, err error)
+}
+
+func TestWingsReconciler_ReconcileWings(t *testing.T) {
+	testCases := []testCaseReconcileWings{
+		{
+			name:    "success-no-drift",
+			balance: economy.AttendDateWings,
+			params:  &economy.ReconcileWingsParams{Repair: true},
+			assertions: func(th *testsuite.Helper, userID string, report *economy.WingsReconciliation, err error) {
+				require.NoError(th.T, err)
+				assert.Empty(th.T, driftOf(report, userID))
+				assert.Equal(th.T, economy.AttendDateWings, getTestUserTotals(th, userID).TotalWings)
+			},
+		},
+		{
+			name:    "success-reports-drift-without-repair",
+			balance: economy.AttendDateWings + 7,
+			assertions: func(th *testsuite.Helper, userID string, report *economy.WingsReconciliation, err error) {
+				require.NoError(th.T, err)
+				drifted := driftOf(report, userID)
+				require.Len(th.T, drifted, 1)
+				assert.Equal(th.T, economy.AttendDateWings, drifted[0].LedgerWings)
+				assert.Equal(th.T, 7, drifted[0].Drift())
+				assert.Zero(th.T, report.Repaired)
+				assert.Equal(th.T, economy.AttendDateWings+7, getTestUserTotals(th, userID).TotalWings, "a report changes nothing")
+			},
+		},
+		{
+			name:    "success-repairs-drift",
+			balance: 0,
+			params:  &economy.ReconcileWingsParams{Repair: true},
+			assertions: func(th *testsuite.Helper, userID string, report *economy.WingsReconciliation, err error) {
+				require.NoError(th.T, err)
+				drifted := driftOf(report, userID)
+				require.Len(th.T, drifted, 1)
+				assert.Equal(th.T, -economy.AttendDateWings, drifted[0].Drift())
+				assert.Equal(th.T, len(report.Drifted), report.Repaired)
+				assert.Equal(th.T, economy.AttendDateWings, getTestUserTotals(th, userID).TotalWings, "the balance is back on its ledger")
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tSuite := testsuite.New(t)
+			tSuite.FakeAPI().App() // init fakes
+			ctn := tSuite.FakeContainer()
+
+			cleanup := tSuite.UseBackendDB()
+			defer cleanup()
+
+			ctx := context.Background()
+			exec := tSuite.BackendAppDb()
+			user := tSuite.PersistRegisteredUser()
+
+			// earn wings through the ledger, then move the balance by hand
+			err := ctn.GetLibEconomy().CreateActionLog(ctx, exec, &economy.InsertActionLog{
+				UserID: user.ID,
+				RefID:  uuid.New().String(),
+				Type:   economy.ActionAttendDate,
+			})
+			require.NoError(t, err, "attending a date")
+
+			_, err = pgmodel.WingsEcnUserTotals(
+				pgmodel.WingsEcnUserTotalWhere.UserRefID.EQ(user.ID),
+			).UpdateAll(ctx, exec, pgmodel.M{
+				pgmodel.WingsEcnUserTotalColumns.TotalWings: tt.balance,
+			})
+			require.NoError(t, err, "setting the balance")
+
+			reconciler, err := economy.NewWingsReconciler(store.NewEconomyStores(applog.NewLogrus("test")).UserTotalsStore)
+			require.NoError(t, err)
+
+			report, err := reconciler.ReconcileWings(ctx, exec, tt.params)
+
+			tt.assertions(tSuite, user.ID, report, err)
+		})
+	}
+}
+
+// driftOf returns the user's drifted balances in the report.
+func driftOf(report *economy.WingsReconciliation, userID string) []economy.WingsLedgerBalance {
+	drifted := make([]economy.WingsLedgerBalance, 0)
+	for _, b := range report.Drifted {
+		if b.UserID == userID {
+			drifted = append(drifted, b)
+		}
+	}
+	return drifted
+}
@@ -162,10 +162,9 @@ func (a *ActionLogger) creditReferrer(ctx context.Context,
 	}
 
 	// Update totals
-	newWings := referrerTotals.Wings + ReferralBonusWings
-	if err := a.userTotalsStorer.Update(ctx, exec, &UpdateUserTotals{
-		ID:    referrerTotals.ID,
-		Wings: null.IntFrom(newWings),
+	if _, err := a.userTotalsStorer.AdjustWings(ctx, exec, &AdjustWings{
+		UserID: referrerID,
+		Delta:  ReferralBonusWings,
 	}); err != nil {
 		return fmt.Errorf("update referrer wings: %w", err)
 	}
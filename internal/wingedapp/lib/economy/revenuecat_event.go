@@ -0,0 +1,93 @@
+package economy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"wingedapp/pgtester/internal/util/validationlib"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// RevenueCatEventLog keeps every RevenueCat webhook as it was received,
+// so an event can be audited, and replayed when processing it failed.
+type RevenueCatEventLog struct {
+	eventStorer revenueCatEventStorer
+}
+
+// NewRevenueCatEventLog creates a new RevenueCatEventLog.
+func NewRevenueCatEventLog(eventStorer revenueCatEventStorer) (*RevenueCatEventLog, error) {
+	if eventStorer == nil {
+		return nil, errors.New("eventStorer is required")
+	}
+	return &RevenueCatEventLog{
+		eventStorer: eventStorer,
+	}, nil
+}
+
+// RecordEvent stores a received event. A redelivered event isn't stored
+// twice: its stored row is returned, with the outcome of processing it.
+func (l *RevenueCatEventLog) RecordEvent(ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *InsertRevenueCatEvent,
+) (*RevenueCatEvent, error) {
+	if err := validationlib.Validate(inserter); err != nil {
+		return nil, fmt.Errorf("param validation: %w", err)
+	}
+
+	event, err := l.eventStorer.Insert(ctx, exec, inserter)
+	if err != nil {
+		return nil, fmt.Errorf("insert event: %w", err)
+	}
+
+	return event, nil
+}
+
+// Events returns the stored events, in the order they happened.
+func (l *RevenueCatEventLog) Events(ctx context.Context,
+	exec boil.ContextExecutor,
+	f *QueryFilterRevenueCatEvent,
+) ([]RevenueCatEvent, error) {
+	events, err := l.eventStorer.Events(ctx, exec, f)
+	if err != nil {
+		return nil, fmt.Errorf("events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Event returns the stored event matching the filter.
+func (l *RevenueCatEventLog) Event(ctx context.Context,
+	exec boil.ContextExecutor,
+	f *QueryFilterRevenueCatEvent,
+) (*RevenueCatEvent, error) {
+	events, err := l.eventStorer.Events(ctx, exec, f)
+	if err != nil {
+		return nil, fmt.Errorf("events: %w", err)
+	}
+
+	if len(events) == 0 {
+		return nil, ErrRevenueCatEventNotFound
+	}
+	if len(events) > 1 {
+		return nil, fmt.Errorf("revenuecat event count mismatch, have %d, want 1", len(events))
+	}
+
+	return &events[0], nil
+}
+
+// MarkEvent records the outcome of processing a stored event.
+func (l *RevenueCatEventLog) MarkEvent(ctx context.Context,
+	exec boil.ContextExecutor,
+	updater *UpdateRevenueCatEvent,
+) error {
+	if err := validationlib.Validate(updater); err != nil {
+		return fmt.Errorf("param validation: %w", err)
+	}
+
+	if err := l.eventStorer.Update(ctx, exec, updater); err != nil {
+		return fmt.Errorf("update event: %w", err)
+	}
+
+	return nil
+}
@@ -9,16 +9,22 @@ import (
 )
 
 // processSendMessage handles deducting wings when a user sends messages.
-// Every SendMessageThreshold (5) messages costs SendMessageWingsCost (1) wing.
-// Premium subscribers short-circuit entirely - no records created.
+// Every WINGS_ECON_INCREMENT_THRESH_SEND_MESSAGE (5) messages costs the
+// Send Message catalog price (1 wing).
+// Users it's free for, e.g. premium subscribers, short-circuit entirely -
+// no records created.
 // actionInserter.RefID should be the message_id.
 func (a *ActionLogger) processSendMessage(ctx context.Context,
 	exec boil.ContextExecutor,
 	userTotals *UserTotals,
 	actionInserter *InsertActionLog,
 ) error {
-	// 1. Premium subscribers skip spend recording entirely
-	if isPremiumActive(userTotals) {
+	// 1. Price the message - users it's free for skip spend recording entirely
+	cost, _, err := a.actionCost(ctx, exec, actionInserter.UserID, userTotals, ActionSendMessage)
+	if err != nil {
+		return fmt.Errorf("action cost: %w", err)
+	}
+	if cost == 0 {
 		return nil
 	}
 
@@ -45,7 +51,7 @@ func (a *ActionLogger) processSendMessage(ctx context.Context,
 	}
 
 	// 4. Check if user has enough wings
-	if userTotals.Wings < SendMessageWingsCost {
+	if userTotals.Wings < cost {
 		return ErrInsufficientWings
 	}
 
@@ -59,39 +65,53 @@ func (a *ActionLogger) processSendMessage(ctx context.Context,
 		return fmt.Errorf("insert action log: %w", err)
 	}
 
-	// 6. Increment sent messages counter
-	newSentMessages := userTotals.SentMessages + 1
+	// 6. Increment sent messages counter, atomically so concurrent
+	// messages each count, and exactly every 5th one deducts
+	newSentMessages, err := a.userTotalsStorer.IncrementSentMessages(ctx, exec, actionInserter.UserID)
+	if err != nil {
+		return fmt.Errorf("increment sent messages: %w", err)
+	}
 
 	// 7. Check if we hit the threshold - deduct wing
-	shouldDeductWing := newSentMessages%SendMessageThreshold == 0
-	newWings := userTotals.Wings
-	if shouldDeductWing {
-		newWings -= SendMessageWingsCost
-
-		// 8. Insert debit transaction only when wing is deducted
-		if err := a.transactionStorer.Insert(ctx, exec, &InsertTransaction{
-			UserID:       actionInserter.UserID,
-			ActionTypeID: string(ActionSendMessage),
-			ActionRefID:  actionLog.ID,
-			WingsAmount:  SendMessageWingsCost,
-			Claimed:      true,
-			IsCredit:     false, // debit
-		}); err != nil {
-			return fmt.Errorf("insert transaction: %w", err)
-		}
+	threshold, err := a.sendMessageThreshold(ctx)
+	if err != nil {
+		return fmt.Errorf("send message threshold: %w", err)
 	}
-
-	// 9. Update user totals (always update sent_messages, conditionally update wings)
-	updateTotals := &UpdateUserTotals{
-		ID:           userTotals.ID,
-		SentMessages: null.IntFrom(newSentMessages),
+	if newSentMessages%threshold != 0 {
+		return nil
 	}
-	if shouldDeductWing {
-		updateTotals.Wings = null.IntFrom(newWings)
+	if _, err = a.userTotalsStorer.AdjustWings(ctx, exec, &AdjustWings{
+		UserID: actionInserter.UserID,
+		Delta:  -cost,
+	}); err != nil {
+		return fmt.Errorf("deduct wings: %w", err)
 	}
-	if err := a.userTotalsStorer.Update(ctx, exec, updateTotals); err != nil {
-		return fmt.Errorf("update user totals: %w", err)
+
+	// 8. Insert debit transaction only when wing is deducted
+	if err = a.transactionStorer.Insert(ctx, exec, &InsertTransaction{
+		UserID:       actionInserter.UserID,
+		ActionTypeID: string(ActionSendMessage),
+		ActionRefID:  actionLog.ID,
+		WingsAmount:  cost,
+		Claimed:      true,
+		IsCredit:     false, // debit
+	}); err != nil {
+		return fmt.Errorf("insert transaction: %w", err)
 	}
 
 	return nil
 }
+
+// sendMessageThreshold returns how many messages cost the Send Message price,
+// WINGS_ECON_INCREMENT_THRESH_SEND_MESSAGE, or SendMessageThreshold when unset.
+func (a *ActionLogger) sendMessageThreshold(ctx context.Context) (int, error) {
+	settings, err := a.settingGetter.Settings(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get settings: %w", err)
+	}
+	if settings.WingsEconIncrementThreshSendMessage > 0 {
+		return settings.WingsEconIncrementThreshSendMessage, nil
+	}
+
+	return SendMessageThreshold, nil
+}
@@ -0,0 +1,127 @@
+package economy_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSendMessage_ConcurrentSpends_RaceCondition tests the fix for concurrent spends.
+// Messages sent at once each count towards the threshold, every 5th one deducts
+// exactly one wing, and the balance never goes below zero.
+func TestSendMessage_ConcurrentSpends_RaceCondition(t *testing.T) {
+	const messages = 10
+
+	testCases := []struct {
+		name         string
+		initialWings int
+		assertions   func(th *testsuite.Helper, userID string, sent int, errs []error)
+	}{
+		{
+			name:         "success-every-5th-message-deducts",
+			initialWings: 2,
+			assertions: func(th *testsuite.Helper, userID string, sent int, errs []error) {
+				require.Empty(th.T, errs, "every message should be sent")
+
+				totals := getTestUserTotals(th, userID)
+				assert.Equal(th.T, messages, totals.CounterSentMessages, "no message count is lost")
+				assert.Equal(th.T, 0, totals.TotalWings, "2 wings deducted for 10 messages")
+				assert.Len(th.T, getTestTransactionsByUser(th, userID), 2, "one debit per 5 messages")
+			},
+		},
+		{
+			name:         "success-balance-never-negative",
+			initialWings: 1,
+			assertions: func(th *testsuite.Helper, userID string, sent int, errs []error) {
+				require.NotEmpty(th.T, errs, "messages past the last wing should be refused")
+				for _, err := range errs {
+					require.ErrorIs(th.T, err, economy.ErrInsufficientWings)
+				}
+
+				totals := getTestUserTotals(th, userID)
+				assert.Equal(th.T, sent, totals.CounterSentMessages, "only sent messages are counted")
+				assert.Equal(th.T, 0, totals.TotalWings, "the last wing is deducted once")
+				assert.Len(th.T, getTestTransactionsByUser(th, userID), 1, "a single debit")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tSuite := testsuite.New(t)
+			tSuite.FakeAPI().App() // init fakes
+			t.Cleanup(tSuite.UseBackendDB())
+
+			ctx := context.Background()
+			user := tSuite.PersistRegisteredUser()
+
+			_, err := pgmodel.WingsEcnUserTotals(
+				pgmodel.WingsEcnUserTotalWhere.UserRefID.EQ(user.ID),
+			).UpdateAll(ctx, tSuite.BackendAppDb(), pgmodel.M{
+				pgmodel.WingsEcnUserTotalColumns.TotalWings: tc.initialWings,
+			})
+			require.NoError(t, err, "setting initial wings")
+
+			e := tSuite.FakeContainer().GetLibEconomy()
+			transactor := tSuite.FakeContainer().GetStoreBackendAppTransactor()
+
+			// Execute: all messages are sent at once, each in its own transaction
+			var (
+				wg   sync.WaitGroup
+				mu   sync.Mutex
+				sent int
+				errs []error
+			)
+			for range messages {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					err := func() error {
+						tx, err := transactor.TX()
+						if err != nil {
+							return err
+						}
+						defer transactor.Rollback(tx)
+
+						if err = e.CreateActionLog(ctx, tx, &economy.InsertActionLog{
+							UserID: user.ID,
+							RefID:  uuid.New().String(),
+							Type:   economy.ActionSendMessage,
+						}); err != nil {
+							return err
+						}
+						return tx.Commit()
+					}()
+
+					mu.Lock()
+					defer mu.Unlock()
+					if err != nil {
+						errs = append(errs, err)
+						return
+					}
+					sent++
+				}()
+			}
+			wg.Wait()
+
+			for _, err := range errs {
+				if !errors.Is(err, economy.ErrInsufficientWings) {
+					require.NoError(t, err, "unexpected send error")
+				}
+			}
+
+			tc.assertions(tSuite, user.ID, sent, errs)
+		})
+	}
+}
@@ -14,6 +14,9 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// sendMessageWingsCost is the Send Message price the action price catalog migration seeds.
+const sendMessageWingsCost = 1
+
 type testCaseSendMessage struct {
 	name string
 
@@ -119,7 +122,7 @@ func sendMessageTestCases() []testCaseSendMessage {
 				})
 				require.NoError(th.T, err, "fetch user totals")
 				require.Equal(th.T, 5, userTotals.CounterSentMessages, "sent_messages should be 5")
-				require.Equal(th.T, tc.initialWings-economy.SendMessageWingsCost, userTotals.TotalWings, "1 wing should be deducted")
+				require.Equal(th.T, tc.initialWings-sendMessageWingsCost, userTotals.TotalWings, "1 wing should be deducted")
 
 				// Verify debit transaction created
 				transactions, err := beStore.WingsEcnTransactions(context.Background(), th.BackendAppDb(), &repo.QueryFilterWingsEcnTransaction{
@@ -127,7 +130,7 @@ func sendMessageTestCases() []testCaseSendMessage {
 				})
 				require.NoError(th.T, err, "fetch transactions")
 				require.Len(th.T, transactions, 1, "should have 1 debit transaction")
-				require.Equal(th.T, economy.SendMessageWingsCost, transactions[0].Amount)
+				require.Equal(th.T, sendMessageWingsCost, transactions[0].Amount)
 				require.False(th.T, transactions[0].IsCredit, "should be debit (not credit)")
 			},
 		},
@@ -247,7 +250,7 @@ func sendMessageTestCases() []testCaseSendMessage {
 				})
 				require.NoError(th.T, err, "fetch user totals")
 				require.Equal(th.T, 10, userTotals.CounterSentMessages, "sent_messages should be 10")
-				require.Equal(th.T, tc.initialWings-economy.SendMessageWingsCost, userTotals.TotalWings, "wing deducted at 10th message")
+				require.Equal(th.T, tc.initialWings-sendMessageWingsCost, userTotals.TotalWings, "wing deducted at 10th message")
 			},
 		},
 	}
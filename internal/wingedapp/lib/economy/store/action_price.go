@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+const actionPriceColumns = `id, action_type, wings_cost, free_cost, winged_plus_cost, wingedx_cost, effective_from`
+
+type ActionPriceStore struct {
+	logger applog.Logger
+}
+
+func NewActionPriceStore(l applog.Logger) *ActionPriceStore {
+	return &ActionPriceStore{logger: l}
+}
+
+// Insert schedules a price for an action, from its EffectiveFrom on.
+func (s *ActionPriceStore) Insert(ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *economy.InsertActionPrice,
+) (*economy.ActionPrice, error) {
+	var price economy.ActionPrice
+
+	if err := pgmodel.NewQuery(qm.SQL(`
+		INSERT INTO wings_ecn_action_price
+			(action_type, wings_cost, free_cost, winged_plus_cost, wingedx_cost, effective_from)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+actionPriceColumns,
+		string(inserter.ActionType),
+		inserter.WingsCost,
+		inserter.FreeCost,
+		inserter.WingedPlusCost,
+		inserter.WingedXCost,
+		inserter.EffectiveFrom,
+	)).Bind(ctx, exec, &price); err != nil {
+		return nil, fmt.Errorf("insert action price: %w", err)
+	}
+
+	return &price, nil
+}
+
+// actionPriceFilters builds query modifiers based on the provided filter.
+func actionPriceFilters(f *economy.QueryFilterActionPrice) []qm.QueryMod {
+	qMods := []qm.QueryMod{
+		qm.Where("COALESCE(is_active, 1) = 1"),
+	}
+
+	if f == nil {
+		return qMods
+	}
+
+	if f.ActionType.Valid {
+		qMods = append(qMods, qm.Where("action_type = ?", f.ActionType.String))
+	}
+	if f.EffectiveAt.Valid {
+		qMods = append(qMods, qm.Where("effective_from <= ?", f.EffectiveAt.Time))
+	}
+
+	return qMods
+}
+
+// Prices returns action prices, per action the latest effective first. With
+// EffectiveAt set, only the price in effect at it is returned per action.
+func (s *ActionPriceStore) Prices(ctx context.Context,
+	exec boil.ContextExecutor,
+	f *economy.QueryFilterActionPrice,
+) ([]economy.ActionPrice, error) {
+	var prices []economy.ActionPrice
+
+	selectCols := actionPriceColumns
+	if f != nil && f.EffectiveAt.Valid {
+		selectCols = "DISTINCT ON (action_type) " + selectCols
+	}
+
+	qMods := append(
+		actionPriceFilters(f),
+		qm.Select(selectCols),
+		qm.From("wings_ecn_action_price"),
+		qm.OrderBy("action_type, effective_from DESC"),
+	)
+
+	if err := pgmodel.NewQuery(qMods...).Bind(ctx, exec, &prices); err != nil {
+		return nil, fmt.Errorf("action prices: %w", err)
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,149 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+const revenueCatEventColumns = `id, event_id, event_type, app_user_id, product_id, transaction_id,
+	environment, payload, status, action, reason, error, event_at, processed_at, created_at`
+
+type RevenueCatEventStore struct {
+	logger applog.Logger
+}
+
+func NewRevenueCatEventStore(l applog.Logger) *RevenueCatEventStore {
+	return &RevenueCatEventStore{logger: l}
+}
+
+// Insert stores a received event. A redelivered event keeps its row, and
+// its processing state, which is returned as is.
+func (s *RevenueCatEventStore) Insert(ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *economy.InsertRevenueCatEvent,
+) (*economy.RevenueCatEvent, error) {
+	var event economy.RevenueCatEvent
+
+	if err := pgmodel.NewQuery(qm.SQL(`
+		INSERT INTO revenuecat_event
+			(event_id, event_type, app_user_id, product_id, transaction_id, environment, payload, event_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (event_id) DO UPDATE SET event_id = EXCLUDED.event_id
+		RETURNING `+revenueCatEventColumns,
+		inserter.EventID,
+		inserter.Type,
+		inserter.AppUserID,
+		inserter.ProductID,
+		inserter.TransactionID,
+		inserter.Environment,
+		inserter.Payload,
+		inserter.EventAt,
+	)).Bind(ctx, exec, &event); err != nil {
+		return nil, fmt.Errorf("insert revenuecat event: %w", err)
+	}
+
+	return &event, nil
+}
+
+// revenueCatEventFilters builds query modifiers based on the provided filter.
+func revenueCatEventFilters(f *economy.QueryFilterRevenueCatEvent) []qm.QueryMod {
+	qMods := make([]qm.QueryMod, 0)
+
+	if f == nil {
+		return qMods
+	}
+
+	if f.ID.Valid {
+		qMods = append(qMods, qm.Where("id = ?", f.ID.String))
+	}
+	if f.EventID.Valid {
+		qMods = append(qMods, qm.Where("event_id = ?", f.EventID.String))
+	}
+	if f.Status.Valid {
+		qMods = append(qMods, qm.Where("status = ?", f.Status.String))
+	}
+	if f.Environment.Valid {
+		qMods = append(qMods, qm.Where("environment = ?", f.Environment.String))
+	}
+	if f.TransactionID.Valid {
+		qMods = append(qMods, qm.Where("transaction_id = ?", f.TransactionID.String))
+	}
+	if len(f.Types) > 0 {
+		types := make([]any, len(f.Types))
+		for i, t := range f.Types {
+			types[i] = t
+		}
+		qMods = append(qMods, qm.WhereIn("event_type IN ?", types...))
+	}
+	if f.Limit > 0 {
+		qMods = append(qMods, qm.Limit(f.Limit))
+	}
+	if f.ForUpdate {
+		qMods = append(qMods, qm.For("UPDATE"))
+	}
+
+	return qMods
+}
+
+// Events returns the stored events, in the order they happened.
+func (s *RevenueCatEventStore) Events(ctx context.Context,
+	exec boil.ContextExecutor,
+	f *economy.QueryFilterRevenueCatEvent,
+) ([]economy.RevenueCatEvent, error) {
+	var events []economy.RevenueCatEvent
+
+	qMods := append(
+		revenueCatEventFilters(f),
+		qm.Select(revenueCatEventColumns),
+		qm.From("revenuecat_event"),
+		qm.OrderBy("COALESCE(event_at, created_at), created_at"),
+	)
+
+	if err := pgmodel.NewQuery(qMods...).Bind(ctx, exec, &events); err != nil {
+		return nil, fmt.Errorf("revenuecat events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Update records the outcome of processing an event.
+func (s *RevenueCatEventStore) Update(ctx context.Context,
+	exec boil.ContextExecutor,
+	updater *economy.UpdateRevenueCatEvent,
+) error {
+	const query = `
+		UPDATE revenuecat_event
+		SET status       = $2,
+		    action       = $3,
+		    reason       = $4,
+		    error        = $5,
+		    processed_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	res, err := exec.ExecContext(ctx, query,
+		updater.ID,
+		updater.Status,
+		updater.Action,
+		updater.Reason,
+		updater.Error,
+	)
+	if err != nil {
+		return fmt.Errorf("update revenuecat event: %w", err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if updated == 0 {
+		return economy.ErrRevenueCatEventNotFound
+	}
+
+	return nil
+}
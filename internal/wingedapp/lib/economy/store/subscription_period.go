@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+const subscriptionPeriodColumns = `wusp.id, wusp.user_id, wusp.subscription_plan_id,
+	wsp.subscription_type, wsp.name, wsp.price, wusp.action_log_ref_id, wusp.wings_granted,
+	wusp.start_date, wusp.end_date, wusp.end_reason, COALESCE(wusp.is_active, 1) AS is_active`
+
+// InsertPeriod records the subscription period a payment bought.
+func (s *SubscriptionStore) InsertPeriod(ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *economy.InsertSubscriptionPeriod,
+) (*economy.SubscriptionPeriod, error) {
+	var period economy.SubscriptionPeriod
+
+	if err := pgmodel.NewQuery(qm.SQL(`
+		WITH wusp AS (
+			INSERT INTO wings_ecn_user_subscription_plan
+				(user_id, subscription_plan_id, action_log_ref_id, wings_granted, start_date, end_date)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING *
+		)
+		SELECT `+subscriptionPeriodColumns+`
+		FROM wusp
+		INNER JOIN wings_ecn_subscription_plan wsp ON wsp.id = wusp.subscription_plan_id`,
+		inserter.UserID,
+		inserter.PlanID,
+		inserter.ActionLogID,
+		inserter.WingsGranted,
+		inserter.StartDate.UTC(),
+		inserter.EndDate.UTC(),
+	)).Bind(ctx, exec, &period); err != nil {
+		return nil, fmt.Errorf("insert subscription period: %w", err)
+	}
+
+	return &period, nil
+}
+
+// subscriptionPeriodFilters builds query modifiers based on the provided filter.
+func subscriptionPeriodFilters(f *economy.QueryFilterSubscriptionPeriod) []qm.QueryMod {
+	qMods := make([]qm.QueryMod, 0)
+
+	if f == nil {
+		return qMods
+	}
+
+	if f.UserID.Valid {
+		qMods = append(qMods, qm.Where("wusp.user_id = ?", f.UserID.String))
+	}
+	if f.ActionLogID.Valid {
+		qMods = append(qMods, qm.Where("wusp.action_log_ref_id = ?", f.ActionLogID.String))
+	}
+	if f.IsActive.Valid {
+		qMods = append(qMods, qm.Where("COALESCE(wusp.is_active, 1) = ?", f.IsActive.Int))
+	}
+	if f.ActiveAt.Valid {
+		at := f.ActiveAt.Time.UTC()
+		qMods = append(qMods,
+			qm.Where("wusp.start_date <= ?", at),
+			qm.Where("wusp.end_date > ?", at),
+		)
+	}
+	if f.EndsAfter.Valid {
+		qMods = append(qMods, qm.Where("wusp.end_date > ?", f.EndsAfter.Time.UTC()))
+	}
+	if f.Limit > 0 {
+		qMods = append(qMods, qm.Limit(f.Limit))
+	}
+
+	return qMods
+}
+
+// Periods returns subscription periods, the latest started first.
+func (s *SubscriptionStore) Periods(ctx context.Context,
+	exec boil.ContextExecutor,
+	f *economy.QueryFilterSubscriptionPeriod,
+) ([]economy.SubscriptionPeriod, error) {
+	var periods []economy.SubscriptionPeriod
+
+	qMods := append(
+		subscriptionPeriodFilters(f),
+		qm.Select(subscriptionPeriodColumns),
+		qm.From("wings_ecn_user_subscription_plan wusp"),
+		qm.InnerJoin("wings_ecn_subscription_plan wsp ON wsp.id = wusp.subscription_plan_id"),
+		qm.OrderBy("wusp.start_date DESC, wusp.created_date DESC"),
+	)
+
+	if err := pgmodel.NewQuery(qMods...).Bind(ctx, exec, &periods); err != nil {
+		return nil, fmt.Errorf("subscription periods: %w", err)
+	}
+
+	return periods, nil
+}
+
+// EndPeriod ends a subscription period early.
+func (s *SubscriptionStore) EndPeriod(ctx context.Context,
+	exec boil.ContextExecutor,
+	updater *economy.EndSubscriptionPeriod,
+) error {
+	const query = `
+		UPDATE wings_ecn_user_subscription_plan
+		SET end_date     = $2,
+		    end_reason   = $3,
+		    last_updated = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	res, err := exec.ExecContext(ctx, query,
+		updater.ID,
+		updater.EndDate.UTC(),
+		updater.EndReason,
+	)
+	if err != nil {
+		return fmt.Errorf("end subscription period: %w", err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if updated == 0 {
+		return economy.ErrSubscriptionPeriodNotFound
+	}
+
+	return nil
+}
+
+// VoidPeriods voids the subscription periods a payment bought, returning how
+// many were voided.
+func (s *SubscriptionStore) VoidPeriods(ctx context.Context,
+	exec boil.ContextExecutor,
+	actionLogID string,
+) (int, error) {
+	const query = `
+		UPDATE wings_ecn_user_subscription_plan
+		SET is_active    = 0,
+		    last_updated = CURRENT_TIMESTAMP
+		WHERE action_log_ref_id = $1
+		  AND COALESCE(is_active, 1) = 1`
+
+	res, err := exec.ExecContext(ctx, query, actionLogID)
+	if err != nil {
+		return 0, fmt.Errorf("void subscription periods: %w", err)
+	}
+
+	voided, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("rows affected: %w", err)
+	}
+
+	return int(voided), nil
+}
@@ -60,23 +60,146 @@ func (u *UserTotalsStore) Create(ctx context.Context, exec boil.ContextExecutor,
 	}, nil
 }
 
-// Update updates the user totals record for the given user UUID.
+// Update updates the set fields of the user totals record. Only those
+// columns are written, so a concurrent wings adjustment isn't overwritten.
 func (u *UserTotalsStore) Update(ctx context.Context, exec boil.ContextExecutor, updater *economy.UpdateUserTotals) error {
-	if err := u.repo.UpdateWingsEcnUserTotals(ctx, exec, toRepoUpdaterUserTotals(updater)); err != nil {
+	col := pgmodel.WingsEcnUserTotalColumns
+
+	cols := pgmodel.M{}
+	if updater.PremiumExpiresIn.Valid {
+		cols[col.PremiumExpiresIn] = updater.PremiumExpiresIn
+	}
+	if updater.SentMessages.Valid {
+		cols[col.CounterSentMessages] = updater.SentMessages.Int
+	}
+	if updater.StreakLastDate.Valid {
+		cols[col.StreakLastDate] = updater.StreakLastDate
+	}
+	if updater.StreakCurrentDays.Valid {
+		cols[col.StreakCurrentDays] = updater.StreakCurrentDays.Int
+	}
+	if updater.StreakLongestDays.Valid {
+		cols[col.StreakLongestDays] = updater.StreakLongestDays.Int
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+
+	if _, err := pgmodel.WingsEcnUserTotals(
+		pgmodel.WingsEcnUserTotalWhere.ID.EQ(updater.ID),
+	).UpdateAll(ctx, exec, cols); err != nil {
 		return fmt.Errorf("update user totals: %w", err)
 	}
 
 	return nil
 }
 
-func toRepoUpdaterUserTotals(u *economy.UpdateUserTotals) *repo.UpdateWingsEcnUserTotals {
-	return &repo.UpdateWingsEcnUserTotals{
-		ID:                u.ID,
-		PremiumExpiresIn:  u.PremiumExpiresIn,
-		TotalWings:        u.Wings,
-		SentMessages:      u.SentMessages,
-		StreakLastDate:    u.StreakLastDate,
-		StreakCurrentDays: u.StreakCurrentDays,
-		StreakLongestDays: u.StreakLongestDays,
+// AdjustWings moves the user's wings balance by the delta in one statement,
+// and returns the new balance. A debit larger than the balance fails with
+// economy.ErrInsufficientWings, unless it's floored at 0.
+func (u *UserTotalsStore) AdjustWings(ctx context.Context, exec boil.ContextExecutor, adjuster *economy.AdjustWings) (int, error) {
+	var balance struct {
+		Wings int `boil:"total_wings"`
 	}
+
+	err := pgmodel.NewQuery(qm.SQL(`
+		UPDATE wings_ecn_user_totals
+		SET total_wings = CASE WHEN $3 THEN GREATEST(total_wings + $2, 0) ELSE total_wings + $2 END
+		WHERE user_ref_id = $1
+		  AND ($3 OR total_wings + $2 >= 0)
+		RETURNING total_wings`,
+		adjuster.UserID, adjuster.Delta, adjuster.FloorAtZero,
+	)).Bind(ctx, exec, &balance)
+	if err == nil {
+		return balance.Wings, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("adjust wings: %w", err)
+	}
+
+	// nothing updated: either there's no totals row, or the balance is short
+	exists, err := pgmodel.WingsEcnUserTotals(
+		pgmodel.WingsEcnUserTotalWhere.UserRefID.EQ(adjuster.UserID),
+	).Exists(ctx, exec)
+	if err != nil {
+		return 0, fmt.Errorf("user totals exists: %w", err)
+	}
+	if !exists {
+		return 0, fmt.Errorf("%w: user %s", economy.ErrUserTotalsNotFound, adjuster.UserID)
+	}
+	return 0, economy.ErrInsufficientWings
+}
+
+// SyncPremiumExpiry sets the user's premium expiry from a store event in one
+// statement, unless the current expiry came from a later event. It returns
+// whether the expiry was set.
+func (u *UserTotalsStore) SyncPremiumExpiry(ctx context.Context, exec boil.ContextExecutor, syncer *economy.SyncPremiumExpiry) (bool, error) {
+	const query = `
+		UPDATE wings_ecn_user_totals
+		SET premium_expires_in = $2,
+		    premium_synced_at  = $3
+		WHERE user_ref_id = $1
+		  AND (premium_synced_at IS NULL OR premium_synced_at <= $3)`
+
+	res, err := exec.ExecContext(ctx, query, syncer.UserID, syncer.ExpiresAt.UTC(), syncer.At.UTC())
+	if err != nil {
+		return false, fmt.Errorf("sync premium expiry: %w", err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("rows affected: %w", err)
+	}
+
+	return updated == 1, nil
+}
+
+// IncrementSentMessages counts a sent message in one statement, and returns
+// the new count.
+func (u *UserTotalsStore) IncrementSentMessages(ctx context.Context, exec boil.ContextExecutor, userID string) (int, error) {
+	var counter struct {
+		SentMessages int `boil:"counter_sent_messages"`
+	}
+
+	if err := pgmodel.NewQuery(qm.SQL(`
+		UPDATE wings_ecn_user_totals
+		SET counter_sent_messages = counter_sent_messages + 1
+		WHERE user_ref_id = $1
+		RETURNING counter_sent_messages`,
+		userID,
+	)).Bind(ctx, exec, &counter); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%w: user %s", economy.ErrUserTotalsNotFound, userID)
+		}
+		return 0, fmt.Errorf("increment sent messages: %w", err)
+	}
+
+	return counter.SentMessages, nil
+}
+
+// LedgerBalances returns every user's wings balance next to the balance
+// their wings_ecn_transaction ledger adds up to.
+func (u *UserTotalsStore) LedgerBalances(ctx context.Context, exec boil.ContextExecutor) ([]economy.WingsLedgerBalance, error) {
+	var balances []economy.WingsLedgerBalance
+
+	if err := pgmodel.NewQuery(qm.SQL(`
+		SELECT
+			ut.user_ref_id AS user_id,
+			ut.total_wings AS wings,
+			GREATEST(COALESCE(SUM(
+				CASE WHEN tx.is_credit THEN tx.amount ELSE -tx.amount END
+			), 0), 0) AS ledger_wings
+		FROM wings_ecn_user_totals ut
+		LEFT JOIN wings_ecn_transaction tx
+			ON tx.user_ref_id = ut.user_ref_id
+			AND tx.is_active = 1
+			AND tx.claimed
+			AND NOT (tx.is_credit AND tx.is_expired)
+		GROUP BY ut.user_ref_id, ut.total_wings
+		ORDER BY ut.user_ref_id`,
+	)).Bind(ctx, exec, &balances); err != nil {
+		return nil, fmt.Errorf("ledger balances: %w", err)
+	}
+
+	return balances, nil
 }
@@ -0,0 +1,128 @@
+package economy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+// SetPremiumExpiry sets when the user's premium access ends, to the expiry a
+// store event at `at` reported. A past expiry ends it. The store can deliver
+// events out of order, so an event older than the one the current expiry came
+// from doesn't change it: false is returned.
+func (a *ActionLogger) SetPremiumExpiry(ctx context.Context,
+	exec boil.ContextExecutor,
+	userID string,
+	expiresAt time.Time,
+	at time.Time,
+) (bool, error) {
+	userTotals, err := a.userTotalsStorer.Totals(ctx, exec, userID)
+	if err != nil {
+		return false, fmt.Errorf("get user totals: %w", err)
+	}
+	if userTotals == nil {
+		if _, err = a.userTotalsStorer.Create(ctx, exec, userID); err != nil {
+			return false, fmt.Errorf("create user totals: %w", err)
+		}
+	}
+
+	applied, err := a.userTotalsStorer.SyncPremiumExpiry(ctx, exec, &SyncPremiumExpiry{
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		At:        at,
+	})
+	if err != nil {
+		return false, fmt.Errorf("sync premium expiry: %w", err)
+	}
+
+	return applied, nil
+}
+
+// TransferPremium moves premium access from the users a subscription was
+// transferred away from, to toUserIDs: each of them gets the latest of the
+// old users' expiries, and theirs end at the time of the transfer, unless
+// they changed after it. Users that aren't ours, such as anonymous store
+// users, are skipped.
+func (a *ActionLogger) TransferPremium(ctx context.Context,
+	exec boil.ContextExecutor,
+	fromUserIDs []string,
+	toUserIDs []string,
+	at time.Time,
+) error {
+	var latest null.Time
+	for _, fromUserID := range fromUserIDs {
+		if slices.Contains(toUserIDs, fromUserID) || uuid.Validate(fromUserID) != nil {
+			continue
+		}
+
+		userTotals, err := a.userTotalsStorer.Totals(ctx, exec, fromUserID)
+		if err != nil {
+			return fmt.Errorf("get user totals of %s: %w", fromUserID, err)
+		}
+		if userTotals == nil || !userTotals.PremiumExpiresIn.Valid || !userTotals.PremiumExpiresIn.Time.After(at) {
+			continue
+		}
+
+		ended, err := a.SetPremiumExpiry(ctx, exec, fromUserID, at, at)
+		if err != nil {
+			return fmt.Errorf("end premium of %s: %w", fromUserID, err)
+		}
+		if !ended {
+			continue // the user's premium changed after the transfer
+		}
+
+		if !latest.Valid || userTotals.PremiumExpiresIn.Time.After(latest.Time) {
+			latest = userTotals.PremiumExpiresIn
+		}
+	}
+
+	if !latest.Valid {
+		return nil
+	}
+
+	for _, toUserID := range toUserIDs {
+		userTotals, err := a.userTotalsStorer.Totals(ctx, exec, toUserID)
+		if err != nil {
+			return fmt.Errorf("get user totals of %s: %w", toUserID, err)
+		}
+		if userTotals != nil && userTotals.PremiumExpiresIn.Valid && !userTotals.PremiumExpiresIn.Time.Before(latest.Time) {
+			continue // the user's own premium lasts longer
+		}
+
+		if _, err = a.SetPremiumExpiry(ctx, exec, toUserID, latest.Time, at); err != nil {
+			return fmt.Errorf("set premium expiry of %s: %w", toUserID, err)
+		}
+	}
+
+	return nil
+}
+
+// VoidPayment voids the active action logs of a payment by its RefID, like
+// DeleteActionLog does, which claws back the wings it credited. It returns
+// how many action logs were voided, none when the payment wasn't recorded or
+// was voided already.
+func (a *ActionLogger) VoidPayment(ctx context.Context,
+	exec boil.ContextExecutor,
+	refID string,
+) (int, error) {
+	actionLogs, err := a.actionLogStorer.ActionLogs(ctx, exec, &QueryFilterActionLog{
+		RefID:    null.StringFrom(refID),
+		IsActive: null.IntFrom(1),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("fetch action logs: %w", err)
+	}
+
+	for _, actionLog := range actionLogs {
+		if err = a.DeleteActionLog(ctx, exec, actionLog.ID); err != nil {
+			return 0, fmt.Errorf("delete action log %s: %w", actionLog.ID, err)
+		}
+	}
+
+	return len(actionLogs), nil
+}
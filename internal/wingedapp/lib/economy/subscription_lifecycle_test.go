@@ -0,0 +1,198 @@
+package economy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/null/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCaseSubscriptionLifecycle struct {
+	name string
+
+	// run moves the subscription of userID, otherUserID is a second registered user
+	run        func(th *testsuite.Helper, e *economy.ActionLogger, userID, otherUserID string) error
+	assertions func(th *testsuite.Helper, userID, otherUserID string, err error)
+}
+
+func TestActionLogger_SubscriptionLifecycle(t *testing.T) {
+	storeExpiration := time.Now().UTC().AddDate(0, 3, 2).Truncate(time.Second)
+	past := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+
+	testCases := []testCaseSubscriptionLifecycle{
+		{
+			name: "success-wingedx-three-month-uses-store-expiration",
+			run: func(th *testsuite.Helper, e *economy.ActionLogger, userID, _ string) error {
+				return e.CreateActionLog(context.Background(), th.BackendAppDb(), &economy.InsertActionLog{
+					UserID:           userID,
+					RefID:            uuid.NewString(),
+					Type:             economy.ActionWingedXThreeMonthPayment,
+					PremiumExpiresAt: null.TimeFrom(storeExpiration),
+				})
+			},
+			assertions: func(th *testsuite.Helper, userID, _ string, err error) {
+				require.NoError(th.T, err)
+				totals := getTestUserTotals(th, userID)
+				require.True(th.T, totals.PremiumExpiresIn.Valid)
+				assert.WithinDuration(th.T, storeExpiration, totals.PremiumExpiresIn.Time, time.Second)
+				assert.Zero(th.T, totals.TotalWings, "WingedX grants no wings")
+			},
+		},
+		{
+			name: "success-wingedx-six-month-extends-by-plan",
+			run: func(th *testsuite.Helper, e *economy.ActionLogger, userID, _ string) error {
+				return e.CreateActionLog(context.Background(), th.BackendAppDb(), &economy.InsertActionLog{
+					UserID: userID,
+					RefID:  uuid.NewString(),
+					Type:   economy.ActionWingedXSixMonthPayment,
+				})
+			},
+			assertions: func(th *testsuite.Helper, userID, _ string, err error) {
+				require.NoError(th.T, err)
+				totals := getTestUserTotals(th, userID)
+				require.True(th.T, totals.PremiumExpiresIn.Valid)
+				assert.WithinDuration(th.T, time.Now().AddDate(0, 6, 0), totals.PremiumExpiresIn.Time, time.Minute)
+			},
+		},
+		{
+			name: "success-void-payment-claws-back-wings-once",
+			run: func(th *testsuite.Helper, e *economy.ActionLogger, userID, _ string) error {
+				ctx := context.Background()
+				refID := uuid.NewString()
+				if err := e.CreateActionLog(ctx, th.BackendAppDb(), &economy.InsertActionLog{
+					UserID: userID,
+					RefID:  refID,
+					Type:   economy.ActionWingedPlusWeeklyPayment,
+				}); err != nil {
+					return err
+				}
+
+				voided, err := e.VoidPayment(ctx, th.BackendAppDb(), refID)
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 1, voided)
+
+				voided, err = e.VoidPayment(ctx, th.BackendAppDb(), refID)
+				require.NoError(th.T, err)
+				assert.Zero(th.T, voided, "a payment is voided once")
+				return nil
+			},
+			assertions: func(th *testsuite.Helper, userID, _ string, err error) {
+				require.NoError(th.T, err)
+				assert.Zero(th.T, getTestUserTotals(th, userID).TotalWings, "the refunded wings are clawed back")
+				for _, txn := range getTestTransactionsByUser(th, userID) {
+					assert.Equal(th.T, null.IntFrom(0), txn.IsActive, "the payment's transaction is voided")
+				}
+			},
+		},
+		{
+			name: "success-set-premium-expiry-ends-access",
+			run: func(th *testsuite.Helper, e *economy.ActionLogger, userID, _ string) error {
+				applied, err := e.SetPremiumExpiry(context.Background(), th.BackendAppDb(), userID, past, past)
+				assert.True(th.T, applied)
+				return err
+			},
+			assertions: func(th *testsuite.Helper, userID, _ string, err error) {
+				require.NoError(th.T, err)
+				totals := getTestUserTotals(th, userID)
+				require.True(th.T, totals.PremiumExpiresIn.Valid)
+				assert.WithinDuration(th.T, past, totals.PremiumExpiresIn.Time, time.Second)
+			},
+		},
+		{
+			name: "success-older-event-does-not-change-expiry",
+			run: func(th *testsuite.Helper, e *economy.ActionLogger, userID, _ string) error {
+				ctx := context.Background()
+				expiredAt := time.Now().UTC().Add(-time.Minute)
+
+				// the EXPIRATION is delivered before the RENEWAL that preceded it
+				applied, err := e.SetPremiumExpiry(ctx, th.BackendAppDb(), userID, expiredAt, expiredAt)
+				require.NoError(th.T, err)
+				require.True(th.T, applied)
+
+				applied, err = e.SetPremiumExpiry(ctx, th.BackendAppDb(), userID, storeExpiration, expiredAt.Add(-time.Hour))
+				assert.False(th.T, applied, "the older renewal is out of order")
+				return err
+			},
+			assertions: func(th *testsuite.Helper, userID, _ string, err error) {
+				require.NoError(th.T, err)
+				totals := getTestUserTotals(th, userID)
+				assert.False(th.T, totals.PremiumExpiresIn.Time.After(time.Now()), "premium stays expired")
+				assert.True(th.T, totals.PremiumSyncedAt.Valid)
+			},
+		},
+		{
+			name: "success-transfer-premium",
+			run: func(th *testsuite.Helper, e *economy.ActionLogger, userID, otherUserID string) error {
+				ctx := context.Background()
+				transferAt := time.Now().UTC()
+				if _, err := e.SetPremiumExpiry(ctx, th.BackendAppDb(), otherUserID, storeExpiration, transferAt.Add(-time.Hour)); err != nil {
+					return err
+				}
+				return e.TransferPremium(ctx, th.BackendAppDb(),
+					[]string{otherUserID, "$RCAnonymousID:skipped"}, []string{userID}, transferAt)
+			},
+			assertions: func(th *testsuite.Helper, userID, otherUserID string, err error) {
+				require.NoError(th.T, err)
+				to := getTestUserTotals(th, userID)
+				require.True(th.T, to.PremiumExpiresIn.Valid)
+				assert.WithinDuration(th.T, storeExpiration, to.PremiumExpiresIn.Time, time.Second)
+
+				from := getTestUserTotals(th, otherUserID)
+				assert.False(th.T, from.PremiumExpiresIn.Time.After(time.Now()), "the premium left the old user")
+			},
+		},
+		{
+			name: "success-transfer-premium-to-every-recipient",
+			run: func(th *testsuite.Helper, e *economy.ActionLogger, userID, otherUserID string) error {
+				ctx := context.Background()
+				transferAt := time.Now().UTC()
+				if _, err := e.SetPremiumExpiry(ctx, th.BackendAppDb(), otherUserID, storeExpiration, transferAt.Add(-time.Hour)); err != nil {
+					return err
+				}
+
+				alias := th.PersistRegisteredUser()
+				if err := e.TransferPremium(ctx, th.BackendAppDb(),
+					[]string{otherUserID}, []string{userID, alias.ID}, transferAt); err != nil {
+					return err
+				}
+
+				aliasTotals := getTestUserTotals(th, alias.ID)
+				require.True(th.T, aliasTotals.PremiumExpiresIn.Valid, "the second recipient gets premium too")
+				assert.WithinDuration(th.T, storeExpiration, aliasTotals.PremiumExpiresIn.Time, time.Second)
+				return nil
+			},
+			assertions: func(th *testsuite.Helper, userID, otherUserID string, err error) {
+				require.NoError(th.T, err)
+				to := getTestUserTotals(th, userID)
+				require.True(th.T, to.PremiumExpiresIn.Valid)
+				assert.WithinDuration(th.T, storeExpiration, to.PremiumExpiresIn.Time, time.Second)
+
+				from := getTestUserTotals(th, otherUserID)
+				assert.False(th.T, from.PremiumExpiresIn.Time.After(time.Now()), "the premium left the old user")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tSuite := testsuite.New(t)
+			tSuite.FakeAPI().App() // init fakes
+			t.Cleanup(tSuite.UseBackendDB())
+
+			user := tSuite.PersistRegisteredUser()
+			otherUser := tSuite.PersistRegisteredUser()
+
+			err := tc.run(tSuite, tSuite.FakeContainer().GetLibEconomy(), user.ID, otherUser.ID)
+
+			tc.assertions(tSuite, user.ID, otherUser.ID, err)
+		})
+	}
+}
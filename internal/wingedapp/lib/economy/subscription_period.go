@@ -0,0 +1,197 @@
+package economy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// subscriptionPeriodStart is a payment's subscription period to record.
+type subscriptionPeriodStart struct {
+	UserID           string
+	SubscriptionType string
+	Plan             *SubscriptionPlan
+	ActionLogID      string
+	Wings            int       // the plan's wings, before proration
+	EndsAt           null.Time // the period's end the store reported, if any
+}
+
+// startSubscriptionPeriod records the subscription period a payment bought,
+// and returns the wings to grant for it.
+//
+// A payment for the plan the user is on renews it: the period starts when the
+// current one ends. A payment for another plan is an upgrade or downgrade:
+// the user's periods end now, and the unused share of the wings they granted
+// is credited towards the new plan's wings, so the same days don't grant wings
+// twice. WingedX grants no wings, so moving to it credits nothing.
+func (a *ActionLogger) startSubscriptionPeriod(ctx context.Context,
+	exec boil.ContextExecutor,
+	s *subscriptionPeriodStart,
+) (int, error) {
+	now := time.Now().UTC()
+
+	periods, err := a.subscriptionStorer.Periods(ctx, exec, &QueryFilterSubscriptionPeriod{
+		UserID:    null.StringFrom(s.UserID),
+		IsActive:  null.IntFrom(1),
+		EndsAfter: null.TimeFrom(now),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("fetch subscription periods: %w", err)
+	}
+
+	start, wings := now, s.Wings
+	if len(periods) > 0 && periods[0].PlanID == s.Plan.ID {
+		start = periods[0].EndDate // renewal
+	} else if len(periods) > 0 {
+		reason := planChangeReason(&periods[0], s.SubscriptionType, s.Plan)
+
+		unused := 0
+		for i := range periods {
+			unused += unusedWings(&periods[i], now)
+
+			endDate := now
+			if periods[i].StartDate.After(now) {
+				endDate = periods[i].StartDate // a renewal that hadn't started
+			}
+			if err = a.subscriptionStorer.EndPeriod(ctx, exec, &EndSubscriptionPeriod{
+				ID:        periods[i].ID,
+				EndDate:   endDate,
+				EndReason: reason,
+			}); err != nil {
+				return 0, fmt.Errorf("end subscription period: %w", err)
+			}
+		}
+
+		wings = max(wings-unused, 0)
+	}
+
+	end := s.EndsAt.Time
+	if !s.EndsAt.Valid {
+		if end, err = planPeriodEnd(s.Plan.Name, start); err != nil {
+			return 0, fmt.Errorf("plan period end: %w", err)
+		}
+	}
+	if !end.After(start) {
+		return wings, nil // the store's period is already over
+	}
+
+	if _, err = a.subscriptionStorer.InsertPeriod(ctx, exec, &InsertSubscriptionPeriod{
+		UserID:       s.UserID,
+		PlanID:       s.Plan.ID,
+		ActionLogID:  null.StringFrom(s.ActionLogID),
+		WingsGranted: wings,
+		StartDate:    start,
+		EndDate:      end,
+	}); err != nil {
+		return 0, fmt.Errorf("insert subscription period: %w", err)
+	}
+
+	return wings, nil
+}
+
+// planPeriodEnd returns when a period of the plan started at start ends.
+func planPeriodEnd(planName string, start time.Time) (time.Time, error) {
+	switch planName {
+	case SubscriptionPaymentWeekly:
+		return start.AddDate(0, 0, 7), nil
+	case SubscriptionPaymentMonthly:
+		return start.AddDate(0, 1, 0), nil
+	case SubscriptionPaymentThreeMonth:
+		return start.AddDate(0, 3, 0), nil
+	case SubscriptionPaymentSixMonth:
+		return start.AddDate(0, 6, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("unknown plan %q", planName)
+	}
+}
+
+// planChangeReason tells whether moving from the current period's plan to
+// plan is an upgrade or a downgrade: WingedX ranks above Winged+, and within
+// a subscription type the pricier plan ranks above.
+func planChangeReason(current *SubscriptionPeriod, subscriptionType string, plan *SubscriptionPlan) string {
+	rank := func(subscriptionType string) int {
+		if subscriptionType == SubscriptionTypeWingedX {
+			return 1
+		}
+		return 0
+	}
+
+	if from, to := rank(current.SubscriptionType), rank(subscriptionType); from != to {
+		if to > from {
+			return SubscriptionEndReasonUpgrade
+		}
+		return SubscriptionEndReasonDowngrade
+	}
+
+	if current.Price.Big != nil && plan.Price.Big != nil && plan.Price.Cmp(current.Price.Big) < 0 {
+		return SubscriptionEndReasonDowngrade
+	}
+
+	return SubscriptionEndReasonUpgrade
+}
+
+// unusedWings returns the share of the wings a period granted for the time
+// left in it at `at`, rounded down.
+func unusedWings(period *SubscriptionPeriod, at time.Time) int {
+	total := period.EndDate.Sub(period.StartDate)
+	left := min(period.EndDate.Sub(at), total)
+	if total <= 0 || left <= 0 {
+		return 0
+	}
+
+	return int(int64(period.WingsGranted) * int64(left) / int64(total))
+}
+
+// SubscriptionHistory returns the subscription periods the user bought, the
+// latest first, including ones voided by a refund.
+func (a *ActionLogger) SubscriptionHistory(ctx context.Context,
+	exec boil.ContextExecutor,
+	userID string,
+) ([]SubscriptionPeriod, error) {
+	periods, err := a.subscriptionStorer.Periods(ctx, exec, &QueryFilterSubscriptionPeriod{
+		UserID: null.StringFrom(userID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch subscription periods: %w", err)
+	}
+
+	return periods, nil
+}
+
+// Entitlement returns what the user is entitled to right now: the current
+// subscription period, premium access and the wings balance.
+func (a *ActionLogger) Entitlement(ctx context.Context,
+	exec boil.ContextExecutor,
+	userID string,
+) (*Entitlement, error) {
+	now := time.Now().UTC()
+	entitlement := &Entitlement{UserID: userID}
+
+	periods, err := a.subscriptionStorer.Periods(ctx, exec, &QueryFilterSubscriptionPeriod{
+		UserID:   null.StringFrom(userID),
+		IsActive: null.IntFrom(1),
+		ActiveAt: null.TimeFrom(now),
+		Limit:    1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch subscription periods: %w", err)
+	}
+	if len(periods) > 0 {
+		entitlement.Period = &periods[0]
+	}
+
+	userTotals, err := a.userTotalsStorer.Totals(ctx, exec, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user totals: %w", err)
+	}
+	if userTotals != nil {
+		entitlement.Wings = userTotals.Wings
+		entitlement.PremiumExpiresAt = userTotals.PremiumExpiresIn
+		entitlement.IsPremium = userTotals.PremiumExpiresIn.Valid && userTotals.PremiumExpiresIn.Time.After(now)
+	}
+
+	return entitlement, nil
+}
@@ -0,0 +1,132 @@
+package economy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/lib/economy"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/null/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCaseSubscriptionPeriod struct {
+	name string
+
+	payments   []economy.ActionType
+	run        func(th *testsuite.Helper, e *economy.ActionLogger, userID string) error // after the payments, optional
+	assertions func(th *testsuite.Helper, e *economy.ActionLogger, userID string, err error)
+}
+
+func TestActionLogger_SubscriptionPeriods(t *testing.T) {
+	testCases := []testCaseSubscriptionPeriod{
+		{
+			name:     "success-renewal-starts-when-current-period-ends",
+			payments: []economy.ActionType{economy.ActionWingedPlusWeeklyPayment, economy.ActionWingedPlusWeeklyPayment},
+			assertions: func(th *testsuite.Helper, e *economy.ActionLogger, userID string, err error) {
+				require.NoError(th.T, err)
+				periods, err := e.SubscriptionHistory(context.Background(), th.BackendAppDb(), userID)
+				require.NoError(th.T, err)
+				require.Len(th.T, periods, 2)
+				assert.WithinDuration(th.T, periods[1].EndDate, periods[0].StartDate, time.Second, "the renewal is stacked")
+				assert.Equal(th.T, 25, periods[0].WingsGranted)
+				assert.Equal(th.T, 50, getTestUserTotals(th, userID).TotalWings)
+			},
+		},
+		{
+			name:     "success-upgrade-credits-unused-wings",
+			payments: []economy.ActionType{economy.ActionWingedPlusWeeklyPayment, economy.ActionWingedPlusMonthlyPayment},
+			assertions: func(th *testsuite.Helper, e *economy.ActionLogger, userID string, err error) {
+				require.NoError(th.T, err)
+				periods, err := e.SubscriptionHistory(context.Background(), th.BackendAppDb(), userID)
+				require.NoError(th.T, err)
+				require.Len(th.T, periods, 2)
+
+				monthly, weekly := periods[0], periods[1]
+				assert.Equal(th.T, null.StringFrom(economy.SubscriptionEndReasonUpgrade), weekly.EndReason)
+				assert.False(th.T, weekly.EndDate.After(time.Now()), "the weekly period ended")
+				assert.Equal(th.T, 55-24, monthly.WingsGranted, "the unused weekly wings are credited")
+				assert.Equal(th.T, 25+55-24, getTestUserTotals(th, userID).TotalWings)
+			},
+		},
+		{
+			name:     "success-upgrade-to-wingedx-keeps-wings",
+			payments: []economy.ActionType{economy.ActionWingedPlusMonthlyPayment, economy.ActionWingedXMonthlyPayment},
+			assertions: func(th *testsuite.Helper, e *economy.ActionLogger, userID string, err error) {
+				require.NoError(th.T, err)
+				entitlement, err := e.Entitlement(context.Background(), th.BackendAppDb(), userID)
+				require.NoError(th.T, err)
+				require.NotNil(th.T, entitlement.Period)
+				assert.Equal(th.T, economy.SubscriptionTypeWingedX, entitlement.Period.SubscriptionType)
+				assert.Zero(th.T, entitlement.Period.WingsGranted)
+				assert.True(th.T, entitlement.IsPremium)
+				assert.Equal(th.T, 55, entitlement.Wings)
+			},
+		},
+		{
+			name:     "success-downgrade-to-winged-plus",
+			payments: []economy.ActionType{economy.ActionWingedXMonthlyPayment, economy.ActionWingedPlusWeeklyPayment},
+			assertions: func(th *testsuite.Helper, e *economy.ActionLogger, userID string, err error) {
+				require.NoError(th.T, err)
+				periods, err := e.SubscriptionHistory(context.Background(), th.BackendAppDb(), userID)
+				require.NoError(th.T, err)
+				require.Len(th.T, periods, 2)
+				assert.Equal(th.T, null.StringFrom(economy.SubscriptionEndReasonDowngrade), periods[1].EndReason)
+				assert.Equal(th.T, 25, periods[0].WingsGranted, "WingedX granted no wings to credit")
+			},
+		},
+		{
+			name:     "success-void-payment-voids-period",
+			payments: []economy.ActionType{economy.ActionWingedPlusWeeklyPayment},
+			run: func(th *testsuite.Helper, e *economy.ActionLogger, userID string) error {
+				periods, err := e.SubscriptionHistory(context.Background(), th.BackendAppDb(), userID)
+				require.NoError(th.T, err)
+				require.Len(th.T, periods, 1)
+				return e.DeleteActionLog(context.Background(), th.BackendAppDb(), periods[0].ActionLogID.String)
+			},
+			assertions: func(th *testsuite.Helper, e *economy.ActionLogger, userID string, err error) {
+				require.NoError(th.T, err)
+				entitlement, err := e.Entitlement(context.Background(), th.BackendAppDb(), userID)
+				require.NoError(th.T, err)
+				assert.Nil(th.T, entitlement.Period, "a voided period entitles to nothing")
+
+				periods, err := e.SubscriptionHistory(context.Background(), th.BackendAppDb(), userID)
+				require.NoError(th.T, err)
+				require.Len(th.T, periods, 1)
+				assert.Zero(th.T, periods[0].IsActive)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tSuite := testsuite.New(t)
+			tSuite.FakeAPI().App() // init fakes
+			t.Cleanup(tSuite.UseBackendDB())
+
+			user := tSuite.PersistRegisteredUser()
+			e := tSuite.FakeContainer().GetLibEconomy()
+
+			var err error
+			for _, payment := range tc.payments {
+				if err = e.CreateActionLog(context.Background(), tSuite.BackendAppDb(), &economy.InsertActionLog{
+					UserID: user.ID,
+					RefID:  uuid.NewString(),
+					Type:   payment,
+				}); err != nil {
+					break
+				}
+			}
+			if err == nil && tc.run != nil {
+				err = tc.run(tSuite, e, user.ID)
+			}
+
+			tc.assertions(tSuite, e, user.ID, err)
+		})
+	}
+}
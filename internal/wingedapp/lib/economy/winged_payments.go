@@ -123,6 +123,20 @@ func (a *ActionLogger) addWingedPayment(ctx context.Context,
 		return fmt.Errorf("insert action log: %w", err)
 	}
 
+	wings, err := a.startSubscriptionPeriod(ctx, exec, &subscriptionPeriodStart{
+		UserID:           actionInserter.UserID,
+		SubscriptionType: subPayment.Type,
+		Plan:             subscriptionPlan,
+		ActionLogID:      actionLog.ID,
+		Wings:            subscriptionPlan.Wings,
+	})
+	if err != nil {
+		return fmt.Errorf("start subscription period: %w", err)
+	}
+	if wings == 0 {
+		return nil // a plan change credited all of them already
+	}
+
 	// Subscription wings expire in 30 days (same as earned wings)
 	expiresAt := null.TimeFrom(time.Now().AddDate(0, 0, EarnedWingsExpiryDays))
 
@@ -130,7 +144,7 @@ func (a *ActionLogger) addWingedPayment(ctx context.Context,
 		UserID:       actionInserter.UserID,       // userID
 		ActionTypeID: string(actionInserter.Type), // enum string value
 		ActionRefID:  actionLog.ID,                // shared duplicate in action log insert
-		WingsAmount:  subscriptionPlan.Wings,      // the number of wings to credit
+		WingsAmount:  wings,                       // the number of wings to credit, after proration
 		Claimed:      true,                        // yes, true by default
 		IsCredit:     true,                        // from the user's perspective
 		ExtraInfo:    actionInserter.JSONDetails,  // pass along any extra details
@@ -141,9 +155,9 @@ func (a *ActionLogger) addWingedPayment(ctx context.Context,
 
 	/* then update totals */
 
-	if err = a.userTotalsStorer.Update(ctx, exec, &UpdateUserTotals{
-		ID:    userTotals.ID,
-		Wings: null.IntFrom(userTotals.Wings + subscriptionPlan.Wings),
+	if _, err = a.userTotalsStorer.AdjustWings(ctx, exec, &AdjustWings{
+		UserID: actionInserter.UserID,
+		Delta:  wings,
 	}); err != nil {
 		return fmt.Errorf("update user totals: %w", err)
 	}
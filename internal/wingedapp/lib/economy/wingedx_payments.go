@@ -32,10 +32,50 @@ func (a *ActionLogger) addWingedXPayment(ctx context.Context,
 		}
 	}
 
-	if _, err := a.actionLogStorer.Insert(ctx, exec, categoryPayment, actionInserter); err != nil {
+	subscriptionType, planName := actionTypeToSubscription(actionInserter.Type)
+	subscriptionPlan, err := a.subscriptionStorer.SubscriptionPlan(ctx, exec, subscriptionType, planName)
+	if err != nil {
+		return fmt.Errorf("fetch subscription plan: %w", err)
+	}
+
+	actionLog, err := a.actionLogStorer.Insert(ctx, exec, categoryPayment, actionInserter)
+	if err != nil {
 		return fmt.Errorf("insert action log: %w", err)
 	}
 
+	if userTotals == nil {
+		if userTotals, err = a.userTotalsStorer.Create(ctx, exec, actionInserter.UserID); err != nil {
+			return fmt.Errorf("create user totals: %w", err)
+		}
+	}
+
+	// WingedX grants no wings, only premium access
+	if _, err = a.startSubscriptionPeriod(ctx, exec, &subscriptionPeriodStart{
+		UserID:           actionInserter.UserID,
+		SubscriptionType: subscriptionType,
+		Plan:             subscriptionPlan,
+		ActionLogID:      actionLog.ID,
+		EndsAt:           actionInserter.PremiumExpiresAt,
+	}); err != nil {
+		return fmt.Errorf("start subscription period: %w", err)
+	}
+
+	// the store's expiration is the source of truth when it's known
+	if actionInserter.PremiumExpiresAt.Valid {
+		at := actionInserter.PremiumEventAt
+		if !at.Valid {
+			at = null.TimeFrom(time.Now().UTC())
+		}
+		if _, err = a.userTotalsStorer.SyncPremiumExpiry(ctx, exec, &SyncPremiumExpiry{
+			UserID:    actionInserter.UserID,
+			ExpiresAt: actionInserter.PremiumExpiresAt.Time,
+			At:        at.Time,
+		}); err != nil {
+			return fmt.Errorf("sync premium expiry: %w", err)
+		}
+		return nil
+	}
+
 	premiumsExpiresIn := userTotals.PremiumExpiresIn
 	if premiumsExpiresIn.IsZero() {
 		premiumsExpiresIn = null.TimeFrom(time.Now().UTC())
@@ -47,7 +87,7 @@ func (a *ActionLogger) addWingedXPayment(ctx context.Context,
 		ID:               userTotals.ID,
 		PremiumExpiresIn: premiumsExpiresIn,
 	}
-	if err := a.userTotalsStorer.Update(ctx, exec, updater); err != nil {
+	if err = a.userTotalsStorer.Update(ctx, exec, updater); err != nil {
 		return fmt.Errorf("update user totals: %w", err)
 	}
 
@@ -95,3 +135,43 @@ func (a *ActionLogger) addWingedXWeeklyPayment(ctx context.Context,
 
 	return nil
 }
+
+func (a *ActionLogger) addWingedXThreeMonthPayment(ctx context.Context,
+	exec boil.ContextExecutor,
+	userTotals *UserTotals,
+	actionInserter *InsertActionLog,
+) error {
+	if err := a.addWingedXPayment(ctx,
+		exec,
+		userTotals,
+		actionInserter,
+		CategoryWingedxThreeMonthPayment,
+		func(d *time.Time) {
+			*d = d.AddDate(0, CountWingedXPaymentThreeMonth, 0) // add 3 months
+		},
+	); err != nil {
+		return fmt.Errorf("add wingedx three month payment: %w", err)
+	}
+
+	return nil
+}
+
+func (a *ActionLogger) addWingedXSixMonthPayment(ctx context.Context,
+	exec boil.ContextExecutor,
+	userTotals *UserTotals,
+	actionInserter *InsertActionLog,
+) error {
+	if err := a.addWingedXPayment(ctx,
+		exec,
+		userTotals,
+		actionInserter,
+		CategoryWingedxSixMonthPayment,
+		func(d *time.Time) {
+			*d = d.AddDate(0, CountWingedXPaymentSixMonth, 0) // add 6 months
+		},
+	); err != nil {
+		return fmt.Errorf("add wingedx six month payment: %w", err)
+	}
+
+	return nil
+}
@@ -127,6 +127,8 @@ const (
 	WingsEconomyActionLogSendMessage           WingsEconomyActionLog = "Send Message"
 	WingsEconomyActionLogWingedXWeeklyPayment  WingsEconomyActionLog = "WingedX - Weekly Payment"
 	WingsEconomyActionLogWingedXMonthlyPayment WingsEconomyActionLog = "WingedX - Monthly Payment"
+	WingsEconomyActionLogWingedX3Month         WingsEconomyActionLog = "WingedX - 3 Month Payment"
+	WingsEconomyActionLogWingedX6Month         WingsEconomyActionLog = "WingedX - 6 Month Payment"
 	WingsEconomyActionLogWingedPlusWeekly      WingsEconomyActionLog = "Winged+ - Weekly Payment"
 	WingsEconomyActionLogWingedPlusMonthly     WingsEconomyActionLog = "Winged+ - Monthly Payment"
 	WingsEconomyActionLogWingedPlus3Month      WingsEconomyActionLog = "Winged+ - 3 Month Payment"
@@ -134,6 +136,11 @@ const (
 	WingsEconomyActionLogTopUpMini             WingsEconomyActionLog = "Top Up - Mini"
 	WingsEconomyActionLogTopUpBoost            WingsEconomyActionLog = "Top Up - Boost"
 	WingsEconomyActionLogTopUpPremium          WingsEconomyActionLog = "Top Up - Premium"
+	WingsEconomyActionLogProposeMatch          WingsEconomyActionLog = "Propose Match"
+	WingsEconomyActionLogRescheduleDate        WingsEconomyActionLog = "Reschedule Date"
+	WingsEconomyActionLogVenueChange           WingsEconomyActionLog = "Venue Change"
+	WingsEconomyActionLogPriorityDrop          WingsEconomyActionLog = "Priority Drop"
+	WingsEconomyActionLogExtraAIMessage        WingsEconomyActionLog = "Extra AI Message"
 )
 
 func (e WingsEconomyActionLog) String() string { return string(e) }
@@ -141,9 +148,12 @@ func (e WingsEconomyActionLog) Valid() bool {
 	switch e {
 	case WingsEconomyActionLogDailyCheckIn, WingsEconomyActionLogSendMessage,
 		WingsEconomyActionLogWingedXWeeklyPayment, WingsEconomyActionLogWingedXMonthlyPayment,
+		WingsEconomyActionLogWingedX3Month, WingsEconomyActionLogWingedX6Month,
 		WingsEconomyActionLogWingedPlusWeekly, WingsEconomyActionLogWingedPlusMonthly,
 		WingsEconomyActionLogWingedPlus3Month, WingsEconomyActionLogWingedPlus6Month,
-		WingsEconomyActionLogTopUpMini, WingsEconomyActionLogTopUpBoost, WingsEconomyActionLogTopUpPremium:
+		WingsEconomyActionLogTopUpMini, WingsEconomyActionLogTopUpBoost, WingsEconomyActionLogTopUpPremium,
+		WingsEconomyActionLogProposeMatch, WingsEconomyActionLogRescheduleDate, WingsEconomyActionLogVenueChange,
+		WingsEconomyActionLogPriorityDrop, WingsEconomyActionLogExtraAIMessage:
 		return true
 	}
 	return false
@@ -230,6 +240,83 @@ func (e MatchUserAction) Valid() bool {
 	return false
 }
 
+// MatchExpiryOutcome records what a user did on a match by the time it expired.
+type MatchExpiryOutcome string
+
+const (
+	MatchExpiryOutcomeIgnored  MatchExpiryOutcome = "Ignored"
+	MatchExpiryOutcomePassed   MatchExpiryOutcome = "Passed"
+	MatchExpiryOutcomeProposed MatchExpiryOutcome = "Proposed"
+)
+
+func (e MatchExpiryOutcome) String() string { return string(e) }
+func (e MatchExpiryOutcome) Valid() bool {
+	switch e {
+	case MatchExpiryOutcomeIgnored, MatchExpiryOutcomePassed, MatchExpiryOutcomeProposed:
+		return true
+	}
+	return false
+}
+
+// IngestionStatus is the status of a match set's ingestion run.
+type IngestionStatus string
+
+const (
+	IngestionStatusPending   IngestionStatus = "Pending"
+	IngestionStatusRunning   IngestionStatus = "Running"
+	IngestionStatusCompleted IngestionStatus = "Completed"
+	IngestionStatusFailed    IngestionStatus = "Failed"
+	IngestionStatusAbandoned IngestionStatus = "Abandoned" // runs only, a later run found it still running
+)
+
+func (e IngestionStatus) String() string { return string(e) }
+func (e IngestionStatus) Valid() bool {
+	switch e {
+	case IngestionStatusPending, IngestionStatusRunning, IngestionStatusCompleted,
+		IngestionStatusFailed, IngestionStatusAbandoned:
+		return true
+	}
+	return false
+}
+
+// MatchResultIngestionStatus is the processing state of a pair in its match set's ingestion.
+type MatchResultIngestionStatus string
+
+const (
+	MatchResultIngestionStatusPending   MatchResultIngestionStatus = "Pending"
+	MatchResultIngestionStatusProcessed MatchResultIngestionStatus = "Processed"
+	MatchResultIngestionStatusFailed    MatchResultIngestionStatus = "Failed"
+)
+
+func (e MatchResultIngestionStatus) String() string { return string(e) }
+func (e MatchResultIngestionStatus) Valid() bool {
+	switch e {
+	case MatchResultIngestionStatusPending, MatchResultIngestionStatusProcessed, MatchResultIngestionStatusFailed:
+		return true
+	}
+	return false
+}
+
+// RevenueCatEventStatus is the processing state of a stored RevenueCat webhook.
+type RevenueCatEventStatus string
+
+const (
+	RevenueCatEventStatusReceived  RevenueCatEventStatus = "Received"
+	RevenueCatEventStatusProcessed RevenueCatEventStatus = "Processed"
+	RevenueCatEventStatusIgnored   RevenueCatEventStatus = "Ignored"
+	RevenueCatEventStatusFailed    RevenueCatEventStatus = "Failed"
+)
+
+func (e RevenueCatEventStatus) String() string { return string(e) }
+func (e RevenueCatEventStatus) Valid() bool {
+	switch e {
+	case RevenueCatEventStatusReceived, RevenueCatEventStatusProcessed,
+		RevenueCatEventStatusIgnored, RevenueCatEventStatusFailed:
+		return true
+	}
+	return false
+}
+
 // DateTypeCore represents canonical date types with associated durations.
 type DateTypeCore string
 
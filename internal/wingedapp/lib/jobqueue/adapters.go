@@ -0,0 +1,15 @@
+package jobqueue
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// jobStorer persists jobs.
+type jobStorer interface {
+	Insert(ctx context.Context, exec boil.ContextExecutor, inserter *InsertJob) (*Job, error)
+	Jobs(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterJob) ([]Job, error)
+	Claim(ctx context.Context, exec boil.ContextExecutor, c *ClaimJob) (*Job, error)
+	Update(ctx context.Context, exec boil.ContextExecutor, u *UpdateJob) error
+}
@@ -0,0 +1,35 @@
+package jobqueue
+
+import "time"
+
+const (
+
+	/* job statuses, match jobs_status_check */
+
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+
+	/* job types */
+
+	JobTypeBatchMatch = "batch_match"
+)
+
+const (
+	// DefaultMaxAttempts matches the jobs.max_attempts column default.
+	DefaultMaxAttempts = 3
+
+	// baseBackoff is the delay before the first retry, doubled on each attempt.
+	baseBackoff = 30 * time.Second
+	// maxBackoff caps the retry delay.
+	maxBackoff = 30 * time.Minute
+
+	// DefaultHeartbeatInterval is how often a running job signals liveness.
+	DefaultHeartbeatInterval = 30 * time.Second
+	// DefaultStaleAfter is how long a running job can go without a heartbeat
+	// before another worker may reclaim it.
+	DefaultStaleAfter = 5 * time.Minute
+	// DefaultPollInterval is how long an idle worker waits before polling again.
+	DefaultPollInterval = 5 * time.Second
+)
@@ -0,0 +1,21 @@
+package jobqueue
+
+import "errors"
+
+/* Job queue sentinel errors */
+
+var (
+	ErrMissingParams = errors.New("missing params")
+	ErrMissingType   = errors.New("job type is required")
+
+	// handler registry errors
+	ErrNilHandler               = errors.New("handler is required")
+	ErrHandlerAlreadyRegistered = errors.New("handler already registered for job type")
+	ErrNoHandler                = errors.New("no handler registered for job type")
+
+	// claim/update errors
+	ErrNoJobAvailable = errors.New("no job available")
+	// ErrJobLost is returned when a job was reclaimed by another worker,
+	// so the current worker no longer owns it.
+	ErrJobLost = errors.New("job no longer owned by worker")
+)
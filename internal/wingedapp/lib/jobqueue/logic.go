@@ -0,0 +1,58 @@
+package jobqueue
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+)
+
+// timeNow is a variable for testing purposes
+var timeNow = time.Now
+
+// Logic is a postgres-backed job queue over the jobs table.
+// Workers claim jobs with SELECT ... FOR UPDATE SKIP LOCKED, so any number
+// of them can poll the same table.
+type Logic struct {
+	logger    applog.Logger
+	jobStorer jobStorer
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+
+	heartbeatInterval time.Duration
+	staleAfter        time.Duration
+}
+
+func NewLogic(
+	l applog.Logger,
+	jobStorer jobStorer,
+) (*Logic, error) {
+	if l == nil {
+		return nil, errors.New("logger is required")
+	}
+	if jobStorer == nil {
+		return nil, errors.New("jobStorer is required")
+	}
+
+	return &Logic{
+		logger:            l,
+		jobStorer:         jobStorer,
+		handlers:          map[string]Handler{},
+		heartbeatInterval: DefaultHeartbeatInterval,
+		staleAfter:        DefaultStaleAfter,
+	}, nil
+}
+
+// SetHeartbeat overrides how often running jobs heartbeat, and how long
+// without one a job is considered abandoned. staleAfter should be a few
+// heartbeat intervals, so a slow heartbeat doesn't get a job reclaimed.
+func (l *Logic) SetHeartbeat(interval, staleAfter time.Duration) error {
+	if interval <= 0 || staleAfter <= interval {
+		return errors.New("staleAfter must be greater than a positive heartbeat interval")
+	}
+	l.heartbeatInterval = interval
+	l.staleAfter = staleAfter
+	return nil
+}
@@ -0,0 +1,89 @@
+package jobqueue
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/google/uuid"
+)
+
+// Job is a durable unit of work in the jobs table.
+type Job struct {
+	ID          uuid.UUID   `json:"id" boil:"id"`
+	Type        string      `json:"type" boil:"type"`
+	Status      string      `json:"status" boil:"status"`
+	Payload     null.JSON   `json:"payload" boil:"payload"`
+	Result      null.JSON   `json:"result" boil:"result"`
+	Error       null.String `json:"error" boil:"error"`
+	Attempts    int         `json:"attempts" boil:"attempts"`
+	MaxAttempts int         `json:"max_attempts" boil:"max_attempts"`
+	RunAfter    time.Time   `json:"run_after" boil:"run_after"`
+	HeartbeatAt null.Time   `json:"heartbeat_at" boil:"heartbeat_at"`
+	CreatedAt   time.Time   `json:"created_at" boil:"created_at"`
+	StartedAt   null.Time   `json:"started_at" boil:"started_at"`
+	CompletedAt null.Time   `json:"completed_at" boil:"completed_at"`
+}
+
+// EnqueueParams describes a job to enqueue.
+// Payload is marshalled to JSON.
+type EnqueueParams struct {
+	Type        string
+	Payload     any
+	MaxAttempts int       // defaults to DefaultMaxAttempts
+	RunAfter    null.Time // defaults to now
+}
+
+func (p *EnqueueParams) Validate() error {
+	if p == nil {
+		return ErrMissingParams
+	}
+	if p.Type == "" {
+		return ErrMissingType
+	}
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("max attempts must not be negative, got %d", p.MaxAttempts)
+	}
+	return nil
+}
+
+// BatchMatchPayload is the payload of a JobTypeBatchMatch job.
+type BatchMatchPayload struct {
+	MatchSetID string `json:"match_set_id"`
+	IsTestUser *bool  `json:"is_test_user,omitempty"`
+}
+
+// InsertJob is the store insert of a job.
+type InsertJob struct {
+	Type        string
+	Payload     json.RawMessage
+	MaxAttempts int
+	RunAfter    null.Time
+}
+
+// ClaimJob filters the next claimable job.
+type ClaimJob struct {
+	Types       []string  // only claim jobs of these types
+	StaleBefore time.Time // running jobs with a heartbeat before this are reclaimable
+}
+
+// QueryFilterJob filters job reads.
+type QueryFilterJob struct {
+	ID     null.String
+	Type   null.String
+	Status null.String
+}
+
+// UpdateJob updates a job owned by a worker.
+// Attempts fences the update: it only applies if the job wasn't reclaimed since.
+type UpdateJob struct {
+	ID          uuid.UUID
+	Attempts    int
+	Status      null.String
+	Result      null.JSON
+	Error       null.String
+	RunAfter    null.Time
+	CompletedAt null.Time
+	HeartbeatAt null.Time
+}
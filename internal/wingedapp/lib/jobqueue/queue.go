@@ -0,0 +1,187 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+// Enqueue inserts a pending job. Pass the caller's transaction as exec to
+// enqueue atomically with the writes the job depends on.
+func (l *Logic) Enqueue(ctx context.Context, exec boil.ContextExecutor, params *EnqueueParams) (*Job, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("validate params: %w", err)
+	}
+
+	var payload json.RawMessage
+	if params.Payload != nil {
+		b, err := json.Marshal(params.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("marshal payload: %w", err)
+		}
+		payload = b
+	}
+
+	maxAttempts := params.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+
+	job, err := l.jobStorer.Insert(ctx, exec, &InsertJob{
+		Type:        params.Type,
+		Payload:     payload,
+		MaxAttempts: maxAttempts,
+		RunAfter:    params.RunAfter,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("job storer insert: %w", err)
+	}
+
+	return job, nil
+}
+
+// EnqueueBatchMatch enqueues running the matching algorithm over a match set.
+func (l *Logic) EnqueueBatchMatch(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	matchSetID string,
+	isTestUser *bool,
+) (*Job, error) {
+	if _, err := uuid.Parse(matchSetID); err != nil {
+		return nil, fmt.Errorf("invalid match set id %q: %w", matchSetID, err)
+	}
+
+	return l.Enqueue(ctx, exec, &EnqueueParams{
+		Type: JobTypeBatchMatch,
+		Payload: &BatchMatchPayload{
+			MatchSetID: matchSetID,
+			IsTestUser: isTestUser,
+		},
+	})
+}
+
+// Job returns a job by id.
+func (l *Logic) Job(ctx context.Context, exec boil.ContextExecutor, id uuid.UUID) (*Job, error) {
+	jobs, err := l.jobStorer.Jobs(ctx, exec, &QueryFilterJob{
+		ID: null.StringFrom(id.String()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("job storer jobs: %w", err)
+	}
+	if len(jobs) != 1 {
+		return nil, fmt.Errorf("job %s: not found", id)
+	}
+
+	return &jobs[0], nil
+}
+
+// Claim marks the next due job of a registered type as running, and returns it.
+// Running jobs whose heartbeat went stale are reclaimed too.
+// Returns ErrNoJobAvailable when there's nothing to do.
+func (l *Logic) Claim(ctx context.Context, exec boil.ContextExecutor) (*Job, error) {
+	types := l.handledTypes()
+	if len(types) == 0 {
+		return nil, ErrNoJobAvailable
+	}
+
+	job, err := l.jobStorer.Claim(ctx, exec, &ClaimJob{
+		Types:       types,
+		StaleBefore: timeNow().Add(-l.staleAfter),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("job storer claim: %w", err)
+	}
+
+	return job, nil
+}
+
+// Heartbeat signals that the job is still being worked on.
+// Returns ErrJobLost if another worker reclaimed it.
+func (l *Logic) Heartbeat(ctx context.Context, exec boil.ContextExecutor, job *Job) error {
+	if err := l.jobStorer.Update(ctx, exec, &UpdateJob{
+		ID:          job.ID,
+		Attempts:    job.Attempts,
+		HeartbeatAt: null.TimeFrom(timeNow()),
+	}); err != nil {
+		return fmt.Errorf("job storer update: %w", err)
+	}
+	return nil
+}
+
+// Complete marks the job completed, and persists its result.
+func (l *Logic) Complete(ctx context.Context, exec boil.ContextExecutor, job *Job, result any) error {
+	u := &UpdateJob{
+		ID:          job.ID,
+		Attempts:    job.Attempts,
+		Status:      null.StringFrom(StatusCompleted),
+		CompletedAt: null.TimeFrom(timeNow()),
+	}
+
+	if result != nil {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("marshal result: %w", err)
+		}
+		u.Result = null.JSONFrom(b)
+	}
+
+	if err := l.jobStorer.Update(ctx, exec, u); err != nil {
+		return fmt.Errorf("job storer update: %w", err)
+	}
+
+	job.Status = StatusCompleted
+	return nil
+}
+
+// Fail persists the cause of a failed attempt. The job is retried after
+// Backoff(attempts), or marked failed once it used up max_attempts.
+func (l *Logic) Fail(ctx context.Context, exec boil.ContextExecutor, job *Job, cause error) error {
+	if cause == nil {
+		cause = errors.New("unknown error")
+	}
+
+	now := timeNow()
+	u := &UpdateJob{
+		ID:       job.ID,
+		Attempts: job.Attempts,
+		Error:    null.StringFrom(cause.Error()),
+	}
+
+	if job.Attempts >= job.MaxAttempts {
+		u.Status = null.StringFrom(StatusFailed)
+		u.CompletedAt = null.TimeFrom(now)
+	} else {
+		u.Status = null.StringFrom(StatusPending)
+		u.RunAfter = null.TimeFrom(now.Add(Backoff(job.Attempts)))
+	}
+
+	if err := l.jobStorer.Update(ctx, exec, u); err != nil {
+		return fmt.Errorf("job storer update: %w", err)
+	}
+
+	job.Status = u.Status.String
+	return nil
+}
+
+// Backoff is the delay before retrying a job that failed its attempt-th attempt.
+// It doubles from baseBackoff, up to maxBackoff.
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	d := baseBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
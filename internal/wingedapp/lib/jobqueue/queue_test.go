@@ -0,0 +1,202 @@
+package jobqueue_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/jobqueue"
+	"wingedapp/pgtester/internal/wingedapp/lib/jobqueue/store"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJobType = "test_job"
+
+type testJobPayload struct {
+	N int `json:"n"`
+}
+
+// createTestJobQueueLogic wires the job queue logic against the real stores.
+func createTestJobQueueLogic(t *testing.T) *jobqueue.Logic {
+	t.Helper()
+	l := applog.NewLogrus("test")
+	stores := store.NewJobQueueStores(l)
+
+	logic, err := jobqueue.NewLogic(l, stores.JobStore)
+	require.NoError(t, err)
+	return logic
+}
+
+type testCaseProcessNext struct {
+	name            string
+	maxAttempts     int
+	handler         func(ctx context.Context, p testJobPayload) (any, error)
+	extraAssertions func(th *testsuite.Helper, queue *jobqueue.Logic, job *jobqueue.Job)
+}
+
+func TestLogic_ProcessNext(t *testing.T) {
+	testCases := []testCaseProcessNext{
+		{
+			name: "success-persists-result",
+			handler: func(ctx context.Context, p testJobPayload) (any, error) {
+				return testJobPayload{N: p.N * 2}, nil
+			},
+			extraAssertions: func(th *testsuite.Helper, queue *jobqueue.Logic, job *jobqueue.Job) {
+				assert.Equal(th.T, jobqueue.StatusCompleted, job.Status)
+				assert.Equal(th.T, 1, job.Attempts)
+				assert.True(th.T, job.CompletedAt.Valid)
+				assert.JSONEq(th.T, `{"n":42}`, string(job.Result.JSON))
+			},
+		},
+		{
+			name: "failure-retries-with-backoff",
+			handler: func(ctx context.Context, p testJobPayload) (any, error) {
+				return nil, errors.New("boom")
+			},
+			extraAssertions: func(th *testsuite.Helper, queue *jobqueue.Logic, job *jobqueue.Job) {
+				assert.Equal(th.T, jobqueue.StatusPending, job.Status)
+				assert.Equal(th.T, 1, job.Attempts)
+				assert.Equal(th.T, "boom", job.Error.String)
+				assert.True(th.T, job.RunAfter.After(time.Now()), "retry must be delayed")
+
+				processed, err := queue.ProcessNext(context.Background(), th.BackendAppDb())
+				require.NoError(th.T, err)
+				assert.False(th.T, processed, "job must not be claimable before run_after")
+			},
+		},
+		{
+			name:        "failure-on-last-attempt-fails-job",
+			maxAttempts: 1,
+			handler: func(ctx context.Context, p testJobPayload) (any, error) {
+				return nil, errors.New("boom")
+			},
+			extraAssertions: func(th *testsuite.Helper, queue *jobqueue.Logic, job *jobqueue.Job) {
+				assert.Equal(th.T, jobqueue.StatusFailed, job.Status)
+				assert.Equal(th.T, "boom", job.Error.String)
+				assert.True(th.T, job.CompletedAt.Valid)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			testSuite := testsuite.New(t)
+			t.Cleanup(testSuite.UseBackendDB())
+
+			ctx := context.Background()
+			exec := testSuite.BackendAppDb()
+
+			queue := createTestJobQueueLogic(t)
+			require.NoError(t, jobqueue.Register(queue, testJobType, tc.handler))
+
+			enqueued, err := queue.Enqueue(ctx, exec, &jobqueue.EnqueueParams{
+				Type:        testJobType,
+				Payload:     testJobPayload{N: 21},
+				MaxAttempts: tc.maxAttempts,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, jobqueue.StatusPending, enqueued.Status)
+
+			processed, err := queue.ProcessNext(ctx, exec)
+			require.NoError(t, err)
+			require.True(t, processed)
+
+			job, err := queue.Job(ctx, exec, enqueued.ID)
+			require.NoError(t, err)
+			tc.extraAssertions(testSuite, queue, job)
+		})
+	}
+}
+
+func TestLogic_Claim(t *testing.T) {
+	t.Run("concurrent-workers-claim-each-job-once", func(t *testing.T) {
+		t.Parallel()
+		testSuite := testsuite.New(t)
+		t.Cleanup(testSuite.UseBackendDB())
+
+		ctx := context.Background()
+		exec := testSuite.BackendAppDb()
+
+		queue := createTestJobQueueLogic(t)
+		require.NoError(t, jobqueue.Register(queue, testJobType,
+			func(ctx context.Context, p testJobPayload) (any, error) { return nil, nil },
+		))
+
+		const jobs, workers = 5, 10
+		for i := 0; i < jobs; i++ {
+			_, err := queue.Enqueue(ctx, exec, &jobqueue.EnqueueParams{Type: testJobType})
+			require.NoError(t, err)
+		}
+
+		var (
+			mu      sync.Mutex
+			claimed = map[uuid.UUID]int{}
+			wg      sync.WaitGroup
+		)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				job, err := queue.Claim(ctx, exec)
+				if errors.Is(err, jobqueue.ErrNoJobAvailable) {
+					return
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+				mu.Lock()
+				claimed[job.ID]++
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, claimed, jobs)
+		for id, n := range claimed {
+			assert.Equal(t, 1, n, "job %s claimed more than once", id)
+		}
+	})
+
+	t.Run("stale-running-job-is-reclaimed-and-fenced", func(t *testing.T) {
+		t.Parallel()
+		testSuite := testsuite.New(t)
+		t.Cleanup(testSuite.UseBackendDB())
+
+		ctx := context.Background()
+		exec := testSuite.BackendAppDb()
+
+		queue := createTestJobQueueLogic(t)
+		require.NoError(t, queue.SetHeartbeat(10*time.Millisecond, 50*time.Millisecond))
+		require.NoError(t, jobqueue.Register(queue, testJobType,
+			func(ctx context.Context, p testJobPayload) (any, error) { return nil, nil },
+		))
+
+		_, err := queue.Enqueue(ctx, exec, &jobqueue.EnqueueParams{Type: testJobType})
+		require.NoError(t, err)
+
+		first, err := queue.Claim(ctx, exec)
+		require.NoError(t, err)
+
+		_, err = queue.Claim(ctx, exec)
+		require.ErrorIs(t, err, jobqueue.ErrNoJobAvailable, "a running job with a fresh heartbeat is not reclaimable")
+
+		time.Sleep(100 * time.Millisecond)
+
+		second, err := queue.Claim(ctx, exec)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, second.ID)
+		assert.Equal(t, 2, second.Attempts)
+
+		// the first worker lost the job, so it can no longer record an outcome
+		require.ErrorIs(t, queue.Complete(ctx, exec, first, nil), jobqueue.ErrJobLost)
+		require.ErrorIs(t, queue.Heartbeat(ctx, exec, first), jobqueue.ErrJobLost)
+		require.NoError(t, queue.Complete(ctx, exec, second, nil))
+	})
+}
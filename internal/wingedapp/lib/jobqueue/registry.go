@@ -0,0 +1,74 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Handler runs a claimed job. The returned result is persisted as JSON
+// on success; a returned error is persisted and the job retried.
+type Handler func(ctx context.Context, job *Job) (any, error)
+
+// RegisterHandler registers the handler of a job type.
+// A worker only claims jobs whose type has a handler.
+func (l *Logic) RegisterHandler(jobType string, h Handler) error {
+	if jobType == "" {
+		return ErrMissingType
+	}
+	if h == nil {
+		return ErrNilHandler
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.handlers[jobType]; ok {
+		return fmt.Errorf("%w: %s", ErrHandlerAlreadyRegistered, jobType)
+	}
+	l.handlers[jobType] = h
+
+	return nil
+}
+
+// Register registers a handler that receives the job payload decoded as T.
+// A payload that doesn't decode fails the attempt like any handler error.
+func Register[T any](l *Logic, jobType string, h func(ctx context.Context, payload T) (any, error)) error {
+	if h == nil {
+		return ErrNilHandler
+	}
+
+	return l.RegisterHandler(jobType, func(ctx context.Context, job *Job) (any, error) {
+		var payload T
+		if job.Payload.Valid {
+			if err := json.Unmarshal(job.Payload.JSON, &payload); err != nil {
+				return nil, fmt.Errorf("unmarshal %s payload: %w", jobType, err)
+			}
+		}
+		return h(ctx, payload)
+	})
+}
+
+// handler returns the handler of a job type.
+func (l *Logic) handler(jobType string) (Handler, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	h, ok := l.handlers[jobType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoHandler, jobType)
+	}
+	return h, nil
+}
+
+// handledTypes returns the job types with a registered handler.
+func (l *Logic) handledTypes() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	types := make([]string, 0, len(l.handlers))
+	for t := range l.handlers {
+		types = append(types, t)
+	}
+	return types
+}
@@ -0,0 +1,37 @@
+package jobqueue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/lib/jobqueue"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, jobqueue.Backoff(0))
+	assert.Equal(t, 30*time.Second, jobqueue.Backoff(1))
+	assert.Equal(t, time.Minute, jobqueue.Backoff(2))
+	assert.Equal(t, 2*time.Minute, jobqueue.Backoff(3))
+	assert.Equal(t, 30*time.Minute, jobqueue.Backoff(20), "backoff is capped")
+}
+
+func TestRegisterHandler(t *testing.T) {
+	queue := createTestJobQueueLogic(t)
+
+	noop := func(ctx context.Context, job *jobqueue.Job) (any, error) { return nil, nil }
+
+	require.ErrorIs(t, queue.RegisterHandler("", noop), jobqueue.ErrMissingType)
+	require.ErrorIs(t, queue.RegisterHandler("noop", nil), jobqueue.ErrNilHandler)
+	require.NoError(t, queue.RegisterHandler("noop", noop))
+	require.ErrorIs(t, queue.RegisterHandler("noop", noop), jobqueue.ErrHandlerAlreadyRegistered)
+
+	require.NoError(t, jobqueue.Register(queue, jobqueue.JobTypeBatchMatch,
+		func(ctx context.Context, p jobqueue.BatchMatchPayload) (any, error) { return nil, nil },
+	))
+	require.ErrorIs(t, jobqueue.Register(queue, jobqueue.JobTypeBatchMatch,
+		func(ctx context.Context, p jobqueue.BatchMatchPayload) (any, error) { return nil, nil },
+	), jobqueue.ErrHandlerAlreadyRegistered)
+}
@@ -0,0 +1,211 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/db/repo"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/jobqueue"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/google/uuid"
+)
+
+// JobStore handles reads and writes of the jobs table.
+type JobStore struct {
+	l    applog.Logger
+	repo *repo.Store
+}
+
+// Insert inserts a pending job.
+func (s *JobStore) Insert(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *jobqueue.InsertJob,
+) (*jobqueue.Job, error) {
+	job, err := s.repo.InsertJob(ctx, exec, &repo.InsertJob{
+		Type:        inserter.Type,
+		Payload:     inserter.Payload,
+		MaxAttempts: inserter.MaxAttempts,
+		RunAfter:    inserter.RunAfter,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("insert job: %w", err)
+	}
+
+	id, err := uuid.Parse(job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parse job id: %w", err)
+	}
+
+	return &jobqueue.Job{
+		ID:          id,
+		Type:        job.Type,
+		Status:      job.Status,
+		Payload:     job.Payload,
+		Result:      job.Result,
+		Error:       job.Error,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAfter:    job.RunAfter,
+		HeartbeatAt: job.HeartbeatAt,
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		CompletedAt: job.CompletedAt,
+	}, nil
+}
+
+// Jobs returns jobs matching the filter, oldest first.
+func (s *JobStore) Jobs(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	f *jobqueue.QueryFilterJob,
+) ([]jobqueue.Job, error) {
+	cols := pgmodel.JobColumns
+
+	qMods := []qm.QueryMod{
+		qm.Select(jobColumns...),
+		qm.OrderBy(cols.CreatedAt + " ASC"),
+	}
+	if f.ID.Valid {
+		qMods = append(qMods, qm.Where(cols.ID+" = ?", f.ID.String))
+	}
+	if f.Type.Valid {
+		qMods = append(qMods, qm.Where(cols.Type+" = ?", f.Type.String))
+	}
+	if f.Status.Valid {
+		qMods = append(qMods, qm.Where(cols.Status+" = ?", f.Status.String))
+	}
+
+	var jobs []jobqueue.Job
+	if err := pgmodel.Jobs(qMods...).Bind(ctx, exec, &jobs); err != nil {
+		return nil, fmt.Errorf("query jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// Claim atomically moves the next due job to running, and bumps its attempts.
+// Due jobs are pending ones past run_after, and running ones whose heartbeat
+// is older than StaleBefore. SKIP LOCKED lets concurrent workers claim
+// different jobs instead of queueing up on the same row.
+func (s *JobStore) Claim(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	c *jobqueue.ClaimJob,
+) (*jobqueue.Job, error) {
+	if len(c.Types) == 0 {
+		return nil, jobqueue.ErrNoJobAvailable
+	}
+
+	args := []any{jobqueue.StatusRunning, jobqueue.StatusPending, c.StaleBefore}
+	placeholders := make([]string, len(c.Types))
+	for i, t := range c.Types {
+		args = append(args, t)
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE jobs
+		SET status = $1,
+		    attempts = attempts + 1,
+		    started_at = NOW(),
+		    heartbeat_at = NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE type IN (%s)
+			  AND (
+			        (status = $2 AND run_after <= NOW())
+			     OR (status = $1 AND heartbeat_at < $3)
+			  )
+			ORDER BY run_after ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s`,
+		strings.Join(placeholders, ", "),
+		strings.Join(jobColumns, ", "),
+	)
+
+	var job jobqueue.Job
+	if err := queries.Raw(query, args...).Bind(ctx, exec, &job); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, jobqueue.ErrNoJobAvailable
+		}
+		return nil, fmt.Errorf("claim job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// Update updates a running job, fenced on its attempts, so a worker whose
+// job got reclaimed can't overwrite the new owner's progress.
+// Returns jobqueue.ErrJobLost if the fence doesn't match.
+func (s *JobStore) Update(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	u *jobqueue.UpdateJob,
+) error {
+	cols := pgmodel.JobColumns
+
+	updateMap := pgmodel.M{}
+	if u.Status.Valid {
+		updateMap[cols.Status] = u.Status.String
+	}
+	if u.Result.Valid {
+		updateMap[cols.Result] = u.Result
+	}
+	if u.Error.Valid {
+		updateMap[cols.Error] = u.Error.String
+	}
+	if u.RunAfter.Valid {
+		updateMap[cols.RunAfter] = u.RunAfter.Time
+	}
+	if u.CompletedAt.Valid {
+		updateMap[cols.CompletedAt] = u.CompletedAt.Time
+	}
+	if u.HeartbeatAt.Valid {
+		updateMap[cols.HeartbeatAt] = u.HeartbeatAt.Time
+	}
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	affected, err := pgmodel.Jobs(
+		qm.Where(cols.ID+" = ?", u.ID.String()),
+		qm.Where(cols.Attempts+" = ?", u.Attempts),
+		qm.Where(cols.Status+" = ?", jobqueue.StatusRunning),
+	).UpdateAll(ctx, exec, updateMap)
+	if err != nil {
+		return fmt.Errorf("update job: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("job %s attempt %d: %w", u.ID, u.Attempts, jobqueue.ErrJobLost)
+	}
+
+	return nil
+}
+
+// jobColumns are the columns bound onto jobqueue.Job.
+var jobColumns = []string{
+	pgmodel.JobColumns.ID,
+	pgmodel.JobColumns.Type,
+	pgmodel.JobColumns.Status,
+	pgmodel.JobColumns.Payload,
+	pgmodel.JobColumns.Result,
+	pgmodel.JobColumns.Error,
+	pgmodel.JobColumns.Attempts,
+	pgmodel.JobColumns.MaxAttempts,
+	pgmodel.JobColumns.RunAfter,
+	pgmodel.JobColumns.HeartbeatAt,
+	pgmodel.JobColumns.CreatedAt,
+	pgmodel.JobColumns.StartedAt,
+	pgmodel.JobColumns.CompletedAt,
+}
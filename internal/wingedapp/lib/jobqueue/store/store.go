@@ -0,0 +1,17 @@
+package store
+
+import (
+	"wingedapp/pgtester/internal/wingedapp/db/repo"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+)
+
+type JobQueueStores struct {
+	JobStore *JobStore
+}
+
+func NewJobQueueStores(l applog.Logger) *JobQueueStores {
+	r := &repo.Store{}
+	return &JobQueueStores{
+		JobStore: &JobStore{l, r},
+	}
+}
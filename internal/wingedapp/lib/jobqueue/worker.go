@@ -0,0 +1,132 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// ProcessNext claims and runs one job. It returns false when no job was due.
+//
+// exec must not be a transaction: the claim, heartbeats and outcome have
+// to be visible to other workers while the handler runs.
+func (l *Logic) ProcessNext(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	job, err := l.Claim(ctx, exec)
+	if errors.Is(err, ErrNoJobAvailable) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("claim: %w", err)
+	}
+
+	// a reclaimed job whose worker died on its last attempt
+	if job.Attempts > job.MaxAttempts {
+		if err = l.Fail(ctx, exec, job, errors.New("abandoned: heartbeat went stale on final attempt")); err != nil {
+			return true, fmt.Errorf("fail abandoned job %s: %w", job.ID, err)
+		}
+		return true, nil
+	}
+
+	result, runErr := l.run(ctx, exec, job)
+	if errors.Is(runErr, ErrJobLost) {
+		// another worker owns it now, its outcome is theirs to record
+		return true, nil
+	}
+
+	// record the outcome even when ctx was cancelled, so a job drained on
+	// shutdown isn't left claimed until its heartbeat goes stale
+	outcomeCtx := context.WithoutCancel(ctx)
+	if runErr != nil {
+		if err = l.Fail(outcomeCtx, exec, job, runErr); err != nil {
+			return true, fmt.Errorf("fail job %s: %w", job.ID, err)
+		}
+		return true, nil
+	}
+
+	if err = l.Complete(outcomeCtx, exec, job, result); err != nil {
+		return true, fmt.Errorf("complete job %s: %w", job.ID, err)
+	}
+
+	return true, nil
+}
+
+// run runs the job handler while heartbeating. If the job gets reclaimed
+// the handler context is cancelled, and ErrJobLost returned.
+func (l *Logic) run(ctx context.Context, exec boil.ContextExecutor, job *Job) (any, error) {
+	h, err := l.handler(job.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lost := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(l.heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				if err := l.Heartbeat(runCtx, exec, job); err != nil {
+					if errors.Is(err, ErrJobLost) {
+						close(lost)
+						cancel()
+						return
+					}
+					l.logger.Warn(runCtx, "job heartbeat failed",
+						applog.F("job_id", job.ID.String()),
+						applog.F("error", err.Error()),
+					)
+				}
+			}
+		}
+	}()
+
+	result, err := h(runCtx, job)
+	cancel()
+	<-done
+
+	select {
+	case <-lost:
+		return nil, ErrJobLost
+	default:
+	}
+
+	return result, err
+}
+
+// Work processes jobs until ctx is cancelled, polling every pollInterval
+// while the queue is empty.
+func (l *Logic) Work(ctx context.Context, exec boil.ContextExecutor, pollInterval time.Duration) error {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	for {
+		processed, err := l.ProcessNext(ctx, exec)
+		if err != nil {
+			l.logger.Error(ctx, "process job", err)
+		}
+
+		if processed && err == nil {
+			continue // drain the queue before sleeping
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -2,6 +2,7 @@ package matching
 
 import (
 	"context"
+	"time"
 
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
@@ -40,6 +41,10 @@ type configStorer interface {
 	Configs(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterMatchConfig) ([]Config, error)
 	Config(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterMatchConfig) (*Config, error)
 	Update(ctx context.Context, exec boil.ContextExecutor, updater *UpdateMatchConfig) (*Config, error)
+	ConfigVersions(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterMatchConfigVersion) ([]ConfigVersion, error)
+	ConfigVersion(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterMatchConfigVersion) (*ConfigVersion, error)
+	InsertVersion(ctx context.Context, exec boil.ContextExecutor, rolledBackFrom null.Int) (*ConfigVersion, error)
+	Restore(ctx context.Context, exec boil.ContextExecutor, version int) error
 }
 
 // matchSetStorer is the interface to store and retrieve match sets.
@@ -48,6 +53,8 @@ type matchSetStorer interface {
 	MatchSet(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterMatchSet) (*MatchSet, error)
 	Insert(ctx context.Context, exec boil.ContextExecutor, inserter *InsertMatchSet) (*MatchSet, error)
 	Update(ctx context.Context, exec boil.ContextExecutor, updater *UpdateMatchSet) error
+	StartIngestionRun(ctx context.Context, exec boil.ContextExecutor, matchSetID uuid.UUID, startedAt time.Time) (*IngestionRun, error)
+	FinishIngestionRun(ctx context.Context, exec boil.ContextExecutor, finisher *FinishIngestionRun) error
 }
 
 // matchResultStorer is the interface to store and retrieve match participants.
@@ -55,7 +62,11 @@ type matchResultStorer interface {
 	MatchResults(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterMatchResult) (*MatchResultPaginated, error)
 	MatchResult(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterMatchResult) (*MatchResult, error)
 	Insert(ctx context.Context, exec boil.ContextExecutor, inserter *InsertMatchResult) (*MatchResult, error)
+	InsertBatch(ctx context.Context, exec boil.ContextExecutor, inserters []InsertMatchResult) (int64, error)
 	Update(ctx context.Context, exec boil.ContextExecutor, inserter *UpdateMatchResult) (*MatchResult, error)
+	ExpireDropped(ctx context.Context, exec boil.ContextExecutor, asOf time.Time) ([]ExpiredMatch, error)
+	DropRuns(ctx context.Context, exec boil.ContextExecutor, slots []DropSlot) ([]DropSlot, error)
+	InsertDropRuns(ctx context.Context, exec boil.ContextExecutor, slots []DropSlot) error
 }
 
 type QualitativeMatchRequest = ProfileData
@@ -67,6 +78,14 @@ type qualitativeQuantifier interface {
 	Qualify(ctx context.Context, req *QualitativeMatchRequest) (*MatchCompatibilityResult, error)
 }
 
+// qualitativeCacher caches qualitative results between match sets.
+type qualitativeCacher interface {
+	// QualitativeCache returns an unexpired result, or ErrQualitativeCacheMiss.
+	QualitativeCache(ctx context.Context, exec boil.ContextExecutor, key *QualitativeCacheKey) (*MatchCompatibilityResult, error)
+	// Upsert stores a result, replacing any entry for the pair and matcher version.
+	Upsert(ctx context.Context, exec boil.ContextExecutor, inserter *InsertQualitativeCache) error
+}
+
 // userMatchActionsStorer handles user-facing match data access (CRUD only).
 type userMatchActionsStorer interface {
 	UserMatches(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterUserMatch) ([]UserMatch, error)
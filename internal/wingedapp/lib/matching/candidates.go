@@ -0,0 +1,223 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+// kmPerDegree is under the km per degree of latitude the haversine lib
+// works with (~111.19), so grid cells err on the wide side.
+const kmPerDegree = 110.0
+
+// CandidatePairs returns the pairs of users that can pass the distance and
+// dating preference qualifiers, a cheap prefilter before the hard qualifiers.
+//
+// Users are bucketed into a lat/lon grid whose cells are at least
+// maxDistanceKM wide, so each user is only compared with users in
+// neighbouring cells rather than with every other user. maxDistanceKM <= 0
+// skips the distance check, and so does matchDatePrefs = false for dating
+// preferences. Users missing what a check needs are left out, as the
+// matching qualifier would reject all their pairs.
+func CandidatePairs(users []User, maxDistanceKM float64, matchDatePrefs bool) UserPairs {
+	if matchDatePrefs {
+		users = usersWithDatePrefs(users)
+	}
+
+	compatible := func(a, b *User) bool {
+		return a.ID != b.ID && (!matchDatePrefs || datePrefsCompatible(a, b))
+	}
+
+	if maxDistanceKM <= 0 {
+		pairs := make(UserPairs, 0)
+		for i := range users {
+			for j := i + 1; j < len(users); j++ {
+				if compatible(&users[i], &users[j]) {
+					pairs = append(pairs, UserPair{UserA: &users[i], UserB: &users[j]})
+				}
+			}
+		}
+		return pairs.dedupedPermutation()
+	}
+
+	grid := newSpatialGrid(maxDistanceKM)
+	for i := range users {
+		if users[i].Latitude.Valid && users[i].Longitude.Valid {
+			grid.add(i, users[i].Latitude.Float64, users[i].Longitude.Float64)
+		}
+	}
+
+	pairs := make(UserPairs, 0)
+	for i := range users {
+		if !users[i].Latitude.Valid || !users[i].Longitude.Valid {
+			continue
+		}
+		for _, j := range grid.near(users[i].Latitude.Float64, users[i].Longitude.Float64) {
+			// j > i emits each pair once
+			if j > i && compatible(&users[i], &users[j]) {
+				pairs = append(pairs, UserPair{UserA: &users[i], UserB: &users[j]})
+			}
+		}
+	}
+
+	return pairs.dedupedPermutation()
+}
+
+// maxDistanceKM returns the widest radius the distance qualifier accepts.
+// The qualifier truncates distances for the adaptive rings, so allow 1km over.
+func maxDistanceKM(cfg *Config) float64 {
+	maxKM := cfg.LocationRadiusKM
+	for _, r := range cfg.LocationAdaptiveExpansion {
+		maxKM = math.Max(maxKM, float64(r)+1)
+	}
+	return maxKM
+}
+
+func usersWithDatePrefs(users []User) []User {
+	filtered := make([]User, 0, len(users))
+	for _, u := range users {
+		if u.Gender.Valid && len(u.DatingPreferences) > 0 {
+			filtered = append(filtered, u)
+		}
+	}
+	return filtered
+}
+
+// datePrefsCompatible reports whether each user's dating preferences include
+// the other's gender, as the dating preference qualifier checks.
+func datePrefsCompatible(a, b *User) bool {
+	return datingPrefsHash(a.DatingPreferences)[b.Gender.String] &&
+		datingPrefsHash(b.DatingPreferences)[a.Gender.String]
+}
+
+// gridCell is a cell of a spatialGrid, a latitude band and a longitude slot in it.
+type gridCell struct {
+	band, slot int
+}
+
+// spatialGrid buckets points into cells at least radiusKM wide in both
+// directions, so every point within radiusKM of another lies in the other's
+// cell or one of its 8 neighbours. Cells get wider in longitude towards the
+// poles, and each band's slots divide 360 degrees evenly, so the neighbours
+// wrap around the antimeridian.
+type spatialGrid struct {
+	bandDeg float64 // band height, in degrees of latitude
+	cells   map[gridCell][]int
+}
+
+func newSpatialGrid(radiusKM float64) *spatialGrid {
+	return &spatialGrid{
+		bandDeg: radiusKM / kmPerDegree,
+		cells:   make(map[gridCell][]int),
+	}
+}
+
+func (g *spatialGrid) band(lat float64) int {
+	return int(math.Floor(lat / g.bandDeg))
+}
+
+// slots returns how many longitude slots a band has. A slot spans the radius
+// one band poleward of the band, as a neighbour can be up to there, and a
+// degree of longitude gets shorter towards the poles.
+func (g *spatialGrid) slots(band int) int {
+	edge := math.Max(math.Abs(float64(band)*g.bandDeg), math.Abs(float64(band+1)*g.bandDeg)) + g.bandDeg
+	if edge >= 90 {
+		return 1
+	}
+
+	slotDeg := g.bandDeg / math.Cos(edge*math.Pi/180)
+	return max(1, int(math.Floor(360/slotDeg)))
+}
+
+func (g *spatialGrid) slot(band int, lon float64) int {
+	n := g.slots(band)
+	s := int(math.Floor((lon + 180) / 360 * float64(n)))
+	return ((s % n) + n) % n
+}
+
+func (g *spatialGrid) add(i int, lat, lon float64) {
+	b := g.band(lat)
+	c := gridCell{b, g.slot(b, lon)}
+	g.cells[c] = append(g.cells[c], i)
+}
+
+// near returns the points in the cell of (lat, lon) and its neighbours.
+func (g *spatialGrid) near(lat, lon float64) []int {
+	var found []int
+
+	b := g.band(lat)
+	for band := b - 1; band <= b+1; band++ {
+		n := g.slots(band)
+		s := g.slot(band, lon)
+
+		seen := make(map[int]bool, 3)
+		for _, d := range []int{-1, 0, 1} {
+			slot := ((s+d)%n + n) % n
+			if seen[slot] { // bands with < 3 slots
+				continue
+			}
+			seen[slot] = true
+			found = append(found, g.cells[gridCell{band, slot}]...)
+		}
+	}
+
+	return found
+}
+
+// candidatePairs returns the pairs ingestion creates match results for.
+// With match_config.candidate_prefilter on, that's the CandidatePairs that the
+// enabled distance and dating preference qualifiers can pass, else every pair.
+func (l *Logic) candidatePairs(ctx context.Context, exec boil.ContextExecutor, cfg *Config, users []User) (UserPairs, error) {
+	if !cfg.CandidatePrefilter {
+		return UserPairsUniqPerm(users), nil
+	}
+
+	var (
+		maxKM     float64
+		datePrefs bool
+	)
+	enabled := l.qualifierRegistry.enabledTypes(cfg.Qualifiers)
+	if enabled[distanceQualifier] {
+		maxKM = maxDistanceKM(cfg)
+	}
+	if enabled[datePrefsQualifier] {
+		datePrefs = true
+		if err := l.enrichUsersDatingPrefs(ctx, exec, users); err != nil {
+			return nil, fmt.Errorf("enrich dating prefs: %w", err)
+		}
+	}
+
+	return CandidatePairs(users, maxKM, datePrefs), nil
+}
+
+// enrichUsersDatingPrefs loads the dating preferences of all users in one query.
+func (l *Logic) enrichUsersDatingPrefs(ctx context.Context, exec boil.ContextExecutor, users []User) error {
+	if len(users) == 0 {
+		return nil // an empty filter would load everyone's
+	}
+
+	userIDs := make([]string, len(users))
+	for i := range users {
+		userIDs[i] = users[i].ID.String()
+	}
+
+	prefs, err := l.userDatingPreferenceStorer.UserDatingPreferences(ctx, exec, &QueryFilterUserDatingPrefs{
+		UserIDs: userIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("user dating prefs storer: %w", err)
+	}
+
+	byUser := make(map[uuid.UUID][]UserDatingPreference, len(users))
+	for _, p := range prefs {
+		byUser[p.UserID] = append(byUser[p.UserID], p)
+	}
+	for i := range users {
+		users[i].DatingPreferences = byUser[users[i].ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package matching_test
+
+import (
+	"math/rand"
+	"testing"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+
+	"github.com/aarondl/null/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/umahmood/haversine"
+)
+
+func candidateUser(gender string, lat, lon float64, datesGenders ...string) matching.User {
+	u := matching.User{
+		ID:        uuid.New(),
+		Gender:    null.StringFrom(gender),
+		Latitude:  null.Float64From(lat),
+		Longitude: null.Float64From(lon),
+	}
+	for _, g := range datesGenders {
+		u.DatingPreferences = append(u.DatingPreferences, matching.UserDatingPreference{
+			UserID:           u.ID,
+			DatingPreference: g,
+		})
+	}
+	return u
+}
+
+func pairKeys(pairs matching.UserPairs) map[[2]uuid.UUID]bool {
+	keys := make(map[[2]uuid.UUID]bool, len(pairs))
+	for _, p := range pairs {
+		a, b := p.UserA.ID, p.UserB.ID
+		if a.String() > b.String() {
+			a, b = b, a
+		}
+		keys[[2]uuid.UUID{a, b}] = true
+	}
+	return keys
+}
+
+func TestCandidatePairs(t *testing.T) {
+	type testCase struct {
+		name           string
+		users          []matching.User
+		maxDistanceKM  float64
+		matchDatePrefs bool
+		expectedPairs  int
+	}
+
+	testCases := []testCase{
+		{
+			name: "nearby-pair-included",
+			users: []matching.User{
+				candidateUser("Male", 40.71, -74.00),
+				candidateUser("Female", 40.73, -73.99),
+			},
+			maxDistanceKM: 50,
+			expectedPairs: 1,
+		},
+		{
+			name: "far-pair-excluded",
+			users: []matching.User{
+				candidateUser("Male", 40.71, -74.00),    // new york
+				candidateUser("Female", 34.05, -118.24), // los angeles
+			},
+			maxDistanceKM: 50,
+			expectedPairs: 0,
+		},
+		{
+			name: "pair-across-antimeridian-included",
+			users: []matching.User{
+				candidateUser("Male", 0, 179.9),
+				candidateUser("Female", 0, -179.9),
+			},
+			maxDistanceKM: 50,
+			expectedPairs: 1,
+		},
+		{
+			name: "pair-near-pole-included",
+			users: []matching.User{
+				candidateUser("Male", 89.9, 0),
+				candidateUser("Female", 89.9, 180),
+			},
+			maxDistanceKM: 50,
+			expectedPairs: 1,
+		},
+		{
+			name: "missing-location-excluded",
+			users: []matching.User{
+				candidateUser("Male", 40.71, -74.00),
+				{ID: uuid.New(), Gender: null.StringFrom("Female")},
+			},
+			maxDistanceKM: 50,
+			expectedPairs: 0,
+		},
+		{
+			name: "no-distance-check-pairs-everyone",
+			users: []matching.User{
+				candidateUser("Male", 40.71, -74.00),
+				candidateUser("Female", 34.05, -118.24),
+				{ID: uuid.New(), Gender: null.StringFrom("Female")},
+			},
+			expectedPairs: 3,
+		},
+		{
+			name: "compatible-dating-prefs-included",
+			users: []matching.User{
+				candidateUser("Male", 40.71, -74.00, "Female"),
+				candidateUser("Female", 40.73, -73.99, "Male", "Female"),
+			},
+			maxDistanceKM:  50,
+			matchDatePrefs: true,
+			expectedPairs:  1,
+		},
+		{
+			name: "one-sided-dating-prefs-excluded",
+			users: []matching.User{
+				candidateUser("Male", 40.71, -74.00, "Female"),
+				candidateUser("Female", 40.73, -73.99, "Female"),
+			},
+			maxDistanceKM:  50,
+			matchDatePrefs: true,
+			expectedPairs:  0,
+		},
+		{
+			name: "missing-dating-prefs-excluded",
+			users: []matching.User{
+				candidateUser("Male", 40.71, -74.00, "Female"),
+				candidateUser("Female", 40.73, -73.99),
+			},
+			matchDatePrefs: true,
+			expectedPairs:  0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pairs := matching.CandidatePairs(tc.users, tc.maxDistanceKM, tc.matchDatePrefs)
+			assert.Len(t, pairs, tc.expectedPairs)
+		})
+	}
+}
+
+// TestCandidatePairs_NoPairWithinRadiusMissed checks the grid against brute
+// force, on points clustered enough to land next to cell edges.
+func TestCandidatePairs_NoPairWithinRadiusMissed(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, radiusKM := range []float64{5, 50, 300} {
+		users := make([]matching.User, 0, 600)
+		for range 600 {
+			lat := rng.Float64()*170 - 85
+			if rng.Intn(3) == 0 { // crowd the poles, where cells are widest
+				lat = 85 + rng.Float64()*5
+			}
+			users = append(users, candidateUser("Female", lat, rng.Float64()*360-180))
+		}
+
+		got := pairKeys(matching.CandidatePairs(users, radiusKM, false))
+
+		for i := range users {
+			for j := i + 1; j < len(users); j++ {
+				a := haversine.Coord{Lat: users[i].Latitude.Float64, Lon: users[i].Longitude.Float64}
+				b := haversine.Coord{Lat: users[j].Latitude.Float64, Lon: users[j].Longitude.Float64}
+				if _, km := haversine.Distance(a, b); km > radiusKM {
+					continue
+				}
+
+				want := pairKeys(matching.UserPairs{{UserA: &users[i], UserB: &users[j]}})
+				for k := range want {
+					assert.True(t, got[k], "radius %vkm: pair %v-%v within radius is missing", radiusKM, a, b)
+				}
+			}
+		}
+	}
+}
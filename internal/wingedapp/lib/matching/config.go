@@ -2,26 +2,313 @@ package matching
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
 
+	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
 )
 
-// UpdateConfig updates the match configuration with the provided fields.
+// UpdateConfig updates the match configuration with the provided fields,
+// and records the result as a new config version. It runs in tx, so the
+// update is only kept with its version.
 func (l *Logic) UpdateConfig(
 	ctx context.Context,
-	exec boil.ContextExecutor,
+	tx boil.ContextTransactor,
 	updater *UpdateMatchConfig,
 ) (*Config, error) {
 	// Validate the update request
 	if err := updater.Validate(); err != nil {
 		return nil, err
 	}
+	if updater.Qualifiers != nil {
+		if err := l.qualifierRegistry.validate(*updater.Qualifiers); err != nil {
+			return nil, err
+		}
+	}
 
-	config, err := l.configStorer.Update(ctx, exec, updater)
+	config, err := l.configStorer.Update(ctx, tx, updater)
 	if err != nil {
 		return nil, fmt.Errorf("update config: %w", err)
 	}
 
+	if _, err = l.configStorer.InsertVersion(ctx, tx, null.Int{}); err != nil {
+		return nil, fmt.Errorf("insert config version: %w", err)
+	}
+
 	return config, nil
 }
+
+// ConfigVersions returns the match config versions, newest first.
+func (l *Logic) ConfigVersions(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	f *QueryFilterMatchConfigVersion,
+) ([]ConfigVersion, error) {
+	versions, err := l.configStorer.ConfigVersions(ctx, exec, f)
+	if err != nil {
+		return nil, fmt.Errorf("config versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// DiffConfigVersions returns the config fields that differ between two versions.
+func (l *Logic) DiffConfigVersions(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	from, to int,
+) (*ConfigDiff, error) {
+	fromVersion, err := l.configVersion(ctx, exec, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := l.configVersion(ctx, exec, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := diffConfigs(&fromVersion.Config, &toVersion.Config)
+	if err != nil {
+		return nil, fmt.Errorf("diff config versions %d and %d: %w", from, to, err)
+	}
+
+	return &ConfigDiff{From: from, To: to, Changes: changes}, nil
+}
+
+// RollbackConfig restores the live match config to an earlier version, and
+// records it as a new version, so the rollback shows in the history and can
+// itself be rolled back. Match sets created before keep their version.
+// It runs in tx, so the restore is only kept with its version.
+func (l *Logic) RollbackConfig(
+	ctx context.Context,
+	tx boil.ContextTransactor,
+	version int,
+) (*ConfigVersion, error) {
+	if _, err := l.configVersion(ctx, tx, version); err != nil {
+		return nil, err
+	}
+
+	if err := l.configStorer.Restore(ctx, tx, version); err != nil {
+		return nil, fmt.Errorf("restore config: %w", err)
+	}
+
+	restored, err := l.configStorer.InsertVersion(ctx, tx, null.IntFrom(version))
+	if err != nil {
+		return nil, fmt.Errorf("insert config version: %w", err)
+	}
+
+	return restored, nil
+}
+
+// configVersion returns a config version by number.
+func (l *Logic) configVersion(ctx context.Context, exec boil.ContextExecutor, version int) (*ConfigVersion, error) {
+	v, err := l.configStorer.ConfigVersion(ctx, exec, &QueryFilterMatchConfigVersion{
+		Version: null.IntFrom(version),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("config version %d: %w", version, err)
+	}
+
+	return v, nil
+}
+
+// currentConfigVersion returns the config version of the live config,
+// recording a new version if the config was changed without one, e.g. by
+// hand in the database.
+func (l *Logic) currentConfigVersion(ctx context.Context, exec boil.ContextExecutor, config *Config) (*ConfigVersion, error) {
+	latest, err := l.configStorer.ConfigVersion(ctx, exec, &QueryFilterMatchConfigVersion{Latest: true})
+	if err != nil && !errors.Is(err, ErrConfigVersionNotFound) {
+		return nil, fmt.Errorf("latest config version: %w", err)
+	}
+
+	if latest != nil {
+		changes, err := diffConfigs(&latest.Config, config)
+		if err != nil {
+			return nil, fmt.Errorf("diff live config: %w", err)
+		}
+		if len(changes) == 0 {
+			return latest, nil
+		}
+	}
+
+	v, err := l.configStorer.InsertVersion(ctx, exec, null.Int{})
+	if err != nil {
+		return nil, fmt.Errorf("insert config version: %w", err)
+	}
+
+	return v, nil
+}
+
+// matchSetConfig returns the config the match set's pairs are qualified
+// against: the version the set was created with, or the live config for
+// sets created before config versions.
+func (l *Logic) matchSetConfig(ctx context.Context, exec boil.ContextExecutor, matchSetID uuid.UUID) (*Config, error) {
+	v, err := l.matchSetConfigVersion(ctx, exec, matchSetID)
+	if err != nil {
+		return nil, err
+	}
+	if v != nil {
+		return &v.Config, nil
+	}
+
+	config, err := l.configStorer.Config(ctx, exec, nil)
+	if err != nil {
+		return nil, fmt.Errorf("live config: %w", err)
+	}
+	return config, nil
+}
+
+// matchSetConfigVersion returns the config version the match set was created
+// with, nil for sets created before config versions.
+func (l *Logic) matchSetConfigVersion(ctx context.Context, exec boil.ContextExecutor, matchSetID uuid.UUID) (*ConfigVersion, error) {
+	matchSet, err := l.matchSetStorer.MatchSet(ctx, exec, &QueryFilterMatchSet{
+		ID: null.StringFrom(matchSetID.String()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("match set %s: %w", matchSetID, err)
+	}
+	if !matchSet.ConfigVersionID.Valid {
+		return nil, nil
+	}
+
+	v, err := l.configStorer.ConfigVersion(ctx, exec, &QueryFilterMatchConfigVersion{
+		ID: null.StringFrom(matchSet.ConfigVersionID.UUID.String()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("config version of match set %s: %w", matchSetID, err)
+	}
+
+	return v, nil
+}
+
+// diffConfigs returns the fields that differ between two configs, by JSON
+// name, in alphabetical order. IDs are ignored.
+func diffConfigs(from, to *Config) ([]ConfigChange, error) {
+	fromFields, err := configFields(from)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := configFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]ConfigChange, 0)
+	for _, field := range slices.Sorted(maps.Keys(fromFields)) {
+		if reflect.DeepEqual(fromFields[field], toFields[field]) {
+			continue
+		}
+		changes = append(changes, ConfigChange{
+			Field: field,
+			From:  fromFields[field],
+			To:    toFields[field],
+		})
+	}
+
+	return changes, nil
+}
+
+// configFields returns the config's fields by JSON name, without its ID.
+func configFields(config *Config) (map[string]any, error) {
+	b, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(b, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshal config: %w", err)
+	}
+	delete(fields, "id")
+
+	return fields, nil
+}
+
+// ApplyTo returns the config with the update's set fields applied. It's the
+// one mapping of update fields to config fields, config stores persist its
+// result.
+func (u *UpdateMatchConfig) ApplyTo(config Config) Config {
+	if u.AgeRangeStart.Valid {
+		config.AgeRangeStart = u.AgeRangeStart.Int
+	}
+	if u.AgeRangeEnd.Valid {
+		config.AgeRangeEnd = u.AgeRangeEnd.Int
+	}
+	if u.AgeRangeWomanOlderBy.Valid {
+		config.AgeRangeWomanOlderBy = u.AgeRangeWomanOlderBy.Int
+	}
+	if u.AgeRangeManOlderBy.Valid {
+		config.AgeRangeManOlderBy = u.AgeRangeManOlderBy.Int
+	}
+	if u.HeightMaleGreaterByCM.Valid {
+		config.HeightMaleGreaterByCM = u.HeightMaleGreaterByCM.Float64
+	}
+	if u.LocationRadiusKM.Valid {
+		config.LocationRadiusKM = u.LocationRadiusKM.Float64
+	}
+	if u.LocationAdaptiveExpansion != nil {
+		config.LocationAdaptiveExpansion = *u.LocationAdaptiveExpansion
+	}
+	if u.DropHours != nil {
+		config.DropHours = *u.DropHours
+	}
+	if u.DropHoursUTC != nil {
+		config.DropHoursUTC = *u.DropHoursUTC
+	}
+	if u.StaleChatNudge.Valid {
+		config.StaleChatNudge = u.StaleChatNudge.Int
+	}
+	if u.StaleChatAgentSetup.Valid {
+		config.StaleChatAgentSetup = u.StaleChatAgentSetup.Int
+	}
+	if u.MatchExpirationHours.Valid {
+		config.MatchExpirationHours = u.MatchExpirationHours.Int
+	}
+	if u.MatchBlockDeclined.Valid {
+		config.MatchBlockDeclined = u.MatchBlockDeclined.Int
+	}
+	if u.MatchBlockIgnored.Valid {
+		config.MatchBlockIgnored = u.MatchBlockIgnored.Int
+	}
+	if u.MatchBlockClosed.Valid {
+		config.MatchBlockClosed = u.MatchBlockClosed.Int
+	}
+	if u.ScoreRangeStart.Valid {
+		config.ScoreRangeStart = u.ScoreRangeStart.Float64
+	}
+	if u.ScoreRangeEnd.Valid {
+		config.ScoreRangeEnd = u.ScoreRangeEnd.Float64
+	}
+	if u.Qualifiers != nil {
+		config.Qualifiers = *u.Qualifiers
+	}
+	if u.ScoreWeightDistance.Valid {
+		config.ScoreWeightDistance = u.ScoreWeightDistance.Float64
+	}
+	if u.ScoreWeightAge.Valid {
+		config.ScoreWeightAge = u.ScoreWeightAge.Float64
+	}
+	if u.ScoreWeightHeight.Valid {
+		config.ScoreWeightHeight = u.ScoreWeightHeight.Float64
+	}
+	if u.ScoreWeightTraits.Valid {
+		config.ScoreWeightTraits = u.ScoreWeightTraits.Float64
+	}
+	if u.ScoreWeightAI.Valid {
+		config.ScoreWeightAI = u.ScoreWeightAI.Float64
+	}
+	if u.QualitativeCacheTTLHours.Valid {
+		config.QualitativeCacheTTLHours = u.QualitativeCacheTTLHours.Int
+	}
+	if u.CandidatePrefilter.Valid {
+		config.CandidatePrefilter = u.CandidatePrefilter.Bool
+	}
+
+	return config
+}
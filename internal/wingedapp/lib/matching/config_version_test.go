@@ -0,0 +1,219 @@
+package matching_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"wingedapp/pgtester/internal/db/factory"
+	wingedFactory "wingedapp/pgtester/internal/wingedapp/db/factory"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching/testhelper"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/null/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// latestConfigVersion returns the newest match config version.
+func latestConfigVersion(th *testsuite.Helper, matchLib *matching.Logic) *matching.ConfigVersion {
+	th.T.Helper()
+	versions, err := matchLib.ConfigVersions(context.Background(), th.BackendAppDb(),
+		&matching.QueryFilterMatchConfigVersion{Latest: true})
+	require.NoError(th.T, err, "fetching latest config version")
+	require.Len(th.T, versions, 1, "a config version should exist")
+	return &versions[0]
+}
+
+// updateMatchExpiration updates the match config's expiration, returning the version it recorded.
+func updateMatchExpiration(th *testsuite.Helper, matchLib *matching.Logic, hours int) *matching.ConfigVersion {
+	th.T.Helper()
+	_, err := testhelper.UpdateConfig(context.Background(), th.BackendAppDb(), matchLib, &matching.UpdateMatchConfig{
+		MatchExpirationHours: null.IntFrom(hours),
+	})
+	require.NoError(th.T, err, "updating match expiration")
+	return latestConfigVersion(th, matchLib)
+}
+
+type testCaseConfigVersions struct {
+	name            string
+	extraAssertions func(th *testsuite.Helper, matchLib *matching.Logic)
+}
+
+func TestLogic_ConfigVersions(t *testing.T) {
+	testCases := []testCaseConfigVersions{
+		{
+			name: "success-update-records-version",
+			extraAssertions: func(th *testsuite.Helper, matchLib *matching.Logic) {
+				first := updateMatchExpiration(th, matchLib, 48)
+				second := updateMatchExpiration(th, matchLib, 96)
+
+				assert.Equal(th.T, first.Version+1, second.Version)
+				assert.Equal(th.T, 48, first.Config.MatchExpirationHours, "a version is immutable")
+				assert.Equal(th.T, 96, second.Config.MatchExpirationHours)
+				assert.False(th.T, second.RolledBackFrom.Valid)
+			},
+		},
+		{
+			name: "success-diff-lists-changed-fields",
+			extraAssertions: func(th *testsuite.Helper, matchLib *matching.Logic) {
+				ctx := context.Background()
+				from := updateMatchExpiration(th, matchLib, 48)
+
+				_, err := testhelper.UpdateConfig(ctx, th.BackendAppDb(), matchLib, &matching.UpdateMatchConfig{
+					MatchExpirationHours: null.IntFrom(96),
+					LocationRadiusKM:     null.Float64From(150),
+				})
+				require.NoError(th.T, err, "updating config")
+				to := latestConfigVersion(th, matchLib)
+
+				diff, err := matchLib.DiffConfigVersions(ctx, th.BackendAppDb(), from.Version, to.Version)
+				require.NoError(th.T, err)
+				assert.Equal(th.T, []matching.ConfigChange{
+					{Field: "location_radius_km", From: from.Config.LocationRadiusKM, To: float64(150)},
+					{Field: "match_expiration_hours", From: float64(48), To: float64(96)},
+				}, diff.Changes)
+
+				diff, err = matchLib.DiffConfigVersions(ctx, th.BackendAppDb(), to.Version, to.Version)
+				require.NoError(th.T, err)
+				assert.Empty(th.T, diff.Changes)
+			},
+		},
+		{
+			name: "success-rollback-restores-and-records-version",
+			extraAssertions: func(th *testsuite.Helper, matchLib *matching.Logic) {
+				ctx := context.Background()
+				target := updateMatchExpiration(th, matchLib, 48)
+				latest := updateMatchExpiration(th, matchLib, 96)
+
+				restored, err := testhelper.RollbackConfig(ctx, th.BackendAppDb(), matchLib, target.Version)
+				require.NoError(th.T, err)
+				assert.Equal(th.T, latest.Version+1, restored.Version)
+				assert.Equal(th.T, null.IntFrom(target.Version), restored.RolledBackFrom)
+				assert.Equal(th.T, 48, restored.Config.MatchExpirationHours)
+
+				live, err := matchLib.Config(ctx, th.BackendAppDb(), nil)
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 48, live.MatchExpirationHours, "the live config is restored")
+			},
+		},
+		{
+			name: "success-concurrent-updates-record-distinct-versions",
+			extraAssertions: func(th *testsuite.Helper, matchLib *matching.Logic) {
+				ctx := context.Background()
+				before := updateMatchExpiration(th, matchLib, 48)
+
+				const updates = 5
+				errs := make(chan error, updates)
+				var wg sync.WaitGroup
+				for i := range updates {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						_, err := testhelper.UpdateConfig(ctx, th.BackendAppDb(), matchLib, &matching.UpdateMatchConfig{
+							MatchExpirationHours: null.IntFrom(49 + i),
+						})
+						errs <- err
+					}()
+				}
+				wg.Wait()
+				close(errs)
+				for err := range errs {
+					require.NoError(th.T, err, "updating config concurrently")
+				}
+
+				versions, err := matchLib.ConfigVersions(ctx, th.BackendAppDb(), &matching.QueryFilterMatchConfigVersion{})
+				require.NoError(th.T, err)
+				seen := make(map[int]bool)
+				for _, v := range versions {
+					if v.Version > before.Version {
+						assert.False(th.T, seen[v.Version], "version %d recorded twice", v.Version)
+						seen[v.Version] = true
+					}
+				}
+				assert.Len(th.T, seen, updates, "every update records its own version")
+			},
+		},
+		{
+			name: "success-match-set-keeps-its-version",
+			extraAssertions: func(th *testsuite.Helper, matchLib *matching.Logic) {
+				ctx := context.Background()
+				exec := th.BackendAppDb()
+
+				// factory users have no location nor dating prefs, pair every user
+				_, err := testhelper.UpdateConfig(ctx, exec, matchLib, &matching.UpdateMatchConfig{
+					CandidatePrefilter: null.BoolFrom(false),
+				})
+				require.NoError(th.T, err, "disabling candidate prefilter")
+				version := latestConfigVersion(th, matchLib)
+
+				factory.NewEntity[*wingedFactory.User](&wingedFactory.User{}).New(th.T, exec)
+				factory.NewEntity[*wingedFactory.User](&wingedFactory.User{}).New(th.T, exec)
+
+				matchSet, err := matchLib.IngestAll(ctx, exec)
+				require.NoError(th.T, err, "ingesting all users")
+				require.NotNil(th.T, matchSet)
+				assert.Equal(th.T, version.ID, matchSet.ConfigVersionID.UUID, "an unchanged config reuses its version")
+
+				updateMatchExpiration(th, matchLib, 96)
+
+				matchSet, err = matchLib.MatchSet(ctx, exec, &matching.QueryFilterMatchSet{
+					ID: null.StringFrom(matchSet.ID.String()),
+				})
+				require.NoError(th.T, err)
+				assert.Equal(th.T, version.ID, matchSet.ConfigVersionID.UUID, "a config update doesn't move the set")
+			},
+		},
+		{
+			name: "success-config-changed-by-hand-records-version",
+			extraAssertions: func(th *testsuite.Helper, matchLib *matching.Logic) {
+				ctx := context.Background()
+				exec := th.BackendAppDb()
+
+				_, err := testhelper.UpdateConfig(ctx, exec, matchLib, &matching.UpdateMatchConfig{
+					CandidatePrefilter: null.BoolFrom(false),
+				})
+				require.NoError(th.T, err, "disabling candidate prefilter")
+				before := latestConfigVersion(th, matchLib)
+
+				_, err = exec.ExecContext(ctx, "UPDATE match_config SET match_expiration_hours = 24")
+				require.NoError(th.T, err, "changing the config by hand")
+
+				factory.NewEntity[*wingedFactory.User](&wingedFactory.User{}).New(th.T, exec)
+				factory.NewEntity[*wingedFactory.User](&wingedFactory.User{}).New(th.T, exec)
+
+				matchSet, err := matchLib.IngestAll(ctx, exec)
+				require.NoError(th.T, err, "ingesting all users")
+				require.NotNil(th.T, matchSet)
+
+				after := latestConfigVersion(th, matchLib)
+				assert.Equal(th.T, before.Version+1, after.Version)
+				assert.Equal(th.T, 24, after.Config.MatchExpirationHours)
+				assert.Equal(th.T, after.ID, matchSet.ConfigVersionID.UUID)
+			},
+		},
+		{
+			name: "error-unknown-version",
+			extraAssertions: func(th *testsuite.Helper, matchLib *matching.Logic) {
+				ctx := context.Background()
+				latest := updateMatchExpiration(th, matchLib, 48)
+
+				_, err := matchLib.DiffConfigVersions(ctx, th.BackendAppDb(), latest.Version, latest.Version+1)
+				require.ErrorIs(th.T, err, matching.ErrConfigVersionNotFound)
+
+				_, err = testhelper.RollbackConfig(ctx, th.BackendAppDb(), matchLib, latest.Version+1)
+				require.ErrorIs(th.T, err, matching.ErrConfigVersionNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tSuite := testsuite.New(t)
+			t.Cleanup(tSuite.UseBackendDB())
+
+			tc.extraAssertions(tSuite, tSuite.FakeContainer().GetLibMatching())
+		})
+	}
+}
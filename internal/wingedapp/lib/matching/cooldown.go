@@ -0,0 +1,72 @@
+package matching
+
+import (
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
+
+	"github.com/aarondl/null/v8"
+)
+
+// matchOutcome is how a match ended, as far as re-matching the pair goes.
+type matchOutcome int
+
+const (
+	// matchOutcomeOpen matches haven't ended, or ended without a cooldown
+	// policy (e.g. failed the qualifiers), so they block the pair for good.
+	matchOutcomeOpen matchOutcome = iota
+	matchOutcomeDeclined
+	matchOutcomeIgnored
+	matchOutcomeClosed
+)
+
+// matchOutcomeOf returns how mr ended, and when.
+//   - closed: the pair went on a date and the match was closed after it
+//   - declined: either side passed
+//   - ignored: the match expired without either side passing
+func matchOutcomeOf(mr *MatchResult) (matchOutcome, time.Time) {
+	switch {
+	case mr.UserLifeCycleStatus.String == string(enums.MatchLifecycleStatusClosed):
+		return matchOutcomeClosed, mr.ClosedAt.Time
+	case mr.InitiatorAction == string(enums.MatchUserActionPassed) ||
+		mr.ReceiverAction == string(enums.MatchUserActionPassed):
+		return matchOutcomeDeclined, latest(mr.InitiatorActionAt, mr.ReceiverActionAt)
+	case mr.IsExpired:
+		return matchOutcomeIgnored, mr.ExpiresAt.Time
+	}
+	return matchOutcomeOpen, time.Time{}
+}
+
+// latest returns the latest of the valid times.
+func latest(times ...null.Time) time.Time {
+	var t time.Time
+	for _, nt := range times {
+		if nt.Valid && nt.Time.After(t) {
+			t = nt.Time
+		}
+	}
+	return t
+}
+
+// rematchAllowed reports whether a pair whose previous match is mr
+// can be matched again at now, given the cooldowns in cfg.
+// An outcome without a known end time counts from the match's creation.
+func rematchAllowed(cfg *Config, mr *MatchResult, now time.Time) bool {
+	outcome, endedAt := matchOutcomeOf(mr)
+
+	var blockHours int
+	switch outcome {
+	case matchOutcomeDeclined:
+		blockHours = cfg.MatchBlockDeclined
+	case matchOutcomeIgnored:
+		blockHours = cfg.MatchBlockIgnored
+	case matchOutcomeClosed:
+		blockHours = cfg.MatchBlockClosed
+	default:
+		return false
+	}
+
+	if endedAt.IsZero() {
+		endedAt = mr.CreatedAt
+	}
+	return !now.Before(endedAt.Add(time.Duration(blockHours) * time.Hour))
+}
@@ -0,0 +1,271 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+/*
+	Drops are per timezone: users are bucketed by UTC offset, and each bucket
+	gets its drops at match_config.drop_hours in its local time.
+*/
+
+// UTC offsets of the nautical timezones, in hours.
+const (
+	minUTCOffset = -12
+	maxUTCOffset = 12
+)
+
+// dropCatchUp is how late a bucket's drop still runs after its drop hour,
+// so a runner restart or a slow tick doesn't skip it, and a drop never
+// lands far from its local hour.
+const dropCatchUp = time.Hour
+
+// DropBucket is the active users sharing a UTC offset, with their next drops.
+type DropBucket struct {
+	UTCOffset int
+	Users     int
+	NextDrops []time.Time
+}
+
+// DropSlot is one drop of a bucket, at one of its local drop hours.
+type DropSlot struct {
+	UTCOffset int       `boil:"utc_offset"`
+	DropAt    time.Time `boil:"drop_at"`
+}
+
+// UserUTCOffsets returns the user's drop buckets: the UTC offset of the
+// nautical timezone of their longitude, or fallbacks without a location.
+func UserUTCOffsets(u *User, fallbacks []int) []int {
+	if !u.Longitude.Valid {
+		return fallbacks
+	}
+	offset := int(math.Round(u.Longitude.Float64 / 15))
+	return []int{max(minUTCOffset, min(maxUTCOffset, offset))}
+}
+
+// DueDropSlots returns the drops due at now, one per bucket and drop hour
+// that was at most dropCatchUp ago in the bucket's local time, ordered by
+// bucket then time. A slot stays due for a while so a missed tick catches
+// up; RunDueDrops records the slots it ran so they don't run twice.
+func DueDropSlots(cfg *Config, now time.Time) ([]DropSlot, error) {
+	drops, err := dropMinutes(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var due []DropSlot
+	for offset := minUTCOffset; offset <= maxUTCOffset; offset++ {
+		local := now.In(utcOffsetZone(offset))
+		midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+
+		var slots []time.Time
+		for _, m := range drops {
+			at := midnight.Add(time.Duration(m) * time.Minute)
+			if at.After(now) {
+				at = at.AddDate(0, 0, -1)
+			}
+			if now.Sub(at) < dropCatchUp && !slices.ContainsFunc(slots, at.Equal) {
+				slots = append(slots, at)
+			}
+		}
+		slices.SortFunc(slots, func(a, b time.Time) int { return a.Compare(b) })
+
+		for _, at := range slots {
+			due = append(due, DropSlot{UTCOffset: offset, DropAt: at.UTC()})
+		}
+	}
+
+	return due, nil
+}
+
+// nextDrops returns the drops of the bucket in the 24 hours from now, in order.
+func nextDrops(drops []int, offset int, now time.Time) []time.Time {
+	local := now.In(utcOffsetZone(offset))
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+
+	next := make([]time.Time, 0, len(drops))
+	for _, m := range drops {
+		at := midnight.Add(time.Duration(m) * time.Minute)
+		if at.Before(now) {
+			at = at.AddDate(0, 0, 1)
+		}
+		next = append(next, at)
+	}
+	slices.SortFunc(next, func(a, b time.Time) int { return a.Compare(b) })
+
+	return next
+}
+
+// dropMinutes parses cfg.DropHours into minutes after midnight.
+func dropMinutes(cfg *Config) ([]int, error) {
+	minutes := make([]int, 0, len(cfg.DropHours))
+	for _, h := range cfg.DropHours {
+		t, err := time.Parse("15:04", h)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrDropHoursInvalidFormat, h)
+		}
+		minutes = append(minutes, t.Hour()*60+t.Minute())
+	}
+	return minutes, nil
+}
+
+// defaultUTCOffsets returns the buckets of users without a location,
+// every timezone of cfg.DropHoursUTC, or UTC without any.
+func defaultUTCOffsets(cfg *Config) ([]int, error) {
+	if len(cfg.DropHoursUTC) == 0 {
+		return []int{0}, nil
+	}
+
+	offsets := make([]int, 0, len(cfg.DropHoursUTC))
+	for _, tz := range cfg.DropHoursUTC {
+		if !isValidTimezoneFormat(tz) {
+			return nil, fmt.Errorf("%w: %q", ErrDropHoursUTCInvalidFormat, tz)
+		}
+		offset, _ := strconv.Atoi(tz[4:])
+		if tz[3] == '-' {
+			offset = -offset
+		}
+		if !slices.Contains(offsets, offset) {
+			offsets = append(offsets, offset)
+		}
+	}
+
+	return offsets, nil
+}
+
+func utcOffsetZone(offset int) *time.Location {
+	return time.FixedZone(fmt.Sprintf("UTC%+d", offset), offset*60*60)
+}
+
+// DropSchedule buckets the active users by UTC offset, with each bucket's
+// drops in the 24 hours from now, for a dry run of RunDueDrops. Users without
+// a location count in every bucket of match_config.drop_hours_utc.
+func (l *Logic) DropSchedule(ctx context.Context, exec boil.ContextExecutor, now time.Time) ([]DropBucket, error) {
+	cfg, err := l.configStorer.Config(ctx, exec, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get config: %w", err)
+	}
+	drops, err := dropMinutes(cfg)
+	if err != nil {
+		return nil, err
+	}
+	fallbacks, err := defaultUTCOffsets(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := l.userStorer.Users(ctx, exec, &QueryFilterUser{
+		IsActive: null.BoolFrom(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch active users: %w", err)
+	}
+
+	counts := make(map[int]int)
+	for i := range users {
+		for _, offset := range UserUTCOffsets(&users[i], fallbacks) {
+			counts[offset]++
+		}
+	}
+
+	buckets := make([]DropBucket, 0, len(counts))
+	for offset, n := range counts {
+		buckets = append(buckets, DropBucket{
+			UTCOffset: offset,
+			Users:     n,
+			NextDrops: nextDrops(drops, offset, now),
+		})
+	}
+	slices.SortFunc(buckets, func(a, b DropBucket) int { return a.UTCOffset - b.UTCOffset })
+
+	return buckets, nil
+}
+
+// RunDueDrops drops matches for the buckets with a drop due at now, see
+// DueDropSlots, and records the drops so they run once. A match drops with
+// the first of its users' buckets to come due, and only to users without
+// an active dropped match, so nobody gets a second match while the first
+// is still open. It's meant to be called every minute, and returns the
+// buckets it dropped matches for.
+func (l *Logic) RunDueDrops(ctx context.Context, exec boil.ContextExecutor, now time.Time) ([]int, error) {
+	cfg, err := l.configStorer.Config(ctx, exec, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get config: %w", err)
+	}
+
+	slots, err := DueDropSlots(cfg, now)
+	if err != nil {
+		return nil, fmt.Errorf("due drop slots: %w", err)
+	}
+	if len(slots) == 0 {
+		return nil, nil
+	}
+
+	ran, err := l.matchResultStorer.DropRuns(ctx, exec, slots)
+	if err != nil {
+		return nil, fmt.Errorf("fetch drop runs: %w", err)
+	}
+	slots = slices.DeleteFunc(slots, func(s DropSlot) bool {
+		return slices.ContainsFunc(ran, func(r DropSlot) bool {
+			return r.UTCOffset == s.UTCOffset && r.DropAt.Equal(s.DropAt)
+		})
+	})
+	if len(slots) == 0 {
+		return nil, nil
+	}
+
+	var due []int
+	for _, s := range slots {
+		if !slices.Contains(due, s.UTCOffset) {
+			due = append(due, s.UTCOffset)
+		}
+	}
+
+	fallbacks, err := defaultUTCOffsets(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// a match drops once either of its users' buckets is due
+	usersDue := func(matchResults []MatchResult) (map[uuid.UUID]bool, error) {
+		userIDs := make([]string, 0, 2*len(matchResults))
+		for _, mr := range matchResults {
+			userIDs = append(userIDs, mr.InitiatorUserID.String(), mr.ReceiverUserID.String())
+		}
+		if len(userIDs) == 0 {
+			return nil, nil // an empty filter would load everyone
+		}
+
+		users, err := l.userStorer.Users(ctx, exec, &QueryFilterUser{IDs: userIDs})
+		if err != nil {
+			return nil, fmt.Errorf("fetch users: %w", err)
+		}
+
+		isDue := make(map[uuid.UUID]bool, len(users))
+		for i := range users {
+			isDue[users[i].ID] = slices.ContainsFunc(UserUTCOffsets(&users[i], fallbacks), func(offset int) bool {
+				return slices.Contains(due, offset)
+			})
+		}
+		return isDue, nil
+	}
+
+	if err = l.dropOneMatchPerUser(ctx, exec, cfg, usersDue); err != nil {
+		return nil, err
+	}
+
+	if err = l.matchResultStorer.InsertDropRuns(ctx, exec, slots); err != nil {
+		return nil, fmt.Errorf("insert drop runs: %w", err)
+	}
+
+	return due, nil
+}
@@ -0,0 +1,114 @@
+package matching_test
+
+import (
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserUTCOffsets(t *testing.T) {
+	testCases := []struct {
+		name     string
+		user     matching.User
+		expected []int
+	}{
+		{
+			name:     "east-of-greenwich",
+			user:     matching.User{Longitude: null.Float64From(37.62)}, // moscow
+			expected: []int{3},
+		},
+		{
+			name:     "west-of-greenwich",
+			user:     matching.User{Longitude: null.Float64From(-74.00)}, // new york
+			expected: []int{-5},
+		},
+		{
+			name:     "antimeridian-clamped",
+			user:     matching.User{Longitude: null.Float64From(179.9)},
+			expected: []int{12},
+		},
+		{
+			name:     "no-location-falls-back-to-every-default",
+			user:     matching.User{},
+			expected: []int{8, -5},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matching.UserUTCOffsets(&tc.user, []int{8, -5}))
+		})
+	}
+}
+
+func TestDueDropSlots(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 10, hour, minute, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name      string
+		dropHours types.StringArray
+		now       time.Time
+		expected  []matching.DropSlot
+	}{
+		{
+			name:      "drop-hour-due-in-one-timezone",
+			dropHours: types.StringArray{"19:00"},
+			now:       at(16, 0),
+			expected:  []matching.DropSlot{{UTCOffset: 3, DropAt: at(16, 0)}},
+		},
+		{
+			name:      "missed-tick-catches-up",
+			dropHours: types.StringArray{"19:00"},
+			now:       at(16, 42),
+			expected:  []matching.DropSlot{{UTCOffset: 3, DropAt: at(16, 0)}},
+		},
+		{
+			name:      "past-catch-up-only-the-next-timezone-due",
+			dropHours: types.StringArray{"19:00"},
+			now:       at(17, 0),
+			expected:  []matching.DropSlot{{UTCOffset: 2, DropAt: at(17, 0)}},
+		},
+		{
+			name:      "several-drop-hours-due-in-several-timezones",
+			dropHours: types.StringArray{"19:00", "20:00"},
+			now:       at(17, 0),
+			expected: []matching.DropSlot{
+				{UTCOffset: 2, DropAt: at(17, 0)},
+				{UTCOffset: 3, DropAt: at(17, 0)},
+			},
+		},
+		{
+			name:      "every-missed-drop-hour-due",
+			dropHours: types.StringArray{"19:30", "19:00"},
+			now:       at(16, 45),
+			expected: []matching.DropSlot{
+				{UTCOffset: 3, DropAt: at(16, 0)},
+				{UTCOffset: 3, DropAt: at(16, 30)},
+			},
+		},
+		{
+			name:      "midnight-due-on-both-sides-of-the-date-line",
+			dropHours: types.StringArray{"00:00"},
+			now:       at(12, 0),
+			expected: []matching.DropSlot{
+				{UTCOffset: -12, DropAt: at(12, 0)},
+				{UTCOffset: 12, DropAt: at(12, 0)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			due, err := matching.DueDropSlots(&matching.Config{DropHours: tc.dropHours}, tc.now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, due)
+		})
+	}
+}
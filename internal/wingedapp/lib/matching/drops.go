@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"time"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
 
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
@@ -11,15 +12,45 @@ import (
 )
 
 // DropOneMatchPerUser checks all match results that are "approved", but not "dropped".
-// It sets one of them to "dropped", in ascending order of created_at.
-// Only drops 1 match per user per invocation.
+// It sets one of them to "dropped", best final_score first (oldest first on ties,
+// unscored results last). Only drops 1 match per user per invocation.
+//
+// Dropped matches expire match_config.match_expiration_hours later, see ExpireStaleMatches.
+// This drops for every user at once, RunDueDrops drops per timezone.
 func (l *Logic) DropOneMatchPerUser(ctx context.Context, exec boil.ContextExecutor) error {
+	cfg, err := l.configStorer.Config(ctx, exec, nil)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+
+	return l.dropOneMatchPerUser(ctx, exec, cfg, nil)
+}
+
+// dropOneMatchPerUser is DropOneMatchPerUser. With usersDue set, it only
+// drops the matches with a user usersDue reports as due, and skips users
+// who still have an active dropped match.
+func (l *Logic) dropOneMatchPerUser(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	cfg *Config,
+	usersDue func(matchResults []MatchResult) (map[uuid.UUID]bool, error),
+) error {
 	matchResults, err := l.matchResultsNotDropped(ctx, exec)
 	if err != nil {
 		return fmt.Errorf("match results not dropped: %w", err)
 	}
 
 	droppedUsers := make(map[string]bool)
+	var due map[uuid.UUID]bool
+	if usersDue != nil {
+		if due, err = usersDue(matchResults); err != nil {
+			return fmt.Errorf("users due: %w", err)
+		}
+		if droppedUsers, err = l.usersWithActiveDrop(ctx, exec); err != nil {
+			return fmt.Errorf("users with an active drop: %w", err)
+		}
+	}
+
 	for _, mr := range matchResults {
 		initiator := mr.InitiatorUserID.String()
 		receiver := mr.ReceiverUserID.String()
@@ -27,8 +58,11 @@ func (l *Logic) DropOneMatchPerUser(ctx context.Context, exec boil.ContextExecut
 		if droppedUsers[initiator] || droppedUsers[receiver] {
 			continue
 		}
+		if usersDue != nil && !due[mr.InitiatorUserID] && !due[mr.ReceiverUserID] {
+			continue
+		}
 
-		if err = l.setMatchResultDropped(ctx, exec, mr.ID.String()); err != nil {
+		if err = l.setMatchResultDropped(ctx, exec, mr.ID.String(), cfg.MatchExpirationHours); err != nil {
 			return fmt.Errorf("set match result dropped: %w", err)
 		}
 
@@ -40,14 +74,42 @@ func (l *Logic) DropOneMatchPerUser(ctx context.Context, exec boil.ContextExecut
 	return nil
 }
 
+// usersWithActiveDrop returns the users of dropped matches still open:
+// not expired, passed on by neither side, and not closed.
+func (l *Logic) usersWithActiveDrop(ctx context.Context,
+	exec boil.ContextExecutor,
+) (map[string]bool, error) {
+	paginated, err := l.matchResultStorer.MatchResults(ctx, exec, &QueryFilterMatchResult{
+		IsDropped: null.BoolFrom(true),
+		IsExpired: null.BoolFrom(false),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch dropped match results: %w", err)
+	}
+
+	users := make(map[string]bool)
+	for _, mr := range paginated.Data {
+		if mr.InitiatorAction == string(enums.MatchUserActionPassed) ||
+			mr.ReceiverAction == string(enums.MatchUserActionPassed) ||
+			mr.UserLifeCycleStatus.String == string(enums.MatchLifecycleStatusClosed) {
+			continue
+		}
+		users[mr.InitiatorUserID.String()] = true
+		users[mr.ReceiverUserID.String()] = true
+	}
+
+	return users, nil
+}
+
 func (l *Logic) matchResultsNotDropped(ctx context.Context,
 	exec boil.ContextExecutor,
 ) ([]MatchResult, error) {
 	paginated, err := l.matchResultStorer.MatchResults(ctx, exec, &QueryFilterMatchResult{
 		IsApproved: null.BoolFrom(true),
 		IsDropped:  null.BoolFrom(false),
-		OrderBy:    null.StringFrom("created_at"),
-		Sort:       null.StringFrom("+"),
+		IsExpired:  null.BoolFrom(false),
+		OrderBy:    null.StringFrom("final_score"),
+		Sort:       null.StringFrom("-"),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("fetch match results not dropped: %w", err)
@@ -56,20 +118,29 @@ func (l *Logic) matchResultsNotDropped(ctx context.Context,
 	return paginated.Data, nil
 }
 
-// setMatchResultDropped sets the given match result as dropped.
+// setMatchResultDropped sets the given match result as dropped, expiring
+// expirationHours later. expirationHours <= 0 never expires it.
 func (l *Logic) setMatchResultDropped(ctx context.Context,
 	exec boil.ContextExecutor,
 	matchResultID string,
+	expirationHours int,
 ) error {
 	id, err := uuid.Parse(matchResultID)
 	if err != nil {
 		return fmt.Errorf("parse match result id: %w", err)
 	}
 
+	now := timeNow()
+	var expiresAt null.Time
+	if expirationHours > 0 {
+		expiresAt = null.TimeFrom(now.Add(time.Duration(expirationHours) * time.Hour))
+	}
+
 	if _, err = l.matchResultStorer.Update(ctx, exec, &UpdateMatchResult{
 		ID:        id,
 		IsDropped: null.BoolFrom(true),
-		DroppedTS: null.TimeFrom(time.Now()),
+		DroppedTS: null.TimeFrom(now),
+		ExpiresAt: expiresAt,
 	}); err != nil {
 		return fmt.Errorf("set match result dropped: %w", err)
 	}
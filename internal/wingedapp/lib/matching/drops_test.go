@@ -56,6 +56,9 @@ func TestLogic_DropMatches(t *testing.T) {
 				require.NoError(th.T, err, "finding match result")
 				assert.True(th.T, mr.IsDropped, "match result should be dropped")
 				assert.True(th.T, mr.DroppedTS.Valid, "dropped_ts should be set")
+				require.True(th.T, mr.ExpiresAt.Valid, "expires_at should be set")
+				assert.WithinDuration(th.T, mr.DroppedTS.Time.Add(72*time.Hour), mr.ExpiresAt.Time, time.Second,
+					"expires_at should be match_expiration_hours after the drop")
 			},
 		},
 		{
@@ -166,6 +169,60 @@ func TestLogic_DropMatches(t *testing.T) {
 				assert.False(th.T, mr2.IsDropped, "second match result should NOT be dropped (UserA already dropped)")
 			},
 		},
+		{
+			name: "best-final-score-dropped-before-older-match",
+			setup: func(th *testsuite.Helper) []*wingedFactory.MatchResult {
+				exec := th.BackendAppDb()
+				ctx := context.Background()
+
+				userA := factory.NewEntity[*wingedFactory.User](&wingedFactory.User{}).New(th.T, exec)
+				userB := factory.NewEntity[*wingedFactory.User](&wingedFactory.User{}).New(th.T, exec)
+				userC := factory.NewEntity[*wingedFactory.User](&wingedFactory.User{}).New(th.T, exec)
+				matchSet := factory.NewEntity[*wingedFactory.MatchSet](&wingedFactory.MatchSet{}).New(th.T, exec)
+
+				older := factory.NewEntity[*wingedFactory.MatchResult](&wingedFactory.MatchResult{
+					Subject: &pgmodel.MatchResult{
+						IsApproved: true,
+						IsDropped:  false,
+						FinalScore: null.Float64From(0.55),
+					},
+					FactoryMatchSet: matchSet,
+					FactoryUserA:    userA,
+					FactoryUserB:    userB,
+				}).New(th.T, exec)
+
+				_, err := exec.ExecContext(ctx, "UPDATE match_result SET created_at = created_at - interval '1 minute' WHERE id = $1", older.Subject.ID)
+				require.NoError(th.T, err, "updating created_at for older match")
+
+				better := factory.NewEntity[*wingedFactory.MatchResult](&wingedFactory.MatchResult{
+					Subject: &pgmodel.MatchResult{
+						IsApproved: true,
+						IsDropped:  false,
+						FinalScore: null.Float64From(0.81),
+					},
+					FactoryMatchSet: matchSet,
+					FactoryUserA:    userA,
+					FactoryUserB:    userC,
+				}).New(th.T, exec)
+
+				return []*wingedFactory.MatchResult{older, better}
+			},
+			extraAssertions: func(th *testsuite.Helper, factories []*wingedFactory.MatchResult, err error) {
+				require.NoError(th.T, err, "DropOneMatchPerUser should succeed")
+				require.Len(th.T, factories, 2, "expecting 2 factories")
+
+				ctx := context.Background()
+				exec := th.BackendAppDb()
+
+				older, err := pgmodel.FindMatchResult(ctx, exec, factories[0].Subject.ID)
+				require.NoError(th.T, err, "finding older match result")
+				assert.False(th.T, older.IsDropped, "older, lower scored match should NOT be dropped")
+
+				better, err := pgmodel.FindMatchResult(ctx, exec, factories[1].Subject.ID)
+				require.NoError(th.T, err, "finding better match result")
+				assert.True(th.T, better.IsDropped, "best scored match should be dropped")
+			},
+		},
 		{
 			name: "multiple-independent-users-all-get-drops",
 			setup: func(th *testsuite.Helper) []*wingedFactory.MatchResult {
@@ -386,3 +443,64 @@ func TestLogic_DropMatches(t *testing.T) {
 		})
 	}
 }
+
+func TestLogic_RunDueDrops(t *testing.T) {
+	testSuite := testsuite.New(t)
+	t.Cleanup(testSuite.UseBackendDB())
+
+	ctx := context.Background()
+	exec := testSuite.BackendAppDb()
+	matchLib := testSuite.FakeContainer().GetLibMatching()
+
+	userAt := func(lon float64) *wingedFactory.User {
+		return factory.NewEntity[*wingedFactory.User](&wingedFactory.User{
+			Subject: &pgmodel.User{
+				Latitude:  null.Float64From(40),
+				Longitude: null.Float64From(lon),
+			},
+		}).New(t, exec)
+	}
+	approvedMatch := func(initiator, receiver *wingedFactory.User) *wingedFactory.MatchResult {
+		return factory.NewEntity[*wingedFactory.MatchResult](&wingedFactory.MatchResult{
+			Subject: &pgmodel.MatchResult{
+				IsApproved: true,
+				IsDropped:  false,
+			},
+			FactoryUserA: initiator,
+			FactoryUserB: receiver,
+		}).New(t, exec)
+	}
+	isDropped := func(mr *wingedFactory.MatchResult) bool {
+		found, err := pgmodel.FindMatchResult(ctx, exec, mr.Subject.ID)
+		require.NoError(t, err, "finding match result")
+		return found.IsDropped
+	}
+
+	const moscowLon, newYorkLon = 37.62, -74.00 // UTC+3, UTC-5
+
+	moscowUser := userAt(moscowLon)
+	moscow := approvedMatch(moscowUser, userAt(moscowLon))
+	newYork := approvedMatch(userAt(newYorkLon), userAt(newYorkLon))
+	receiverInMoscow := approvedMatch(userAt(newYorkLon), userAt(moscowLon))
+
+	// 19:05 in UTC+3, five minutes after a default drop hour, 11:05 in UTC-5
+	now := time.Date(2026, 3, 10, 16, 5, 0, 0, time.UTC)
+	buckets, err := matchLib.RunDueDrops(ctx, exec, now)
+	require.NoError(t, err, "RunDueDrops should succeed")
+	assert.Contains(t, buckets, 3)
+	assert.NotContains(t, buckets, -5)
+
+	assert.True(t, isDropped(moscow), "the UTC+3 match should drop at 19:00 local, even on a late tick")
+	assert.False(t, isDropped(newYork), "the UTC-5 match should wait for its local drop hour")
+	assert.True(t, isDropped(receiverInMoscow), "a match drops at its receiver's drop hour too")
+
+	buckets, err = matchLib.RunDueDrops(ctx, exec, now.Add(5*time.Minute))
+	require.NoError(t, err, "rerunning RunDueDrops should succeed")
+	assert.NotContains(t, buckets, 3, "the 19:00 drop of UTC+3 already ran")
+
+	// 20:00 in UTC+3, the next drop hour
+	moscowSecond := approvedMatch(moscowUser, userAt(moscowLon))
+	_, err = matchLib.RunDueDrops(ctx, exec, time.Date(2026, 3, 10, 17, 0, 0, 0, time.UTC))
+	require.NoError(t, err, "RunDueDrops should succeed at the next drop hour")
+	assert.False(t, isDropped(moscowSecond), "a user with an open dropped match gets no second one")
+}
@@ -12,6 +12,8 @@ var (
 	ErrNotInDatePrefs       = errors.New("not in date preferences")
 	ErrHeightGapExists      = errors.New("height gap exists")
 
+	ErrCompatibilityScoreOutOfRange = errors.New("compatibility score must be between 0 and 1")
+
 	ErrAgeGapHetero = errors.New("hetero age gap too large")
 	ErrNoMale       = errors.New("no male user")
 	ErrNoFemale     = errors.New("no female user")
@@ -31,4 +33,24 @@ var (
 	ErrDropHoursUTCInvalidFormat        = errors.New("drop_hours_utc must contain valid timezone strings (e.g., \"GMT+3\")")
 	ErrNegativeValue                    = errors.New("numeric configuration values must be non-negative")
 	ErrConfigNotFound                   = errors.New("match configuration not found")
+	ErrDuplicateQualifier               = errors.New("qualifiers must not contain duplicates")
+	ErrScoreWeightAIOutOfRange          = errors.New("score_weight_ai must be between 0 and 1")
+	ErrConfigVersionNotFound            = errors.New("match configuration version not found")
+
+	// qualifier registry errors
+	ErrQualifierTypeRequired      = errors.New("qualifier type is required")
+	ErrNilQualifier               = errors.New("qualifier func is nil")
+	ErrQualifierAlreadyRegistered = errors.New("qualifier already registered")
+	ErrUnknownQualifier           = errors.New("unknown qualifier")
+	ErrNoQualifierResult          = errors.New("qualifier returned no result")
+
+	// qualitative cache errors
+	ErrQualitativeCacheMiss = errors.New("qualitative cache miss")
+
+	// ingestion run errors
+	ErrMatchSetNotFound    = errors.New("match set not found")
+	ErrIngestionIncomplete = errors.New("ingestion run incomplete, some pairs failed")
+
+	// simulation errors
+	ErrSimulationConfigRequired = errors.New("simulation config is required")
 )
@@ -0,0 +1,21 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// ExpireStaleMatches expires dropped matches left undecided past their
+// expires_at: no date came out of them, as both sides never proposed.
+// Their match_status becomes Expired, and each side's outcome is recorded
+// as Ignored (never acted), Passed or Proposed.
+func (l *Logic) ExpireStaleMatches(ctx context.Context, exec boil.ContextExecutor) ([]ExpiredMatch, error) {
+	expired, err := l.matchResultStorer.ExpireDropped(ctx, exec, timeNow())
+	if err != nil {
+		return nil, fmt.Errorf("expire dropped matches: %w", err)
+	}
+
+	return expired, nil
+}
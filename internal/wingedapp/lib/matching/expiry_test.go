@@ -0,0 +1,136 @@
+package matching_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/db/factory"
+	wingedFactory "wingedapp/pgtester/internal/wingedapp/db/factory"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/null/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCaseExpireStaleMatches struct {
+	name            string
+	setup           func(th *testsuite.Helper) *wingedFactory.MatchResult
+	extraAssertions func(th *testsuite.Helper, mr *pgmodel.MatchResult, expired []matching.ExpiredMatch, err error)
+}
+
+// droppedMatch creates a dropped match result expiring at expiresAt,
+// with the given actions of each side.
+func droppedMatch(th *testsuite.Helper, expiresAt time.Time, initiator, receiver enums.MatchUserAction) *wingedFactory.MatchResult {
+	return factory.NewEntity[*wingedFactory.MatchResult](&wingedFactory.MatchResult{
+		Subject: &pgmodel.MatchResult{
+			IsApproved:      true,
+			IsDropped:       true,
+			DroppedTS:       null.TimeFrom(expiresAt.Add(-72 * time.Hour)),
+			ExpiresAt:       null.TimeFrom(expiresAt),
+			InitiatorAction: string(initiator),
+			ReceiverAction:  string(receiver),
+		},
+	}).New(th.T, th.BackendAppDb())
+}
+
+func TestLogic_ExpireStaleMatches(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	testCases := []testCaseExpireStaleMatches{
+		{
+			name: "ignored-match-past-expiry-expires",
+			setup: func(th *testsuite.Helper) *wingedFactory.MatchResult {
+				return droppedMatch(th, past, enums.MatchUserActionPending, enums.MatchUserActionPending)
+			},
+			extraAssertions: func(th *testsuite.Helper, mr *pgmodel.MatchResult, expired []matching.ExpiredMatch, err error) {
+				require.NoError(th.T, err, "ExpireStaleMatches should succeed")
+				require.Len(th.T, expired, 1, "the match should expire")
+				assert.Equal(th.T, enums.MatchExpiryOutcomeIgnored, expired[0].InitiatorOutcome)
+				assert.Equal(th.T, enums.MatchExpiryOutcomeIgnored, expired[0].ReceiverOutcome)
+
+				assert.True(th.T, mr.IsExpired, "is_expired should be set")
+				assert.Equal(th.T, string(enums.MatchStatusExpired), mr.MatchStatus)
+				assert.Equal(th.T, null.StringFrom("Ignored"), mr.InitiatorExpiryOutcome)
+				assert.Equal(th.T, null.StringFrom("Ignored"), mr.ReceiverExpiryOutcome)
+			},
+		},
+		{
+			name: "one-sided-proposal-records-each-side",
+			setup: func(th *testsuite.Helper) *wingedFactory.MatchResult {
+				return droppedMatch(th, past, enums.MatchUserActionProposed, enums.MatchUserActionPassed)
+			},
+			extraAssertions: func(th *testsuite.Helper, mr *pgmodel.MatchResult, expired []matching.ExpiredMatch, err error) {
+				require.NoError(th.T, err, "ExpireStaleMatches should succeed")
+				require.Len(th.T, expired, 1, "the match should expire")
+
+				assert.True(th.T, mr.IsExpired, "is_expired should be set")
+				assert.Equal(th.T, null.StringFrom("Proposed"), mr.InitiatorExpiryOutcome)
+				assert.Equal(th.T, null.StringFrom("Passed"), mr.ReceiverExpiryOutcome)
+			},
+		},
+		{
+			name: "match-before-expiry-not-expired",
+			setup: func(th *testsuite.Helper) *wingedFactory.MatchResult {
+				return droppedMatch(th, future, enums.MatchUserActionPending, enums.MatchUserActionPending)
+			},
+			extraAssertions: func(th *testsuite.Helper, mr *pgmodel.MatchResult, expired []matching.ExpiredMatch, err error) {
+				require.NoError(th.T, err, "ExpireStaleMatches should succeed")
+				assert.Empty(th.T, expired)
+				assert.False(th.T, mr.IsExpired, "is_expired should not be set")
+				assert.Equal(th.T, string(enums.MatchStatusActive), mr.MatchStatus)
+			},
+		},
+		{
+			name: "mutual-proposal-not-expired",
+			setup: func(th *testsuite.Helper) *wingedFactory.MatchResult {
+				return droppedMatch(th, past, enums.MatchUserActionProposed, enums.MatchUserActionProposed)
+			},
+			extraAssertions: func(th *testsuite.Helper, mr *pgmodel.MatchResult, expired []matching.ExpiredMatch, err error) {
+				require.NoError(th.T, err, "ExpireStaleMatches should succeed")
+				assert.Empty(th.T, expired)
+				assert.False(th.T, mr.IsExpired, "a decided match should not expire")
+			},
+		},
+		{
+			name: "match-without-expiry-not-expired",
+			setup: func(th *testsuite.Helper) *wingedFactory.MatchResult {
+				mr := droppedMatch(th, past, enums.MatchUserActionPending, enums.MatchUserActionPending)
+				_, err := th.BackendAppDb().ExecContext(context.Background(),
+					"UPDATE match_result SET expires_at = NULL WHERE id = $1", mr.Subject.ID)
+				require.NoError(th.T, err, "clearing expires_at")
+				return mr
+			},
+			extraAssertions: func(th *testsuite.Helper, mr *pgmodel.MatchResult, expired []matching.ExpiredMatch, err error) {
+				require.NoError(th.T, err, "ExpireStaleMatches should succeed")
+				assert.Empty(th.T, expired)
+				assert.False(th.T, mr.IsExpired, "is_expired should not be set")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testSuite := testsuite.New(t)
+			t.Cleanup(testSuite.UseBackendDB())
+
+			mrFactory := tc.setup(testSuite)
+
+			ctx := context.Background()
+			exec := testSuite.BackendAppDb()
+			matchLib := testSuite.FakeContainer().GetLibMatching()
+			expired, err := matchLib.ExpireStaleMatches(ctx, exec)
+
+			mr, findErr := pgmodel.FindMatchResult(ctx, exec, mrFactory.Subject.ID)
+			require.NoError(t, findErr, "finding match result")
+
+			tc.extraAssertions(testSuite, mr, expired, err)
+		})
+	}
+}
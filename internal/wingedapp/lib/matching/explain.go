@@ -0,0 +1,250 @@
+package matching
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+// qualifierRule describes a built-in hard qualifier, and the config fields it reads.
+type qualifierRule struct {
+	rule   string
+	fields []string
+}
+
+var qualifierRules = map[QualifierType]qualifierRule{
+	ageWindowQualifier: {
+		rule: "The age gap may be at most age_range_man_older_by years when the man is older, " +
+			"age_range_woman_older_by years when the woman is older, and age_range_end years for other pairs.",
+		fields: []string{"age_range_man_older_by", "age_range_woman_older_by", "age_range_end"},
+	},
+	datePrefsQualifier: {
+		rule: "Each user's dating preferences must include the other user's gender.",
+	},
+	heightQualifier: {
+		rule: "The man must be at least height_male_greater_by_cm taller than the woman. " +
+			"Same-sex pairs and pairs with a non-binary user are exempt.",
+		fields: []string{"height_male_greater_by_cm"},
+	},
+	distanceQualifier: {
+		rule: "The users must live within location_radius_km of each other, " +
+			"or within one of the location_adaptive_expansion radii.",
+		fields: []string{"location_radius_km", "location_adaptive_expansion"},
+	},
+}
+
+// ExplainMatch explains how processing a pair ended: each hard qualifier's
+// inputs, the config thresholds it applied and its outcome, and the AI
+// sub-scores for a pair that passed them all.
+func (l *Logic) ExplainMatch(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	matchResultID uuid.UUID,
+) (*MatchExplanation, error) {
+	mr, err := l.matchResultStorer.MatchResult(ctx, exec, &QueryFilterMatchResult{
+		ID: null.StringFrom(matchResultID.String()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("match result %s: %w", matchResultID, err)
+	}
+
+	version, err := l.matchSetConfigVersion(ctx, exec, mr.MatchSetID)
+	if err != nil {
+		return nil, fmt.Errorf("config version: %w", err)
+	}
+	var config *Config
+	if version != nil {
+		config = &version.Config
+	} else if config, err = l.configStorer.Config(ctx, exec, nil); err != nil {
+		return nil, fmt.Errorf("live config: %w", err)
+	}
+
+	explanation := &MatchExplanation{
+		MatchResultID:   mr.ID,
+		MatchSetID:      mr.MatchSetID,
+		InitiatorUserID: mr.InitiatorUserID,
+		ReceiverUserID:  mr.ReceiverUserID,
+		Qualifiers:      make([]QualifierExplanation, 0),
+	}
+	if version != nil {
+		explanation.ConfigVersion = null.IntFrom(version.Version)
+	}
+
+	matched := mr.MatchedQualitatively.Bool
+
+	// pairs processed before hard qualifier results were kept separately
+	// only have them in qualifier_results, and only when they failed
+	hardResults := mr.HardQualifierResults
+	if !hardResults.Valid && !matched {
+		hardResults = mr.QualifierResults
+	}
+	if hardResults.Valid {
+		var results QualifierResults
+		if err = json.Unmarshal(hardResults.JSON, &results); err != nil {
+			return nil, fmt.Errorf("unmarshal qualifier results: %w", err)
+		}
+		if explanation.Qualifiers, err = explainQualifiers(config, results); err != nil {
+			return nil, fmt.Errorf("explain qualifiers: %w", err)
+		}
+	}
+
+	switch {
+	case matched:
+		explanation.Outcome = MatchOutcomeMatched
+		if explanation.Scores, err = explainScores(config, mr); err != nil {
+			return nil, fmt.Errorf("explain scores: %w", err)
+		}
+	case hardResults.Valid:
+		explanation.Outcome = MatchOutcomeFailedQualifiers
+	case mr.IngestionStatus == string(enums.MatchResultIngestionStatusFailed):
+		explanation.Outcome = MatchOutcomeErrored
+	default:
+		explanation.Outcome = MatchOutcomePending
+	}
+	explanation.Summary = summarizeMatch(explanation, mr)
+
+	return explanation, nil
+}
+
+// explainQualifiers explains the qualifier results, in the config's qualifier
+// order, then any qualifier the config no longer lists by name.
+func explainQualifiers(config *Config, results QualifierResults) ([]QualifierExplanation, error) {
+	fields, err := configFields(config)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]QualifierType, 0, len(results))
+	for _, name := range config.Qualifiers {
+		if q, ok := results[QualifierType(name)]; ok && q != nil {
+			names = append(names, QualifierType(name))
+		}
+	}
+	rest := make([]QualifierType, 0)
+	for name, q := range results {
+		if q != nil && !slices.Contains(names, name) { // older rows keep null entries
+			rest = append(rest, name)
+		}
+	}
+	slices.Sort(rest)
+	names = append(names, rest...)
+
+	explanations := make([]QualifierExplanation, 0, len(names))
+	for _, name := range names {
+		q := results[name]
+		rule, known := qualifierRules[name]
+
+		e := QualifierExplanation{
+			Name:       name,
+			Passed:     q.ErrorMsg == "",
+			Rule:       rule.rule,
+			Inputs:     q.Telemetry,
+			Thresholds: make(map[string]any, len(rule.fields)),
+		}
+		if e.Inputs == nil {
+			e.Inputs = make(map[string]any)
+		}
+		for _, field := range rule.fields {
+			e.Thresholds[field] = fields[field]
+		}
+
+		switch {
+		case !known && e.Passed:
+			e.Explanation = "Passed."
+		case !known:
+			e.Explanation = "Failed: " + q.ErrorMsg
+		case e.Passed:
+			e.Explanation = "Passed. " + rule.rule
+		default:
+			e.Explanation = "Failed: " + q.ErrorMsg + ". " + rule.rule
+		}
+
+		explanations = append(explanations, e)
+	}
+
+	return explanations, nil
+}
+
+// explainScores breaks down the scores of a pair that passed the hard qualifiers.
+func explainScores(config *Config, mr *MatchResult) (*ScoreExplanation, error) {
+	scores := &ScoreExplanation{
+		SoftScore:       mr.SoftScore,
+		AIScore:         mr.AIScore,
+		FinalScore:      mr.FinalScore,
+		ScoreWeightAI:   config.ScoreWeightAI,
+		ScoreRangeStart: config.ScoreRangeStart,
+		ScoreRangeEnd:   config.ScoreRangeEnd,
+		AIScores:        make([]ScoreComponent, 0),
+		Explanation: fmt.Sprintf("The AI score is rescaled from score_range_start..score_range_end (%.2f..%.2f) to 0..1. "+
+			"The final score is %.0f%% the rescaled AI score and %.0f%% the soft score, "+
+			"from distance, age, height and trait similarity.",
+			config.ScoreRangeStart, config.ScoreRangeEnd,
+			config.ScoreWeightAI*100, (1-config.ScoreWeightAI)*100),
+	}
+
+	if !mr.QualifierResults.Valid {
+		return scores, nil
+	}
+
+	var compatibility MatchCompatibilityResult
+	if err := json.Unmarshal(mr.QualifierResults.JSON, &compatibility); err != nil {
+		return nil, fmt.Errorf("unmarshal qualitative result: %w", err)
+	}
+	scores.AIScores = append(scores.AIScores,
+		ScoreComponent{
+			Name:        "personality",
+			Score:       compatibility.PersonalityCompatibilityScore.Score,
+			Explanation: compatibility.PersonalityCompatibilityScore.Explanation,
+		},
+		ScoreComponent{
+			Name:        "lifestyle",
+			Score:       compatibility.LifestyleCompatibilityScore.Score,
+			Explanation: compatibility.LifestyleCompatibilityScore.Explanation,
+		},
+		ScoreComponent{
+			Name:        "values",
+			Score:       compatibility.ValuesCompatibilityScore.Score,
+			Explanation: compatibility.ValuesCompatibilityScore.Explanation,
+		},
+	)
+
+	return scores, nil
+}
+
+// summarizeMatch sums the explanation up in a sentence for support.
+func summarizeMatch(e *MatchExplanation, mr *MatchResult) string {
+	var failed []string
+	for _, q := range e.Qualifiers {
+		if !q.Passed {
+			failed = append(failed, string(q.Name))
+		}
+	}
+
+	switch e.Outcome {
+	case MatchOutcomeMatched:
+		summary := fmt.Sprintf("Matched: passed all %d hard qualifiers", len(e.Qualifiers))
+		if len(e.Qualifiers) == 0 {
+			summary = "Matched: passed the hard qualifiers, whose details weren't kept for this pair"
+		}
+		if mr.FinalScore.Valid {
+			summary += fmt.Sprintf(", final score %.2f", mr.FinalScore.Float64)
+		}
+		return summary + "."
+	case MatchOutcomeFailedQualifiers:
+		if len(failed) == 0 {
+			return "Not matched: failed the hard qualifiers, whose details weren't kept for this pair."
+		}
+		return fmt.Sprintf("Not matched: failed %s.", strings.Join(failed, ", "))
+	case MatchOutcomeErrored:
+		return fmt.Sprintf("Not decided yet: processing the pair failed (%s), it's retried on the next run.", mr.IngestionError.String)
+	default:
+		return "Not decided yet: the pair wasn't processed."
+	}
+}
@@ -0,0 +1,202 @@
+package matching_test
+
+import (
+	"context"
+	"testing"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching/testhelper"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCaseExplainMatch struct {
+	name              string
+	population        string
+	disablePrefilter  bool
+	runIngestionSet   bool
+	legacyResults     string // qualifier_results as pairs processed before migration 23 stored them
+	explainedResultID func(matchResultID uuid.UUID) uuid.UUID
+	extraAssertions   func(th *testsuite.Helper, explanation *matching.MatchExplanation, err error)
+}
+
+func TestLogic_ExplainMatch(t *testing.T) {
+	testCases := []testCaseExplainMatch{
+		{
+			name:            "success-matched-keeps-hard-qualifiers-and-ai-scores",
+			population:      "./testdata/population_3_pass_all.csv",
+			runIngestionSet: true,
+			extraAssertions: func(th *testsuite.Helper, explanation *matching.MatchExplanation, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, matching.MatchOutcomeMatched, explanation.Outcome)
+				assert.True(th.T, explanation.ConfigVersion.Valid, "the set was created with a config version")
+
+				require.NotEmpty(th.T, explanation.Qualifiers, "hard qualifier results are kept next to the AI result")
+				for _, q := range explanation.Qualifiers {
+					assert.True(th.T, q.Passed, "qualifier %s", q.Name)
+					assert.NotEmpty(th.T, q.Rule, "qualifier %s", q.Name)
+				}
+
+				require.NotNil(th.T, explanation.Scores)
+				assert.True(th.T, explanation.Scores.AIScore.Valid)
+				assert.True(th.T, explanation.Scores.FinalScore.Valid)
+				assert.Less(th.T, explanation.Scores.ScoreRangeStart, explanation.Scores.ScoreRangeEnd)
+				assert.Contains(th.T, explanation.Scores.Explanation, "rescaled")
+				require.Len(th.T, explanation.Scores.AIScores, 3)
+				assert.Equal(th.T, "personality", explanation.Scores.AIScores[0].Name)
+				assert.InDelta(th.T, 1, explanation.Scores.AIScores[0].Score, 0.001)
+				assert.NotEmpty(th.T, explanation.Scores.AIScores[0].Explanation)
+				assert.Contains(th.T, explanation.Summary, "Matched")
+			},
+		},
+		{
+			name:             "success-failed-qualifiers-with-thresholds",
+			population:       "./testdata/population_2_fail_all.csv",
+			disablePrefilter: true,
+			runIngestionSet:  true,
+			extraAssertions: func(th *testsuite.Helper, explanation *matching.MatchExplanation, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, matching.MatchOutcomeFailedQualifiers, explanation.Outcome)
+				assert.Nil(th.T, explanation.Scores, "no AI scores without passing the hard qualifiers")
+
+				var ageWindow *matching.QualifierExplanation
+				for i := range explanation.Qualifiers {
+					if explanation.Qualifiers[i].Name == "age_window_qualifier" {
+						ageWindow = &explanation.Qualifiers[i]
+					}
+				}
+				require.NotNil(th.T, ageWindow, "the age window qualifier should be explained")
+				assert.False(th.T, ageWindow.Passed, "the users are 25 years apart")
+				assert.Contains(th.T, ageWindow.Thresholds, "age_range_man_older_by")
+				assert.Contains(th.T, ageWindow.Thresholds, "age_range_woman_older_by")
+				assert.Contains(th.T, ageWindow.Explanation, "Failed")
+				assert.Contains(th.T, explanation.Summary, "age_window_qualifier")
+			},
+		},
+		{
+			name:             "success-legacy-qualifier-results",
+			population:       "./testdata/population_2_fail_all.csv",
+			disablePrefilter: true,
+			runIngestionSet:  true,
+			legacyResults: `{
+				"age_window": {"name": "age_window", "telemetry": {"age_diff": 25}, "error_msg": "age difference too large"},
+				"height": {"name": "height", "telemetry": {}, "error_msg": ""},
+				"qualitative": null
+			}`,
+			extraAssertions: func(th *testsuite.Helper, explanation *matching.MatchExplanation, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, matching.MatchOutcomeFailedQualifiers, explanation.Outcome)
+				require.Len(th.T, explanation.Qualifiers, 2, "the null qualitative entry is skipped")
+
+				assert.Equal(th.T, matching.QualifierType("age_window_qualifier"), explanation.Qualifiers[0].Name)
+				assert.False(th.T, explanation.Qualifiers[0].Passed)
+				assert.NotEmpty(th.T, explanation.Qualifiers[0].Rule, "old keys map to the known qualifiers")
+				assert.Contains(th.T, explanation.Qualifiers[0].Explanation, "age difference too large")
+
+				assert.Equal(th.T, matching.QualifierType("height_qualifier"), explanation.Qualifiers[1].Name)
+				assert.True(th.T, explanation.Qualifiers[1].Passed)
+				assert.NotEmpty(th.T, explanation.Qualifiers[1].Rule)
+			},
+		},
+		{
+			name:             "success-legacy-qualifier-results-without-details",
+			population:       "./testdata/population_2_fail_all.csv",
+			disablePrefilter: true,
+			runIngestionSet:  true,
+			legacyResults: `{
+				"age_window": null,
+				"height": null
+			}`,
+			extraAssertions: func(th *testsuite.Helper, explanation *matching.MatchExplanation, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, matching.MatchOutcomeFailedQualifiers, explanation.Outcome)
+				assert.Empty(th.T, explanation.Qualifiers, "null entries are skipped")
+				assert.Equal(th.T,
+					"Not matched: failed the hard qualifiers, whose details weren't kept for this pair.",
+					explanation.Summary)
+			},
+		},
+		{
+			name:       "success-pending-pair",
+			population: "./testdata/population_3_pass_all.csv",
+			extraAssertions: func(th *testsuite.Helper, explanation *matching.MatchExplanation, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, matching.MatchOutcomePending, explanation.Outcome)
+				assert.Empty(th.T, explanation.Qualifiers)
+				assert.Nil(th.T, explanation.Scores)
+			},
+		},
+		{
+			name:       "error-unknown-match-result",
+			population: "./testdata/population_3_pass_all.csv",
+			explainedResultID: func(uuid.UUID) uuid.UUID {
+				return uuid.New()
+			},
+			extraAssertions: func(th *testsuite.Helper, explanation *matching.MatchExplanation, err error) {
+				require.Error(th.T, err)
+				assert.Nil(th.T, explanation)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tSuite := testsuite.New(t)
+			t.Cleanup(tSuite.UseBackendDB())
+			t.Cleanup(tSuite.UseAiDB())
+			t.Cleanup(tSuite.UseSupabaseAuthDB())
+
+			ctx := context.Background()
+			matchLib := tSuite.FakeContainer().GetLibMatching()
+			matchLib.SetQualitativeQuantifier(testhelper.MockSuccessQualitativeMatch(t))
+
+			ingestor := testhelper.NewPopulationIngestor(t, tSuite, matchLib)
+			if tc.disablePrefilter {
+				ingestor.DisableCandidatePrefilter() // the hard qualifiers are under test, pair every user
+			}
+			_, _, err := ingestor.IngestFromCSVFile(tc.population)
+			require.NoError(t, err, "ingesting population data")
+
+			matchSet, err := matchLib.IngestAll(ctx, tSuite.BackendAppDb())
+			require.NoError(t, err, "creating match set")
+			require.NotNil(t, matchSet)
+
+			if tc.runIngestionSet {
+				_, err = matchLib.RunIngestionSet(ctx, tSuite.BackendAppDb(), tSuite.AiBackendDb(), matchSet.ID)
+				require.NoError(t, err, "RunIngestionSet should succeed")
+			}
+
+			mr, err := pgmodel.MatchResults(
+				pgmodel.MatchResultWhere.MatchSetRefID.EQ(matchSet.ID.String()),
+			).One(ctx, tSuite.BackendAppDb())
+			require.NoError(t, err, "fetching the match result")
+
+			if tc.legacyResults != "" {
+				mr.QualifierResults = null.JSONFrom([]byte(tc.legacyResults))
+				mr.HardQualifierResults = null.JSON{}
+				mr.MatchedQualitatively = false
+				_, err = mr.Update(ctx, tSuite.BackendAppDb(), boil.Whitelist(
+					pgmodel.MatchResultColumns.QualifierResults,
+					pgmodel.MatchResultColumns.HardQualifierResults,
+					pgmodel.MatchResultColumns.MatchedQualitatively,
+				))
+				require.NoError(t, err, "storing legacy qualifier results")
+			}
+
+			matchResultID := uuid.MustParse(mr.ID)
+			if tc.explainedResultID != nil {
+				matchResultID = tc.explainedResultID(matchResultID)
+			}
+
+			explanation, err := matchLib.ExplainMatch(ctx, tSuite.BackendAppDb(), matchResultID)
+
+			tc.extraAssertions(tSuite, explanation, err)
+		})
+	}
+}
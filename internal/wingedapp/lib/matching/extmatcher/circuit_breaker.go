@@ -0,0 +1,79 @@
+package extmatcher
+
+import (
+	"sync"
+	"time"
+)
+
+// timeNow is a variable for testing purposes
+var timeNow = time.Now
+
+// circuitBreaker fails fast after threshold consecutive failures.
+// Once cooldown has passed it lets a single trial request through:
+// a success closes it again, a failure re-opens it.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+
+	failures int
+	openedAt time.Time // zero while closed
+	trial    bool      // a half-open trial request is in flight
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow returns ErrCircuitOpen if a request must not be sent.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openedAt.IsZero() {
+		return nil
+	}
+
+	if b.trial || timeNow().Before(b.openedAt.Add(b.cooldown)) {
+		return ErrCircuitOpen
+	}
+
+	b.trial = true
+	return nil
+}
+
+// success closes the breaker.
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.openedAt = time.Time{}
+	b.trial = false
+}
+
+// release ends a half-open trial without an outcome, e.g. when the caller gave up.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+// failure records a failed request, and reports whether the breaker (re-)opened.
+func (b *circuitBreaker) failure() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.trial || b.failures >= b.threshold {
+		b.openedAt = timeNow()
+		b.trial = false
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,40 @@
+package extmatcher
+
+import (
+	"wingedapp/pgtester/internal/util/validationlib"
+)
+
+// defaultMatchmakingPath is the AI backend's qualitative matching endpoint.
+const defaultMatchmakingPath = "/matchmaking_v2"
+
+// QualitativeMatcherCfg configures the AI backend qualitative matcher.
+type QualitativeMatcherCfg struct {
+	BaseURL                 string `json:"EXTMATCHER_BASE_URL" mapstructure:"EXTMATCHER_BASE_URL" validate:"required,url"`
+	APIKey                  string `json:"EXTMATCHER_API_KEY" mapstructure:"EXTMATCHER_API_KEY" validate:"required"`
+	Path                    string `json:"EXTMATCHER_PATH" mapstructure:"EXTMATCHER_PATH"`                                                               // defaults to /matchmaking_v2
+	RequestTimeoutDuration  int    `json:"EXTMATCHER_REQUEST_TIMEOUT_DURATION" mapstructure:"EXTMATCHER_REQUEST_TIMEOUT_DURATION" validate:"required"`   // seconds, across retries
+	BreakerThreshold        int    `json:"EXTMATCHER_BREAKER_THRESHOLD" mapstructure:"EXTMATCHER_BREAKER_THRESHOLD" validate:"required"`                 // consecutive failures before the breaker opens
+	BreakerCooldownDuration int    `json:"EXTMATCHER_BREAKER_COOLDOWN_DURATION" mapstructure:"EXTMATCHER_BREAKER_COOLDOWN_DURATION" validate:"required"` // seconds the breaker stays open
+
+	HttpClientCfg `mapstructure:",squash"`
+}
+
+func (c *QualitativeMatcherCfg) Validate() error {
+	if err := c.HttpClientCfg.Validate(); err != nil {
+		return err
+	}
+	return validationlib.Validate(c)
+}
+
+// path returns the matchmaking endpoint's path.
+func (c *QualitativeMatcherCfg) path() string {
+	if c.Path == "" {
+		return defaultMatchmakingPath
+	}
+	return c.Path
+}
+
+// url returns the matchmaking endpoint.
+func (c *QualitativeMatcherCfg) url() string {
+	return c.BaseURL + c.path()
+}
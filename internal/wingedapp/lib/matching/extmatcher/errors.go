@@ -0,0 +1,8 @@
+package extmatcher
+
+import "errors"
+
+var (
+	ErrCircuitOpen      = errors.New("extmatcher circuit breaker is open")
+	ErrUnexpectedStatus = errors.New("extmatcher unexpected response status")
+)
@@ -3,7 +3,10 @@ package extmatcher
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -14,18 +17,39 @@ import (
 )
 
 type QualitativeMatcher struct {
-	cln *HttpClient
+	cln     *HttpClient
+	cfg     *QualitativeMatcherCfg
+	breaker *circuitBreaker
+	logger  applog.Logger
 }
 
-func NewQualitativeMatcher(logger applog.Logger) *QualitativeMatcher {
-	cln := NewHttpClient(&HttpClientCfg{
-		GeneralTimeoutDuration: 120,
-		ReadTimeoutDuration:    120,
-		RetryDuration:          60,
-		MaxRetries:             3,
-	}, logger)
+func NewQualitativeMatcher(cfg *QualitativeMatcherCfg, logger applog.Logger) (*QualitativeMatcher, error) {
+	if cfg == nil {
+		return nil, errors.New("cfg is required")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validate cfg: %w", err)
+	}
+	if logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	cln := NewHttpClient(&cfg.HttpClientCfg, logger)
+	// hand exhausted 5xx responses back, so the breaker can count them
+	cln.Client.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	return &QualitativeMatcher{
+		cln:     cln,
+		cfg:     cfg,
+		breaker: newCircuitBreaker(cfg.BreakerThreshold, time.Duration(cfg.BreakerCooldownDuration)*time.Second),
+		logger:  logger,
+	}, nil
+}
 
-	return &QualitativeMatcher{cln}
+// Version identifies the matcher's results. The endpoint path is versioned
+// (e.g. /matchmaking_v2), so results follow it.
+func (qm *QualitativeMatcher) Version() string {
+	return "ext:" + qm.cfg.path()
 }
 
 func (qm *QualitativeMatcher) Qualify(
@@ -36,50 +60,90 @@ func (qm *QualitativeMatcher) Qualify(
 		return nil, fmt.Errorf("validate qualitative match request: %w", err)
 	}
 
-	// TODO: switch URL based on environment
-	url := "https://winged-ai-backend-dev-1020977593789.asia-east1.run.app/matchmaking_v2"
-
-	ctx, cancel := context.WithTimeout(ctx, time.Duration(120)*time.Second)
-	defer cancel()
-
 	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("marshal request data: %w", err)
 	}
 
-	fmt.Println("==== AI BACKEND REQUEST (before send) ====")
-	fmt.Println(string(body))
-	fmt.Println("===========================================")
+	reqCtx, cancel := context.WithTimeout(ctx, time.Duration(qm.cfg.RequestTimeoutDuration)*time.Second)
+	defer cancel()
 
-	reqCtx, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	httpReq, err := retryablehttp.NewRequestWithContext(reqCtx, http.MethodPost, qm.cfg.url(), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-API-Key", qm.cfg.APIKey)
+
+	if err := qm.breaker.allow(); err != nil {
+		return nil, err
+	}
 
-	reqCtx.Header = make(map[string][]string)
-	reqCtx.Header["X-API-Key"] = []string{"winged-api-key"}
+	// profiles hold intimate free text, so only log a fingerprint of the payload
+	qm.logger.Debug(ctx, "extmatcher request",
+		applog.F("url", qm.cfg.url()),
+		applog.F("payload", redact(body)),
+	)
 
-	resp, err := qm.cln.Client.Do(reqCtx)
+	resp, err := qm.cln.Client.Do(httpReq)
 	if err != nil {
+		if ctx.Err() != nil { // caller cancellation isn't the service's fault
+			qm.breaker.release()
+		} else {
+			qm.recordFailure(ctx, err)
+		}
 		return nil, fmt.Errorf("do request: %w", err)
 	}
+	defer resp.Body.Close()
 
 	respBody, err := readResponseBody(ctx, resp)
 	if err != nil {
+		qm.recordFailure(ctx, err)
 		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
 
-	fmt.Println("==== AI BACKEND REQUEST ====")
-	fmt.Println(string(body))
-	fmt.Println("==== AI BACKEND RESPONSE ====")
-	fmt.Println("Status:", resp.StatusCode)
-	fmt.Println("Body:", string(respBody))
-	fmt.Println("============================")
+	qm.logger.Debug(ctx, "extmatcher response",
+		applog.F("status", resp.StatusCode),
+		applog.F("payload", redact(respBody)),
+	)
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		err = fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+		qm.recordFailure(ctx, err)
+		return nil, err
+	}
+
+	// the service answered, so it's up, even if the answer is unusable
+	qm.breaker.success()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
 
 	var compatRes matching.MatchCompatibilityResult
 	if err := json.Unmarshal(respBody, &compatRes); err != nil {
 		return nil, fmt.Errorf("unmarshal response body: %w", err)
 	}
 
+	if err := compatRes.Validate(); err != nil {
+		return nil, fmt.Errorf("validate response: %w", err)
+	}
+
 	return &compatRes, nil
 }
+
+// recordFailure counts a failure towards the breaker.
+func (qm *QualitativeMatcher) recordFailure(ctx context.Context, err error) {
+	if qm.breaker.failure() {
+		qm.logger.Warn(ctx, "extmatcher circuit breaker opened",
+			applog.F("cooldown_seconds", qm.cfg.BreakerCooldownDuration),
+			applog.F("error", err.Error()),
+		)
+	}
+}
+
+// redact describes a payload by size and hash, without its content.
+func redact(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return fmt.Sprintf("<redacted %d bytes sha256:%s>", len(payload), hex.EncodeToString(sum[:8]))
+}
@@ -2,23 +2,37 @@ package extmatcher
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 	"wingedapp/pgtester/internal/util/strutil"
 	"wingedapp/pgtester/internal/wingedapp/lib/applog"
 	"wingedapp/pgtester/internal/wingedapp/lib/matching"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestNewQualitativeMatcher(t *testing.T) {
 	t.Skip() // I know this works, won't even bother hitting the external service every time
-	q := NewQualitativeMatcher(applog.NewLogrus("test"))
+	q, err := NewQualitativeMatcher(&QualitativeMatcherCfg{
+		BaseURL:                 "https://winged-ai-backend-dev-1020977593789.asia-east1.run.app",
+		APIKey:                  os.Getenv("EXTMATCHER_API_KEY"),
+		RequestTimeoutDuration:  120,
+		BreakerThreshold:        3,
+		BreakerCooldownDuration: 30,
+		HttpClientCfg:           testHttpClientCfg,
+	}, applog.NewLogrus("test"))
+	require.NoError(t, err)
 
 	req := &matching.ProfileData{
 		Romeo: &matching.PersonProfile{
 			Qualitative: matching.QualitativeSection{
 				SelfPortrait:               "string",
-				Interests:                  "string",
+				CorePassions:               "string",
 				WellbeingHabits:            "string",
 				SelfCareHabits:             "string",
 				MoneyManagement:            "string",
@@ -53,7 +67,7 @@ func TestNewQualitativeMatcher(t *testing.T) {
 		Juliet: &matching.PersonProfile{
 			Qualitative: matching.QualitativeSection{
 				SelfPortrait:               "string",
-				Interests:                  "string",
+				CorePassions:               "string",
 				WellbeingHabits:            "string",
 				SelfCareHabits:             "string",
 				MoneyManagement:            "string",
@@ -93,3 +107,198 @@ func TestNewQualitativeMatcher(t *testing.T) {
 
 	t.Log("===== compatRes:", strutil.GetAsJson(compatRes))
 }
+
+var testHttpClientCfg = HttpClientCfg{
+	GeneralTimeoutDuration: 5,
+	ReadTimeoutDuration:    5,
+	RetryDuration:          1,
+	MaxRetries:             2,
+}
+
+func testProfile() *matching.PersonProfile {
+	return &matching.PersonProfile{
+		Qualitative: matching.QualitativeSection{
+			SelfPortrait:               "string",
+			CorePassions:               "string",
+			WellbeingHabits:            "string",
+			SelfCareHabits:             "string",
+			MoneyManagement:            "string",
+			SelfReflectionCapabilities: "string",
+			MoralFrameworks:            "string",
+			LifeGoals:                  "string",
+			PartnershipValues:          "string",
+			MutualCommitment:           "string",
+			SpiritualityGrowthMindset:  "string",
+			CulturalValues:             "string",
+			FamilyPlanning:             "string",
+			IdealDate:                  "string",
+			RedGreenFlags:              "string",
+		},
+		Quantitative: matching.QuantitativeSection{
+			ExtroversionSocialEnergy: 1,
+			RoutineVsSpontaneity:     1,
+			Agreeableness:            1,
+			Conscientiousness:        1,
+			Neuroticism:              1,
+			DominanceLevel:           1,
+			EmotionalExpressiveness:  1,
+			SexDrive:                 1,
+			GeographicalMobility:     1,
+		},
+		Categorical: matching.CategoricalSection{
+			ConflictResolutionStyle: "validating",
+			SexualityPreferences:    "monogamy",
+			Religion:                "christian",
+		},
+	}
+}
+
+const validResponse = `{
+	"personality_compatibility_score": {"score": 1, "explanation": "x"},
+	"lifestyle_compatibility_score": {"score": 0.6, "explanation": "x"},
+	"values_compatibility_score": {"score": 0.6, "explanation": "x"},
+	"total_score": 0.73
+}`
+
+// testServer answers with the status and body returned by respond, and counts hits.
+type testServer struct {
+	*httptest.Server
+	hits    atomic.Int32
+	respond func(hit int32, r *http.Request) (int, string)
+}
+
+func newTestServer(t *testing.T, respond func(hit int32, r *http.Request) (int, string)) *testServer {
+	t.Helper()
+	ts := &testServer{respond: respond}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, body := ts.respond(ts.hits.Add(1), r)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func newTestMatcher(t *testing.T, baseURL string) *QualitativeMatcher {
+	t.Helper()
+	qm, err := NewQualitativeMatcher(&QualitativeMatcherCfg{
+		BaseURL:                 baseURL,
+		APIKey:                  "test-key",
+		RequestTimeoutDuration:  5,
+		BreakerThreshold:        2,
+		BreakerCooldownDuration: 30,
+		HttpClientCfg:           testHttpClientCfg,
+	}, applog.NewLogrus("test"))
+	require.NoError(t, err)
+	return qm
+}
+
+func qualify(qm *QualitativeMatcher) (*matching.MatchCompatibilityResult, error) {
+	return qm.Qualify(context.Background(), &matching.ProfileData{Romeo: testProfile(), Juliet: testProfile()})
+}
+
+type testCaseQualify struct {
+	name       string
+	respond    func(hit int32, r *http.Request) (int, string)
+	assertions func(t *testing.T, ts *testServer, res *matching.MatchCompatibilityResult, err error)
+}
+
+func TestQualitativeMatcher_Qualify(t *testing.T) {
+	testCases := []testCaseQualify{
+		{
+			name: "sends-api-key-to-configured-url",
+			respond: func(_ int32, r *http.Request) (int, string) {
+				if r.Header.Get("X-API-Key") != "test-key" || r.URL.Path != defaultMatchmakingPath {
+					return http.StatusUnauthorized, ""
+				}
+				return http.StatusOK, validResponse
+			},
+			assertions: func(t *testing.T, ts *testServer, res *matching.MatchCompatibilityResult, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, 0.73, res.TotalScore)
+			},
+		},
+		{
+			name: "retries-5xx-until-success",
+			respond: func(hit int32, _ *http.Request) (int, string) {
+				if hit < 3 {
+					return http.StatusInternalServerError, ""
+				}
+				return http.StatusOK, validResponse
+			},
+			assertions: func(t *testing.T, ts *testServer, res *matching.MatchCompatibilityResult, err error) {
+				require.NoError(t, err)
+				assert.EqualValues(t, 3, ts.hits.Load())
+			},
+		},
+		{
+			name: "4xx-not-retried",
+			respond: func(_ int32, _ *http.Request) (int, string) {
+				return http.StatusBadRequest, ""
+			},
+			assertions: func(t *testing.T, ts *testServer, res *matching.MatchCompatibilityResult, err error) {
+				require.ErrorIs(t, err, ErrUnexpectedStatus)
+				assert.EqualValues(t, 1, ts.hits.Load())
+			},
+		},
+		{
+			name: "out-of-range-score-rejected",
+			respond: func(_ int32, _ *http.Request) (int, string) {
+				return http.StatusOK, `{"total_score": 7}`
+			},
+			assertions: func(t *testing.T, ts *testServer, res *matching.MatchCompatibilityResult, err error) {
+				require.ErrorIs(t, err, matching.ErrCompatibilityScoreOutOfRange)
+				assert.Nil(t, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := newTestServer(t, tc.respond)
+			res, err := qualify(newTestMatcher(t, ts.URL))
+			tc.assertions(t, ts, res, err)
+		})
+	}
+}
+
+func TestQualitativeMatcher_CircuitBreaker(t *testing.T) {
+	now := time.Now()
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	healthy := atomic.Bool{}
+	ts := newTestServer(t, func(_ int32, _ *http.Request) (int, string) {
+		if healthy.Load() {
+			return http.StatusOK, validResponse
+		}
+		return http.StatusServiceUnavailable, ""
+	})
+	qm := newTestMatcher(t, ts.URL)
+
+	// 2 consecutive failures, each after 1 try + 2 retries, open the breaker
+	for range 2 {
+		_, err := qualify(qm)
+		require.ErrorIs(t, err, ErrUnexpectedStatus)
+	}
+	require.EqualValues(t, 6, ts.hits.Load())
+
+	_, err := qualify(qm)
+	require.ErrorIs(t, err, ErrCircuitOpen, "open breaker should fail fast")
+	require.EqualValues(t, 6, ts.hits.Load(), "open breaker should not hit the server")
+
+	// after cooldown, a failing trial re-opens it
+	now = now.Add(31 * time.Second)
+	_, err = qualify(qm)
+	require.ErrorIs(t, err, ErrUnexpectedStatus)
+	_, err = qualify(qm)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+
+	// after another cooldown, a successful trial closes it
+	healthy.Store(true)
+	now = now.Add(31 * time.Second)
+	_, err = qualify(qm)
+	require.NoError(t, err)
+	_, err = qualify(qm)
+	require.NoError(t, err, "closed breaker should let requests through")
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
 
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
@@ -86,29 +89,38 @@ func (l *Logic) ingestUsers(ctx context.Context, exec boil.ContextExecutor, user
 	return matchSet, nil
 }
 
-// createMatchSetForUsers creates a new match set and generates all unique
-// pairings for the given users. Skips pairs that have already been matched before.
+// createMatchSetForUsers creates a new match set and generates the candidate
+// pairings for the given users (see candidatePairs). Skips pairs that have
+// been matched before, until their cooldown passes (see rematchAllowed).
 func (l *Logic) createMatchSetForUsers(ctx context.Context, exec boil.ContextExecutor, users []User) (*MatchSet, error) {
 	settings, err := l.configStorer.Config(ctx, exec, nil)
 	if err != nil {
 		return nil, fmt.Errorf("get config: %w", err)
 	}
+	configVersion, err := l.currentConfigVersion(ctx, exec, settings)
+	if err != nil {
+		return nil, fmt.Errorf("current config version: %w", err)
+	}
 	bytesSettings, err := json.Marshal(settings)
 	if err != nil {
 		return nil, fmt.Errorf("marshal config: %w", err)
 	}
 
-	// Build set of existing pairs to avoid re-matching
-	existingPairs, err := l.getExistingPairs(ctx, exec, users)
+	allPairs, err := l.candidatePairs(ctx, exec, settings, users)
 	if err != nil {
-		return nil, fmt.Errorf("get existing pairs: %w", err)
+		return nil, fmt.Errorf("candidate pairs: %w", err)
 	}
 
-	// Filter out pairs that already exist
-	allPairs := UserPairsUniqPerm(users)
+	// Build set of pairs still blocked by a previous match
+	blockedPairs, err := l.getBlockedPairs(ctx, exec, settings, allPairs)
+	if err != nil {
+		return nil, fmt.Errorf("get blocked pairs: %w", err)
+	}
+
+	// Filter out pairs that can't be matched again yet
 	newPairs := make([]UserPair, 0, len(allPairs))
 	for _, up := range allPairs {
-		if !existingPairs[sortedPairKey(up.UserA.ID, up.UserB.ID)] {
+		if !blockedPairs[sortedPairKey(up.UserA.ID, up.UserB.ID)] {
 			newPairs = append(newPairs, up)
 		}
 	}
@@ -123,44 +135,82 @@ func (l *Logic) createMatchSetForUsers(ctx context.Context, exec boil.ContextExe
 		Name:                 time.Now().Format(time.RFC3339),
 		MatchingParameters:   bytesSettings,
 		NumberOfParticipants: len(users),
+		ConfigVersionID:      configVersion.ID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("insert match set: %w", err)
 	}
 
 	// create match results for new pairs only
-	for _, up := range newPairs {
-		if _, err = l.matchResultStorer.Insert(ctx, exec, &InsertMatchResult{
-			MatchSetID:      matchSet.ID,
-			InitiatorUserID: up.UserA.ID,
-			ReceiverUserID:  up.UserB.ID,
-		}); err != nil {
-			return nil, fmt.Errorf("insert match participant: %w", err)
-		}
+	if err = l.insertMatchResults(ctx, exec, matchSet.ID, newPairs); err != nil {
+		return nil, fmt.Errorf("insert match results: %w", err)
 	}
 
 	return matchSet, nil
 }
 
-// getExistingPairs fetches all existing match_result pairs for the given users
-// and returns a set of sorted pair keys for O(1) lookup.
-func (l *Logic) getExistingPairs(ctx context.Context, exec boil.ContextExecutor, users []User) (map[string]bool, error) {
-	existingPairs := make(map[string]bool)
+// matchResultInsertChunk is how many match results go in one insert statement.
+const matchResultInsertChunk = 1000
+
+// insertMatchResults inserts a match result per pair, in chunks of
+// matchResultInsertChunk rows.
+func (l *Logic) insertMatchResults(ctx context.Context, exec boil.ContextExecutor, matchSetID uuid.UUID, pairs []UserPair) error {
+	for start := 0; start < len(pairs); start += matchResultInsertChunk {
+		chunk := pairs[start:min(start+matchResultInsertChunk, len(pairs))]
+
+		inserters := make([]InsertMatchResult, len(chunk))
+		for i, up := range chunk {
+			inserters[i] = InsertMatchResult{
+				MatchSetID:      matchSetID,
+				InitiatorUserID: up.UserA.ID,
+				ReceiverUserID:  up.UserB.ID,
+			}
+		}
+
+		if _, err := l.matchResultStorer.InsertBatch(ctx, exec, inserters); err != nil {
+			return fmt.Errorf("insert batch at %d: %w", start, err)
+		}
+	}
+
+	return nil
+}
+
+// getBlockedPairs fetches the existing match_result pairs among the users of
+// pairs, and returns the sorted pair keys of those any previous match still
+// blocks, for O(1) lookup.
+func (l *Logic) getBlockedPairs(ctx context.Context, exec boil.ContextExecutor, cfg *Config, pairs UserPairs) (map[string]bool, error) {
+	blockedPairs := make(map[string]bool)
 
-	// Fetch all match results (no filter = all historical matches)
-	// This could be optimized to only fetch pairs involving these users,
-	// but for simplicity we fetch all and filter in memory
-	allResults, err := l.matchResultStorer.MatchResults(ctx, exec, &QueryFilterMatchResult{})
+	involved := make(map[uuid.UUID]struct{})
+	for _, up := range pairs {
+		involved[up.UserA.ID] = struct{}{}
+		involved[up.UserB.ID] = struct{}{}
+	}
+	if len(involved) == 0 {
+		return blockedPairs, nil // an empty filter would fetch every match result
+	}
+
+	userIDs := make([]string, 0, len(involved))
+	for id := range involved {
+		userIDs = append(userIDs, id.String())
+	}
+
+	allResults, err := l.matchResultStorer.MatchResults(ctx, exec, &QueryFilterMatchResult{
+		BetweenUserIDs: userIDs,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("fetch all match results: %w", err)
 	}
 
-	for _, mr := range allResults.Data {
-		key := sortedPairKey(mr.InitiatorUserID, mr.ReceiverUserID)
-		existingPairs[key] = true
+	now := timeNow()
+	for i := range allResults.Data {
+		mr := &allResults.Data[i]
+		if !rematchAllowed(cfg, mr, now) {
+			blockedPairs[sortedPairKey(mr.InitiatorUserID, mr.ReceiverUserID)] = true
+		}
 	}
 
-	return existingPairs, nil
+	return blockedPairs, nil
 }
 
 // sortedPairKey returns a consistent key for a user pair regardless of order.
@@ -189,35 +239,177 @@ func (l *Logic) allUsers(ctx context.Context, exec boil.ContextExecutor) ([]User
 // RunIngestionSet runs the matching algorithm on all MatchResults in a given MatchSet.
 // It processes each pair through ProcessMatchResult which evaluates hard qualifiers
 // (age, dating prefs, height, distance) and qualitative matching if hard qualifiers pass.
-// Processing continues even if individual pairs fail - errors are logged but don't stop the batch.
+// Processing continues even if individual pairs fail - a failed pair is marked Failed
+// with its error, and doesn't stop the batch.
 //
-// Uses pond worker pool with batchMatchWorkers (50) concurrent workers for parallel processing.
+// Each run is recorded against the match set, and pairs an earlier run already
+// processed are skipped, so rerunning a set that died mid-batch, or had failed
+// pairs, resumes where it stopped. When pairs failed, the summary comes back
+// with ErrIngestionIncomplete, and the set's ingestion status is Failed.
+//
+// Once ctx is done no more pairs are started: the pairs in flight are drained,
+// the rest stay pending for the next run, and the run is recorded as Failed.
+//
+// Uses pond worker pool with batchWorkers (SetBatchWorkers, batchMatchWorkers by default)
+// concurrent workers for parallel processing.
 // IMPORTANT: exec MUST be a DB pool (not a transaction) for safe concurrent access.
 // Each goroutine will get its own connection from the pool.
 //
 // aiExec is the executor for ai_backend database (for profile lookups).
-func (l *Logic) RunIngestionSet(ctx context.Context, exec boil.ContextExecutor, aiExec boil.ContextExecutor, matchSetID uuid.UUID) error {
+func (l *Logic) RunIngestionSet(ctx context.Context, exec boil.ContextExecutor, aiExec boil.ContextExecutor, matchSetID uuid.UUID) (*IngestionSetSummary, error) {
+	run, err := l.matchSetStorer.StartIngestionRun(ctx, exec, matchSetID, timeNow())
+	if err != nil {
+		return nil, fmt.Errorf("start ingestion run for set %s: %w", matchSetID, err)
+	}
+
+	// Every pair of the set is qualified against the config the set was created with
+	config, err := l.matchSetConfig(ctx, exec, matchSetID)
+	if err != nil {
+		err = fmt.Errorf("config of set %s: %w", matchSetID, err)
+		l.abortIngestionRun(ctx, exec, run, err)
+		return nil, err
+	}
+
 	// Fetch all MatchResults for this MatchSet
 	results, err := l.matchResultStorer.MatchResults(ctx, exec, &QueryFilterMatchResult{
 		MatchSetID: null.StringFrom(matchSetID.String()),
 	})
 	if err != nil {
-		return fmt.Errorf("fetch match results for set %s: %w", matchSetID, err)
+		err = fmt.Errorf("fetch match results for set %s: %w", matchSetID, err)
+		l.abortIngestionRun(ctx, exec, run, err)
+		return nil, err
 	}
 
-	// Process each MatchResult through the matching algorithm concurrently.
+	// Process each pending or failed MatchResult through the matching algorithm concurrently.
 	// Using pond worker pool for parallel processing - each goroutine gets its own
 	// connection from the DB pool, enabling safe concurrent database access.
-	p := pond.New(batchMatchWorkers, len(results.Data))
+	var (
+		stats  ingestionStats
+		counts ingestionRunCounts
+	)
+	// pairs already started finish even if ctx is cancelled meanwhile
+	pairCtx := context.WithoutCancel(ctx)
+	p := pond.New(l.batchWorkers, len(results.Data))
 	for i := range results.Data {
 		result := &results.Data[i]
+		if result.IngestionStatus == string(enums.MatchResultIngestionStatusProcessed) {
+			counts.skipped.Add(1)
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
 		p.Submit(func() {
-			// Errors are logged but don't stop the batch - one bad pair shouldn't stop processing.
-			// Error details are stored in QualifierResults for visibility.
-			_, _ = l.ProcessMatchResult(ctx, exec, aiExec, result)
+			if ctx.Err() != nil {
+				return // queued when ctx was cancelled, left for the next run
+			}
+			if _, err := l.processMatchResult(pairCtx, exec, aiExec, result, config, &stats); err != nil {
+				counts.fail(err)
+				l.failMatchResult(ctx, exec, result.ID, err)
+				return
+			}
+			counts.processed.Add(1)
 		})
 	}
 	p.StopAndWait()
 
-	return nil
+	summary := &IngestionSetSummary{
+		MatchSetID:             matchSetID,
+		RunID:                  run.ID,
+		Status:                 enums.IngestionStatusCompleted,
+		Pairs:                  len(results.Data),
+		Processed:              int(counts.processed.Load()),
+		Failed:                 int(counts.failed.Load()),
+		Skipped:                int(counts.skipped.Load()),
+		LastError:              counts.lastError(),
+		QualitativeCacheHits:   stats.cacheHits.Load(),
+		QualitativeCacheMisses: stats.cacheMisses.Load(),
+		StartedAt:              run.StartedAt,
+		FinishedAt:             timeNow(),
+	}
+	summary.Pending = summary.Pairs - summary.Processed - summary.Failed - summary.Skipped
+	if summary.Pending > 0 && summary.LastError == "" {
+		summary.LastError = fmt.Sprintf("cancelled: %v", context.Cause(ctx))
+	}
+	if summary.Failed > 0 || summary.Pending > 0 {
+		summary.Status = enums.IngestionStatusFailed
+	}
+
+	// record the outcome even if ctx was cancelled mid-batch
+	if err = l.matchSetStorer.FinishIngestionRun(context.WithoutCancel(ctx), exec, &FinishIngestionRun{
+		ID:         run.ID,
+		MatchSetID: matchSetID,
+		Status:     summary.Status,
+		Pairs:      summary.Pairs,
+		Processed:  summary.Processed,
+		Failed:     summary.Failed,
+		Skipped:    summary.Skipped,
+		LastError:  null.NewString(summary.LastError, summary.LastError != ""),
+		FinishedAt: summary.FinishedAt,
+	}); err != nil {
+		return summary, fmt.Errorf("finish ingestion run %s: %w", run.ID, err)
+	}
+
+	if summary.Pending > 0 {
+		return summary, fmt.Errorf("%w: cancelled with %d of %d pairs pending: %w",
+			ErrIngestionIncomplete, summary.Pending, summary.Pairs, context.Cause(ctx))
+	}
+	if summary.Failed > 0 {
+		return summary, fmt.Errorf("%w: %d of %d pairs failed, last: %s",
+			ErrIngestionIncomplete, summary.Failed, summary.Pairs, summary.LastError)
+	}
+
+	return summary, nil
+}
+
+// ingestionRunCounts counts the outcome of a run's pairs across its workers.
+type ingestionRunCounts struct {
+	processed atomic.Int64
+	failed    atomic.Int64
+	skipped   atomic.Int64
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+func (c *ingestionRunCounts) fail(err error) {
+	c.failed.Add(1)
+	c.mu.Lock()
+	c.lastErr = err
+	c.mu.Unlock()
+}
+
+func (c *ingestionRunCounts) lastError() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lastErr == nil {
+		return ""
+	}
+	return c.lastErr.Error()
+}
+
+// failMatchResult marks a pair Failed with the error that failed it, so the
+// next run retries it. It's best effort: a pair it can't mark stays pending,
+// which the next run retries all the same. It's recorded even if ctx was cancelled.
+func (l *Logic) failMatchResult(ctx context.Context, exec boil.ContextExecutor, matchResultID uuid.UUID, cause error) {
+	if _, err := l.matchResultStorer.Update(context.WithoutCancel(ctx), exec, &UpdateMatchResult{
+		ID:              matchResultID,
+		IngestionStatus: null.StringFrom(string(enums.MatchResultIngestionStatusFailed)),
+		IngestionError:  null.StringFrom(cause.Error()),
+	}); err != nil {
+		fmt.Printf("[RunIngestionSet] ERROR marking match result %s failed: %v\n", matchResultID, err)
+	}
+}
+
+// abortIngestionRun records a run that failed before processing any pair.
+func (l *Logic) abortIngestionRun(ctx context.Context, exec boil.ContextExecutor, run *IngestionRun, cause error) {
+	if err := l.matchSetStorer.FinishIngestionRun(context.WithoutCancel(ctx), exec, &FinishIngestionRun{
+		ID:         run.ID,
+		MatchSetID: run.MatchSetID,
+		Status:     enums.IngestionStatusFailed,
+		LastError:  null.StringFrom(cause.Error()),
+		FinishedAt: timeNow(),
+	}); err != nil {
+		fmt.Printf("[RunIngestionSet] ERROR finishing aborted run %s: %v\n", run.ID, err)
+	}
 }
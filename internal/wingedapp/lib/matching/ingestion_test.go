@@ -6,8 +6,11 @@ import (
 	"os"
 	"testing"
 	"time"
+	"wingedapp/pgtester/internal/db/factory"
+	wingedFactory "wingedapp/pgtester/internal/wingedapp/db/factory"
 	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
 	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
 	"wingedapp/pgtester/internal/wingedapp/lib/matching"
 	"wingedapp/pgtester/internal/wingedapp/lib/matching/store"
 	"wingedapp/pgtester/internal/wingedapp/lib/matching/testhelper"
@@ -82,7 +85,8 @@ func TestIngestion(t *testing.T) {
 			require.NoError(t, err, "fetching users for permutation calc")
 			require.NotEmpty(t, users, "users exist for permutation calc")
 
-			require.Len(t, paginateMatchResults.Data, len(matching.UserPairsUniqPerm(users)), "expecting single match set")
+			// all 6 users are men, and only 3 of them date men
+			require.Len(t, paginateMatchResults.Data, 3, "expecting the 3 pairs of men dating men")
 		})
 	}
 }
@@ -487,6 +491,7 @@ func TestIngestion_MatchResultsCreated(t *testing.T) {
 
 	// Ingest population data
 	ingestor := testhelper.NewPopulationIngestor(t, testSuite, matchLib)
+	ingestor.DisableCandidatePrefilter() // count every pair
 	parseResult, populateResult, err := ingestor.IngestFromCSVFile("./testdata/population_1.csv")
 	require.NoError(t, err, "ingesting population data")
 	require.Empty(t, populateResult.Errors, "expected no populate errors")
@@ -657,6 +662,10 @@ func TestIngestion_OnlyTestUsers(t *testing.T) {
 	batchOptions := &matching.BatchIngestOptions{
 		IsTestUser: &isTestUser,
 	}
+	_, err = testhelper.UpdateConfig(ctx, testSuite.BackendAppDb(), matchLib, &matching.UpdateMatchConfig{
+		CandidatePrefilter: null.BoolFrom(false), // count every pair
+	})
+	require.NoError(t, err, "disabling candidate prefilter")
 	matchSet, err := matchLib.IngestWithOptions(ctx, testSuite.BackendAppDb(), batchOptions)
 	require.NoError(t, err, "ingesting only test users")
 	require.NotNil(t, matchSet, "match set should not be nil")
@@ -716,6 +725,10 @@ func TestIngestion_OnlyNonTestUsers(t *testing.T) {
 	batchOptions := &matching.BatchIngestOptions{
 		IsTestUser: &isTestUser,
 	}
+	_, err = testhelper.UpdateConfig(ctx, testSuite.BackendAppDb(), matchLib, &matching.UpdateMatchConfig{
+		CandidatePrefilter: null.BoolFrom(false), // count every pair
+	})
+	require.NoError(t, err, "disabling candidate prefilter")
 	matchSet, err := matchLib.IngestWithOptions(ctx, testSuite.BackendAppDb(), batchOptions)
 	require.NoError(t, err, "ingesting only non-test users")
 	require.NotNil(t, matchSet, "match set should not be nil")
@@ -734,3 +747,148 @@ func TestIngestion_OnlyNonTestUsers(t *testing.T) {
 	require.NoError(t, err, "fetching match results")
 	assert.Len(t, paginatedResults.Data, 3, "should have 3 match pairs for 3 regular users")
 }
+
+type testCaseRematchCooldown struct {
+	name string
+	// previous is the pair's previous match, as it ended
+	previous      *pgmodel.MatchResult
+	expectRematch bool
+}
+
+// TestIngestion_RematchCooldown verifies a pair previously matched is only
+// matched again once the cooldown for how that match ended has passed.
+func TestIngestion_RematchCooldown(t *testing.T) {
+	const blockHours = 168 // match_block_* defaults
+	withinCooldown := time.Now().Add(-(blockHours - 1) * time.Hour)
+	pastCooldown := time.Now().Add(-(blockHours + 1) * time.Hour)
+
+	testCases := []testCaseRematchCooldown{
+		{
+			name: "passed-within-cooldown-blocked",
+			previous: &pgmodel.MatchResult{
+				IsApproved:       true,
+				IsDropped:        true,
+				ReceiverAction:   string(enums.MatchUserActionPassed),
+				ReceiverActionAt: null.TimeFrom(withinCooldown),
+			},
+			expectRematch: false,
+		},
+		{
+			name: "passed-past-cooldown-rematched",
+			previous: &pgmodel.MatchResult{
+				IsApproved:       true,
+				IsDropped:        true,
+				ReceiverAction:   string(enums.MatchUserActionPassed),
+				ReceiverActionAt: null.TimeFrom(pastCooldown),
+			},
+			expectRematch: true,
+		},
+		{
+			name: "expired-unseen-within-cooldown-blocked",
+			previous: &pgmodel.MatchResult{
+				IsApproved: true,
+				IsDropped:  true,
+				IsExpired:  true,
+				ExpiresAt:  null.TimeFrom(withinCooldown),
+			},
+			expectRematch: false,
+		},
+		{
+			name: "expired-unseen-past-cooldown-rematched",
+			previous: &pgmodel.MatchResult{
+				IsApproved: true,
+				IsDropped:  true,
+				IsExpired:  true,
+				ExpiresAt:  null.TimeFrom(pastCooldown),
+			},
+			expectRematch: true,
+		},
+		{
+			name: "closed-after-date-within-cooldown-blocked",
+			previous: &pgmodel.MatchResult{
+				IsApproved:           true,
+				IsDropped:            true,
+				MatchLifecycleStatus: null.StringFrom(string(enums.MatchLifecycleStatusClosed)),
+				ClosedAt:             null.TimeFrom(withinCooldown),
+			},
+			expectRematch: false,
+		},
+		{
+			name: "closed-after-date-past-cooldown-rematched",
+			previous: &pgmodel.MatchResult{
+				IsApproved:           true,
+				IsDropped:            true,
+				MatchLifecycleStatus: null.StringFrom(string(enums.MatchLifecycleStatusClosed)),
+				ClosedAt:             null.TimeFrom(pastCooldown),
+			},
+			expectRematch: true,
+		},
+		{
+			name: "closed-past-cooldown-updated-since-rematched",
+			previous: &pgmodel.MatchResult{
+				IsApproved:           true,
+				IsDropped:            true,
+				MatchLifecycleStatus: null.StringFrom(string(enums.MatchLifecycleStatusClosed)),
+				ClosedAt:             null.TimeFrom(pastCooldown),
+				UpdatedAt:            null.TimeFrom(time.Now()), // later writes don't restart the cooldown
+			},
+			expectRematch: true,
+		},
+		{
+			name: "undecided-match-blocks-however-old",
+			previous: &pgmodel.MatchResult{
+				IsApproved: true,
+				IsDropped:  true,
+			},
+			expectRematch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testSuite := testsuite.New(t)
+			t.Cleanup(testSuite.UseBackendDB())
+
+			ctx := context.Background()
+			exec := testSuite.BackendAppDb()
+			matchLib := testSuite.FakeContainer().GetLibMatching()
+
+			// factory users have no location nor dating prefs, pair every user
+			_, err := testhelper.UpdateConfig(ctx, exec, matchLib, &matching.UpdateMatchConfig{
+				CandidatePrefilter: null.BoolFrom(false),
+			})
+			require.NoError(t, err, "disabling candidate prefilter")
+
+			previous := factory.NewEntity[*wingedFactory.MatchResult](&wingedFactory.MatchResult{
+				Subject: tc.previous,
+			}).New(t, exec)
+
+			// the previous match is as old as its outcome, at least
+			_, err = exec.ExecContext(ctx,
+				"UPDATE match_result SET created_at = $1, updated_at = $2 WHERE id = $3",
+				pastCooldown.Add(-time.Hour), tc.previous.UpdatedAt, previous.Subject.ID)
+			require.NoError(t, err, "backdating previous match")
+
+			matchSet, err := matchLib.IngestAll(ctx, exec)
+			require.NoError(t, err, "ingesting all users")
+
+			if !tc.expectRematch {
+				assert.Nil(t, matchSet, "no match set expected, the only pair is blocked")
+				return
+			}
+
+			require.NotNil(t, matchSet, "a match set for the pair expected")
+			results, err := pgmodel.MatchResults(
+				pgmodel.MatchResultWhere.MatchSetRefID.EQ(matchSet.ID.String()),
+			).All(ctx, exec)
+			require.NoError(t, err, "fetching match results")
+			require.Len(t, results, 1, "the pair should be matched again")
+			assert.ElementsMatch(t,
+				[]string{previous.Subject.InitiatorUserRefID, previous.Subject.ReceiverUserRefID},
+				[]string{results[0].InitiatorUserRefID, results[0].ReceiverUserRefID},
+			)
+		})
+	}
+}
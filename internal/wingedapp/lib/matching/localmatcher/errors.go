@@ -0,0 +1,7 @@
+package localmatcher
+
+import "errors"
+
+var (
+	ErrMissingProfile = errors.New("romeo and juliet profiles are required")
+)
@@ -0,0 +1,257 @@
+package localmatcher
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+)
+
+// section weights of the total score
+const (
+	personalityWeight = 0.40
+	lifestyleWeight   = 0.25
+	valuesWeight      = 0.35
+)
+
+// version identifies this matcher's results, bump it when scoring changes
+// so cached results of the old scoring aren't reused.
+const version = "local:1"
+
+// QualitativeMatcher scores a pair locally, from the Quantitative and
+// Categorical sections of their profiles. It is deterministic and needs
+// no network, so it's a drop-in for extmatcher.QualitativeMatcher when
+// running offline or in tests.
+type QualitativeMatcher struct{}
+
+func NewQualitativeMatcher() *QualitativeMatcher {
+	return &QualitativeMatcher{}
+}
+
+// Version identifies the matcher's results.
+func (qm *QualitativeMatcher) Version() string {
+	return version
+}
+
+func (qm *QualitativeMatcher) Qualify(
+	ctx context.Context,
+	req *matching.QualitativeMatchRequest,
+) (*matching.MatchCompatibilityResult, error) {
+	if req == nil || req.Romeo == nil || req.Juliet == nil {
+		return nil, ErrMissingProfile
+	}
+
+	personality := personalityScore(req.Romeo, req.Juliet)
+	lifestyle := lifestyleScore(req.Romeo, req.Juliet)
+	values := valuesScore(req.Romeo, req.Juliet)
+
+	return &matching.MatchCompatibilityResult{
+		PersonalityCompatibilityScore: personality,
+		LifestyleCompatibilityScore:   lifestyle,
+		ValuesCompatibilityScore:      values,
+		TotalScore: round(personality.Score*personalityWeight +
+			lifestyle.Score*lifestyleWeight +
+			values.Score*valuesWeight),
+	}, nil
+}
+
+// personalityScore combines personality trait distance
+// with attachment and conflict resolution style compatibility.
+func personalityScore(romeo, juliet *matching.PersonProfile) matching.CompatibilityScore {
+	a, b := romeo.Quantitative, juliet.Quantitative
+	traitScore, traitExplanation := traitSimilarity([]trait{
+		{"extroversion", a.ExtroversionSocialEnergy, b.ExtroversionSocialEnergy, true},
+		{"agreeableness", a.Agreeableness, b.Agreeableness, true},
+		{"conscientiousness", a.Conscientiousness, b.Conscientiousness, true},
+		{"neuroticism", a.Neuroticism, b.Neuroticism, true},
+		{"dominance", a.DominanceLevel, b.DominanceLevel, true},
+		{"emotional expressiveness", a.EmotionalExpressiveness, b.EmotionalExpressiveness, true},
+	})
+
+	attachment := categoryPart("Attachment styles", 0.2, attachmentTable, attachmentAliases,
+		romeo.Categorical.AttachmentStyle, juliet.Categorical.AttachmentStyle)
+	conflict := categoryPart("Conflict resolution styles", 0.2, conflictTable, conflictAliases,
+		romeo.Categorical.ConflictResolutionStyle, juliet.Categorical.ConflictResolutionStyle)
+
+	return combine(
+		part{score: traitScore, weight: 0.6, explanation: traitExplanation, ok: true},
+		attachment,
+		conflict,
+	)
+}
+
+// lifestyleScore is the distance of the lifestyle traits.
+func lifestyleScore(romeo, juliet *matching.PersonProfile) matching.CompatibilityScore {
+	a, b := romeo.Quantitative, juliet.Quantitative
+	score, explanation := traitSimilarity([]trait{
+		{"routine vs spontaneity", a.RoutineVsSpontaneity, b.RoutineVsSpontaneity, true},
+		{"sex drive", a.SexDrive, b.SexDrive, true},
+		{"geographical mobility", a.GeographicalMobility, b.GeographicalMobility, true},
+		{"financial risk tolerance", a.FinancialRiskTolerance, b.FinancialRiskTolerance, false},
+		{"abstract vs concrete", a.AbstractVsConcrete, b.AbstractVsConcrete, false},
+	})
+
+	return combine(part{score: score, weight: 1, explanation: explanation, ok: true})
+}
+
+// valuesScore combines religion and sexuality preference compatibility.
+func valuesScore(romeo, juliet *matching.PersonProfile) matching.CompatibilityScore {
+	religion := religionPart(romeo.Categorical.Religion, juliet.Categorical.Religion)
+
+	sexuality := part{weight: 0.3}
+	prefA := normalize(nil, romeo.Categorical.SexualityPreferences)
+	prefB := normalize(nil, juliet.Categorical.SexualityPreferences)
+	if prefA != "" && prefB != "" {
+		sexuality.ok = true
+		sexuality.score = 0.4
+		sexuality.explanation = fmt.Sprintf("Sexuality preferences differ (%s vs %s).", prefA, prefB)
+		if prefA == prefB {
+			sexuality.score = 1
+			sexuality.explanation = fmt.Sprintf("Shared sexuality preference (%s).", prefA)
+		}
+	}
+
+	return combine(religion, sexuality)
+}
+
+func religionPart(religionA, religionB string) part {
+	p := part{weight: 0.7}
+
+	a, b := normalize(nil, religionA), normalize(nil, religionB)
+	if a == "" || b == "" {
+		return p
+	}
+
+	p.ok = true
+	if a == b {
+		p.score = sameReligionScore
+		p.explanation = fmt.Sprintf("Both %s.", a)
+		return p
+	}
+
+	p.score, _ = religionTable.score(religionGroup(a), religionGroup(b))
+	p.explanation = fmt.Sprintf("Religions %s and %s: %.2f.", a, b, p.score)
+	return p
+}
+
+// categoryPart scores a categorical pair from its table. It's skipped
+// when either side is missing.
+func categoryPart(
+	label string,
+	weight float64,
+	table compatTable,
+	aliases map[string]string,
+	valueA, valueB string,
+) part {
+	p := part{weight: weight}
+
+	a, b := normalize(aliases, valueA), normalize(aliases, valueB)
+	if a == "" || b == "" {
+		return p
+	}
+
+	score, known := table.score(a, b)
+	p.ok = true
+	p.score = score
+	p.explanation = fmt.Sprintf("%s %s and %s: %.2f.", label, a, b, score)
+	if !known {
+		p.explanation = fmt.Sprintf("%s %s and %s are unrated, scored neutral.", label, a, b)
+	}
+	return p
+}
+
+// trait is a pair of trait values in [0, 1]. Optional traits
+// are skipped when either side is unset (0).
+type trait struct {
+	name     string
+	a, b     float64
+	required bool
+}
+
+// traitSimilarity is 1 minus the mean distance of the traits,
+// and names the closest and furthest of them.
+func traitSimilarity(traits []trait) (float64, string) {
+	var (
+		diff                float64
+		n                   int
+		closest, furthest   *trait
+		closestD, furthestD float64
+	)
+
+	for i := range traits {
+		t := &traits[i]
+		if !t.required && (t.a == 0 || t.b == 0) {
+			continue
+		}
+
+		d := math.Abs(clamp01(t.a) - clamp01(t.b))
+		diff += d
+		n++
+
+		if closest == nil || d < closestD {
+			closest, closestD = t, d
+		}
+		if furthest == nil || d > furthestD {
+			furthest, furthestD = t, d
+		}
+	}
+
+	if n == 0 {
+		return neutralScore, "No trait data, scored neutral."
+	}
+
+	score := 1 - diff/float64(n)
+	if closestD == furthestD {
+		return score, fmt.Sprintf("Equally apart on all %d traits (%.2f).", n, closestD)
+	}
+
+	return score, fmt.Sprintf("Closest on %s (%.2f vs %.2f), furthest apart on %s (%.2f vs %.2f).",
+		closest.name, closest.a, closest.b, furthest.name, furthest.a, furthest.b)
+}
+
+// part is one weighted component of a CompatibilityScore.
+type part struct {
+	score       float64
+	weight      float64
+	explanation string
+	ok          bool // false when its inputs are missing
+}
+
+// combine weighs the available parts into a CompatibilityScore.
+func combine(parts ...part) matching.CompatibilityScore {
+	var (
+		sum, weights float64
+		explanations []string
+	)
+
+	for _, p := range parts {
+		if !p.ok {
+			continue
+		}
+		sum += p.score * p.weight
+		weights += p.weight
+		explanations = append(explanations, p.explanation)
+	}
+
+	if weights == 0 {
+		return matching.CompatibilityScore{
+			Score:       neutralScore,
+			Explanation: "Not enough profile data, scored neutral.",
+		}
+	}
+
+	return matching.CompatibilityScore{
+		Score:       round(sum / weights),
+		Explanation: strings.Join(explanations, " "),
+	}
+}
+
+func clamp01(f float64) float64 {
+	return math.Min(1, math.Max(0, f))
+}
+
+// round keeps 2 decimals, like the external matcher's scores.
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,118 @@
+package localmatcher_test
+
+import (
+	"context"
+	"testing"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching/localmatcher"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func profile(trait float64, attachment, conflict, religion string) *matching.PersonProfile {
+	return &matching.PersonProfile{
+		Quantitative: matching.QuantitativeSection{
+			ExtroversionSocialEnergy: trait,
+			RoutineVsSpontaneity:     trait,
+			Agreeableness:            trait,
+			Conscientiousness:        trait,
+			Neuroticism:              trait,
+			DominanceLevel:           trait,
+			EmotionalExpressiveness:  trait,
+			SexDrive:                 trait,
+			GeographicalMobility:     trait,
+		},
+		Categorical: matching.CategoricalSection{
+			AttachmentStyle:         attachment,
+			ConflictResolutionStyle: conflict,
+			SexualityPreferences:    "heterosexual",
+			Religion:                religion,
+		},
+	}
+}
+
+type testCaseQualify struct {
+	name       string
+	req        *matching.QualitativeMatchRequest
+	assertions func(t *testing.T, res *matching.MatchCompatibilityResult, err error)
+}
+
+func TestQualitativeMatcher_Qualify(t *testing.T) {
+	testCases := []testCaseQualify{
+		{
+			name: "identical-secure-profiles-score-top",
+			req: &matching.QualitativeMatchRequest{
+				Romeo:  profile(0.6, "secure", "collaborative", "christian"),
+				Juliet: profile(0.6, "Secure", "Collaborative", "Christian"),
+			},
+			assertions: func(t *testing.T, res *matching.MatchCompatibilityResult, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, 1.0, res.PersonalityCompatibilityScore.Score)
+				assert.Equal(t, 1.0, res.LifestyleCompatibilityScore.Score)
+				assert.Equal(t, 1.0, res.ValuesCompatibilityScore.Score)
+				assert.Equal(t, 1.0, res.TotalScore)
+				assert.Contains(t, res.ValuesCompatibilityScore.Explanation, "Both christian")
+			},
+		},
+		{
+			name: "anxious-avoidant-and-distant-traits-score-low",
+			req: &matching.QualitativeMatchRequest{
+				Romeo:  profile(0.1, "anxious", "competitive", "atheist"),
+				Juliet: profile(0.9, "avoidant", "avoiding", "muslim"),
+			},
+			assertions: func(t *testing.T, res *matching.MatchCompatibilityResult, err error) {
+				require.NoError(t, err)
+				assert.Less(t, res.PersonalityCompatibilityScore.Score, 0.3)
+				assert.Less(t, res.TotalScore, 0.4)
+				assert.Contains(t, res.PersonalityCompatibilityScore.Explanation, "anxious and avoidant")
+				assert.Contains(t, res.PersonalityCompatibilityScore.Explanation, "competitive and avoidant",
+					"aliases should map onto the table's styles")
+			},
+		},
+		{
+			name: "missing-categories-are-skipped",
+			req: &matching.QualitativeMatchRequest{
+				Romeo:  profile(0.5, "", "", ""),
+				Juliet: profile(0.5, "", "", ""),
+			},
+			assertions: func(t *testing.T, res *matching.MatchCompatibilityResult, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, 1.0, res.PersonalityCompatibilityScore.Score, "only traits count")
+				assert.NotEmpty(t, res.ValuesCompatibilityScore.Explanation)
+			},
+		},
+		{
+			name: "missing-profile",
+			req:  &matching.QualitativeMatchRequest{Romeo: profile(0.5, "", "", "")},
+			assertions: func(t *testing.T, res *matching.MatchCompatibilityResult, err error) {
+				require.ErrorIs(t, err, localmatcher.ErrMissingProfile)
+				assert.Nil(t, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := localmatcher.NewQualitativeMatcher().Qualify(context.Background(), tc.req)
+			tc.assertions(t, res, err)
+		})
+	}
+}
+
+func TestQualitativeMatcher_Deterministic(t *testing.T) {
+	req := &matching.QualitativeMatchRequest{
+		Romeo:  profile(0.3, "secure", "compromising", "agnostic"),
+		Juliet: profile(0.7, "fearful-avoidant", "accommodating", "spiritual"),
+	}
+
+	qm := localmatcher.NewQualitativeMatcher()
+	first, err := qm.Qualify(context.Background(), req)
+	require.NoError(t, err)
+
+	for range 10 {
+		again, err := qm.Qualify(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, first, again)
+	}
+}
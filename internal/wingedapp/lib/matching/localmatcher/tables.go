@@ -0,0 +1,132 @@
+package localmatcher
+
+import "strings"
+
+// neutralScore is used when a category is missing or unknown.
+const neutralScore = 0.5
+
+// compatTable holds symmetric pair scores in [0, 1], keyed by normalized category.
+type compatTable map[[2]string]float64
+
+// score looks up a pair in either order, and falls back to neutralScore.
+func (t compatTable) score(a, b string) (float64, bool) {
+	if s, ok := t[[2]string{a, b}]; ok {
+		return s, true
+	}
+	if s, ok := t[[2]string{b, a}]; ok {
+		return s, true
+	}
+	return neutralScore, false
+}
+
+// normalize lower-cases a category, and maps known aliases onto it.
+func normalize(aliases map[string]string, v string) string {
+	v = strings.ToLower(strings.TrimSpace(v))
+	v = strings.ReplaceAll(v, "_", "-")
+	if alias, ok := aliases[v]; ok {
+		return alias
+	}
+	return v
+}
+
+const (
+	attachmentSecure   = "secure"
+	attachmentAnxious  = "anxious"
+	attachmentAvoidant = "avoidant"
+	attachmentFearful  = "fearful"
+)
+
+var attachmentAliases = map[string]string{
+	"anxious-preoccupied": attachmentAnxious,
+	"preoccupied":         attachmentAnxious,
+	"dismissive":          attachmentAvoidant,
+	"dismissive-avoidant": attachmentAvoidant,
+	"fearful-avoidant":    attachmentFearful,
+	"disorganized":        attachmentFearful,
+}
+
+// attachmentTable scores attachment style pairs. Secure partners pair well
+// with anyone, the anxious-avoidant trap scores lowest.
+var attachmentTable = compatTable{
+	{attachmentSecure, attachmentSecure}:     1.0,
+	{attachmentSecure, attachmentAnxious}:    0.75,
+	{attachmentSecure, attachmentAvoidant}:   0.7,
+	{attachmentSecure, attachmentFearful}:    0.6,
+	{attachmentAnxious, attachmentAnxious}:   0.5,
+	{attachmentAnxious, attachmentAvoidant}:  0.2,
+	{attachmentAnxious, attachmentFearful}:   0.35,
+	{attachmentAvoidant, attachmentAvoidant}: 0.45,
+	{attachmentAvoidant, attachmentFearful}:  0.3,
+	{attachmentFearful, attachmentFearful}:   0.3,
+}
+
+const (
+	conflictCollaborative = "collaborative"
+	conflictCompromising  = "compromising"
+	conflictAccommodating = "accommodating"
+	conflictAvoidant      = "avoidant"
+	conflictCompetitive   = "competitive"
+)
+
+var conflictAliases = map[string]string{
+	"validating":        conflictCollaborative,
+	"avoiding":          conflictAvoidant,
+	"conflict-avoiding": conflictAvoidant,
+	"volatile":          conflictCompetitive,
+	"hostile":           conflictCompetitive,
+}
+
+// conflictTable scores conflict resolution style pairs.
+var conflictTable = compatTable{
+	{conflictCollaborative, conflictCollaborative}: 1.0,
+	{conflictCollaborative, conflictCompromising}:  0.85,
+	{conflictCollaborative, conflictAccommodating}: 0.75,
+	{conflictCollaborative, conflictAvoidant}:      0.5,
+	{conflictCollaborative, conflictCompetitive}:   0.55,
+	{conflictCompromising, conflictCompromising}:   0.8,
+	{conflictCompromising, conflictAccommodating}:  0.75,
+	{conflictCompromising, conflictAvoidant}:       0.5,
+	{conflictCompromising, conflictCompetitive}:    0.5,
+	{conflictAccommodating, conflictAccommodating}: 0.6,
+	{conflictAccommodating, conflictAvoidant}:      0.45,
+	{conflictAccommodating, conflictCompetitive}:   0.5,
+	{conflictAvoidant, conflictAvoidant}:           0.4,
+	{conflictAvoidant, conflictCompetitive}:        0.25,
+	{conflictCompetitive, conflictCompetitive}:     0.3,
+}
+
+const (
+	religionSecular   = "secular"
+	religionSpiritual = "spiritual"
+	religionReligious = "religious"
+)
+
+// religionGroups maps a religion onto its group, anything unlisted is religious.
+var religionGroups = map[string]string{
+	"none":          religionSecular,
+	"secular":       religionSecular,
+	"atheist":       religionSecular,
+	"agnostic":      religionSecular,
+	"non-religious": religionSecular,
+	"spiritual":     religionSpiritual,
+}
+
+// religionTable scores religion group pairs. Two people of the same
+// religion score sameReligionScore instead.
+var religionTable = compatTable{
+	{religionSecular, religionSecular}:     0.9,
+	{religionSecular, religionSpiritual}:   0.7,
+	{religionSecular, religionReligious}:   0.3,
+	{religionSpiritual, religionSpiritual}: 0.85,
+	{religionSpiritual, religionReligious}: 0.6,
+	{religionReligious, religionReligious}: 0.4, // different religions
+}
+
+const sameReligionScore = 1.0
+
+func religionGroup(religion string) string {
+	if g, ok := religionGroups[religion]; ok {
+		return g
+	}
+	return religionReligious
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/google/uuid"
+	"golang.org/x/time/rate"
 
 	"wingedapp/pgtester/internal/wingedapp/lib/enums"
 )
@@ -44,6 +45,16 @@ type Logic struct {
 	// Date instance dependencies (Tier 1)
 	dateInstanceInserter dateInstanceInserter
 	matchResultUpdater   matchResultUpdater
+
+	// hard qualifiers, enabled and ordered by match_config.qualifiers
+	qualifierRegistry *qualifierRegistry
+
+	// optional, qualitative results are not cached when nil
+	qualitativeCacher qualitativeCacher
+
+	// batch matching concurrency, and the qualitative matcher's rate limit (nil is unlimited)
+	batchWorkers       int
+	qualitativeLimiter *rate.Limiter
 }
 
 func NewLogic(
@@ -95,7 +106,7 @@ func NewLogic(
 	}
 	// supabaseUserStorer, userMatchActionsStorer, userDeleter, dateInstanceInserter, matchResultUpdater are optional
 
-	return &Logic{
+	l := &Logic{
 		configStorer:               configStorer,
 		userStorer:                 userStorer,
 		userDatingPreferenceStorer: userDatingPreferenceStorer,
@@ -111,7 +122,14 @@ func NewLogic(
 		userDeleter:                userDeleter,
 		dateInstanceInserter:       dateInstanceInserter,
 		matchResultUpdater:         matchResultUpdater,
-	}, nil
+		qualifierRegistry:          newQualifierRegistry(),
+		batchWorkers:               batchMatchWorkers,
+	}
+	if err := l.registerDefaultQualifiers(); err != nil {
+		return nil, fmt.Errorf("register default qualifiers: %w", err)
+	}
+
+	return l, nil
 }
 
 // SetQualitativeQuantifier sets the qualitativeQuantifier implementation.
@@ -119,6 +137,30 @@ func (l *Logic) SetQualitativeQuantifier(q qualitativeQuantifier) {
 	l.qualitativeQuantifier = q
 }
 
+// SetQualitativeCacher sets the qualitativeCacher implementation, enabling the qualitative cache.
+func (l *Logic) SetQualitativeCacher(c qualitativeCacher) {
+	l.qualitativeCacher = c
+}
+
+// SetBatchWorkers sets how many pairs RunIngestionSet processes concurrently.
+// Zero or less restores the default, batchMatchWorkers.
+func (l *Logic) SetBatchWorkers(n int) {
+	if n <= 0 {
+		n = batchMatchWorkers
+	}
+	l.batchWorkers = n
+}
+
+// SetQualitativeRateLimit limits calls to the qualitative matcher to qps per
+// second, with bursts of up to burst calls. Zero or less qps removes the limit.
+func (l *Logic) SetQualitativeRateLimit(qps float64, burst int) {
+	if qps <= 0 {
+		l.qualitativeLimiter = nil
+		return
+	}
+	l.qualitativeLimiter = rate.NewLimiter(rate.Limit(qps), max(burst, 1))
+}
+
 // SetAIPublicURLer sets the aiPublicURLer implementation.
 func (l *Logic) SetAIPublicURLer(p publicURLer) {
 	l.aiPublicURLer = p
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
 
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
@@ -15,8 +16,27 @@ import (
 */
 
 // ProcessMatchResult will process a validatedUserMatchingDetails pair, and return a match result.
+// The pair is qualified against the config version its match set was created with.
 // aiExec is the executor for ai_backend database (for profile lookups).
 func (l *Logic) ProcessMatchResult(ctx context.Context, exec boil.ContextExecutor, aiExec boil.ContextExecutor, matchResult *MatchResult) (*MatchResult, error) {
+	config, err := l.matchSetConfig(ctx, exec, matchResult.MatchSetID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch config: %w", err)
+	}
+
+	return l.processMatchResult(ctx, exec, aiExec, matchResult, config, nil)
+}
+
+// processMatchResult is ProcessMatchResult against the given config,
+// counting qualitative cache use into stats.
+func (l *Logic) processMatchResult(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	aiExec boil.ContextExecutor,
+	matchResult *MatchResult,
+	config *Config,
+	stats *ingestionStats,
+) (*MatchResult, error) {
 	fmt.Printf("[ProcessMatchResult] processing pair: %s <-> %s\n", matchResult.InitiatorUserID, matchResult.ReceiverUserID)
 
 	initiatorUser, err := l.validatedUserMatchingDetails(ctx, exec, matchResult.InitiatorUserID)
@@ -31,45 +51,39 @@ func (l *Logic) ProcessMatchResult(ctx context.Context, exec boil.ContextExecuto
 		return nil, fmt.Errorf("fetch receiver user matching details: %w", err)
 	}
 
-	// TODO: get config from MatchSet, only hesitation is you need fixed versioned fields,
-	// because the JSON might drift from the stored config.
-	config, err := l.configStorer.Config(ctx, exec, nil)
+	hardQualifiers, err := l.qualifierRegistry.enabled(config.Qualifiers)
 	if err != nil {
-		return nil, fmt.Errorf("fetch config: %w", err)
+		return nil, fmt.Errorf("enabled qualifiers: %w", err)
 	}
 
-	qualifierResults := &QualifierResults{}
-	hardQualifiers := qualifiers{
-		l.ageQualifier,
-		l.datePrefsQualifier,
-		l.heightQualifier,
-		l.distanceQualifier,
-
-		// extend as needed
-	}
-
-	res := hardQualifiers.ExecuteAll(ctx, config, qualifierResults, &QualifierParameters{
+	qualifierResults := hardQualifiers.ExecuteAll(ctx, &QualifierParameters{
 		config: config,
 		UserA:  initiatorUser,
 		UserB:  receiverUser,
 		// keep expanding this as needed
 	})
 
-	// save intermediary qualifier results
+	// kept for every pair, so a match decision can be explained later
 	bytesQualifierResults, err := qualifierResults.AsJSON()
 	if err != nil {
 		return nil, fmt.Errorf("serialize qualifier results: %w", err)
 	}
-	if matchResult, err = l.matchResultStorer.Update(ctx, exec, &UpdateMatchResult{
-		ID:                   matchResult.ID,
-		MatchedQualitatively: null.BoolFrom(false), // did not pass hard qualifiers
-		QualifierResults:     null.JSONFrom(bytesQualifierResults),
-	}); err != nil {
-		return nil, fmt.Errorf("updating match set with errors: %w", err)
-	}
 
-	if res.Error() != nil {
-		fmt.Printf("[ProcessMatchResult] hard qualifiers FAILED: %v\n", res.Error())
+	// a pair's outcome is written in one update, together with its ingestion
+	// state, so a run that dies mid-pair leaves the pair pending.
+	if qualifierResults.Error() != nil {
+		fmt.Printf("[ProcessMatchResult] hard qualifiers FAILED: %v\n", qualifierResults.Error())
+
+		if matchResult, err = l.matchResultStorer.Update(ctx, exec, &UpdateMatchResult{
+			ID:                   matchResult.ID,
+			MatchedQualitatively: null.BoolFrom(false), // did not pass hard qualifiers
+			QualifierResults:     null.JSONFrom(bytesQualifierResults),
+			HardQualifierResults: null.JSONFrom(bytesQualifierResults),
+			IngestionStatus:      null.StringFrom(string(enums.MatchResultIngestionStatusProcessed)),
+			IngestedAt:           null.TimeFrom(timeNow()),
+		}); err != nil {
+			return nil, fmt.Errorf("updating match set with errors: %w", err)
+		}
 		return matchResult, nil
 	}
 
@@ -88,10 +102,11 @@ func (l *Logic) ProcessMatchResult(ctx context.Context, exec boil.ContextExecuto
 		return nil, fmt.Errorf("fetch receiver user profile: %w", err)
 	}
 
-	matchCompatibilityResult, err := l.qualitativeQuantifier.Qualify(ctx, &QualitativeMatchRequest{
-		Romeo:  initiatorProf,
-		Juliet: receiverProf,
-	})
+	matchCompatibilityResult, err := l.qualify(ctx, exec, config,
+		initiatorUser.ID, receiverUser.ID,
+		initiatorProf, receiverProf,
+		stats,
+	)
 	if err != nil {
 		fmt.Printf("[ProcessMatchResult] ERROR qualitative quantify: %v\n", err)
 		return nil, fmt.Errorf("qualitative quantify: %w", err)
@@ -102,12 +117,22 @@ func (l *Logic) ProcessMatchResult(ctx context.Context, exec boil.ContextExecuto
 		return nil, fmt.Errorf("marshal qualitative match result: %w", err)
 	}
 
+	// rank the pair: local soft score blended with the AI score
+	softScore := ComputeSoftScore(config, initiatorUser, receiverUser, initiatorProf, receiverProf)
+	finalScore := FinalScore(config, softScore.Total, matchCompatibilityResult.TotalScore)
+
 	// update with qualitative match result
 	matchResult, err = l.matchResultStorer.Update(ctx, exec, &UpdateMatchResult{
 		ID:                   matchResult.ID,
 		MatchedQualitatively: null.BoolFrom(true),
 		IsPossibleMatch:      null.BoolFrom(true),
 		QualifierResults:     null.JSONFrom(bytesMatchCompatibility),
+		HardQualifierResults: null.JSONFrom(bytesQualifierResults),
+		SoftScore:            null.Float64From(softScore.Total),
+		AIScore:              null.Float64From(matchCompatibilityResult.TotalScore),
+		FinalScore:           null.Float64From(finalScore),
+		IngestionStatus:      null.StringFrom(string(enums.MatchResultIngestionStatusProcessed)),
+		IngestedAt:           null.TimeFrom(timeNow()),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("match result storer update: %w", err)
@@ -155,7 +180,7 @@ func (l *Logic) RunMatchForUnmatchedUsers(ctx context.Context, exec boil.Context
 }
 
 // usersWithoutPendingMatches returns active users who don't have any
-// match results that are approved but not yet dropped.
+// match results that are approved but not yet dropped nor expired.
 func (l *Logic) usersWithoutPendingMatches(ctx context.Context, exec boil.ContextExecutor) ([]User, error) {
 	allUsers, err := l.userStorer.Users(ctx, exec, &QueryFilterUser{
 		IsActive: null.BoolFrom(true),
@@ -167,6 +192,7 @@ func (l *Logic) usersWithoutPendingMatches(ctx context.Context, exec boil.Contex
 	pendingMatches, err := l.matchResultStorer.MatchResults(ctx, exec, &QueryFilterMatchResult{
 		IsApproved: null.BoolFrom(true),
 		IsDropped:  null.BoolFrom(false),
+		IsExpired:  null.BoolFrom(false),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("fetch pending matches: %w", err)
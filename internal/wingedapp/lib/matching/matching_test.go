@@ -127,6 +127,7 @@ func TestProcessMatch(t *testing.T) {
 
 			// ingest population data
 			ingestor := testhelper.NewPopulationIngestor(t, tSuite, matchingLib)
+			ingestor.DisableCandidatePrefilter() // the hard qualifiers are under test, pair every user
 			parseResult, populateResult, err := ingestor.IngestFromCSVFile(tc.populationFile)
 			require.NoError(t, err, "ingesting population data")
 			require.NotEmpty(t, parseResult.Rows, "expected rows to not be empty")
@@ -384,6 +385,35 @@ func TestLogic_RunMatchForUnmatchedUsers(t *testing.T) {
 				assert.Len(th.T, matchSets, 2, "new match set should be created (unapproved doesn't count as pending)")
 			},
 		},
+		{
+			name: "expired-matches-dont-block-users",
+			setup: func(th *testsuite.Helper) {
+				exec := th.BackendAppDb()
+
+				userA := factory.NewEntity[*wingedFactory.User](&wingedFactory.User{}).New(th.T, exec)
+				userB := factory.NewEntity[*wingedFactory.User](&wingedFactory.User{}).New(th.T, exec)
+
+				factory.NewEntity[*wingedFactory.MatchResult](&wingedFactory.MatchResult{
+					Subject: &pgmodel.MatchResult{
+						IsApproved: true,
+						IsDropped:  false,
+						IsExpired:  true,
+					},
+					FactoryUserA: userA,
+					FactoryUserB: userB,
+				}).New(th.T, exec)
+			},
+			extraAssertions: func(th *testsuite.Helper, err error) {
+				require.NoError(th.T, err, "RunMatchForUnmatchedUsers should succeed")
+
+				ctx := context.Background()
+				exec := th.BackendAppDb()
+
+				matchSets, err := pgmodel.MatchSets().All(ctx, exec)
+				require.NoError(th.T, err, "fetching match sets")
+				assert.Len(th.T, matchSets, 2, "new match set should be created (expired doesn't count as pending)")
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -401,7 +431,14 @@ func TestLogic_RunMatchForUnmatchedUsers(t *testing.T) {
 			tc.setup(testSuite)
 
 			matchLib := testSuite.FakeContainer().GetLibMatching()
-			_, err := matchLib.RunMatchForUnmatchedUsers(context.Background(), testSuite.BackendAppDb())
+
+			// factory users have no location nor dating prefs, pair every user
+			_, err := testhelper.UpdateConfig(context.Background(), testSuite.BackendAppDb(), matchLib, &matching.UpdateMatchConfig{
+				CandidatePrefilter: null.BoolFrom(false),
+			})
+			require.NoError(t, err, "disabling candidate prefilter")
+
+			_, err = matchLib.RunMatchForUnmatchedUsers(context.Background(), testSuite.BackendAppDb())
 
 			tc.extraAssertions(testSuite, err)
 		})
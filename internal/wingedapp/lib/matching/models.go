@@ -3,10 +3,14 @@ package matching
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
 	"time"
 	"wingedapp/pgtester/internal/util/errutil"
 	"wingedapp/pgtester/internal/util/validationlib"
 	"wingedapp/pgtester/internal/wingedapp/business/sdk"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
 
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/types"
@@ -147,6 +151,58 @@ type MatchSet struct {
 	TimeEnd              null.Time `boil:"time_end" json:"time_end,omitempty"`
 	CreatedAt            null.Time `boil:"created_at" json:"created_at,omitempty"`
 	UpdatedAt            null.Time `boil:"updated_at" json:"updated_at,omitempty"`
+	IngestionStatus      string    `boil:"ingestion_status" json:"ingestion_status"`
+
+	// ConfigVersionID is the match config version the set was created with,
+	// unset for sets created before config versions.
+	ConfigVersionID uuid.NullUUID `boil:"match_config_version_id" json:"match_config_version_id"`
+}
+
+// IngestionSetSummary summarises a RunIngestionSet run.
+// Skipped counts the pairs an earlier run already processed, and Pending the
+// pairs a cancelled run didn't get to.
+type IngestionSetSummary struct {
+	MatchSetID             uuid.UUID             `json:"match_set_id"`
+	RunID                  uuid.UUID             `json:"run_id"`
+	Status                 enums.IngestionStatus `json:"status"`
+	Pairs                  int                   `json:"pairs"`
+	Processed              int                   `json:"processed"`
+	Failed                 int                   `json:"failed"`
+	Skipped                int                   `json:"skipped"`
+	Pending                int                   `json:"pending"`
+	LastError              string                `json:"last_error,omitempty"`
+	QualitativeCacheHits   int64                 `json:"qualitative_cache_hits"`
+	QualitativeCacheMisses int64                 `json:"qualitative_cache_misses"`
+	StartedAt              time.Time             `json:"started_at"`
+	FinishedAt             time.Time             `json:"finished_at"`
+}
+
+// IngestionRun is a recorded run of a match set's ingestion.
+type IngestionRun struct {
+	ID         uuid.UUID             `boil:"id"`
+	MatchSetID uuid.UUID             `boil:"match_set_ref_id"`
+	Status     enums.IngestionStatus `boil:"status"`
+	Pairs      int                   `boil:"pairs"`
+	Processed  int                   `boil:"processed"`
+	Failed     int                   `boil:"failed"`
+	Skipped    int                   `boil:"skipped"`
+	LastError  null.String           `boil:"last_error"`
+	StartedAt  time.Time             `boil:"started_at"`
+	FinishedAt null.Time             `boil:"finished_at"`
+}
+
+// FinishIngestionRun records the outcome of an ingestion run,
+// and sets its match set's ingestion status to match.
+type FinishIngestionRun struct {
+	ID         uuid.UUID
+	MatchSetID uuid.UUID
+	Status     enums.IngestionStatus
+	Pairs      int
+	Processed  int
+	Failed     int
+	Skipped    int
+	LastError  null.String
+	FinishedAt time.Time
 }
 
 type MatchResultPaginated struct {
@@ -158,16 +214,30 @@ type MatchResultPaginated struct {
 // The result of this struct will be stored into a JSONB column in the DB.
 // Alpha
 type MatchResult struct {
-	ID                   uuid.UUID   `boil:"id" json:"id"`
-	MatchSetID           uuid.UUID   `boil:"match_set_id" json:"match_set_id"`
-	InitiatorUserID      uuid.UUID   `boil:"initiator_user_id" json:"initiator_user_id"`
-	ReceiverUserID       uuid.UUID   `boil:"receiver_user_id" json:"receiver_user_id"`
-	QualifierResults     null.JSON   `boil:"qualifier_results" json:"qualifier_results,omitempty"`
-	MatchedQualitatively null.Bool   `boil:"matched_qualitatively" json:"matched_qualitatively"`
-	IsPossibleMatch      bool        `boil:"is_possible_match" json:"is_possible_match"`
-	IsApproved           bool        `boil:"is_approved" json:"is_approved"`
-	IsExpired            bool        `boil:"is_expired" json:"is_expired"`
-	UserLifeCycleStatus  null.String `boil:"user_lifecycle_status" json:"user_lifecycle_status,omitempty"`
+	ID                   uuid.UUID    `boil:"id" json:"id"`
+	MatchSetID           uuid.UUID    `boil:"match_set_id" json:"match_set_id"`
+	InitiatorUserID      uuid.UUID    `boil:"initiator_user_id" json:"initiator_user_id"`
+	ReceiverUserID       uuid.UUID    `boil:"receiver_user_id" json:"receiver_user_id"`
+	QualifierResults     null.JSON    `boil:"qualifier_results" json:"qualifier_results,omitempty"`
+	HardQualifierResults null.JSON    `boil:"hard_qualifier_results" json:"hard_qualifier_results,omitempty"`
+	MatchedQualitatively null.Bool    `boil:"matched_qualitatively" json:"matched_qualitatively"`
+	IsPossibleMatch      bool         `boil:"is_possible_match" json:"is_possible_match"`
+	IsApproved           bool         `boil:"is_approved" json:"is_approved"`
+	IsExpired            bool         `boil:"is_expired" json:"is_expired"`
+	UserLifeCycleStatus  null.String  `boil:"user_lifecycle_status" json:"user_lifecycle_status,omitempty"`
+	SoftScore            null.Float64 `boil:"soft_score" json:"soft_score,omitempty"`
+	AIScore              null.Float64 `boil:"ai_score" json:"ai_score,omitempty"`
+	FinalScore           null.Float64 `boil:"final_score" json:"final_score,omitempty"`
+	InitiatorAction      string       `boil:"initiator_action" json:"initiator_action"`
+	InitiatorActionAt    null.Time    `boil:"initiator_action_at" json:"initiator_action_at,omitempty"`
+	ReceiverAction       string       `boil:"receiver_action" json:"receiver_action"`
+	ReceiverActionAt     null.Time    `boil:"receiver_action_at" json:"receiver_action_at,omitempty"`
+	ExpiresAt            null.Time    `boil:"expires_at" json:"expires_at,omitempty"`
+	CreatedAt            time.Time    `boil:"created_at" json:"created_at"`
+	UpdatedAt            null.Time    `boil:"updated_at" json:"updated_at,omitempty"`
+	ClosedAt             null.Time    `boil:"closed_at" json:"closed_at,omitempty"`
+	IngestionStatus      string       `boil:"ingestion_status" json:"ingestion_status"`
+	IngestionError       null.String  `boil:"ingestion_error" json:"ingestion_error,omitempty"`
 
 	/* enriched admin fields */
 	InitiatorUserDetails *User          `json:"initiator_user_details,omitempty"`
@@ -179,10 +249,9 @@ type MatchResult struct {
 type QualifierType string
 
 type Qualifier struct {
-	Name             QualifierType     `json:"name"`
-	QualifierResults *QualifierResults `json:"-"` // omit
-	Telemetry        map[string]any    `json:"telemetry"`
-	ErrorMsg         string            `json:"error_msg"`
+	Name      QualifierType  `json:"name"`
+	Telemetry map[string]any `json:"telemetry"`
+	ErrorMsg  string         `json:"error_msg"`
 }
 
 func (q *Qualifier) SetError(err error) *Qualifier {
@@ -190,24 +259,36 @@ func (q *Qualifier) SetError(err error) *Qualifier {
 	return q
 }
 
+// NewQualifier creates an empty qualifier result, for use by a QualifierFunc.
+func NewQualifier(qt QualifierType) *Qualifier {
+	return newQualifier(qt)
+}
+
+// qualifier is a hard qualifier, with the type it's registered under.
+type qualifier struct {
+	qt QualifierType
+	fn QualifierFunc
+}
+
 type qualifiers []qualifier
 
-func (q *QualifierResults) HasErrors() bool {
+func (q QualifierResults) HasErrors() bool {
 	return q.Error() != nil
 }
 
-// Error aggregates all qualifier errors into one.
-func (q *QualifierResults) Error() error {
+// Error aggregates all qualifier errors into one,
+// in qualifier name order so the message is stable.
+func (q QualifierResults) Error() error {
 	var errList errutil.List
-	qs := []*Qualifier{
-		q.AgeWindow,
-		q.DatingPreferences,
-		q.Height,
-		q.Distance,
-		q.Qualitative,
+
+	names := make([]string, 0, len(q))
+	for name := range q {
+		names = append(names, string(name))
 	}
+	sort.Strings(names)
 
-	for _, q_ := range qs {
+	for _, name := range names {
+		q_ := q[QualifierType(name)]
 		if q_ == nil {
 			continue // ignore nil qualifiers
 		}
@@ -220,52 +301,98 @@ func (q *QualifierResults) Error() error {
 	return errList.Error()
 }
 
-// ExecuteAll runs all qualifier logic collection
-// that populates the Results.
+// ExecuteAll runs all qualifiers in order, and collects their results by
+// the type they're registered under. A qualifier without a result fails.
 func (qs qualifiers) ExecuteAll(
 	ctx context.Context,
-	config *Config,
-	results *QualifierResults,
 	parameters *QualifierParameters,
-) *QualifierResults {
-	parameters.QualifierResults = results // assign to parameters
+) QualifierResults {
+	results := make(QualifierResults, len(qs))
 	for _, q := range qs {
-		q(ctx, parameters)
+		res := q.fn(ctx, parameters)
+		if res == nil {
+			res = newQualifier(q.qt).SetError(ErrNoQualifierResult)
+		}
+		res.Name = q.qt
+		results[q.qt] = res
 	}
 	return results
 }
 
-type qualifier func(
-	ctx context.Context,
-	parameters *QualifierParameters,
-	/*
-	   ctx context.Context,
-	   config *Config,
-	   results *QualifierResults,
-	   parameters *QualifierParameters,
-	*/
-) *Qualifier
-
-func (q *QualifierResults) AsJSON() ([]byte, error) {
+func (q QualifierResults) AsJSON() ([]byte, error) {
 	return json.Marshal(q)
 }
 
-// QualifierResults is the different qualifiers we
-// need to run 2 people against.
-type QualifierResults struct {
-	AgeWindow         *Qualifier `json:"age_window"`
-	DatingPreferences *Qualifier `json:"dating_preferences"`
-	Height            *Qualifier `json:"height"`
-	Distance          *Qualifier `json:"distance"`
-	Qualitative       *Qualifier `json:"qualitative"`
+// QualifierResults is the outcome of the qualifiers
+// we ran 2 people against, keyed by qualifier name.
+type QualifierResults map[QualifierType]*Qualifier
+
+// legacyQualifierKeys are the qualifier_results keys of the built-in
+// qualifiers from before the qualifier registry, when the results were a
+// struct. Stored results keep them, so old and new rows read the same.
+var legacyQualifierKeys = map[QualifierType]string{
+	ageWindowQualifier:       "age_window",
+	datePrefsQualifier:       "dating_preferences",
+	heightQualifier:          "height",
+	distanceQualifier:        "distance",
+	qualitativeQualifierName: "qualitative",
+}
+
+// MarshalJSON keys the built-in qualifiers by their legacy keys,
+// and other qualifiers by name.
+func (q QualifierResults) MarshalJSON() ([]byte, error) {
+	if q == nil {
+		return []byte("null"), nil
+	}
+
+	keyed := make(map[string]*Qualifier, len(q))
+	for name, result := range q {
+		key, ok := legacyQualifierKeys[name]
+		if !ok {
+			key = string(name)
+		}
+		keyed[key] = result
+	}
+
+	return json.Marshal(keyed)
+}
+
+// UnmarshalJSON reads results keyed by legacy key or by qualifier name.
+// Qualifiers stored as null didn't run, and are left out.
+func (q *QualifierResults) UnmarshalJSON(b []byte) error {
+	var keyed map[string]*Qualifier
+	if err := json.Unmarshal(b, &keyed); err != nil {
+		return err
+	}
+	if keyed == nil {
+		*q = nil
+		return nil
+	}
+
+	results := make(QualifierResults, len(keyed))
+	for key, result := range keyed {
+		if result == nil {
+			continue
+		}
+		name := QualifierType(key)
+		for qt, legacyKey := range legacyQualifierKeys {
+			if legacyKey == key {
+				name = qt
+				break
+			}
+		}
+		results[name] = result
+	}
+	*q = results
 
-	// add more qualifiers as needed
+	return nil
 }
 
 type InsertMatchSet struct {
 	Name                 string
 	NumberOfParticipants int
 	MatchingParameters   json.RawMessage
+	ConfigVersionID      uuid.UUID
 }
 
 type UpdateMatchSet struct {
@@ -276,11 +403,13 @@ type UpdateUser struct {
 }
 
 type QueryFilterUserDatingPrefs struct {
-	UserID null.String
+	UserID  null.String
+	UserIDs []string // matches any of the users
 }
 
 type QueryFilterUser struct {
 	ID       null.String
+	IDs      []string // Filter by any of these user IDs
 	IsActive null.Bool
 
 	// User type filters for batch matching exclusions
@@ -329,14 +458,15 @@ type QueryFilterMatchResult struct {
 	MatchSetID null.String
 
 	// User filters - UserID matches EITHER initiator OR receiver (OR condition)
-	UserID           null.String // matches user as either initiator OR receiver
-	InitiatorUserID  null.String // matches user as initiator specifically
-	ReceiverUserID   null.String // matches user as receiver specifically
+	UserID          null.String // matches user as either initiator OR receiver
+	InitiatorUserID null.String // matches user as initiator specifically
+	ReceiverUserID  null.String // matches user as receiver specifically
+	BetweenUserIDs  []string    // matches results whose initiator and receiver are both listed
 
 	// Status filters (now string enum values instead of category UUIDs)
 	MatchLifecycleStatus null.String // String enum - lifecycle status
-	InitiatorAction          null.String // String enum - user A's action (Pending/Proposed/Passed)
-	ReceiverAction          null.String // String enum - user B's action (Pending/Proposed/Passed)
+	InitiatorAction      null.String // String enum - user A's action (Pending/Proposed/Passed)
+	ReceiverAction       null.String // String enum - user B's action (Pending/Proposed/Passed)
 
 	// Boolean filters
 	MatchedQualitatively null.Bool
@@ -366,12 +496,30 @@ type UpdateMatchResult struct {
 	ID                   uuid.UUID
 	MatchedQualitatively null.Bool
 	QualifierResults     null.JSON
+	HardQualifierResults null.JSON
 	IsVerified           null.Bool
 	IsApproved           null.Bool
 	IsDropped            null.Bool
 	DroppedTS            null.Time
 	IsPossibleMatch      null.Bool
 	IsExpired            null.Bool
+	ExpiresAt            null.Time
+	SoftScore            null.Float64
+	AIScore              null.Float64
+	FinalScore           null.Float64
+	IngestionStatus      null.String
+	IngestionError       null.String
+	IngestedAt           null.Time
+}
+
+// ExpiredMatch is a dropped match the expiry sweeper expired,
+// with what each side did on it by then.
+type ExpiredMatch struct {
+	ID               uuid.UUID
+	InitiatorUserID  uuid.UUID
+	ReceiverUserID   uuid.UUID
+	InitiatorOutcome enums.MatchExpiryOutcome
+	ReceiverOutcome  enums.MatchExpiryOutcome
 }
 
 type InsertMatchParticipant struct {
@@ -398,13 +546,58 @@ type Config struct {
 	MatchBlockClosed          int               `boil:"match_block_closed" json:"match_block_closed"`
 	ScoreRangeStart           float64           `boil:"score_range_start" json:"score_range_start"`
 	ScoreRangeEnd             float64           `boil:"score_range_end" json:"score_range_end"`
+	Qualifiers                types.StringArray `boil:"qualifiers" json:"qualifiers"`
+	ScoreWeightDistance       float64           `boil:"score_weight_distance" json:"score_weight_distance"`
+	ScoreWeightAge            float64           `boil:"score_weight_age" json:"score_weight_age"`
+	ScoreWeightHeight         float64           `boil:"score_weight_height" json:"score_weight_height"`
+	ScoreWeightTraits         float64           `boil:"score_weight_traits" json:"score_weight_traits"`
+	ScoreWeightAI             float64           `boil:"score_weight_ai" json:"score_weight_ai"`
+	QualitativeCacheTTLHours  int               `boil:"qualitative_cache_ttl_hours" json:"qualitative_cache_ttl_hours"`
+	CandidatePrefilter        bool              `boil:"candidate_prefilter" json:"candidate_prefilter"`
+}
+
+// ConfigVersion is an immutable snapshot of the match config, recorded on
+// every update. Config.ID is the version's ID.
+type ConfigVersion struct {
+	ID             uuid.UUID `boil:"version_id" json:"id"`
+	Version        int       `boil:"version" json:"version"`
+	RolledBackFrom null.Int  `boil:"rolled_back_from" json:"rolled_back_from"`
+	CreatedAt      time.Time `boil:"created_at" json:"created_at"`
+	Config         Config    `boil:",bind" json:"config"`
+}
+
+// QueryFilterMatchConfigVersion filters config version reads. Rows come
+// newest version first.
+type QueryFilterMatchConfigVersion struct {
+	ID      null.String
+	Version null.Int
+	Latest  bool // only the newest version
+}
+
+// ConfigDiff lists the config fields that differ between two versions.
+type ConfigDiff struct {
+	From    int            `json:"from"`
+	To      int            `json:"to"`
+	Changes []ConfigChange `json:"changes"`
+}
+
+// ConfigChange is a config field that differs between two versions, keyed
+// by its JSON name.
+type ConfigChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
 }
 
 type QualifierParameters struct {
-	config           *Config
-	QualifierResults *QualifierResults
-	UserA            *User
-	UserB            *User
+	config *Config
+	UserA  *User
+	UserB  *User
+}
+
+// Config returns the match config the qualifiers run against.
+func (cp *QualifierParameters) Config() *Config {
+	return cp.config
 }
 
 // Users returns the two users to be matched.
@@ -424,6 +617,45 @@ type MatchCompatibilityResult struct {
 	TotalScore                    float64            `json:"total_score"`
 }
 
+// Validate checks every score is within [0, 1].
+func (m *MatchCompatibilityResult) Validate() error {
+	scores := []struct {
+		name  string
+		score float64
+	}{
+		{"personality_compatibility_score", m.PersonalityCompatibilityScore.Score},
+		{"lifestyle_compatibility_score", m.LifestyleCompatibilityScore.Score},
+		{"values_compatibility_score", m.ValuesCompatibilityScore.Score},
+		{"total_score", m.TotalScore},
+	}
+
+	for _, s := range scores {
+		if math.IsNaN(s.score) || s.score < 0 || s.score > 1 {
+			return fmt.Errorf("%w: %s is %v", ErrCompatibilityScoreOutOfRange, s.name, s.score)
+		}
+	}
+
+	return nil
+}
+
+// QualitativeCacheKey identifies a cached qualitative result.
+// UserAID < UserBID, and RomeoID tells which of them the result was
+// computed as Romeo, so each orientation of a pair has its own key.
+type QualitativeCacheKey struct {
+	UserAID        uuid.UUID
+	UserBID        uuid.UUID
+	RomeoID        uuid.UUID
+	ProfileHash    string // sha256 of both profile payloads
+	MatcherVersion string
+}
+
+// InsertQualitativeCache stores a qualitative result for reuse until ExpiresAt.
+type InsertQualitativeCache struct {
+	Key       *QualitativeCacheKey
+	Result    *MatchCompatibilityResult
+	ExpiresAt time.Time
+}
+
 // Profile represents a user profile for qualitative matching.
 type Profile struct {
 }
@@ -731,6 +963,22 @@ type UpdateMatchConfig struct {
 	// Score range settings
 	ScoreRangeStart null.Float64 `json:"score_range_start"`
 	ScoreRangeEnd   null.Float64 `json:"score_range_end"`
+
+	// Hard qualifier settings, run in the given order
+	Qualifiers *types.StringArray `json:"qualifiers"` // pointer to distinguish between null and empty
+
+	// Soft score weights, and the AI score's share of the final score
+	ScoreWeightDistance null.Float64 `json:"score_weight_distance"`
+	ScoreWeightAge      null.Float64 `json:"score_weight_age"`
+	ScoreWeightHeight   null.Float64 `json:"score_weight_height"`
+	ScoreWeightTraits   null.Float64 `json:"score_weight_traits"`
+	ScoreWeightAI       null.Float64 `json:"score_weight_ai"`
+
+	// How long a cached qualitative result is reused, 0 disables the cache
+	QualitativeCacheTTLHours null.Int `json:"qualitative_cache_ttl_hours"`
+
+	// Only pair users that can pass the distance and dating preference qualifiers
+	CandidatePrefilter null.Bool `json:"candidate_prefilter"`
 }
 
 // Validate validates the UpdateMatchConfig with business rules.
@@ -781,6 +1029,17 @@ func (u *UpdateMatchConfig) Validate() error {
 		}
 	}
 
+	// Validate qualifiers are listed once each
+	if u.Qualifiers != nil {
+		seen := make(map[string]struct{}, len(*u.Qualifiers))
+		for _, q := range *u.Qualifiers {
+			if _, ok := seen[q]; ok {
+				return ErrDuplicateQualifier
+			}
+			seen[q] = struct{}{}
+		}
+	}
+
 	// Validate non-negative values
 	if u.StaleChatNudge.Valid && u.StaleChatNudge.Int < 0 {
 		return ErrNegativeValue
@@ -800,12 +1059,23 @@ func (u *UpdateMatchConfig) Validate() error {
 	if u.MatchBlockClosed.Valid && u.MatchBlockClosed.Int < 0 {
 		return ErrNegativeValue
 	}
+	if u.QualitativeCacheTTLHours.Valid && u.QualitativeCacheTTLHours.Int < 0 {
+		return ErrNegativeValue
+	}
 	if u.LocationRadiusKM.Valid && u.LocationRadiusKM.Float64 < 0 {
 		return ErrNegativeValue
 	}
 	if u.HeightMaleGreaterByCM.Valid && u.HeightMaleGreaterByCM.Float64 < 0 {
 		return ErrNegativeValue
 	}
+	for _, w := range []null.Float64{u.ScoreWeightDistance, u.ScoreWeightAge, u.ScoreWeightHeight, u.ScoreWeightTraits, u.ScoreWeightAI} {
+		if w.Valid && w.Float64 < 0 {
+			return ErrNegativeValue
+		}
+	}
+	if u.ScoreWeightAI.Valid && u.ScoreWeightAI.Float64 > 1 {
+		return ErrScoreWeightAIOutOfRange
+	}
 
 	return nil
 }
@@ -892,3 +1162,130 @@ func isValidTimezoneFormat(tz string) bool {
 
 	return offset <= 14 // valid timezone offsets are -12 to +14
 }
+
+// SimulateParams dry-runs a proposed config update against the active users
+// the filter selects, nil for all of them.
+type SimulateParams struct {
+	Config *UpdateMatchConfig
+	Users  *QueryFilterUser
+}
+
+func (p *SimulateParams) Validate() error {
+	if p == nil || p.Config == nil {
+		return ErrSimulationConfigRequired
+	}
+	return p.Config.Validate()
+}
+
+// SimulationReport compares the hard qualifier outcomes of every pair of
+// the simulated users under the live config and under the proposed one.
+type SimulationReport struct {
+	Users   int            `json:"users"`
+	Pairs   int            `json:"pairs"`
+	Changes []ConfigChange `json:"changes"` // proposed against live config
+
+	Current  SimulationOutcome `json:"current"`
+	Proposed SimulationOutcome `json:"proposed"`
+
+	// pairs whose outcome the proposed config flips
+	NewlyPassing int `json:"newly_passing"`
+	NewlyFailing int `json:"newly_failing"`
+
+	DistancesKM Distribution `json:"distances_km"`
+	AgeGaps     Distribution `json:"age_gaps"`
+}
+
+// SimulationOutcome counts the pairs passing every hard qualifier of a
+// config, and the passes and failures of each qualifier.
+type SimulationOutcome struct {
+	Passed     int                                 `json:"passed"`
+	Failed     int                                 `json:"failed"`
+	Qualifiers map[QualifierType]*QualifierOutcome `json:"qualifiers"`
+}
+
+// QualifierOutcome counts the pairs a qualifier passed and failed.
+type QualifierOutcome struct {
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+}
+
+// Distribution summarises a value over the pairs it's known for,
+// e.g. pairs where both users have a location for distances.
+type Distribution struct {
+	Pairs   int                  `json:"pairs"`
+	Min     float64              `json:"min"`
+	Max     float64              `json:"max"`
+	Mean    float64              `json:"mean"`
+	Buckets []DistributionBucket `json:"buckets"`
+}
+
+// DistributionBucket counts the pairs with a value in [From, To).
+// To is unset for the last, open-ended bucket.
+type DistributionBucket struct {
+	From  float64      `json:"from"`
+	To    null.Float64 `json:"to"`
+	Pairs int          `json:"pairs"`
+}
+
+// MatchOutcome is how processing a pair ended, as ExplainMatch tells it.
+type MatchOutcome string
+
+const (
+	// MatchOutcomePending is a pair that wasn't processed yet.
+	MatchOutcomePending MatchOutcome = "Pending"
+	// MatchOutcomeErrored is a pair whose processing failed, and is retried.
+	MatchOutcomeErrored MatchOutcome = "Errored"
+	// MatchOutcomeFailedQualifiers is a pair that failed a hard qualifier.
+	MatchOutcomeFailedQualifiers MatchOutcome = "FailedQualifiers"
+	// MatchOutcomeMatched is a pair that passed the hard qualifiers and was scored.
+	MatchOutcomeMatched MatchOutcome = "Matched"
+)
+
+// MatchExplanation is a readable breakdown of a match decision: how each hard
+// qualifier judged the pair against the config its match set was created
+// with, and the scores if the pair got that far.
+type MatchExplanation struct {
+	MatchResultID   uuid.UUID    `json:"match_result_id"`
+	MatchSetID      uuid.UUID    `json:"match_set_id"`
+	InitiatorUserID uuid.UUID    `json:"initiator_user_id"`
+	ReceiverUserID  uuid.UUID    `json:"receiver_user_id"`
+	Outcome         MatchOutcome `json:"outcome"`
+	Summary         string       `json:"summary"`
+
+	// ConfigVersion is the config version the pair was judged against, unset
+	// for sets created before config versions, explained with the live config.
+	ConfigVersion null.Int `json:"config_version"`
+
+	Qualifiers []QualifierExplanation `json:"qualifiers"`
+	Scores     *ScoreExplanation      `json:"scores,omitempty"`
+}
+
+// QualifierExplanation is how a hard qualifier judged a pair: the user data
+// it looked at, and the config thresholds it compared them against.
+type QualifierExplanation struct {
+	Name        QualifierType  `json:"name"`
+	Passed      bool           `json:"passed"`
+	Rule        string         `json:"rule"`
+	Inputs      map[string]any `json:"inputs"`
+	Thresholds  map[string]any `json:"thresholds"`
+	Explanation string         `json:"explanation"`
+}
+
+// ScoreExplanation breaks down the scores of a pair that passed the hard qualifiers.
+type ScoreExplanation struct {
+	SoftScore       null.Float64     `json:"soft_score"`
+	AIScore         null.Float64     `json:"ai_score"`
+	FinalScore      null.Float64     `json:"final_score"`
+	ScoreWeightAI   float64          `json:"score_weight_ai"`
+	ScoreRangeStart float64          `json:"score_range_start"`
+	ScoreRangeEnd   float64          `json:"score_range_end"`
+	Explanation     string           `json:"explanation"`
+	AIScores        []ScoreComponent `json:"ai_scores"`
+}
+
+// ScoreComponent is an AI sub-score, with the matcher's reasoning.
+type ScoreComponent struct {
+	Name        string  `json:"name"`
+	Score       float64 `json:"score"`
+	Explanation string  `json:"explanation"`
+}
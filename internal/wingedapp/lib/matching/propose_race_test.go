@@ -136,6 +136,7 @@ func TestProposeMatch_ConcurrentProposals_RaceCondition(t *testing.T) {
 	finalMatch, err := pgmodel.FindMatchResult(ctx, exec, matchResult.Subject.ID)
 	require.NoError(t, err)
 	assert.True(t, finalMatch.CurrentDateInstanceID.Valid, "Match should have date_instance_id")
+	assert.True(t, finalMatch.ChatUnlockedAt.Valid, "Mutual proposal should unlock chat")
 	assert.True(t, finalMatch.MatchLifecycleStatus.Valid, "Match should have lifecycle status")
 }
 
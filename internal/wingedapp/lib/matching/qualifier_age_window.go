@@ -7,19 +7,9 @@ import (
 	"math"
 )
 
-// newAgeWindowQualifier creates a new age window qualifier,
-// and attaches it to the QualifierResults.
-func newAgeWindowQualifier(qr *QualifierResults) *Qualifier {
-	if qr.AgeWindow != nil {
-		panic(ageWindowQualifier + " already set")
-	}
-	qr.AgeWindow = newQualifier(ageWindowQualifier)
-	return qr.AgeWindow
-}
-
 // ageWindowQualifier checks if the age gap between two users is acceptable
 func (l *Logic) ageQualifier(ctx context.Context, params *QualifierParameters) *Qualifier {
-	q := newAgeWindowQualifier(params.QualifierResults)
+	q := newQualifier(ageWindowQualifier)
 
 	userA := params.UserA
 	userB := params.UserB
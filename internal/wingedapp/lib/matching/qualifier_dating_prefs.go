@@ -6,18 +6,8 @@ import (
 	"fmt"
 )
 
-// newDatePrefsQualifier creates a dating preferences qualifier,
-// and attaches it to the QualifierResults.
-func newDatePrefsQualifier(qr *QualifierResults) *Qualifier {
-	if qr.Distance != nil {
-		panic(datePrefsQualifier + " already set")
-	}
-	qr.DatingPreferences = newQualifier(datePrefsQualifier)
-	return qr.DatingPreferences
-}
-
 func (l *Logic) datePrefsQualifier(ctx context.Context, params *QualifierParameters) *Qualifier {
-	q := newDatePrefsQualifier(params.QualifierResults)
+	q := newQualifier(datePrefsQualifier)
 
 	// guard: both users must have valid gender
 	if !params.UserA.Gender.Valid || !params.UserB.Gender.Valid {
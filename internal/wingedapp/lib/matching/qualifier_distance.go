@@ -8,19 +8,9 @@ import (
 	"github.com/umahmood/haversine"
 )
 
-// newDistanceQualifier creates a new distance qualifier,
-// and attaches it to the QualifierResults.
-func newDistanceQualifier(qr *QualifierResults) *Qualifier {
-	if qr.Distance != nil {
-		panic(distanceQualifier + " already set")
-	}
-	qr.Distance = newQualifier(distanceQualifier)
-	return qr.Distance
-}
-
 // distanceQualifier checks if the distance between two users is acceptable.
 func (l *Logic) distanceQualifier(ctx context.Context, params *QualifierParameters) *Qualifier {
-	q := newDistanceQualifier(params.QualifierResults)
+	q := newQualifier(distanceQualifier)
 
 	userA, userB := params.Users()
 
@@ -7,19 +7,9 @@ import (
 	"math"
 )
 
-// newHeightQualifier creates a new distance qualifier,
-// and attaches it to the QualifierResults.
-func newHeightQualifier(qr *QualifierResults) *Qualifier {
-	if qr.Height != nil {
-		panic(heightQualifier + " already set")
-	}
-	qr.Height = newQualifier(heightQualifier)
-	return qr.Height
-}
-
 // distanceQualifier checks if the distance between two users is acceptable.
 func (l *Logic) heightQualifier(ctx context.Context, params *QualifierParameters) *Qualifier {
-	q := newHeightQualifier(params.QualifierResults)
+	q := newQualifier(heightQualifier)
 
 	// guard: both users must have valid height
 	if !params.UserA.Height.Valid || !params.UserB.Height.Valid {
@@ -0,0 +1,148 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// QualifierFunc evaluates one hard filter for a pair of users.
+// It returns its own Qualifier (see NewQualifier), with telemetry
+// and an error message set if the pair fails.
+type QualifierFunc func(ctx context.Context, params *QualifierParameters) *Qualifier
+
+// qualifierRegistry holds the hard qualifiers available to match_config.
+// match_config.qualifiers picks which of them run, and in which order.
+type qualifierRegistry struct {
+	mu         sync.RWMutex
+	order      []QualifierType // registration order
+	qualifiers map[QualifierType]QualifierFunc
+}
+
+func newQualifierRegistry() *qualifierRegistry {
+	return &qualifierRegistry{
+		qualifiers: make(map[QualifierType]QualifierFunc),
+	}
+}
+
+func (r *qualifierRegistry) register(qt QualifierType, fn QualifierFunc) error {
+	if qt == "" {
+		return ErrQualifierTypeRequired
+	}
+	if fn == nil {
+		return fmt.Errorf("%w: %s", ErrNilQualifier, qt)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.qualifiers[qt]; ok {
+		return fmt.Errorf("%w: %s", ErrQualifierAlreadyRegistered, qt)
+	}
+	r.qualifiers[qt] = fn
+	r.order = append(r.order, qt)
+
+	return nil
+}
+
+// enabled returns the qualifiers named by types, in that order.
+// A nil list, i.e. a config without one, enables every registered qualifier
+// in registration order, so a missing config never silently disables all
+// hard filters. An empty list enables none.
+func (r *qualifierRegistry) enabled(types []string) (qualifiers, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if types == nil {
+		qs := make(qualifiers, 0, len(r.order))
+		for _, qt := range r.order {
+			qs = append(qs, qualifier{qt: qt, fn: r.qualifiers[qt]})
+		}
+		return qs, nil
+	}
+
+	qs := make(qualifiers, 0, len(types))
+	for _, t := range types {
+		fn, ok := r.qualifiers[QualifierType(t)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownQualifier, t)
+		}
+		qs = append(qs, qualifier{qt: QualifierType(t), fn: fn})
+	}
+
+	return qs, nil
+}
+
+// enabledTypes returns the set of qualifier types enabled by types, see enabled.
+func (r *qualifierRegistry) enabledTypes(types []string) map[QualifierType]bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	set := make(map[QualifierType]bool)
+	if types == nil {
+		for _, qt := range r.order {
+			set[qt] = true
+		}
+		return set
+	}
+	for _, t := range types {
+		set[QualifierType(t)] = true
+	}
+
+	return set
+}
+
+// validate checks that types only names registered qualifiers, once each.
+func (r *qualifierRegistry) validate(types []string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		if _, ok := r.qualifiers[QualifierType(t)]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownQualifier, t)
+		}
+		if _, ok := seen[t]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateQualifier, t)
+		}
+		seen[t] = struct{}{}
+	}
+
+	return nil
+}
+
+// registerDefaultQualifiers registers the built-in hard qualifiers.
+func (l *Logic) registerDefaultQualifiers() error {
+	defaults := []struct {
+		qt QualifierType
+		fn QualifierFunc
+	}{
+		{ageWindowQualifier, l.ageQualifier},
+		{datePrefsQualifier, l.datePrefsQualifier},
+		{heightQualifier, l.heightQualifier},
+		{distanceQualifier, l.distanceQualifier},
+	}
+
+	for _, d := range defaults {
+		if err := l.qualifierRegistry.register(d.qt, d.fn); err != nil {
+			return fmt.Errorf("register %s: %w", d.qt, err)
+		}
+	}
+
+	return nil
+}
+
+// RegisterQualifier makes a hard qualifier available to match_config.
+// It only runs once its type is listed in match_config.qualifiers
+// (or the config has no list).
+func (l *Logic) RegisterQualifier(qt QualifierType, fn QualifierFunc) error {
+	return l.qualifierRegistry.register(qt, fn)
+}
+
+// RegisteredQualifiers returns the registered qualifier types, in registration order.
+func (l *Logic) RegisteredQualifiers() []QualifierType {
+	l.qualifierRegistry.mu.RLock()
+	defer l.qualifierRegistry.mu.RUnlock()
+
+	return append([]QualifierType(nil), l.qualifierRegistry.order...)
+}
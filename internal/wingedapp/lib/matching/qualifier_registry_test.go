@@ -0,0 +1,197 @@
+package matching_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching/testhelper"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const blockedContactQualifier matching.QualifierType = "blocked_contact_qualifier"
+
+var errBlockedContact = errors.New("users are blocked contacts")
+
+// blockedContact is a custom qualifier that always fails.
+func blockedContact(ctx context.Context, params *matching.QualifierParameters) *matching.Qualifier {
+	q := matching.NewQualifier(blockedContactQualifier)
+	q.Telemetry["radius_km"] = params.Config().LocationRadiusKM
+	return q.SetError(errBlockedContact)
+}
+
+const (
+	noResultQualifier matching.QualifierType = "no_result_qualifier"
+	misnamedQualifier matching.QualifierType = "misnamed_qualifier"
+)
+
+// noResult is a custom qualifier that returns no result.
+func noResult(context.Context, *matching.QualifierParameters) *matching.Qualifier {
+	return nil
+}
+
+// misnamed is a custom qualifier that passes, under a name it isn't registered by.
+func misnamed(context.Context, *matching.QualifierParameters) *matching.Qualifier {
+	return matching.NewQualifier("")
+}
+
+func TestLogic_RegisterQualifier(t *testing.T) {
+	tSuite := testsuite.New(t)
+	l := tSuite.FakeContainer().GetLibMatching()
+
+	assert.Equal(t, []matching.QualifierType{
+		"age_window_qualifier",
+		"date_prefs_qualifier",
+		"height_qualifier",
+		"distance_qualifier",
+	}, l.RegisteredQualifiers(), "defaults should be registered in order")
+
+	require.ErrorIs(t, l.RegisterQualifier("", blockedContact), matching.ErrQualifierTypeRequired)
+	require.ErrorIs(t, l.RegisterQualifier(blockedContactQualifier, nil), matching.ErrNilQualifier)
+	require.ErrorIs(t, l.RegisterQualifier("age_window_qualifier", blockedContact), matching.ErrQualifierAlreadyRegistered)
+
+	require.NoError(t, l.RegisterQualifier(blockedContactQualifier, blockedContact))
+	assert.Equal(t, blockedContactQualifier, l.RegisteredQualifiers()[4])
+}
+
+type testCaseConfiguredQualifiers struct {
+	name       string
+	qualifiers types.StringArray
+	assertions func(th *testsuite.Helper, results matching.QualifierResults)
+}
+
+func TestProcessMatch_ConfiguredQualifiers(t *testing.T) {
+	testCases := []testCaseConfiguredQualifiers{
+		{
+			name:       "only-configured-qualifiers-run",
+			qualifiers: types.StringArray{string(blockedContactQualifier)},
+			assertions: func(th *testsuite.Helper, results matching.QualifierResults) {
+				require.Len(th.T, results, 1)
+				require.NotNil(th.T, results[blockedContactQualifier])
+				assert.Equal(th.T, errBlockedContact.Error(), results[blockedContactQualifier].ErrorMsg)
+				assert.Contains(th.T, results[blockedContactQualifier].Telemetry, "radius_km")
+			},
+		},
+		{
+			name:       "configured-order-with-built-in",
+			qualifiers: types.StringArray{string(blockedContactQualifier), "age_window_qualifier"},
+			assertions: func(th *testsuite.Helper, results matching.QualifierResults) {
+				require.Len(th.T, results, 2)
+				assert.Equal(th.T, matching.ErrAgeGapMaleExceeds.Error(), results["age_window_qualifier"].ErrorMsg)
+				assert.Equal(th.T, errBlockedContact.Error(), results[blockedContactQualifier].ErrorMsg)
+			},
+		},
+		{
+			name:       "no-result-fails",
+			qualifiers: types.StringArray{string(noResultQualifier)},
+			assertions: func(th *testsuite.Helper, results matching.QualifierResults) {
+				require.Len(th.T, results, 1)
+				require.NotNil(th.T, results[noResultQualifier])
+				assert.Equal(th.T, matching.ErrNoQualifierResult.Error(), results[noResultQualifier].ErrorMsg)
+			},
+		},
+		{
+			name:       "results-keyed-by-registered-type",
+			qualifiers: types.StringArray{string(misnamedQualifier), string(blockedContactQualifier)},
+			assertions: func(th *testsuite.Helper, results matching.QualifierResults) {
+				require.Len(th.T, results, 2)
+				require.NotNil(th.T, results[misnamedQualifier])
+				assert.Equal(th.T, misnamedQualifier, results[misnamedQualifier].Name)
+				assert.Empty(th.T, results[misnamedQualifier].ErrorMsg)
+				assert.Equal(th.T, errBlockedContact.Error(), results[blockedContactQualifier].ErrorMsg)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tSuite := testsuite.New(t)
+			t.Cleanup(tSuite.UseBackendDB())
+			t.Cleanup(tSuite.UseAiDB())
+			t.Cleanup(tSuite.UseSupabaseAuthDB())
+
+			ctx := context.Background()
+			ctn := tSuite.FakeContainer()
+			matchingLib := ctn.GetLibMatching()
+			require.NoError(t, matchingLib.RegisterQualifier(blockedContactQualifier, blockedContact))
+			require.NoError(t, matchingLib.RegisterQualifier(noResultQualifier, noResult))
+			require.NoError(t, matchingLib.RegisterQualifier(misnamedQualifier, misnamed))
+
+			_, err := testhelper.UpdateConfig(ctx, tSuite.BackendAppDb(), matchingLib, &matching.UpdateMatchConfig{
+				Qualifiers: &tc.qualifiers,
+			})
+			require.NoError(t, err, "updating config qualifiers")
+
+			ingestor := testhelper.NewPopulationIngestor(t, tSuite, matchingLib)
+			ingestor.DisableCandidatePrefilter() // pair every user
+			_, _, err = ingestor.IngestFromCSVFile("./testdata/population_2_fail_all.csv")
+			require.NoError(t, err, "ingesting population data")
+
+			matchSet, err := matchingLib.IngestAll(ctx, tSuite.BackendAppDb())
+			require.NoError(t, err, "ingesting population data")
+
+			paginatedMatchResults, err := ctn.GetStoreMatching().MatchResultStore.MatchResults(ctx, tSuite.BackendAppDb(),
+				&matching.QueryFilterMatchResult{MatchSetID: null.StringFrom(matchSet.ID.String())},
+			)
+			require.NoError(t, err, "fetching match results")
+			require.Len(t, paginatedMatchResults.Data, 1)
+
+			matchResult, err := matchingLib.ProcessMatchResult(ctx, tSuite.BackendAppDb(), tSuite.AiBackendDb(), &paginatedMatchResults.Data[0])
+			require.NoError(t, err, "running matching algorithm")
+			assert.False(t, matchResult.MatchedQualitatively.Bool, "failing qualifier must stop qualitative matching")
+
+			var results matching.QualifierResults
+			require.NoError(t, json.Unmarshal(matchResult.QualifierResults.JSON, &results))
+			tc.assertions(tSuite, results)
+		})
+	}
+}
+
+func TestLogic_UpdateConfig_Qualifiers(t *testing.T) {
+	tSuite := testsuite.New(t)
+	t.Cleanup(tSuite.UseBackendDB())
+
+	l := tSuite.FakeContainer().GetLibMatching()
+	ctx := context.Background()
+
+	unknown := types.StringArray{"age_window_qualifier", "dietary_qualifier"}
+	_, err := testhelper.UpdateConfig(ctx, tSuite.BackendAppDb(), l, &matching.UpdateMatchConfig{Qualifiers: &unknown})
+	require.ErrorIs(t, err, matching.ErrUnknownQualifier)
+
+	duplicate := types.StringArray{"height_qualifier", "height_qualifier"}
+	_, err = testhelper.UpdateConfig(ctx, tSuite.BackendAppDb(), l, &matching.UpdateMatchConfig{Qualifiers: &duplicate})
+	require.ErrorIs(t, err, matching.ErrDuplicateQualifier)
+
+	ordered := types.StringArray{"distance_qualifier", "age_window_qualifier"}
+	cfg, err := testhelper.UpdateConfig(ctx, tSuite.BackendAppDb(), l, &matching.UpdateMatchConfig{Qualifiers: &ordered})
+	require.NoError(t, err)
+	assert.Equal(t, ordered, cfg.Qualifiers)
+}
+
+func TestQualifierResults_JSON(t *testing.T) {
+	results := matching.QualifierResults{
+		"age_window_qualifier":  matching.NewQualifier("age_window_qualifier").SetError(matching.ErrAgeGapMaleExceeds),
+		blockedContactQualifier: matching.NewQualifier(blockedContactQualifier),
+	}
+
+	b, err := results.AsJSON()
+	require.NoError(t, err)
+
+	var keyed map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(b, &keyed))
+	assert.Contains(t, keyed, "age_window", "built-in qualifiers keep their legacy key")
+	assert.Contains(t, keyed, string(blockedContactQualifier))
+
+	var decoded matching.QualifierResults
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Len(t, decoded, 2)
+	assert.Equal(t, matching.ErrAgeGapMaleExceeds.Error(), decoded["age_window_qualifier"].ErrorMsg)
+	assert.NotNil(t, decoded[blockedContactQualifier])
+}
@@ -0,0 +1,135 @@
+package matching
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+// matcherVersioner is implemented by qualitative matchers that version their
+// results, so a new matcher doesn't reuse an older one's cached results.
+type matcherVersioner interface {
+	Version() string
+}
+
+// matcherVersion returns the qualitative matcher's version, falling back to its type.
+func (l *Logic) matcherVersion() string {
+	if v, ok := l.qualitativeQuantifier.(matcherVersioner); ok {
+		return v.Version()
+	}
+	return fmt.Sprintf("%T", l.qualitativeQuantifier)
+}
+
+// ingestionStats counts qualitative cache hits and misses across a run's workers.
+// A nil *ingestionStats counts nothing.
+type ingestionStats struct {
+	cacheHits   atomic.Int64
+	cacheMisses atomic.Int64
+}
+
+func (s *ingestionStats) hit() {
+	if s != nil {
+		s.cacheHits.Add(1)
+	}
+}
+
+func (s *ingestionStats) miss() {
+	if s != nil {
+		s.cacheMisses.Add(1)
+	}
+}
+
+// newQualitativeCacheKey keys a pair's result on both profile payloads,
+// so a changed profile never reads a result computed from its old version.
+// The pair is sorted the way postgres sorts uuids, and RomeoID keeps the
+// orientation, since the matcher isn't symmetric.
+func newQualitativeCacheKey(version string, romeo, juliet uuid.UUID, profRomeo, profJuliet *PersonProfile) (*QualitativeCacheKey, error) {
+	h := sha256.New()
+	for _, p := range []*PersonProfile{profRomeo, profJuliet} {
+		b, err := json.Marshal(p)
+		if err != nil {
+			return nil, fmt.Errorf("marshal profile: %w", err)
+		}
+		h.Write(b)
+		h.Write([]byte{0})
+	}
+
+	userA, userB := romeo, juliet
+	if bytes.Compare(userA[:], userB[:]) > 0 {
+		userA, userB = userB, userA
+	}
+
+	return &QualitativeCacheKey{
+		UserAID:        userA,
+		UserBID:        userB,
+		RomeoID:        romeo,
+		ProfileHash:    hex.EncodeToString(h.Sum(nil)),
+		MatcherVersion: version,
+	}, nil
+}
+
+// qualify runs the qualitative matcher on a pair, reusing a cached result
+// when the cache is enabled and neither profile changed since.
+func (l *Logic) qualify(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	config *Config,
+	userA, userB uuid.UUID,
+	profA, profB *PersonProfile,
+	stats *ingestionStats,
+) (*MatchCompatibilityResult, error) {
+	req := &QualitativeMatchRequest{Romeo: profA, Juliet: profB}
+	if l.qualitativeCacher == nil || config.QualitativeCacheTTLHours <= 0 {
+		return l.qualitativeQualify(ctx, req)
+	}
+
+	key, err := newQualitativeCacheKey(l.matcherVersion(), userA, userB, profA, profB)
+	if err != nil {
+		return nil, fmt.Errorf("qualitative cache key: %w", err)
+	}
+
+	cached, err := l.qualitativeCacher.QualitativeCache(ctx, exec, key)
+	if err == nil {
+		stats.hit()
+		return cached, nil
+	}
+	if !errors.Is(err, ErrQualitativeCacheMiss) {
+		// the cache only saves calls, so a broken cache mustn't stop matching
+		fmt.Printf("[ProcessMatchResult] ERROR reading qualitative cache: %v\n", err)
+	}
+	stats.miss()
+
+	res, err := l.qualitativeQualify(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = l.qualitativeCacher.Upsert(ctx, exec, &InsertQualitativeCache{
+		Key:       key,
+		Result:    res,
+		ExpiresAt: timeNow().Add(time.Duration(config.QualitativeCacheTTLHours) * time.Hour),
+	}); err != nil {
+		fmt.Printf("[ProcessMatchResult] ERROR writing qualitative cache: %v\n", err)
+	}
+
+	return res, nil
+}
+
+// qualitativeQualify calls the qualitative matcher, waiting for the rate limit.
+func (l *Logic) qualitativeQualify(ctx context.Context, req *QualitativeMatchRequest) (*MatchCompatibilityResult, error) {
+	if l.qualitativeLimiter != nil {
+		if err := l.qualitativeLimiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("qualitative rate limit: %w", err)
+		}
+	}
+	return l.qualitativeQuantifier.Qualify(ctx, req)
+}
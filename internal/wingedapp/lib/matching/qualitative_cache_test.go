@@ -0,0 +1,110 @@
+package matching_test
+
+import (
+	"context"
+	"testing"
+	"wingedapp/pgtester/internal/wingedapp/aibackend/db/aipgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching/testhelper"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/null/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogic_RunIngestionSet_QualitativeCache(t *testing.T) {
+	tSuite := testsuite.New(t)
+	t.Cleanup(tSuite.UseBackendDB())
+	t.Cleanup(tSuite.UseAiDB())
+	t.Cleanup(tSuite.UseSupabaseAuthDB())
+
+	ctx := context.Background()
+	ctn := tSuite.FakeContainer()
+	matchLib := ctn.GetLibMatching()
+
+	mockQM := testhelper.MockSuccessQualitativeMatch(t)
+	matchLib.SetQualitativeQuantifier(mockQM)
+	matchLib.SetQualitativeCacher(ctn.GetStoreMatching().QualitativeCacheStore)
+
+	ingestor := testhelper.NewPopulationIngestor(t, tSuite, matchLib)
+	_, _, err := ingestor.IngestFromCSVFile("./testdata/population_3_pass_all.csv")
+	require.NoError(t, err, "ingesting population data")
+
+	matchSet, err := matchLib.IngestAll(ctx, tSuite.BackendAppDb())
+	require.NoError(t, err, "creating match set")
+
+	run := func() *matching.IngestionSetSummary {
+		// a rerun skips processed pairs, have it process the pair again
+		_, err := tSuite.BackendAppDb().ExecContext(ctx,
+			"UPDATE match_result SET ingestion_status = 'Pending' WHERE match_set_ref_id = $1", matchSet.ID)
+		require.NoError(t, err, "resetting ingestion status")
+
+		summary, err := matchLib.RunIngestionSet(ctx, tSuite.BackendAppDb(), tSuite.AiBackendDb(), matchSet.ID)
+		require.NoError(t, err, "RunIngestionSet should succeed")
+		require.Equal(t, 1, summary.Pairs)
+		return summary
+	}
+
+	summary := run()
+	assert.EqualValues(t, 0, summary.QualitativeCacheHits)
+	assert.EqualValues(t, 1, summary.QualitativeCacheMisses)
+	assert.Equal(t, 1, mockQM.QualifyCallCount())
+
+	summary = run()
+	assert.EqualValues(t, 1, summary.QualitativeCacheHits, "unchanged profiles should hit")
+	assert.EqualValues(t, 0, summary.QualitativeCacheMisses)
+	assert.Equal(t, 1, mockQM.QualifyCallCount(), "a hit must not call the matcher")
+
+	_, err = aipgmodel.Profiles().UpdateAll(ctx, tSuite.AiBackendDb(), aipgmodel.M{
+		aipgmodel.ProfileColumns.SelfPortrait: "rewritten self portrait",
+	})
+	require.NoError(t, err, "updating profiles")
+
+	summary = run()
+	assert.EqualValues(t, 0, summary.QualitativeCacheHits, "a changed profile should miss")
+	assert.EqualValues(t, 1, summary.QualitativeCacheMisses)
+	assert.Equal(t, 2, mockQM.QualifyCallCount())
+
+	_, err = tSuite.BackendAppDb().ExecContext(ctx, `
+		UPDATE match_result
+		SET initiator_user_ref_id = receiver_user_ref_id, receiver_user_ref_id = initiator_user_ref_id
+		WHERE match_set_ref_id = $1`, matchSet.ID)
+	require.NoError(t, err, "swapping initiator and receiver")
+
+	summary = run()
+	assert.EqualValues(t, 0, summary.QualitativeCacheHits, "the matcher isn't symmetric, the other orientation should miss")
+	assert.EqualValues(t, 1, summary.QualitativeCacheMisses)
+	assert.Equal(t, 3, mockQM.QualifyCallCount())
+
+	_, err = tSuite.BackendAppDb().ExecContext(ctx, `
+		UPDATE match_result
+		SET initiator_user_ref_id = receiver_user_ref_id, receiver_user_ref_id = initiator_user_ref_id
+		WHERE match_set_ref_id = $1`, matchSet.ID)
+	require.NoError(t, err, "swapping initiator and receiver back")
+
+	summary = run()
+	assert.EqualValues(t, 1, summary.QualitativeCacheHits, "each orientation keeps its own entry")
+	assert.EqualValues(t, 0, summary.QualitativeCacheMisses)
+	assert.Equal(t, 3, mockQM.QualifyCallCount())
+
+	_, err = testhelper.UpdateConfig(ctx, tSuite.BackendAppDb(), matchLib, &matching.UpdateMatchConfig{
+		QualitativeCacheTTLHours: null.IntFrom(0),
+	})
+	require.NoError(t, err, "disabling the cache")
+
+	summary = run()
+	assert.EqualValues(t, 1, summary.QualitativeCacheHits, "the set keeps the config version it was created with")
+	assert.Equal(t, 3, mockQM.QualifyCallCount())
+
+	_, err = tSuite.BackendAppDb().ExecContext(ctx, `
+		UPDATE match_set
+		SET match_config_version_ref_id = (SELECT id FROM match_config_version ORDER BY version DESC LIMIT 1)
+		WHERE id = $1`, matchSet.ID)
+	require.NoError(t, err, "moving the set to the latest config version")
+
+	summary = run()
+	assert.EqualValues(t, 0, summary.QualitativeCacheHits, "a 0 TTL disables the cache")
+	assert.EqualValues(t, 0, summary.QualitativeCacheMisses)
+	assert.Equal(t, 4, mockQM.QualifyCallCount())
+}
@@ -2,15 +2,20 @@ package matching_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 	"wingedapp/pgtester/internal/db/factory"
 	wingedFactory "wingedapp/pgtester/internal/wingedapp/db/factory"
 	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
 	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching/matchingfakes"
 	"wingedapp/pgtester/internal/wingedapp/lib/matching/testhelper"
 	"wingedapp/pgtester/internal/wingedapp/testsuite"
 
 	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -30,6 +35,7 @@ func TestLogic_RunIngestionSet(t *testing.T) {
 			setup: func(th *testsuite.Helper, matchLib *matching.Logic) *matching.MatchSet {
 				// Seed 2 users that will fail hard qualifiers (different locations)
 				ingestor := testhelper.NewPopulationIngestor(th.T, th, matchLib)
+				ingestor.DisableCandidatePrefilter() // pair every user
 				parseResult, populateResult, err := ingestor.IngestFromCSVFile("./testdata/population_2_fail_all.csv")
 				require.NoError(th.T, err, "ingesting population data")
 				require.Equal(th.T, 2, parseResult.ValidRows, "expected 2 valid rows")
@@ -66,6 +72,7 @@ func TestLogic_RunIngestionSet(t *testing.T) {
 			name: "success-stores-qualifier-results-json",
 			setup: func(th *testsuite.Helper, matchLib *matching.Logic) *matching.MatchSet {
 				ingestor := testhelper.NewPopulationIngestor(th.T, th, matchLib)
+				ingestor.DisableCandidatePrefilter() // pair every user
 				parseResult, populateResult, err := ingestor.IngestFromCSVFile("./testdata/population_2_fail_all.csv")
 				require.NoError(th.T, err, "ingesting population data")
 				require.Equal(th.T, 2, parseResult.ValidRows, "expected 2 valid rows")
@@ -100,6 +107,7 @@ func TestLogic_RunIngestionSet(t *testing.T) {
 			setup: func(th *testsuite.Helper, matchLib *matching.Logic) *matching.MatchSet {
 				// Use population that fails all hard qualifiers
 				ingestor := testhelper.NewPopulationIngestor(th.T, th, matchLib)
+				ingestor.DisableCandidatePrefilter() // pair every user
 				parseResult, populateResult, err := ingestor.IngestFromCSVFile("./testdata/population_2_fail_all.csv")
 				require.NoError(th.T, err, "ingesting population data")
 				require.Equal(th.T, 2, parseResult.ValidRows, "expected 2 valid rows")
@@ -210,6 +218,7 @@ func TestLogic_RunIngestionSet(t *testing.T) {
 			name: "success-updates-matched-qualitatively-flag-false-on-hard-fail",
 			setup: func(th *testsuite.Helper, matchLib *matching.Logic) *matching.MatchSet {
 				ingestor := testhelper.NewPopulationIngestor(th.T, th, matchLib)
+				ingestor.DisableCandidatePrefilter() // pair every user
 				parseResult, populateResult, err := ingestor.IngestFromCSVFile("./testdata/population_2_fail_all.csv")
 				require.NoError(th.T, err, "ingesting population data")
 				require.Equal(th.T, 2, parseResult.ValidRows, "expected 2 valid rows")
@@ -245,6 +254,7 @@ func TestLogic_RunIngestionSet(t *testing.T) {
 			setup: func(th *testsuite.Helper, matchLib *matching.Logic) *matching.MatchSet {
 				// Use population with 6 users = 15 pairs
 				ingestor := testhelper.NewPopulationIngestor(th.T, th, matchLib)
+				ingestor.DisableCandidatePrefilter() // pair every user
 				parseResult, populateResult, err := ingestor.IngestFromCSVFile("./testdata/population_1.csv")
 				require.NoError(th.T, err, "ingesting population data")
 				require.Equal(th.T, 6, parseResult.ValidRows, "expected 6 valid rows")
@@ -305,9 +315,252 @@ func TestLogic_RunIngestionSet(t *testing.T) {
 			matchSet := tc.setup(testSuite, matchLib)
 
 			aiExec := testSuite.AiBackendDb()
-			runErr := matchLib.RunIngestionSet(context.Background(), testSuite.BackendAppDb(), aiExec, matchSet.ID)
+			_, runErr := matchLib.RunIngestionSet(context.Background(), testSuite.BackendAppDb(), aiExec, matchSet.ID)
 
 			tc.assertions(testSuite, matchSet.ID.String(), runErr)
 		})
 	}
 }
+
+// ingestMatchSet populates the users of a population file and ingests them into a match set.
+func ingestMatchSet(th *testsuite.Helper, matchLib *matching.Logic, populationFile string) *matching.MatchSet {
+	th.T.Helper()
+	ingestor := testhelper.NewPopulationIngestor(th.T, th, matchLib)
+	ingestor.DisableCandidatePrefilter() // pair every user
+	_, populateResult, err := ingestor.IngestFromCSVFile(populationFile)
+	require.NoError(th.T, err, "ingesting population data")
+	require.Empty(th.T, populateResult.Errors, "expected no populate errors")
+
+	matchSet, err := matchLib.Ingest(context.Background(), th.BackendAppDb(), &matching.QueryFilterUser{
+		IsActive: null.BoolFrom(true),
+	})
+	require.NoError(th.T, err, "creating match set")
+	return matchSet
+}
+
+// ingestionRunsOf returns the recorded runs of a match set, oldest first.
+func ingestionRunsOf(th *testsuite.Helper, matchSetID string) []matching.IngestionRun {
+	th.T.Helper()
+	var runs []matching.IngestionRun
+	err := pgmodel.NewQuery(qm.SQL(`
+		SELECT id, match_set_ref_id, status, pairs, processed, failed, skipped,
+		       last_error, started_at, finished_at
+		FROM match_set_run WHERE match_set_ref_id = $1 ORDER BY started_at, created_at`, matchSetID),
+	).Bind(context.Background(), th.BackendAppDb(), &runs)
+	require.NoError(th.T, err, "fetching ingestion runs")
+	return runs
+}
+
+type testCaseRunIngestionSetRuns struct {
+	name       string
+	setup      func(th *testsuite.Helper, matchLib *matching.Logic) *matching.MatchSet
+	assertions func(th *testsuite.Helper, matchLib *matching.Logic, matchSetID string, summary *matching.IngestionSetSummary, err error)
+}
+
+func TestLogic_RunIngestionSet_Runs(t *testing.T) {
+	testCases := []testCaseRunIngestionSetRuns{
+		{
+			name: "success-records-completed-run",
+			setup: func(th *testsuite.Helper, matchLib *matching.Logic) *matching.MatchSet {
+				return ingestMatchSet(th, matchLib, "./testdata/population_2_fail_all.csv")
+			},
+			assertions: func(th *testsuite.Helper, matchLib *matching.Logic, matchSetID string, summary *matching.IngestionSetSummary, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, enums.IngestionStatusCompleted, summary.Status)
+				assert.Equal(th.T, 1, summary.Pairs)
+				assert.Equal(th.T, 1, summary.Processed)
+				assert.Zero(th.T, summary.Failed)
+				assert.Zero(th.T, summary.Skipped)
+
+				runs := ingestionRunsOf(th, matchSetID)
+				require.Len(th.T, runs, 1)
+				assert.Equal(th.T, summary.RunID, runs[0].ID)
+				assert.Equal(th.T, enums.IngestionStatusCompleted, runs[0].Status)
+				assert.Equal(th.T, 1, runs[0].Processed)
+				assert.True(th.T, runs[0].FinishedAt.Valid, "run should be finished")
+
+				ms, err := pgmodel.FindMatchSet(context.Background(), th.BackendAppDb(), matchSetID)
+				require.NoError(th.T, err)
+				assert.Equal(th.T, string(enums.IngestionStatusCompleted), ms.IngestionStatus)
+
+				mrs, err := pgmodel.MatchResults(pgmodel.MatchResultWhere.MatchSetRefID.EQ(matchSetID)).All(context.Background(), th.BackendAppDb())
+				require.NoError(th.T, err)
+				for _, mr := range mrs {
+					assert.Equal(th.T, string(enums.MatchResultIngestionStatusProcessed), mr.IngestionStatus)
+					assert.True(th.T, mr.IngestedAt.Valid, "ingested_at should be set")
+				}
+			},
+		},
+		{
+			name: "success-rerun-skips-processed-pairs",
+			setup: func(th *testsuite.Helper, matchLib *matching.Logic) *matching.MatchSet {
+				matchSet := ingestMatchSet(th, matchLib, "./testdata/population_2_fail_all.csv")
+				_, err := matchLib.RunIngestionSet(context.Background(), th.BackendAppDb(), th.AiBackendDb(), matchSet.ID)
+				require.NoError(th.T, err, "first run")
+				return matchSet
+			},
+			assertions: func(th *testsuite.Helper, matchLib *matching.Logic, matchSetID string, summary *matching.IngestionSetSummary, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, enums.IngestionStatusCompleted, summary.Status)
+				assert.Zero(th.T, summary.Processed)
+				assert.Equal(th.T, 1, summary.Skipped)
+				assert.Len(th.T, ingestionRunsOf(th, matchSetID), 2, "each run is recorded")
+			},
+		},
+		{
+			name: "error-failed-pair-recorded-and-retried-by-rerun",
+			setup: func(th *testsuite.Helper, matchLib *matching.Logic) *matching.MatchSet {
+				mockQM := testhelper.MockSuccessQualitativeMatch(th.T)
+				mockQM.QualifyReturnsOnCall(0, nil, errors.New("ai backend unavailable"))
+				matchLib.SetQualitativeQuantifier(mockQM)
+
+				return ingestMatchSet(th, matchLib, "./testdata/population_3_pass_all.csv")
+			},
+			assertions: func(th *testsuite.Helper, matchLib *matching.Logic, matchSetID string, summary *matching.IngestionSetSummary, err error) {
+				require.ErrorIs(th.T, err, matching.ErrIngestionIncomplete)
+				require.NotNil(th.T, summary, "summary is returned with the error")
+				assert.Equal(th.T, enums.IngestionStatusFailed, summary.Status)
+				assert.Equal(th.T, 1, summary.Failed)
+				assert.Contains(th.T, summary.LastError, "ai backend unavailable")
+
+				ctx := context.Background()
+				mrs, err := pgmodel.MatchResults(pgmodel.MatchResultWhere.MatchSetRefID.EQ(matchSetID)).All(ctx, th.BackendAppDb())
+				require.NoError(th.T, err)
+				require.Len(th.T, mrs, 1)
+				assert.Equal(th.T, string(enums.MatchResultIngestionStatusFailed), mrs[0].IngestionStatus)
+				assert.Contains(th.T, mrs[0].IngestionError.String, "ai backend unavailable")
+
+				ms, err := pgmodel.FindMatchSet(ctx, th.BackendAppDb(), matchSetID)
+				require.NoError(th.T, err)
+				assert.Equal(th.T, string(enums.IngestionStatusFailed), ms.IngestionStatus)
+
+				// the rerun resumes with the failed pair
+				summary, err = matchLib.RunIngestionSet(ctx, th.BackendAppDb(), th.AiBackendDb(), uuid.MustParse(matchSetID))
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 1, summary.Processed)
+				assert.Zero(th.T, summary.Skipped)
+
+				require.NoError(th.T, mrs[0].Reload(ctx, th.BackendAppDb()))
+				assert.Equal(th.T, string(enums.MatchResultIngestionStatusProcessed), mrs[0].IngestionStatus)
+				assert.True(th.T, mrs[0].MatchedQualitatively)
+			},
+		},
+		{
+			name: "success-abandons-run-left-running",
+			setup: func(th *testsuite.Helper, matchLib *matching.Logic) *matching.MatchSet {
+				matchSet := ingestMatchSet(th, matchLib, "./testdata/population_2_fail_all.csv")
+
+				// a run whose process died mid-batch
+				_, err := th.BackendAppDb().ExecContext(context.Background(),
+					`INSERT INTO match_set_run (match_set_ref_id, status, started_at) VALUES ($1, 'Running', NOW() - INTERVAL '1 hour')`,
+					matchSet.ID)
+				require.NoError(th.T, err)
+				return matchSet
+			},
+			assertions: func(th *testsuite.Helper, matchLib *matching.Logic, matchSetID string, summary *matching.IngestionSetSummary, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 1, summary.Processed)
+
+				runs := ingestionRunsOf(th, matchSetID)
+				require.Len(th.T, runs, 2)
+				assert.Equal(th.T, enums.IngestionStatusAbandoned, runs[0].Status)
+				assert.Equal(th.T, enums.IngestionStatusCompleted, runs[1].Status)
+			},
+		},
+		{
+			name: "error-unknown-match-set",
+			setup: func(th *testsuite.Helper, matchLib *matching.Logic) *matching.MatchSet {
+				return &matching.MatchSet{ID: uuid.New()}
+			},
+			assertions: func(th *testsuite.Helper, matchLib *matching.Logic, matchSetID string, summary *matching.IngestionSetSummary, err error) {
+				require.ErrorIs(th.T, err, matching.ErrMatchSetNotFound)
+				assert.Nil(th.T, summary)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testSuite := testsuite.New(t)
+			t.Cleanup(testSuite.UseBackendDB())
+			t.Cleanup(testSuite.UseAiDB())
+			t.Cleanup(testSuite.UseSupabaseAuthDB())
+
+			matchLib := testSuite.FakeContainer().GetLibMatching()
+			matchSet := tc.setup(testSuite, matchLib)
+
+			summary, err := matchLib.RunIngestionSet(context.Background(), testSuite.BackendAppDb(), testSuite.AiBackendDb(), matchSet.ID)
+
+			tc.assertions(testSuite, matchLib, matchSet.ID.String(), summary, err)
+		})
+	}
+}
+
+func TestLogic_RunIngestionSet_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	testSuite := testsuite.New(t)
+	t.Cleanup(testSuite.UseBackendDB())
+	t.Cleanup(testSuite.UseAiDB())
+	t.Cleanup(testSuite.UseSupabaseAuthDB())
+
+	matchLib := testSuite.FakeContainer().GetLibMatching()
+	matchLib.SetBatchWorkers(1)
+
+	// cancel the run from the first qualitative call
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	success := testhelper.MockSuccessQualitativeMatch(t)
+	mockQM := &matchingfakes.FakeQualitativeQuantifier{}
+	mockQM.QualifyCalls(func(ctx context.Context, req *matching.QualitativeMatchRequest) (*matching.MatchCompatibilityResult, error) {
+		cancel()
+		return success.Qualify(ctx, req)
+	})
+	matchLib.SetQualitativeQuantifier(mockQM)
+
+	matchSet := ingestMatchSet(testSuite, matchLib, "./testdata/population_1.csv")
+
+	summary, err := matchLib.RunIngestionSet(ctx, testSuite.BackendAppDb(), testSuite.AiBackendDb(), matchSet.ID)
+	require.ErrorIs(t, err, matching.ErrIngestionIncomplete)
+	require.ErrorIs(t, err, context.Canceled)
+	require.NotNil(t, summary)
+	assert.Equal(t, enums.IngestionStatusFailed, summary.Status)
+	assert.Positive(t, summary.Processed, "the pair in flight is drained")
+	assert.Positive(t, summary.Pending, "queued pairs aren't started")
+	assert.Equal(t, summary.Pairs, summary.Processed+summary.Pending)
+
+	// the next run picks up the pending pairs
+	resumed, err := matchLib.RunIngestionSet(context.Background(), testSuite.BackendAppDb(), testSuite.AiBackendDb(), matchSet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, summary.Processed, resumed.Skipped)
+	assert.Equal(t, summary.Pending, resumed.Processed)
+	assert.Zero(t, resumed.Pending)
+}
+
+func TestLogic_RunIngestionSet_QualitativeRateLimit(t *testing.T) {
+	t.Parallel()
+
+	testSuite := testsuite.New(t)
+	t.Cleanup(testSuite.UseBackendDB())
+	t.Cleanup(testSuite.UseAiDB())
+	t.Cleanup(testSuite.UseSupabaseAuthDB())
+
+	const qps = 20
+	matchLib := testSuite.FakeContainer().GetLibMatching()
+	matchLib.SetQualitativeRateLimit(qps, 1)
+	mockQM := testhelper.MockSuccessQualitativeMatch(t)
+	matchLib.SetQualitativeQuantifier(mockQM)
+
+	matchSet := ingestMatchSet(testSuite, matchLib, "./testdata/population_1.csv")
+
+	start := time.Now()
+	_, err := matchLib.RunIngestionSet(context.Background(), testSuite.BackendAppDb(), testSuite.AiBackendDb(), matchSet.ID)
+	require.NoError(t, err)
+
+	calls := mockQM.QualifyCallCount()
+	require.Greater(t, calls, 1, "expected several qualitative calls")
+	minElapsed := time.Duration(calls-1) * time.Second / qps
+	assert.GreaterOrEqual(t, time.Since(start), minElapsed, "%d calls should be spread at %d/s", calls, qps)
+}
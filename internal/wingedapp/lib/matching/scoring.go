@@ -0,0 +1,183 @@
+package matching
+
+import (
+	"math"
+
+	"github.com/umahmood/haversine"
+)
+
+const (
+	// idealHeightGapCM is the male-female height gap that scores highest.
+	idealHeightGapCM = 10.0
+	// heightScoreSpanCM is how far from the ideal gap the height score drops to 0.
+	heightScoreSpanCM = 30.0
+)
+
+// SoftScore is the local score of a pair that passed the hard qualifiers.
+// Every component is in [0, 1]. A component is nil when its inputs are
+// missing (or its weight is 0), and then doesn't count towards Total.
+type SoftScore struct {
+	Distance *float64 `json:"distance,omitempty"`
+	AgeGap   *float64 `json:"age_gap,omitempty"`
+	Height   *float64 `json:"height,omitempty"`
+	Traits   *float64 `json:"traits,omitempty"`
+	Total    float64  `json:"total"`
+}
+
+// ComputeSoftScore scores a pair on distance, age gap, height delta and
+// trait similarity, weighted by the match config.
+func ComputeSoftScore(cfg *Config, userA, userB *User, profA, profB *PersonProfile) *SoftScore {
+	s := &SoftScore{
+		Distance: distanceScore(cfg, userA, userB),
+		AgeGap:   ageGapScore(cfg, userA, userB),
+		Height:   heightScore(userA, userB),
+		Traits:   traitScore(profA, profB),
+	}
+
+	components := []struct {
+		score  *float64
+		weight float64
+	}{
+		{s.Distance, cfg.ScoreWeightDistance},
+		{s.AgeGap, cfg.ScoreWeightAge},
+		{s.Height, cfg.ScoreWeightHeight},
+		{s.Traits, cfg.ScoreWeightTraits},
+	}
+
+	var sum, weights float64
+	for _, c := range components {
+		if c.score == nil || c.weight <= 0 {
+			continue
+		}
+		sum += *c.score * c.weight
+		weights += c.weight
+	}
+
+	if weights > 0 {
+		s.Total = sum / weights
+	}
+
+	return s
+}
+
+// FinalScore blends the soft score with the AI score,
+// giving the AI score a ScoreWeightAI share.
+// The AI score is first rescaled from the config's score range, see aiScoreInRange.
+func FinalScore(cfg *Config, softScore, aiScore float64) float64 {
+	w := clamp01(cfg.ScoreWeightAI)
+	return w*aiScoreInRange(cfg, aiScore) + (1-w)*clamp01(softScore)
+}
+
+// aiScoreInRange maps the AI score from [ScoreRangeStart, ScoreRangeEnd],
+// the band AI scores fall in, onto [0, 1]: ScoreRangeStart and below score 0,
+// ScoreRangeEnd and above score 1. Without a range, the AI score is only clamped.
+func aiScoreInRange(cfg *Config, aiScore float64) float64 {
+	if cfg.ScoreRangeEnd <= cfg.ScoreRangeStart {
+		return clamp01(aiScore)
+	}
+	return clamp01((aiScore - cfg.ScoreRangeStart) / (cfg.ScoreRangeEnd - cfg.ScoreRangeStart))
+}
+
+// distanceScore is 1 for users in the same place, dropping to 0
+// at the widest radius the distance qualifier would allow.
+func distanceScore(cfg *Config, userA, userB *User) *float64 {
+	if !userA.Latitude.Valid || !userA.Longitude.Valid ||
+		!userB.Latitude.Valid || !userB.Longitude.Valid {
+		return nil
+	}
+
+	maxKM := maxDistanceKM(cfg)
+	if maxKM <= 0 {
+		return nil
+	}
+
+	_, distKM := haversine.Distance(
+		haversine.Coord{Lat: userA.Latitude.Float64, Lon: userA.Longitude.Float64},
+		haversine.Coord{Lat: userB.Latitude.Float64, Lon: userB.Longitude.Float64},
+	)
+
+	return ptr(clamp01(1 - distKM/maxKM))
+}
+
+// ageGapScore is 1 for users of the same age, dropping to 0
+// at the widest age gap the config allows.
+func ageGapScore(cfg *Config, userA, userB *User) *float64 {
+	if !userA.Age.Valid || !userB.Age.Valid {
+		return nil
+	}
+
+	gap := math.Abs(float64(userA.Age.Int - userB.Age.Int))
+	maxGap := float64(max(cfg.AgeRangeManOlderBy, cfg.AgeRangeWomanOlderBy, cfg.AgeRangeEnd))
+	if maxGap <= 0 {
+		if gap == 0 {
+			return ptr(1)
+		}
+		return ptr(0)
+	}
+
+	return ptr(clamp01(1 - gap/maxGap))
+}
+
+// heightScore is 1 when the man is idealHeightGapCM taller in hetero pairs,
+// or for equal heights otherwise, dropping to 0 heightScoreSpanCM away.
+func heightScore(userA, userB *User) *float64 {
+	if !userA.Height.Valid || !userB.Height.Valid {
+		return nil
+	}
+
+	delta := math.Abs(userA.Height.Float64 - userB.Height.Float64)
+	if usersAreHetero(userA, userB) {
+		maleUser, femaleUser, err := getMaleAndFemaleUsers(userA, userB)
+		if err != nil {
+			return nil
+		}
+		delta = math.Abs(maleUser.Height.Float64 - femaleUser.Height.Float64 - idealHeightGapCM)
+	}
+
+	return ptr(clamp01(1 - delta/heightScoreSpanCM))
+}
+
+// traitScore is 1 minus the mean distance of the two trait vectors.
+// Optional traits are skipped when either profile lacks them.
+func traitScore(profA, profB *PersonProfile) *float64 {
+	if profA == nil || profB == nil {
+		return nil
+	}
+
+	a, b := profA.Quantitative, profB.Quantitative
+	pairs := [][2]float64{
+		{a.ExtroversionSocialEnergy, b.ExtroversionSocialEnergy},
+		{a.RoutineVsSpontaneity, b.RoutineVsSpontaneity},
+		{a.Agreeableness, b.Agreeableness},
+		{a.Conscientiousness, b.Conscientiousness},
+		{a.Neuroticism, b.Neuroticism},
+		{a.DominanceLevel, b.DominanceLevel},
+		{a.EmotionalExpressiveness, b.EmotionalExpressiveness},
+		{a.SexDrive, b.SexDrive},
+		{a.GeographicalMobility, b.GeographicalMobility},
+	}
+	optional := [][2]float64{
+		{a.FinancialRiskTolerance, b.FinancialRiskTolerance},
+		{a.AbstractVsConcrete, b.AbstractVsConcrete},
+	}
+	for _, p := range optional {
+		if p[0] != 0 && p[1] != 0 {
+			pairs = append(pairs, p)
+		}
+	}
+
+	var diff float64
+	for _, p := range pairs {
+		diff += math.Abs(clamp01(p[0]) - clamp01(p[1]))
+	}
+
+	return ptr(clamp01(1 - diff/float64(len(pairs))))
+}
+
+func clamp01(f float64) float64 {
+	return math.Min(1, math.Max(0, f))
+}
+
+func ptr(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,141 @@
+package matching_test
+
+import (
+	"testing"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scoringConfig() *matching.Config {
+	return &matching.Config{
+		AgeRangeEnd:               5,
+		AgeRangeWomanOlderBy:      5,
+		AgeRangeManOlderBy:        10,
+		LocationRadiusKM:          100,
+		LocationAdaptiveExpansion: types.Int64Array{150, 200},
+		ScoreWeightDistance:       0.25,
+		ScoreWeightAge:            0.20,
+		ScoreWeightHeight:         0.10,
+		ScoreWeightTraits:         0.45,
+		ScoreWeightAI:             0.60,
+	}
+}
+
+func scoringUser(gender string, age int, heightCM, lat float64) *matching.User {
+	return &matching.User{
+		Gender:    null.StringFrom(gender),
+		Age:       null.IntFrom(age),
+		Height:    null.Float64From(heightCM),
+		Latitude:  null.Float64From(lat),
+		Longitude: null.Float64From(0),
+	}
+}
+
+func scoringProfile(trait float64) *matching.PersonProfile {
+	return &matching.PersonProfile{
+		Quantitative: matching.QuantitativeSection{
+			ExtroversionSocialEnergy: trait,
+			RoutineVsSpontaneity:     trait,
+			Agreeableness:            trait,
+			Conscientiousness:        trait,
+			Neuroticism:              trait,
+			DominanceLevel:           trait,
+			EmotionalExpressiveness:  trait,
+			SexDrive:                 trait,
+			GeographicalMobility:     trait,
+		},
+	}
+}
+
+type testCaseComputeSoftScore struct {
+	name       string
+	cfg        func() *matching.Config
+	userA      *matching.User
+	userB      *matching.User
+	profA      *matching.PersonProfile
+	profB      *matching.PersonProfile
+	assertions func(t *testing.T, s *matching.SoftScore)
+}
+
+func TestComputeSoftScore(t *testing.T) {
+	testCases := []testCaseComputeSoftScore{
+		{
+			name:  "ideal-pair-scores-one",
+			cfg:   scoringConfig,
+			userA: scoringUser("Male", 30, 185, 0),
+			userB: scoringUser("Female", 30, 175, 0),
+			profA: scoringProfile(0.5),
+			profB: scoringProfile(0.5),
+			assertions: func(t *testing.T, s *matching.SoftScore) {
+				assert.InDelta(t, 1, s.Total, 1e-9)
+			},
+		},
+		{
+			name:  "components-degrade-with-distance-age-and-traits",
+			cfg:   scoringConfig,
+			userA: scoringUser("Male", 35, 185, 0),
+			userB: scoringUser("Female", 30, 175, 0.9), // ~100km apart
+			profA: scoringProfile(0.2),
+			profB: scoringProfile(0.6),
+			assertions: func(t *testing.T, s *matching.SoftScore) {
+				require.NotNil(t, s.Distance)
+				require.NotNil(t, s.AgeGap)
+				require.NotNil(t, s.Traits)
+				assert.InDelta(t, 0.5, *s.Distance, 0.01, "100km of a 200km max radius")
+				assert.InDelta(t, 0.5, *s.AgeGap, 1e-9, "5 years of a 10 year max gap")
+				assert.InDelta(t, 0.6, *s.Traits, 1e-9)
+				assert.Less(t, s.Total, 1.0)
+			},
+		},
+		{
+			name:  "missing-inputs-are-skipped",
+			cfg:   scoringConfig,
+			userA: &matching.User{Gender: null.StringFrom("Male"), Age: null.IntFrom(30)},
+			userB: &matching.User{Gender: null.StringFrom("Female"), Age: null.IntFrom(30)},
+			assertions: func(t *testing.T, s *matching.SoftScore) {
+				assert.Nil(t, s.Distance)
+				assert.Nil(t, s.Height)
+				assert.Nil(t, s.Traits)
+				assert.InDelta(t, 1, s.Total, 1e-9, "only the age gap counts")
+			},
+		},
+		{
+			name: "zero-weights-score-zero",
+			cfg: func() *matching.Config {
+				return &matching.Config{LocationRadiusKM: 100}
+			},
+			userA: scoringUser("Male", 30, 185, 0),
+			userB: scoringUser("Female", 30, 175, 0),
+			assertions: func(t *testing.T, s *matching.SoftScore) {
+				assert.Zero(t, s.Total)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.assertions(t, matching.ComputeSoftScore(tc.cfg(), tc.userA, tc.userB, tc.profA, tc.profB))
+		})
+	}
+}
+
+func TestFinalScore(t *testing.T) {
+	cfg := scoringConfig()
+	assert.InDelta(t, 0.6*0.8+0.4*0.5, matching.FinalScore(cfg, 0.5, 0.8), 1e-9)
+
+	cfg.ScoreWeightAI = 0
+	assert.InDelta(t, 0.5, matching.FinalScore(cfg, 0.5, 0.8), 1e-9, "soft score only")
+
+	cfg.ScoreWeightAI = 1
+	assert.InDelta(t, 0.8, matching.FinalScore(cfg, 0.5, 0.8), 1e-9, "AI score only")
+	assert.InDelta(t, 1, matching.FinalScore(cfg, 0.5, 7), 1e-9, "scores are clamped to [0, 1]")
+
+	cfg.ScoreRangeStart, cfg.ScoreRangeEnd = 0.5, 0.7
+	assert.InDelta(t, 0.5, matching.FinalScore(cfg, 0.5, 0.6), 1e-9, "the AI score is rescaled from the score range")
+	assert.InDelta(t, 0, matching.FinalScore(cfg, 0.5, 0.4), 1e-9, "below the score range scores 0")
+	assert.InDelta(t, 1, matching.FinalScore(cfg, 0.5, 0.9), 1e-9, "above the score range scores 1")
+}
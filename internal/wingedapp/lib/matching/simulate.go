@@ -0,0 +1,217 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/umahmood/haversine"
+)
+
+var (
+	// simulationDistanceBoundsKM are the bucket bounds of simulated pair distances.
+	simulationDistanceBoundsKM = []float64{10, 25, 50, 100, 200, 350, 500}
+	// simulationAgeGapBounds are the bucket bounds of simulated pair age gaps, in years.
+	simulationAgeGapBounds = []float64{1, 3, 5, 8, 10, 15}
+)
+
+// Simulate dry-runs the hard qualifiers over every pair of the selected
+// active users, once against the live config and once against the live
+// config with the proposed update applied, and reports how the outcomes
+// compare. Nothing is written, and no qualitative matching runs.
+// Every pair is simulated, as with candidate_prefilter off, and pairs
+// blocked by a previous match aren't left out.
+func (l *Logic) Simulate(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	params *SimulateParams,
+) (*SimulationReport, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("validate params: %w", err)
+	}
+	if params.Config.Qualifiers != nil {
+		if err := l.qualifierRegistry.validate(*params.Config.Qualifiers); err != nil {
+			return nil, fmt.Errorf("validate qualifiers: %w", err)
+		}
+	}
+
+	current, err := l.configStorer.Config(ctx, exec, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get config: %w", err)
+	}
+	proposed := params.Config.ApplyTo(*current)
+
+	changes, err := diffConfigs(current, &proposed)
+	if err != nil {
+		return nil, fmt.Errorf("diff proposed config: %w", err)
+	}
+
+	currentQualifiers, err := l.qualifierRegistry.enabled(current.Qualifiers)
+	if err != nil {
+		return nil, fmt.Errorf("enabled qualifiers: %w", err)
+	}
+	proposedQualifiers, err := l.qualifierRegistry.enabled(proposed.Qualifiers)
+	if err != nil {
+		return nil, fmt.Errorf("proposed qualifiers: %w", err)
+	}
+
+	filter := QueryFilterUser{}
+	if params.Users != nil {
+		filter = *params.Users
+	}
+	filter.IsActive = null.BoolFrom(true)
+	filter.EnrichDatingPrefs = false // loaded for all users at once below
+
+	users, err := l.userStorer.Users(ctx, exec, &filter)
+	if err != nil {
+		return nil, fmt.Errorf("users: %w", err)
+	}
+	if err = l.enrichUsersDatingPrefs(ctx, exec, users); err != nil {
+		return nil, fmt.Errorf("enrich dating prefs: %w", err)
+	}
+
+	report := &SimulationReport{
+		Users:    len(users),
+		Changes:  changes,
+		Current:  SimulationOutcome{Qualifiers: make(map[QualifierType]*QualifierOutcome)},
+		Proposed: SimulationOutcome{Qualifiers: make(map[QualifierType]*QualifierOutcome)},
+	}
+	distances := newDistributionBuilder(simulationDistanceBoundsKM)
+	ageGaps := newDistributionBuilder(simulationAgeGapBounds)
+
+	// pairs are walked in place, all pairs of a large population don't fit in memory
+	for i := range users {
+		if err = ctx.Err(); err != nil {
+			return nil, fmt.Errorf("simulate: %w", err)
+		}
+
+		for j := i + 1; j < len(users); j++ {
+			userA, userB := &users[i], &users[j]
+			if userA.ID == userB.ID {
+				continue
+			}
+			report.Pairs++
+
+			currentPassed := report.Current.add(currentQualifiers.ExecuteAll(ctx, &QualifierParameters{
+				config: current,
+				UserA:  userA,
+				UserB:  userB,
+			}))
+			proposedPassed := report.Proposed.add(proposedQualifiers.ExecuteAll(ctx, &QualifierParameters{
+				config: &proposed,
+				UserA:  userA,
+				UserB:  userB,
+			}))
+
+			switch {
+			case proposedPassed && !currentPassed:
+				report.NewlyPassing++
+			case currentPassed && !proposedPassed:
+				report.NewlyFailing++
+			}
+
+			if userA.Latitude.Valid && userA.Longitude.Valid && userB.Latitude.Valid && userB.Longitude.Valid {
+				_, distKM := haversine.Distance(
+					haversine.Coord{Lat: userA.Latitude.Float64, Lon: userA.Longitude.Float64},
+					haversine.Coord{Lat: userB.Latitude.Float64, Lon: userB.Longitude.Float64},
+				)
+				distances.add(distKM)
+			}
+			if userA.Age.Valid && userB.Age.Valid {
+				ageGaps.add(math.Abs(float64(userA.Age.Int - userB.Age.Int)))
+			}
+		}
+	}
+
+	report.DistancesKM = distances.distribution()
+	report.AgeGaps = ageGaps.distribution()
+
+	return report, nil
+}
+
+// add counts a pair's qualifier results, and reports whether it passed them all.
+func (o *SimulationOutcome) add(results QualifierResults) bool {
+	for name, q := range results {
+		counts, ok := o.Qualifiers[name]
+		if !ok {
+			counts = &QualifierOutcome{}
+			o.Qualifiers[name] = counts
+		}
+		if q.ErrorMsg == "" {
+			counts.Passed++
+		} else {
+			counts.Failed++
+		}
+	}
+
+	if results.HasErrors() {
+		o.Failed++
+		return false
+	}
+	o.Passed++
+	return true
+}
+
+// distributionBuilder accumulates a Distribution over fixed bucket bounds,
+// without keeping the values.
+type distributionBuilder struct {
+	bounds []float64
+	counts []int
+
+	n        int
+	min, max float64
+	sum      float64
+}
+
+func newDistributionBuilder(bounds []float64) *distributionBuilder {
+	return &distributionBuilder{
+		bounds: bounds,
+		counts: make([]int, len(bounds)+1),
+	}
+}
+
+func (d *distributionBuilder) add(v float64) {
+	if d.n == 0 || v < d.min {
+		d.min = v
+	}
+	if d.n == 0 || v > d.max {
+		d.max = v
+	}
+	d.n++
+	d.sum += v
+
+	bucket := len(d.bounds)
+	for i, bound := range d.bounds {
+		if v < bound {
+			bucket = i
+			break
+		}
+	}
+	d.counts[bucket]++
+}
+
+func (d *distributionBuilder) distribution() Distribution {
+	dist := Distribution{
+		Pairs:   d.n,
+		Min:     d.min,
+		Max:     d.max,
+		Buckets: make([]DistributionBucket, len(d.counts)),
+	}
+	if d.n > 0 {
+		dist.Mean = d.sum / float64(d.n)
+	}
+
+	from := 0.0
+	for i, count := range d.counts {
+		bucket := DistributionBucket{From: from, Pairs: count}
+		if i < len(d.bounds) {
+			bucket.To = null.Float64From(d.bounds[i])
+			from = d.bounds[i]
+		}
+		dist.Buckets[i] = bucket
+	}
+
+	return dist
+}
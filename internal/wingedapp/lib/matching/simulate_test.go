@@ -0,0 +1,149 @@
+package matching_test
+
+import (
+	"context"
+	"testing"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching/testhelper"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCaseSimulate struct {
+	name            string
+	population      string
+	params          *matching.SimulateParams
+	extraAssertions func(th *testsuite.Helper, report *matching.SimulationReport, err error)
+}
+
+func TestLogic_Simulate(t *testing.T) {
+	unknownQualifier := types.StringArray{"unknown_qualifier"}
+	noQualifiers := types.StringArray{}
+
+	testCases := []testCaseSimulate{
+		{
+			name:       "success-stricter-height-gap-fails-pair",
+			population: "./testdata/population_3_pass_all.csv",
+			params: &matching.SimulateParams{
+				Config: &matching.UpdateMatchConfig{HeightMaleGreaterByCM: null.Float64From(5)},
+			},
+			extraAssertions: func(th *testsuite.Helper, report *matching.SimulationReport, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 2, report.Users)
+				assert.Equal(th.T, 1, report.Pairs)
+
+				require.Len(th.T, report.Changes, 1)
+				assert.Equal(th.T, "height_male_greater_by_cm", report.Changes[0].Field)
+
+				assert.Equal(th.T, 1, report.Current.Passed)
+				assert.Equal(th.T, 1, report.Proposed.Failed)
+				assert.Equal(th.T, 1, report.NewlyFailing)
+				assert.Equal(th.T, &matching.QualifierOutcome{Passed: 1}, report.Current.Qualifiers["height_qualifier"])
+				assert.Equal(th.T, &matching.QualifierOutcome{Failed: 1}, report.Proposed.Qualifiers["height_qualifier"])
+				assert.Equal(th.T, &matching.QualifierOutcome{Passed: 1}, report.Proposed.Qualifiers["age_window_qualifier"])
+
+				assert.Equal(th.T, 1, report.DistancesKM.Pairs)
+				assert.Equal(th.T, 1, report.DistancesKM.Buckets[0].Pairs, "the users live in the same place")
+				assert.Equal(th.T, 1, report.AgeGaps.Pairs)
+
+				ctx := context.Background()
+				sets, err := pgmodel.MatchSets().Count(ctx, th.BackendAppDb())
+				require.NoError(th.T, err)
+				results, err := pgmodel.MatchResults().Count(ctx, th.BackendAppDb())
+				require.NoError(th.T, err)
+				assert.Zero(th.T, sets+results, "a simulation writes nothing")
+			},
+		},
+		{
+			name:       "success-unchanged-config-far-pair",
+			population: "./testdata/population_2_fail_all.csv",
+			params: &matching.SimulateParams{
+				Config: &matching.UpdateMatchConfig{},
+			},
+			extraAssertions: func(th *testsuite.Helper, report *matching.SimulationReport, err error) {
+				require.NoError(th.T, err)
+				assert.Empty(th.T, report.Changes)
+				assert.Equal(th.T, 1, report.Current.Failed)
+				assert.Equal(th.T, report.Current, report.Proposed)
+				assert.Zero(th.T, report.NewlyPassing+report.NewlyFailing)
+
+				last := len(report.DistancesKM.Buckets) - 1
+				assert.Equal(th.T, 1, report.DistancesKM.Buckets[last].Pairs, "the users live over 500 km apart")
+				assert.False(th.T, report.DistancesKM.Buckets[last].To.Valid)
+
+				last = len(report.AgeGaps.Buckets) - 1
+				assert.Equal(th.T, 1, report.AgeGaps.Buckets[last].Pairs, "the users are 25 years apart")
+			},
+		},
+		{
+			name:       "success-empty-qualifiers-run-none",
+			population: "./testdata/population_2_fail_all.csv",
+			params: &matching.SimulateParams{
+				Config: &matching.UpdateMatchConfig{Qualifiers: &noQualifiers},
+			},
+			extraAssertions: func(th *testsuite.Helper, report *matching.SimulationReport, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 1, report.Current.Failed)
+				assert.Equal(th.T, 1, report.Proposed.Passed, "no qualifier runs, so the pair passes")
+				assert.Empty(th.T, report.Proposed.Qualifiers)
+				assert.Equal(th.T, 1, report.NewlyPassing)
+			},
+		},
+		{
+			name:       "success-user-filter",
+			population: "./testdata/population_3_pass_all.csv",
+			params: &matching.SimulateParams{
+				Config: &matching.UpdateMatchConfig{},
+				Users:  &matching.QueryFilterUser{IsTestUser: null.BoolFrom(true)},
+			},
+			extraAssertions: func(th *testsuite.Helper, report *matching.SimulationReport, err error) {
+				require.NoError(th.T, err)
+				assert.Zero(th.T, report.Users, "the population has no test users")
+				assert.Zero(th.T, report.Pairs)
+			},
+		},
+		{
+			name:       "error-config-required",
+			population: "./testdata/population_3_pass_all.csv",
+			params:     &matching.SimulateParams{},
+			extraAssertions: func(th *testsuite.Helper, report *matching.SimulationReport, err error) {
+				require.ErrorIs(th.T, err, matching.ErrSimulationConfigRequired)
+			},
+		},
+		{
+			name:       "error-unknown-qualifier",
+			population: "./testdata/population_3_pass_all.csv",
+			params: &matching.SimulateParams{
+				Config: &matching.UpdateMatchConfig{Qualifiers: &unknownQualifier},
+			},
+			extraAssertions: func(th *testsuite.Helper, report *matching.SimulationReport, err error) {
+				require.ErrorIs(th.T, err, matching.ErrUnknownQualifier)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tSuite := testsuite.New(t)
+			t.Cleanup(tSuite.UseBackendDB())
+			t.Cleanup(tSuite.UseAiDB())
+			t.Cleanup(tSuite.UseSupabaseAuthDB())
+
+			matchLib := tSuite.FakeContainer().GetLibMatching()
+
+			ingestor := testhelper.NewPopulationIngestor(t, tSuite, matchLib)
+			_, _, err := ingestor.IngestFromCSVFile(tc.population)
+			require.NoError(t, err, "ingesting population data")
+
+			report, err := matchLib.Simulate(context.Background(), tSuite.BackendAppDb(), tc.params)
+
+			tc.extraAssertions(tSuite, report, err)
+		})
+	}
+}
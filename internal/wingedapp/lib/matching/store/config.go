@@ -27,30 +27,9 @@ func (s *ConfigStore) Configs(
 ) ([]matching.Config, error) {
 	var configs []matching.Config
 
-	c := pgmodel.MatchConfigColumns
-
 	qMods := append(
 		qModsMatchConfig(f),
-		qm.Select(
-			"mc."+c.ID+" AS id",
-			"COALESCE(mc."+c.AgeRangeStart+", 0) AS age_range_start",
-			"COALESCE(mc."+c.AgeRangeEnd+", 0) AS age_range_end",
-			"mc."+c.AgeRangeWomanOlderBy+" AS age_range_woman_older_by",
-			"mc."+c.AgeRangeManOlderBy+" AS age_range_man_older_by",
-			"mc."+c.HeightMaleGreaterByCM+"::float8 AS height_male_greater_by_cm",
-			"mc."+c.LocationRadiusKM+"::float8 AS location_radius_km",
-			"mc."+c.LocationAdaptiveExpansion+" AS location_adaptive_expansion",
-			"mc."+c.DropHours+" AS drop_hours",
-			"mc."+c.DropHoursUtc+" AS drop_hours_utc",
-			"mc."+c.StaleChatAgentSetup+" AS stale_chat_agent_setup",
-			"mc."+c.StaleChatNudge+" AS stale_chat_nudge",
-			"mc."+c.MatchExpirationHours+" AS match_expiration_hours",
-			"mc."+c.MatchBlockDeclined+" AS match_block_declined",
-			"mc."+c.MatchBlockIgnored+" AS match_block_ignored",
-			"mc."+c.MatchBlockClosed+" AS match_block_closed",
-			"mc."+c.ScoreRangeStart+"::float8 AS score_range_start",
-			"mc."+c.ScoreRangeEnd+"::float8 AS score_range_end",
-		),
+		qm.Select(configColumns("mc")...),
 		qm.From(pgmodel.TableNames.MatchConfig+" mc"),
 	)
 
@@ -61,6 +40,42 @@ func (s *ConfigStore) Configs(
 	return configs, nil
 }
 
+// configColumns selects a Config from the match_config, or
+// match_config_version, row aliased as alias.
+func configColumns(alias string) []string {
+	c := pgmodel.MatchConfigColumns
+	p := alias + "."
+
+	return []string{
+		p + c.ID + " AS id",
+		"COALESCE(" + p + c.AgeRangeStart + ", 0) AS age_range_start",
+		"COALESCE(" + p + c.AgeRangeEnd + ", 0) AS age_range_end",
+		p + c.AgeRangeWomanOlderBy + " AS age_range_woman_older_by",
+		p + c.AgeRangeManOlderBy + " AS age_range_man_older_by",
+		p + c.HeightMaleGreaterByCM + "::float8 AS height_male_greater_by_cm",
+		p + c.LocationRadiusKM + "::float8 AS location_radius_km",
+		p + c.LocationAdaptiveExpansion + " AS location_adaptive_expansion",
+		p + c.DropHours + " AS drop_hours",
+		p + c.DropHoursUtc + " AS drop_hours_utc",
+		p + c.StaleChatAgentSetup + " AS stale_chat_agent_setup",
+		p + c.StaleChatNudge + " AS stale_chat_nudge",
+		p + c.MatchExpirationHours + " AS match_expiration_hours",
+		p + c.MatchBlockDeclined + " AS match_block_declined",
+		p + c.MatchBlockIgnored + " AS match_block_ignored",
+		p + c.MatchBlockClosed + " AS match_block_closed",
+		p + c.ScoreRangeStart + "::float8 AS score_range_start",
+		p + c.ScoreRangeEnd + "::float8 AS score_range_end",
+		p + c.Qualifiers + " AS qualifiers",
+		p + c.ScoreWeightDistance + "::float8 AS score_weight_distance",
+		p + c.ScoreWeightAge + "::float8 AS score_weight_age",
+		p + c.ScoreWeightHeight + "::float8 AS score_weight_height",
+		p + c.ScoreWeightTraits + "::float8 AS score_weight_traits",
+		p + c.ScoreWeightAi + "::float8 AS score_weight_ai",
+		p + c.QualitativeCacheTTLHours + " AS qualitative_cache_ttl_hours",
+		p + c.CandidatePrefilter + " AS candidate_prefilter",
+	}
+}
+
 func (s *ConfigStore) Config(
 	ctx context.Context,
 	exec boil.ContextExecutor,
@@ -216,59 +231,14 @@ func (s *ConfigStore) Update(
 		return nil, fmt.Errorf("%w: %v", matching.ErrConfigNotFound, err)
 	}
 
-	// Apply updates only for provided fields
-	if updater.AgeRangeStart.Valid {
-		existing.AgeRangeStart.SetValid(updater.AgeRangeStart.Int)
-	}
-	if updater.AgeRangeEnd.Valid {
-		existing.AgeRangeEnd.SetValid(updater.AgeRangeEnd.Int)
-	}
-	if updater.AgeRangeWomanOlderBy.Valid {
-		existing.AgeRangeWomanOlderBy = updater.AgeRangeWomanOlderBy.Int
-	}
-	if updater.AgeRangeManOlderBy.Valid {
-		existing.AgeRangeManOlderBy = updater.AgeRangeManOlderBy.Int
-	}
-	if updater.HeightMaleGreaterByCM.Valid {
-		existing.HeightMaleGreaterByCM = decimalFromFloat64(updater.HeightMaleGreaterByCM.Float64)
-	}
-	if updater.LocationRadiusKM.Valid {
-		existing.LocationRadiusKM = decimalFromFloat64(updater.LocationRadiusKM.Float64)
-	}
-	if updater.LocationAdaptiveExpansion != nil {
-		existing.LocationAdaptiveExpansion = *updater.LocationAdaptiveExpansion
-	}
-	if updater.DropHours != nil {
-		existing.DropHours = *updater.DropHours
-	}
-	if updater.DropHoursUTC != nil {
-		existing.DropHoursUtc = *updater.DropHoursUTC
-	}
-	if updater.StaleChatNudge.Valid {
-		existing.StaleChatNudge = updater.StaleChatNudge.Int
-	}
-	if updater.StaleChatAgentSetup.Valid {
-		existing.StaleChatAgentSetup = updater.StaleChatAgentSetup.Int
-	}
-	if updater.MatchExpirationHours.Valid {
-		existing.MatchExpirationHours = updater.MatchExpirationHours.Int
-	}
-	if updater.MatchBlockDeclined.Valid {
-		existing.MatchBlockDeclined = updater.MatchBlockDeclined.Int
-	}
-	if updater.MatchBlockIgnored.Valid {
-		existing.MatchBlockIgnored = updater.MatchBlockIgnored.Int
-	}
-	if updater.MatchBlockClosed.Valid {
-		existing.MatchBlockClosed = updater.MatchBlockClosed.Int
-	}
-	if updater.ScoreRangeStart.Valid {
-		existing.ScoreRangeStart = decimalFromFloat64(updater.ScoreRangeStart.Float64)
-	}
-	if updater.ScoreRangeEnd.Valid {
-		existing.ScoreRangeEnd = decimalFromFloat64(updater.ScoreRangeEnd.Float64)
+	current, err := s.Config(ctx, exec, nil)
+	if err != nil {
+		return nil, fmt.Errorf("current config: %w", err)
 	}
 
+	// Apply updates only for provided fields
+	setMatchConfig(existing, updater.ApplyTo(*current))
+
 	// Persist the updated config
 	_, err = existing.Update(ctx, exec, boil.Infer())
 	if err != nil {
@@ -279,6 +249,40 @@ func (s *ConfigStore) Update(
 	return s.Config(ctx, exec, nil)
 }
 
+// setMatchConfig sets every match_config column of row from config.
+func setMatchConfig(row *pgmodel.MatchConfig, config matching.Config) {
+	// unset age ranges read as 0, keep them unset
+	if row.AgeRangeStart.Valid || config.AgeRangeStart != 0 {
+		row.AgeRangeStart.SetValid(config.AgeRangeStart)
+	}
+	if row.AgeRangeEnd.Valid || config.AgeRangeEnd != 0 {
+		row.AgeRangeEnd.SetValid(config.AgeRangeEnd)
+	}
+	row.AgeRangeWomanOlderBy = config.AgeRangeWomanOlderBy
+	row.AgeRangeManOlderBy = config.AgeRangeManOlderBy
+	row.HeightMaleGreaterByCM = decimalFromFloat64(config.HeightMaleGreaterByCM)
+	row.LocationRadiusKM = decimalFromFloat64(config.LocationRadiusKM)
+	row.LocationAdaptiveExpansion = config.LocationAdaptiveExpansion
+	row.DropHours = config.DropHours
+	row.DropHoursUtc = config.DropHoursUTC
+	row.StaleChatNudge = config.StaleChatNudge
+	row.StaleChatAgentSetup = config.StaleChatAgentSetup
+	row.MatchExpirationHours = config.MatchExpirationHours
+	row.MatchBlockDeclined = config.MatchBlockDeclined
+	row.MatchBlockIgnored = config.MatchBlockIgnored
+	row.MatchBlockClosed = config.MatchBlockClosed
+	row.ScoreRangeStart = decimalFromFloat64(config.ScoreRangeStart)
+	row.ScoreRangeEnd = decimalFromFloat64(config.ScoreRangeEnd)
+	row.Qualifiers = config.Qualifiers
+	row.ScoreWeightDistance = decimalFromFloat64(config.ScoreWeightDistance)
+	row.ScoreWeightAge = decimalFromFloat64(config.ScoreWeightAge)
+	row.ScoreWeightHeight = decimalFromFloat64(config.ScoreWeightHeight)
+	row.ScoreWeightTraits = decimalFromFloat64(config.ScoreWeightTraits)
+	row.ScoreWeightAi = decimalFromFloat64(config.ScoreWeightAI)
+	row.QualitativeCacheTTLHours = config.QualitativeCacheTTLHours
+	row.CandidatePrefilter = config.CandidatePrefilter
+}
+
 func decimalFromFloat64(f float64) types.Decimal {
 	d := new(decimal.Big)
 	d, _ = d.SetString(strconv.FormatFloat(f, 'f', -1, 64))
@@ -0,0 +1,161 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+// configVersionColumns are the match_config columns a version snapshots.
+// A column added to match_config must be added here, and to
+// match_config_version, too.
+var configVersionColumns = strings.Join([]string{
+	"age_range_start",
+	"age_range_end",
+	"age_range_woman_older_by",
+	"age_range_man_older_by",
+	"height_male_greater_by_cm",
+	"location_radius_km",
+	"location_adaptive_expansion",
+	"match_hours",
+	"drop_hours",
+	"drop_hours_utc",
+	"stale_chat_nudge",
+	"stale_chat_agent_setup",
+	"match_expiration_hours",
+	"match_block_declined",
+	"match_block_ignored",
+	"match_block_closed",
+	"score_range_start",
+	"score_range_end",
+	"qualifiers",
+	"score_weight_distance",
+	"score_weight_age",
+	"score_weight_height",
+	"score_weight_traits",
+	"score_weight_ai",
+	"qualitative_cache_ttl_hours",
+	"candidate_prefilter",
+}, ", ")
+
+// ConfigVersions returns the match config versions, newest first.
+func (s *ConfigStore) ConfigVersions(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	f *matching.QueryFilterMatchConfigVersion,
+) ([]matching.ConfigVersion, error) {
+	var versions []matching.ConfigVersion
+
+	qMods := append(
+		qModsMatchConfigVersion(f),
+		qm.Select(append(configColumns("mcv"),
+			"mcv.id AS version_id",
+			"mcv.version AS version",
+			"mcv.rolled_back_from AS rolled_back_from",
+			"mcv.created_at AS created_at",
+		)...),
+		qm.From("match_config_version mcv"),
+		qm.OrderBy("mcv.version DESC"),
+	)
+
+	if err := pgmodel.NewQuery(qMods...).Bind(ctx, exec, &versions); err != nil {
+		return nil, fmt.Errorf("config versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+func (s *ConfigStore) ConfigVersion(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	f *matching.QueryFilterMatchConfigVersion,
+) (*matching.ConfigVersion, error) {
+	versions, err := s.ConfigVersions(ctx, exec, f)
+	if err != nil {
+		return nil, fmt.Errorf("query config versions: %w", err)
+	}
+
+	if len(versions) == 0 {
+		return nil, matching.ErrConfigVersionNotFound
+	}
+
+	if len(versions) > 1 {
+		return nil, fmt.Errorf("config version count mismatch, have %d, want 1", len(versions))
+	}
+
+	return &versions[0], nil
+}
+
+func qModsMatchConfigVersion(f *matching.QueryFilterMatchConfigVersion) []qm.QueryMod {
+	qMods := make([]qm.QueryMod, 0)
+
+	if f == nil {
+		return qMods
+	}
+
+	if f.ID.Valid {
+		qMods = append(qMods, qm.Where("mcv.id = ?", f.ID.String))
+	}
+	if f.Version.Valid {
+		qMods = append(qMods, qm.Where("mcv.version = ?", f.Version.Int))
+	}
+	if f.Latest {
+		qMods = append(qMods, qm.Limit(1))
+	}
+
+	return qMods
+}
+
+// InsertVersion records the live match config as the next version, numbered
+// by match_config_version_version_seq.
+// rolledBackFrom is the version it was restored from, if any.
+func (s *ConfigStore) InsertVersion(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	rolledBackFrom null.Int,
+) (*matching.ConfigVersion, error) {
+	query := `
+		INSERT INTO match_config_version (rolled_back_from, ` + configVersionColumns + `)
+		SELECT $1, ` + configVersionColumns + `
+		FROM match_config
+		LIMIT 1
+		RETURNING id`
+
+	var inserted struct {
+		ID string `boil:"id"`
+	}
+	if err := pgmodel.NewQuery(qm.SQL(query, rolledBackFrom)).Bind(ctx, exec, &inserted); err != nil {
+		return nil, fmt.Errorf("insert config version: %w", err)
+	}
+
+	return s.ConfigVersion(ctx, exec, &matching.QueryFilterMatchConfigVersion{
+		ID: null.StringFrom(inserted.ID),
+	})
+}
+
+// Restore overwrites the live match config with a version's.
+func (s *ConfigStore) Restore(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	version int,
+) error {
+	query := `
+		UPDATE match_config
+		SET (` + configVersionColumns + `) = (
+			SELECT ` + configVersionColumns + `
+			FROM match_config_version
+			WHERE version = $1
+		)`
+
+	if _, err := exec.ExecContext(ctx, query, version); err != nil {
+		return fmt.Errorf("restore config version %d: %w", version, err)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"fmt"
+	"time"
 	"wingedapp/pgtester/internal/wingedapp/db/boilhelper"
 	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
 	"wingedapp/pgtester/internal/wingedapp/db/repo"
@@ -13,6 +14,7 @@ import (
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/aarondl/sqlboiler/v4/types"
 	"github.com/google/uuid"
 )
 
@@ -45,8 +47,22 @@ func (s *MatchResultStore) matchResultsRows(
 			"mr."+matchResultCols.IsPossibleMatch+" AS is_possible_match",
 			"mr."+matchResultCols.IsApproved+" AS is_approved",
 			"mr."+matchResultCols.QualifierResults+" AS qualifier_results",
+			"mr."+matchResultCols.HardQualifierResults+" AS hard_qualifier_results",
 			"mr."+matchResultCols.MatchedQualitatively+" AS matched_qualitatively",
 			"mr."+matchResultCols.MatchLifecycleStatus+" AS user_lifecycle_status", // Now a direct string enum
+			"mr."+matchResultCols.SoftScore+" AS soft_score",
+			"mr."+matchResultCols.AiScore+" AS ai_score",
+			"mr."+matchResultCols.FinalScore+" AS final_score",
+			"mr."+matchResultCols.InitiatorAction+" AS initiator_action",
+			"mr."+matchResultCols.InitiatorActionAt+" AS initiator_action_at",
+			"mr."+matchResultCols.ReceiverAction+" AS receiver_action",
+			"mr."+matchResultCols.ReceiverActionAt+" AS receiver_action_at",
+			"mr."+matchResultCols.ExpiresAt+" AS expires_at",
+			"mr."+matchResultCols.CreatedAt+" AS created_at",
+			"mr."+matchResultCols.UpdatedAt+" AS updated_at",
+			"mr."+matchResultCols.ClosedAt+" AS closed_at",
+			"mr."+matchResultCols.IngestionStatus+" AS ingestion_status",
+			"mr."+matchResultCols.IngestionError+" AS ingestion_error",
 		),
 		qm.From(matchResultTbl+" mr"),
 	)
@@ -168,6 +184,51 @@ func (s *MatchResultStore) Insert(
 	}, nil
 }
 
+// InsertBatch inserts match results in a single statement, skipping pairs
+// already in their match set, and returns how many rows were inserted.
+// The initiator is user A and the receiver user B, as in Insert.
+func (s *MatchResultStore) InsertBatch(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserters []matching.InsertMatchResult,
+) (int64, error) {
+	if len(inserters) == 0 {
+		return 0, nil
+	}
+
+	// one array param per column, however many rows
+	matchSetIDs := make(types.StringArray, len(inserters))
+	userAIDs := make(types.StringArray, len(inserters))
+	userBIDs := make(types.StringArray, len(inserters))
+	for i, ins := range inserters {
+		matchSetIDs[i] = ins.MatchSetID.String()
+		userAIDs[i] = ins.InitiatorUserID.String()
+		userBIDs[i] = ins.ReceiverUserID.String()
+	}
+
+	const query = `
+		INSERT INTO match_result
+			(match_set_ref_id, user_a_ref_id, user_b_ref_id,
+			 initiator_user_ref_id, receiver_user_ref_id, match_lifecycle_status)
+		SELECT ms, a, b, a, b, $4
+		FROM unnest($1::uuid[], $2::uuid[], $3::uuid[]) AS r(ms, a, b)
+		ON CONFLICT (match_set_ref_id, user_a_ref_id, user_b_ref_id) DO NOTHING`
+
+	res, err := exec.ExecContext(ctx, query,
+		matchSetIDs, userAIDs, userBIDs, string(enums.MatchLifecycleStatusScheduling),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("insert match results: %w", err)
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("rows affected: %w", err)
+	}
+
+	return inserted, nil
+}
+
 func (s *MatchResultStore) Update(
 	ctx context.Context,
 	exec boil.ContextExecutor,
@@ -176,13 +237,21 @@ func (s *MatchResultStore) Update(
 	if err := s.repo.UpdateMatchResult(ctx, exec, &repo.UpdateMatchResult{
 		ID:                   updater.ID.String(),
 		QualifierResults:     updater.QualifierResults,
+		HardQualifierResults: updater.HardQualifierResults,
 		MatchedQualitatively: updater.MatchedQualitatively,
 		IsVerified:           updater.IsVerified,
 		IsExpired:            updater.IsExpired,
+		ExpiresAt:            updater.ExpiresAt,
 		IsApproved:           updater.IsApproved,
 		IsDropped:            updater.IsDropped,
 		DroppedTS:            updater.DroppedTS,
 		IsPossibleMatch:      updater.IsPossibleMatch,
+		SoftScore:            updater.SoftScore,
+		AiScore:              updater.AIScore,
+		FinalScore:           updater.FinalScore,
+		IngestionStatus:      updater.IngestionStatus,
+		IngestionError:       updater.IngestionError,
+		IngestedAt:           updater.IngestedAt,
 	}); err != nil {
 		return nil, fmt.Errorf("update match result: %w", err)
 	}
@@ -197,6 +266,108 @@ func (s *MatchResultStore) Update(
 	return matchResult, nil
 }
 
+// ExpireDropped expires the dropped matches whose expires_at is past asOf,
+// unless both sides proposed or a date instance came out of them, and
+// returns them. A side still Pending is recorded as having ignored the match.
+func (s *MatchResultStore) ExpireDropped(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	asOf time.Time,
+) ([]matching.ExpiredMatch, error) {
+	const query = `
+		UPDATE match_result
+		SET is_expired               = TRUE,
+		    match_status             = 'Expired',
+		    initiator_expiry_outcome = CASE initiator_action WHEN 'Pending' THEN 'Ignored' ELSE initiator_action END,
+		    receiver_expiry_outcome  = CASE receiver_action WHEN 'Pending' THEN 'Ignored' ELSE receiver_action END,
+		    updated_at               = NOW()
+		WHERE is_dropped = TRUE
+		  AND is_expired = FALSE
+		  AND expires_at <= $1
+		  AND current_date_instance_id IS NULL
+		  AND NOT (initiator_action = 'Proposed' AND receiver_action = 'Proposed')
+		RETURNING id, initiator_user_ref_id, receiver_user_ref_id,
+		          initiator_expiry_outcome, receiver_expiry_outcome`
+
+	rows, err := exec.QueryContext(ctx, query, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("expire dropped match results: %w", err)
+	}
+	defer rows.Close()
+
+	var expired []matching.ExpiredMatch
+	for rows.Next() {
+		var (
+			m                   matching.ExpiredMatch
+			initiator, receiver string
+		)
+		if err = rows.Scan(&m.ID, &m.InitiatorUserID, &m.ReceiverUserID, &initiator, &receiver); err != nil {
+			return nil, fmt.Errorf("scan expired match result: %w", err)
+		}
+		m.InitiatorOutcome = enums.MatchExpiryOutcome(initiator)
+		m.ReceiverOutcome = enums.MatchExpiryOutcome(receiver)
+		expired = append(expired, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate expired match results: %w", err)
+	}
+
+	return expired, nil
+}
+
+// DropRuns returns which of the drop slots have run.
+func (s *MatchResultStore) DropRuns(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	slots []matching.DropSlot,
+) ([]matching.DropSlot, error) {
+	const query = `
+		SELECT dr.utc_offset, dr.drop_at
+		FROM match_drop_run dr
+		JOIN unnest($1::int[], $2::timestamptz[]) AS s(utc_offset, drop_at)
+		  ON s.utc_offset = dr.utc_offset AND s.drop_at = dr.drop_at`
+
+	offsets, dropAts := dropSlotColumns(slots)
+
+	var ran []matching.DropSlot
+	if err := pgmodel.NewQuery(qm.SQL(query, offsets, dropAts)).Bind(ctx, exec, &ran); err != nil {
+		return nil, fmt.Errorf("fetch drop runs: %w", err)
+	}
+
+	return ran, nil
+}
+
+// InsertDropRuns records the drop slots as run. Slots already recorded,
+// e.g. by a concurrent runner, are left as they are.
+func (s *MatchResultStore) InsertDropRuns(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	slots []matching.DropSlot,
+) error {
+	const query = `
+		INSERT INTO match_drop_run (utc_offset, drop_at)
+		SELECT * FROM unnest($1::int[], $2::timestamptz[])
+		ON CONFLICT (utc_offset, drop_at) DO NOTHING`
+
+	offsets, dropAts := dropSlotColumns(slots)
+	if _, err := exec.ExecContext(ctx, query, offsets, dropAts); err != nil {
+		return fmt.Errorf("insert drop runs: %w", err)
+	}
+
+	return nil
+}
+
+// dropSlotColumns splits slots into one array param per column.
+func dropSlotColumns(slots []matching.DropSlot) (types.Int64Array, types.StringArray) {
+	offsets := make(types.Int64Array, len(slots))
+	dropAts := make(types.StringArray, len(slots))
+	for i, slot := range slots {
+		offsets[i] = int64(slot.UTCOffset)
+		dropAts[i] = slot.DropAt.UTC().Format(time.RFC3339)
+	}
+	return offsets, dropAts
+}
+
 // UpdateMatchForDateInstance links a match to its date instance.
 func (s *MatchResultStore) UpdateMatchForDateInstance(
 	ctx context.Context,
@@ -231,6 +402,15 @@ func qModsMatchResult(f *matching.QueryFilterMatchResult, paginated bool) []qm.Q
 		))
 	}
 
+	// BetweenUserIDs matches results among the listed users
+	if len(f.BetweenUserIDs) > 0 {
+		userIDs := types.StringArray(f.BetweenUserIDs)
+		qMods = append(qMods, qm.Where(
+			"mr."+mrCols.InitiatorUserRefID+" = ANY(?::uuid[]) AND mr."+mrCols.ReceiverUserRefID+" = ANY(?::uuid[])",
+			userIDs, userIDs,
+		))
+	}
+
 	// InitiatorUserID matches initiator specifically
 	if f.InitiatorUserID.Valid {
 		qMods = append(qMods, qm.Where("mr."+mrCols.InitiatorUserRefID+" = ?", f.InitiatorUserID.String))
@@ -289,6 +469,7 @@ func qModsMatchResult(f *matching.QueryFilterMatchResult, paginated bool) []qm.Q
 				"is_dropped":            mrCols.IsDropped,
 				"dropped_ts":            mrCols.DroppedTS,
 				"expires_at":            mrCols.ExpiresAt,
+				"final_score":           mrCols.FinalScore,
 			}
 
 			if col, ok := allowedColumns[f.OrderBy.String]; ok {
@@ -296,7 +477,12 @@ func qModsMatchResult(f *matching.QueryFilterMatchResult, paginated bool) []qm.Q
 				if f.Sort.String == "+" {
 					orderDir = "ASC"
 				}
-				qMods = append(qMods, qm.OrderBy("mr."+col+" "+orderDir))
+				orderBy := "mr." + col + " " + orderDir
+				if col == mrCols.FinalScore {
+					// unscored results rank last, ties go to the oldest
+					orderBy += " NULLS LAST, mr." + mrCols.CreatedAt + " ASC"
+				}
+				qMods = append(qMods, qm.OrderBy(orderBy))
 			}
 		}
 
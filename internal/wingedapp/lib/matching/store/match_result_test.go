@@ -0,0 +1,166 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"wingedapp/pgtester/internal/db/factory"
+	wingedFactory "wingedapp/pgtester/internal/wingedapp/db/factory"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching/store"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMatchResultStore() *store.MatchResultStore {
+	return store.NewMatchingStores(applog.NewLogrus("test")).MatchResultStore
+}
+
+// seedMatchResultInserts creates a match set and n users, and returns the
+// inserters of every pair of them.
+func seedMatchResultInserts(th *testsuite.Helper, n int) []matching.InsertMatchResult {
+	exec := th.BackendAppDb()
+
+	matchSet := factory.NewEntity[*wingedFactory.MatchSet](&wingedFactory.MatchSet{}).New(th.T, exec)
+	matchSetID := uuid.MustParse(matchSet.Subject.ID)
+
+	userIDs := make([]uuid.UUID, n)
+	for i := range userIDs {
+		u := factory.NewEntity[*wingedFactory.User](&wingedFactory.User{}).New(th.T, exec)
+		userIDs[i] = uuid.MustParse(u.Subject.ID)
+	}
+
+	inserters := make([]matching.InsertMatchResult, 0, n*(n-1)/2)
+	for i := range userIDs {
+		for j := i + 1; j < len(userIDs); j++ {
+			inserters = append(inserters, matching.InsertMatchResult{
+				MatchSetID:      matchSetID,
+				InitiatorUserID: userIDs[i],
+				ReceiverUserID:  userIDs[j],
+			})
+		}
+	}
+
+	return inserters
+}
+
+type testCaseInsertBatch struct {
+	name            string
+	setup           func(th *testsuite.Helper) []matching.InsertMatchResult
+	extraAssertions func(th *testsuite.Helper, inserted int64, err error)
+}
+
+func insertBatchTestCases() []testCaseInsertBatch {
+	return []testCaseInsertBatch{
+		{
+			name: "success-inserts-every-row",
+			setup: func(th *testsuite.Helper) []matching.InsertMatchResult {
+				return seedMatchResultInserts(th, 5)
+			},
+			extraAssertions: func(th *testsuite.Helper, inserted int64, err error) {
+				require.NoError(th.T, err, "should not error")
+				assert.EqualValues(th.T, 10, inserted, "5 users make 10 pairs")
+
+				count, err := pgmodel.MatchResults().Count(context.Background(), th.BackendAppDb())
+				require.NoError(th.T, err, "counting match results")
+				assert.EqualValues(th.T, 10, count)
+			},
+		},
+		{
+			name: "success-skips-rows-already-in-match-set",
+			setup: func(th *testsuite.Helper) []matching.InsertMatchResult {
+				inserters := seedMatchResultInserts(th, 4)
+
+				_, err := newMatchResultStore().InsertBatch(context.Background(), th.BackendAppDb(), inserters[:2])
+				require.NoError(th.T, err, "inserting the first pairs")
+
+				return inserters
+			},
+			extraAssertions: func(th *testsuite.Helper, inserted int64, err error) {
+				require.NoError(th.T, err, "should not error")
+				assert.EqualValues(th.T, 4, inserted, "the 2 existing pairs of the 6 are skipped")
+
+				count, err := pgmodel.MatchResults().Count(context.Background(), th.BackendAppDb())
+				require.NoError(th.T, err, "counting match results")
+				assert.EqualValues(th.T, 6, count)
+			},
+		},
+		{
+			name: "success-empty-input-inserts-nothing",
+			setup: func(th *testsuite.Helper) []matching.InsertMatchResult {
+				return nil
+			},
+			extraAssertions: func(th *testsuite.Helper, inserted int64, err error) {
+				require.NoError(th.T, err, "should not error")
+				assert.Zero(th.T, inserted)
+			},
+		},
+	}
+}
+
+func TestMatchResultStore_InsertBatch(t *testing.T) {
+	for _, tt := range insertBatchTestCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tSuite := testsuite.New(t)
+			t.Cleanup(tSuite.UseBackendDB())
+
+			inserters := tt.setup(tSuite)
+
+			inserted, err := newMatchResultStore().InsertBatch(context.Background(), tSuite.BackendAppDb(), inserters)
+
+			tt.extraAssertions(tSuite, inserted, err)
+		})
+	}
+}
+
+// benchmarkMatchResultInsert times insert on a match set's results, on the
+// test container postgres. Every iteration inserts into a fresh match set,
+// so none conflict.
+func benchmarkMatchResultInsert(b *testing.B,
+	insert func(ctx context.Context, exec boil.ContextExecutor, stor *store.MatchResultStore, inserters []matching.InsertMatchResult) error,
+) {
+	tSuite := testsuite.New(b)
+	b.Cleanup(tSuite.UseBackendDB())
+
+	ctx := context.Background()
+	exec := tSuite.BackendAppDb()
+	stor := newMatchResultStore()
+
+	const users = 64
+	b.ReportMetric(users*(users-1)/2, "rows/op")
+
+	for range b.N {
+		b.StopTimer()
+		inserters := seedMatchResultInserts(tSuite, users)
+		b.StartTimer()
+
+		if err := insert(ctx, exec, stor, inserters); err != nil {
+			b.Fatalf("insert: %v", err)
+		}
+	}
+}
+
+func BenchmarkMatchResultStore_InsertPerRow(b *testing.B) {
+	benchmarkMatchResultInsert(b, func(ctx context.Context, exec boil.ContextExecutor, stor *store.MatchResultStore, inserters []matching.InsertMatchResult) error {
+		for i := range inserters {
+			if _, err := stor.Insert(ctx, exec, &inserters[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func BenchmarkMatchResultStore_InsertBatch(b *testing.B) {
+	benchmarkMatchResultInsert(b, func(ctx context.Context, exec boil.ContextExecutor, stor *store.MatchResultStore, inserters []matching.InsertMatchResult) error {
+		_, err := stor.InsertBatch(ctx, exec, inserters)
+		return err
+	})
+}
@@ -2,7 +2,10 @@ package store
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 	"wingedapp/pgtester/internal/wingedapp/db/boilhelper"
 	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
 	"wingedapp/pgtester/internal/wingedapp/db/repo"
@@ -12,6 +15,7 @@ import (
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/google/uuid"
 )
 
 type MatchSetStore struct {
@@ -39,6 +43,8 @@ func (s *MatchSetStore) matchSetsRows(
 			"ms."+msCols.TimeEnd+" AS time_end",
 			"ms."+msCols.CreatedAt+" AS created_at",
 			"ms."+msCols.UpdatedAt+" AS updated_at",
+			"ms."+msCols.IngestionStatus+" AS ingestion_status",
+			"ms."+msCols.MatchConfigVersionRefID+" AS match_config_version_id",
 		),
 		qm.From(pgmodel.TableNames.MatchSet+" ms"),
 	)
@@ -196,6 +202,7 @@ func (s *MatchSetStore) Insert(
 		Name:                  inserter.Name,
 		NumberOfParticipants:  inserter.NumberOfParticipants,
 		MatchingConfiguration: inserter.MatchingParameters,
+		ConfigVersionID:       null.NewString(inserter.ConfigVersionID.String(), inserter.ConfigVersionID != uuid.Nil),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("insert match set: %w", err)
@@ -218,3 +225,80 @@ func (s *MatchSetStore) Update(
 ) error {
 	return nil
 }
+
+// StartIngestionRun records a new ingestion run of the match set and marks
+// the set Running. Runs of the set still Running are from a process that
+// died mid-batch, and are marked Abandoned.
+func (s *MatchSetStore) StartIngestionRun(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	matchSetID uuid.UUID,
+	startedAt time.Time,
+) (*matching.IngestionRun, error) {
+	const query = `
+		WITH abandoned AS (
+			UPDATE match_set_run
+			SET status = 'Abandoned', finished_at = $2
+			WHERE match_set_ref_id = $1 AND status = 'Running'
+		), match_set_running AS (
+			UPDATE match_set
+			SET ingestion_status = 'Running', updated_at = NOW()
+			WHERE id = $1
+			RETURNING id
+		)
+		INSERT INTO match_set_run (match_set_ref_id, status, started_at)
+		SELECT id, 'Running', $2 FROM match_set_running
+		RETURNING id, match_set_ref_id, status, pairs, processed, failed, skipped,
+		          last_error, started_at, finished_at`
+
+	var run matching.IngestionRun
+	err := pgmodel.NewQuery(qm.SQL(query, matchSetID, startedAt)).Bind(ctx, exec, &run)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", matching.ErrMatchSetNotFound, matchSetID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("start ingestion run: %w", err)
+	}
+
+	return &run, nil
+}
+
+// FinishIngestionRun records the outcome of an ingestion run,
+// and sets the match set's ingestion status to the run's.
+func (s *MatchSetStore) FinishIngestionRun(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	finisher *matching.FinishIngestionRun,
+) error {
+	const query = `
+		WITH finished AS (
+			UPDATE match_set_run
+			SET status      = $2,
+			    pairs       = $3,
+			    processed   = $4,
+			    failed      = $5,
+			    skipped     = $6,
+			    last_error  = $7,
+			    finished_at = $8
+			WHERE id = $1
+		)
+		UPDATE match_set
+		SET ingestion_status = $2, updated_at = NOW()
+		WHERE id = $9`
+
+	if _, err := exec.ExecContext(ctx, query,
+		finisher.ID,
+		string(finisher.Status),
+		finisher.Pairs,
+		finisher.Processed,
+		finisher.Failed,
+		finisher.Skipped,
+		finisher.LastError,
+		finisher.FinishedAt,
+		finisher.MatchSetID,
+	); err != nil {
+		return fmt.Errorf("finish ingestion run: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/matching"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// QualitativeCacheStore handles reads and writes of the qualitative_match_cache table.
+type QualitativeCacheStore struct {
+	l applog.Logger
+}
+
+// NewQualitativeCacheStore creates a new QualitativeCacheStore.
+func NewQualitativeCacheStore(l applog.Logger) *QualitativeCacheStore {
+	return &QualitativeCacheStore{l: l}
+}
+
+// QualitativeCache returns the unexpired result cached under key,
+// or matching.ErrQualitativeCacheMiss.
+func (s *QualitativeCacheStore) QualitativeCache(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	key *matching.QualitativeCacheKey,
+) (*matching.MatchCompatibilityResult, error) {
+	const query = `
+		SELECT result
+		FROM qualitative_match_cache
+		WHERE user_a_ref_id = $1
+		  AND user_b_ref_id = $2
+		  AND romeo_ref_id = $3
+		  AND matcher_version = $4
+		  AND profile_hash = $5
+		  AND expires_at > NOW()`
+
+	var raw []byte
+	err := exec.QueryRowContext(ctx, query,
+		key.UserAID.String(), key.UserBID.String(), key.RomeoID.String(), key.MatcherVersion, key.ProfileHash,
+	).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, matching.ErrQualitativeCacheMiss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select qualitative cache: %w", err)
+	}
+
+	var res matching.MatchCompatibilityResult
+	if err = json.Unmarshal(raw, &res); err != nil {
+		return nil, fmt.Errorf("unmarshal qualitative cache result: %w", err)
+	}
+
+	return &res, nil
+}
+
+// Upsert stores a result for the pair, orientation and matcher version. It
+// replaces the previous entry, so results of outdated profiles don't pile up.
+func (s *QualitativeCacheStore) Upsert(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *matching.InsertQualitativeCache,
+) error {
+	if inserter == nil || inserter.Key == nil || inserter.Result == nil {
+		return fmt.Errorf("key and result are required")
+	}
+
+	raw, err := json.Marshal(inserter.Result)
+	if err != nil {
+		return fmt.Errorf("marshal qualitative cache result: %w", err)
+	}
+
+	const query = `
+		INSERT INTO qualitative_match_cache
+			(user_a_ref_id, user_b_ref_id, romeo_ref_id, matcher_version, profile_hash, result, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_a_ref_id, user_b_ref_id, romeo_ref_id, matcher_version) DO UPDATE
+		SET profile_hash = EXCLUDED.profile_hash,
+		    result       = EXCLUDED.result,
+		    expires_at   = EXCLUDED.expires_at,
+		    created_at   = NOW()`
+
+	k := inserter.Key
+	if _, err = exec.ExecContext(ctx, query,
+		k.UserAID.String(), k.UserBID.String(), k.RomeoID.String(), k.MatcherVersion, k.ProfileHash, raw, inserter.ExpiresAt,
+	); err != nil {
+		return fmt.Errorf("upsert qualitative cache: %w", err)
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@ type MatchingStores struct {
 	AudioStore            *AudioStore
 	LovestoryStore        *LovestoryStore
 	DateInstanceStore     *DateInstanceStore
+	QualitativeCacheStore *QualitativeCacheStore
 }
 
 // NewMatchingStores creates a new instance of MatchingStores with the provided logger.
@@ -37,5 +38,6 @@ func NewMatchingStores(l applog.Logger) *MatchingStores {
 		AudioStore:            NewAudioStore(l),
 		LovestoryStore:        NewLovestoryStore(l),
 		DateInstanceStore:     &DateInstanceStore{l, r},
+		QualitativeCacheStore: NewQualitativeCacheStore(l),
 	}
 }
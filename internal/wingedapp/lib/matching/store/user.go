@@ -12,6 +12,7 @@ import (
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/aarondl/sqlboiler/v4/types"
 	"github.com/google/uuid"
 )
 
@@ -145,6 +146,10 @@ func qModsUser(f *matching.QueryFilterUser) []qm.QueryMod {
 		qMods = append(qMods, qm.Where("u.id=?", f.ID.String))
 	}
 
+	if len(f.IDs) > 0 {
+		qMods = append(qMods, qm.Where("u.id = ANY(?::uuid[])", types.StringArray(f.IDs)))
+	}
+
 	if f.IsActive.Valid {
 		qMods = append(qMods, qm.Where("u.is_active=?", f.IsActive.Bool))
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/aarondl/sqlboiler/v4/types"
 	"github.com/google/uuid"
 )
 
@@ -38,6 +39,11 @@ func qModsUserDatingPrefs(f *matching.QueryFilterUserDatingPrefs) []qm.QueryMod
 		qMods = append(qMods, pgmodel.UserDatingPreferenceWhere.UserID.EQ(f.UserID.String))
 	}
 
+	if len(f.UserIDs) > 0 {
+		// one array param, however many users
+		qMods = append(qMods, qm.Where(pgmodel.UserDatingPreferenceColumns.UserID+" = ANY(?::uuid[])", types.StringArray(f.UserIDs)))
+	}
+
 	return qMods
 }
 
@@ -9,6 +9,7 @@ import (
 	"wingedapp/pgtester/internal/wingedapp/lib/applog"
 	"wingedapp/pgtester/internal/wingedapp/lib/matching"
 
+	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
 	"github.com/google/uuid"
@@ -271,6 +272,13 @@ func (s *UserMatchActionsStore) Update(
 		} else {
 			matchResult.ReceiverAction = params.ActionCategoryID.String
 		}
+
+		// Both proposed: chat unlocks, once
+		if matchResult.InitiatorAction == matching.MatchUserActionProposed &&
+			matchResult.ReceiverAction == matching.MatchUserActionProposed &&
+			!matchResult.ChatUnlockedAt.Valid {
+			matchResult.ChatUnlockedAt = null.TimeFrom(time.Now())
+		}
 	}
 
 	// Update seen_at if provided
@@ -0,0 +1,59 @@
+package testhelper
+
+import (
+	"context"
+	"fmt"
+	matching "wingedapp/pgtester/internal/wingedapp/lib/matching"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// UpdateConfig updates the match config in its own transaction,
+// committed before it returns.
+func UpdateConfig(ctx context.Context,
+	db *sqlx.DB,
+	matchLib *matching.Logic,
+	updater *matching.UpdateMatchConfig,
+) (*matching.Config, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // a no-op once committed
+
+	config, err := matchLib.UpdateConfig(ctx, tx, updater)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return config, nil
+}
+
+// RollbackConfig rolls the match config back to version in its own
+// transaction, committed before it returns.
+func RollbackConfig(ctx context.Context,
+	db *sqlx.DB,
+	matchLib *matching.Logic,
+	version int,
+) (*matching.ConfigVersion, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // a no-op once committed
+
+	restored, err := matchLib.RollbackConfig(ctx, tx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return restored, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	matching "wingedapp/pgtester/internal/wingedapp/lib/matching"
 
+	"github.com/aarondl/null/v8"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/require"
 )
@@ -83,6 +84,15 @@ func (i *PopulationIngestor) IngestFromCSVFileSimple(file string) (int, error) {
 	return parseResult.ValidRows, nil
 }
 
+// DisableCandidatePrefilter makes ingestion pair every user, for tests that
+// run the hard qualifiers on pairs the prefilter would never create.
+func (i *PopulationIngestor) DisableCandidatePrefilter() {
+	_, err := UpdateConfig(context.Background(), i.db.BackendAppDb(), i.matchLib, &matching.UpdateMatchConfig{
+		CandidatePrefilter: null.BoolFrom(false),
+	})
+	require.NoError(i.t, err, "disabling candidate prefilter")
+}
+
 // ProdDBProvider wraps database connections for production use.
 type ProdDBProvider struct {
 	backendApp   *sqlx.DB
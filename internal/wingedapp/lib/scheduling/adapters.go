@@ -0,0 +1,21 @@
+package scheduling
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// availabilityStorer enables user availability CRUD, and overlap queries.
+type availabilityStorer interface {
+	TimeBlocks(ctx context.Context, exec boil.ContextExecutor, userID string) ([]TimeBlock, error)
+	Insert(ctx context.Context, exec boil.ContextExecutor, inserter *InsertUserAvailability) error
+	DeleteAll(ctx context.Context, exec boil.ContextExecutor, userID string) (int, error)
+	Overlaps(ctx context.Context, exec boil.ContextExecutor, userAID, userBID string) ([]TimeBlock, error)
+}
+
+// dateInstanceStorer enables date instance queries.
+type dateInstanceStorer interface {
+	DateInstances(ctx context.Context, exec boil.ContextExecutor, f *QueryFilterDateInstance) (*DateInstancePaginated, error)
+	PartnerInfo(ctx context.Context, exec boil.ContextExecutor, userID string) (*PartnerInfo, error)
+}
@@ -0,0 +1,104 @@
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+// UserTimeBlocks returns all availability blocks of a user, ordered by start.
+func (l *Logic) UserTimeBlocks(ctx context.Context, exec boil.ContextExecutor, userID string) ([]TimeBlock, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidUserID, userID)
+	}
+
+	blocks, err := l.availabilityStorer.TimeBlocks(ctx, exec, userID)
+	if err != nil {
+		return nil, fmt.Errorf("availability storer time blocks: %w", err)
+	}
+
+	return blocks, nil
+}
+
+// SyncUserAvailability replaces all availability of a user with the given blocks.
+// Overlapping blocks are merged first, since user_availability excludes
+// overlapping ranges per user. Run inside a transaction, so a failed insert
+// doesn't leave the user with no availability.
+func (l *Logic) SyncUserAvailability(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	params *SyncUserAvailabilityParams,
+) (*SyncUserAvailabilityResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("validate params: %w", err)
+	}
+
+	deleted, err := l.availabilityStorer.DeleteAll(ctx, exec, params.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("availability storer delete all: %w", err)
+	}
+
+	blocks := MergeTimeBlocks(params.TimeBlocks)
+	for _, b := range blocks {
+		if err = l.availabilityStorer.Insert(ctx, exec, &InsertUserAvailability{
+			UserID:    params.UserID,
+			TimeBlock: b,
+		}); err != nil {
+			return nil, fmt.Errorf("availability storer insert: %w", err)
+		}
+	}
+
+	return &SyncUserAvailabilityResult{
+		UserID:        params.UserID,
+		DeletedCount:  deleted,
+		InsertedCount: len(blocks),
+		TimeBlocks:    blocks,
+	}, nil
+}
+
+// FindOverlaps returns the intersections of two users' availability, ordered by start.
+func (l *Logic) FindOverlaps(ctx context.Context, exec boil.ContextExecutor, userAID, userBID string) ([]TimeBlock, error) {
+	for _, id := range []string{userAID, userBID} {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidUserID, id)
+		}
+	}
+
+	overlaps, err := l.availabilityStorer.Overlaps(ctx, exec, userAID, userBID)
+	if err != nil {
+		return nil, fmt.Errorf("availability storer overlaps: %w", err)
+	}
+
+	return overlaps, nil
+}
+
+// MergeTimeBlocks sorts blocks by start, and merges the ones that overlap.
+// Touching blocks ([a,b) and [b,c)) are kept apart, as postgres does.
+func MergeTimeBlocks(blocks []TimeBlock) []TimeBlock {
+	if len(blocks) == 0 {
+		return []TimeBlock{}
+	}
+
+	sorted := make([]TimeBlock, len(blocks))
+	copy(sorted, blocks)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	merged := []TimeBlock{sorted[0]}
+	for _, b := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if b.Start.Before(last.End) {
+			if b.End.After(last.End) {
+				last.End = b.End
+			}
+			continue
+		}
+		merged = append(merged, b)
+	}
+
+	return merged
+}
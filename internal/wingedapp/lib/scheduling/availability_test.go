@@ -0,0 +1,208 @@
+package scheduling_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/db/factory"
+	wingedFactory "wingedapp/pgtester/internal/wingedapp/db/factory"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/scheduling"
+	"wingedapp/pgtester/internal/wingedapp/lib/scheduling/store"
+	"wingedapp/pgtester/internal/wingedapp/testsuite"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestSchedulingLogic wires the scheduling logic against the real stores.
+func createTestSchedulingLogic(t *testing.T) *scheduling.Logic {
+	t.Helper()
+	stores := store.NewSchedulingStores(applog.NewLogrus("test"))
+
+	logic, err := scheduling.NewLogic(stores.AvailabilityStore, stores.DateInstanceStore)
+	require.NoError(t, err)
+	return logic
+}
+
+// persistAvailability inserts a raw availability block for a user.
+func persistAvailability(th *testsuite.Helper, userID string, b scheduling.TimeBlock) {
+	th.T.Helper()
+	factory.NewEntity[*wingedFactory.UserAvailability](&wingedFactory.UserAvailability{
+		Subject: &pgmodel.UserAvailability{
+			UserID:    userID,
+			TimeBlock: b.Range(),
+		},
+	}).New(th.T, th.BackendAppDb())
+}
+
+func hoursFrom(base time.Time, startH, endH int) scheduling.TimeBlock {
+	return scheduling.TimeBlock{
+		Start: base.Add(time.Duration(startH) * time.Hour),
+		End:   base.Add(time.Duration(endH) * time.Hour),
+	}
+}
+
+type testCaseSyncUserAvailability struct {
+	name            string
+	setup           func(th *testsuite.Helper, base time.Time) *scheduling.SyncUserAvailabilityParams
+	extraAssertions func(th *testsuite.Helper, base time.Time, res *scheduling.SyncUserAvailabilityResult, err error)
+}
+
+func TestLogic_SyncUserAvailability(t *testing.T) {
+	base := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+
+	testCases := []testCaseSyncUserAvailability{
+		{
+			name: "replaces-existing-availability",
+			setup: func(th *testsuite.Helper, base time.Time) *scheduling.SyncUserAvailabilityParams {
+				user := th.PersistRegisteredUser()
+				persistAvailability(th, user.ID, hoursFrom(base, 0, 1))
+				persistAvailability(th, user.ID, hoursFrom(base, 5, 6))
+
+				return &scheduling.SyncUserAvailabilityParams{
+					UserID:     user.ID,
+					TimeBlocks: []scheduling.TimeBlock{hoursFrom(base, 10, 12)},
+				}
+			},
+			extraAssertions: func(th *testsuite.Helper, base time.Time, res *scheduling.SyncUserAvailabilityResult, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 2, res.DeletedCount)
+				assert.Equal(th.T, 1, res.InsertedCount)
+
+				blocks, err := createTestSchedulingLogic(th.T).UserTimeBlocks(context.Background(), th.BackendAppDb(), res.UserID)
+				require.NoError(th.T, err)
+				require.Len(th.T, blocks, 1)
+				assert.True(th.T, blocks[0].Start.Equal(base.Add(10*time.Hour)))
+				assert.True(th.T, blocks[0].End.Equal(base.Add(12*time.Hour)))
+			},
+		},
+		{
+			name: "merges-overlapping-input-blocks",
+			setup: func(th *testsuite.Helper, base time.Time) *scheduling.SyncUserAvailabilityParams {
+				user := th.PersistRegisteredUser()
+				return &scheduling.SyncUserAvailabilityParams{
+					UserID: user.ID,
+					TimeBlocks: []scheduling.TimeBlock{
+						hoursFrom(base, 1, 3),
+						hoursFrom(base, 0, 2),
+						hoursFrom(base, 4, 5),
+					},
+				}
+			},
+			extraAssertions: func(th *testsuite.Helper, base time.Time, res *scheduling.SyncUserAvailabilityResult, err error) {
+				require.NoError(th.T, err, "overlapping input must not trip the exclusion constraint")
+				assert.Equal(th.T, 2, res.InsertedCount)
+				assert.Equal(th.T, 240, scheduling.TotalMinutes(res.TimeBlocks))
+			},
+		},
+		{
+			name: "empty-blocks-clears-availability",
+			setup: func(th *testsuite.Helper, base time.Time) *scheduling.SyncUserAvailabilityParams {
+				user := th.PersistRegisteredUser()
+				persistAvailability(th, user.ID, hoursFrom(base, 0, 1))
+				return &scheduling.SyncUserAvailabilityParams{UserID: user.ID}
+			},
+			extraAssertions: func(th *testsuite.Helper, base time.Time, res *scheduling.SyncUserAvailabilityResult, err error) {
+				require.NoError(th.T, err)
+				assert.Equal(th.T, 1, res.DeletedCount)
+				assert.Equal(th.T, 0, res.InsertedCount)
+			},
+		},
+		{
+			name: "inverted-block-rejected",
+			setup: func(th *testsuite.Helper, base time.Time) *scheduling.SyncUserAvailabilityParams {
+				user := th.PersistRegisteredUser()
+				return &scheduling.SyncUserAvailabilityParams{
+					UserID:     user.ID,
+					TimeBlocks: []scheduling.TimeBlock{hoursFrom(base, 3, 1)},
+				}
+			},
+			extraAssertions: func(th *testsuite.Helper, base time.Time, res *scheduling.SyncUserAvailabilityResult, err error) {
+				require.ErrorIs(th.T, err, scheduling.ErrTimeBlockEndBeforeStart)
+				assert.Nil(th.T, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			testSuite := testsuite.New(t)
+			t.Cleanup(testSuite.UseBackendDB())
+
+			params := tc.setup(testSuite, base)
+			res, err := createTestSchedulingLogic(t).SyncUserAvailability(context.Background(), testSuite.BackendAppDb(), params)
+			tc.extraAssertions(testSuite, base, res, err)
+		})
+	}
+}
+
+type testCaseFindOverlaps struct {
+	name     string
+	blocksA  [][2]int
+	blocksB  [][2]int
+	expected [][2]int
+}
+
+func TestLogic_FindOverlaps(t *testing.T) {
+	base := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+
+	testCases := []testCaseFindOverlaps{
+		{
+			name:     "no-availability-no-overlap",
+			expected: [][2]int{},
+		},
+		{
+			name:     "disjoint-blocks-no-overlap",
+			blocksA:  [][2]int{{0, 2}},
+			blocksB:  [][2]int{{3, 4}},
+			expected: [][2]int{},
+		},
+		{
+			name:     "touching-blocks-no-overlap",
+			blocksA:  [][2]int{{0, 2}},
+			blocksB:  [][2]int{{2, 4}},
+			expected: [][2]int{},
+		},
+		{
+			name:     "partial-overlap-is-intersection",
+			blocksA:  [][2]int{{0, 3}},
+			blocksB:  [][2]int{{2, 5}},
+			expected: [][2]int{{2, 3}},
+		},
+		{
+			name:     "one-block-spans-many",
+			blocksA:  [][2]int{{0, 10}},
+			blocksB:  [][2]int{{1, 2}, {4, 6}, {9, 12}},
+			expected: [][2]int{{1, 2}, {4, 6}, {9, 10}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			testSuite := testsuite.New(t)
+			t.Cleanup(testSuite.UseBackendDB())
+
+			userA := testSuite.PersistRegisteredUser()
+			userB := testSuite.PersistRegisteredUser()
+			for _, b := range tc.blocksA {
+				persistAvailability(testSuite, userA.ID, hoursFrom(base, b[0], b[1]))
+			}
+			for _, b := range tc.blocksB {
+				persistAvailability(testSuite, userB.ID, hoursFrom(base, b[0], b[1]))
+			}
+
+			overlaps, err := createTestSchedulingLogic(t).FindOverlaps(context.Background(), testSuite.BackendAppDb(), userA.ID, userB.ID)
+			require.NoError(t, err)
+			require.Len(t, overlaps, len(tc.expected))
+			for i, e := range tc.expected {
+				want := hoursFrom(base, e[0], e[1])
+				assert.True(t, overlaps[i].Start.Equal(want.Start), "overlap %d start", i)
+				assert.True(t, overlaps[i].End.Equal(want.End), "overlap %d end", i)
+			}
+		})
+	}
+}
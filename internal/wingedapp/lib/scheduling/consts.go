@@ -0,0 +1,30 @@
+package scheduling
+
+import "wingedapp/pgtester/internal/wingedapp/lib/enums"
+
+// Participant roles, from the requesting user's perspective.
+const (
+	RoleInitiator = "initiator"
+	RoleReceiver  = "receiver"
+	RoleBoth      = "both" // only used by ActionGuards
+)
+
+// Date instance column values the UI state machine branches on.
+const (
+	BookingStatusBookingFailed  = string(enums.BookingStatusBookingFailed)
+	VenueProposalStatusAccepted = string(enums.VenueProposalStatusAccepted)
+)
+
+// Date instance proposal statuses.
+const (
+	ProposalStatusPending    = "pending"
+	ProposalStatusAccepted   = "accepted"
+	ProposalStatusRejected   = "rejected"
+	ProposalStatusSuperseded = "superseded"
+)
+
+// Initiator responses to a receiver's venue suggestion.
+const (
+	VenueSuggestionAccepted         = "accepted"
+	VenueSuggestionShowAlternatives = "show_alternatives"
+)
@@ -0,0 +1,152 @@
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+const (
+	// activeWindowLead is how long before the scheduled time the logistics panel opens.
+	activeWindowLead = 30 * time.Minute
+	// activeWindowTail is how long after the scheduled time the logistics panel stays open.
+	activeWindowTail = 2 * time.Hour
+)
+
+// DateInstanceForUser returns a date instance from the requesting user's perspective.
+func (l *Logic) DateInstanceForUser(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	dateInstanceID, requestingUserID uuid.UUID,
+) (*DateInstanceUI, error) {
+	res, err := l.dateInstanceStorer.DateInstances(ctx, exec, &QueryFilterDateInstance{
+		ID: null.StringFrom(dateInstanceID.String()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("date instance storer: %w", err)
+	}
+	if len(res.Data) != 1 {
+		return nil, fmt.Errorf("%w: %s", ErrDateInstanceNotFound, dateInstanceID)
+	}
+
+	di := res.Data[0]
+	if di.InitiatorUserID != requestingUserID && di.ReceiverUserID != requestingUserID {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotInDate, requestingUserID)
+	}
+
+	ui, err := l.toDateInstanceUI(ctx, exec, &di, requestingUserID)
+	if err != nil {
+		return nil, fmt.Errorf("to date instance ui: %w", err)
+	}
+
+	return ui, nil
+}
+
+// DateInstancesForUser returns the date instances the user participates in.
+func (l *Logic) DateInstancesForUser(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	f *QueryFilterUserDateInstances,
+) (*DateInstanceUIPaginated, error) {
+	if f == nil {
+		return nil, ErrMissingParams
+	}
+
+	res, err := l.dateInstanceStorer.DateInstances(ctx, exec, &QueryFilterDateInstance{
+		UserID:     null.StringFrom(f.UserID.String()),
+		Status:     f.Status,
+		OrderBy:    f.OrderBy,
+		Sort:       f.Sort,
+		Pagination: f.Pagination,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("date instance storer: %w", err)
+	}
+
+	data := make([]DateInstanceUI, 0, len(res.Data))
+	for i := range res.Data {
+		ui, err := l.toDateInstanceUI(ctx, exec, &res.Data[i], f.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("to date instance ui: %w", err)
+		}
+		data = append(data, *ui)
+	}
+
+	return &DateInstanceUIPaginated{
+		Data:       data,
+		Pagination: res.Pagination,
+	}, nil
+}
+
+// toDateInstanceUI projects a date instance onto the requesting user's view,
+// and computes its UI state.
+func (l *Logic) toDateInstanceUI(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	di *DateInstance,
+	requestingUserID uuid.UUID,
+) (*DateInstanceUI, error) {
+	role, partnerID := RoleInitiator, di.ReceiverUserID
+	if di.ReceiverUserID == requestingUserID {
+		role, partnerID = RoleReceiver, di.InitiatorUserID
+	}
+
+	partner, err := l.dateInstanceStorer.PartnerInfo(ctx, exec, partnerID.String())
+	if err != nil {
+		return nil, fmt.Errorf("partner info: %w", err)
+	}
+
+	ui := &DateInstanceUI{
+		ID:                   di.ID,
+		MatchResultRefID:     di.MatchResultRefID,
+		Status:               di.Status,
+		MyRole:               role,
+		PartnerInfo:          partner,
+		HasPendingProposals:  di.PendingProposals > 0,
+		AllProposalsRejected: di.TotalProposals > 0 && di.RejectedProposals == di.TotalProposals,
+		VenueRefID:           di.VenueRefID.Ptr(),
+		VenueName:            di.VenueName.Ptr(),
+		DateTypeCore:         di.DateTypeCore.Ptr(),
+		BookingStatus:        di.BookingStatus.Ptr(),
+		VenueProposalStatus:  di.VenueProposalStatus.Ptr(),
+		ScheduledTimeUTC:     di.ScheduledTimeUTC.Ptr(),
+		InitiatorConfirmedAt: di.InitiatorConfirmedAt.Ptr(),
+		ReceiverConfirmedAt:  di.ReceiverConfirmedAt.Ptr(),
+		DecisionWindowEnd:    di.DecisionWindowEnd,
+		CreatedAt:            di.CreatedAt,
+	}
+
+	ui.UIState = ComputeUIState(UIStateInputFor(ui, timeNow()))
+	ui.Hint = GetHintForState(ui.UIState, role)
+
+	return ui, nil
+}
+
+// UIStateInputFor flattens a DateInstanceUI into a UIStateInput, as of now.
+func UIStateInputFor(di *DateInstanceUI, now time.Time) UIStateInput {
+	return UIStateInput{
+		StatusName:            di.Status,
+		HasProposals:          di.HasPendingProposals,
+		AllProposalsRejected:  di.AllProposalsRejected,
+		HasVenue:              di.VenueRefID != nil,
+		HasDateType:           di.DateTypeCore != nil,
+		IsWithinActiveWindow:  IsWithinActiveWindow(di.ScheduledTimeUTC, now),
+		BookingFailed:         di.BookingStatus != nil && *di.BookingStatus == BookingStatusBookingFailed,
+		VenueProposalAccepted: di.VenueProposalStatus != nil && *di.VenueProposalStatus == VenueProposalStatusAccepted,
+		InitiatorConfirmed:    di.InitiatorConfirmedAt != nil,
+		ReceiverConfirmed:     di.ReceiverConfirmedAt != nil,
+	}
+}
+
+// IsWithinActiveWindow reports whether now falls in the logistics window
+// around the scheduled time.
+func IsWithinActiveWindow(scheduled *time.Time, now time.Time) bool {
+	if scheduled == nil {
+		return false
+	}
+	return now.After(scheduled.Add(-activeWindowLead)) && now.Before(scheduled.Add(activeWindowTail))
+}
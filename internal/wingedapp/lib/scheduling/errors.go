@@ -0,0 +1,24 @@
+package scheduling
+
+import "errors"
+
+/* Scheduling logic sentinel errors */
+
+var (
+	ErrMissingParams = errors.New("missing params")
+	ErrInvalidUserID = errors.New("invalid user id")
+
+	// time block validation errors
+	ErrTimeBlockMissingBounds  = errors.New("time block must have a start and end")
+	ErrTimeBlockEndBeforeStart = errors.New("time block end must be after start")
+	ErrInvalidTimeRange        = errors.New("invalid time range")
+
+	// date instance errors
+	ErrDateInstanceNotFound = errors.New("date instance not found")
+	ErrUserNotInDate        = errors.New("user is not a participant of the date instance")
+
+	// action validation errors
+	ErrUnknownAction           = errors.New("unknown action")
+	ErrActionNotAllowedInState = errors.New("action not allowed in current state")
+	ErrActionNotAllowedForRole = errors.New("action not allowed for role")
+)
@@ -0,0 +1,311 @@
+package scheduling
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+	flows.go houses the params, results, and client payloads of the
+	date instance flows (time, venue, modification, feedback, logistics).
+	Every action params embeds the date instance and the requesting user.
+*/
+
+// DateInstanceAction identifies the date instance and who is acting on it.
+type DateInstanceAction struct {
+	DateInstanceID   uuid.UUID `json:"date_instance_id"`
+	RequestingUserID uuid.UUID `json:"requesting_user_id"`
+}
+
+// ActionResult is the generic result of a flow action.
+type ActionResult struct {
+	Message string `json:"message"`
+}
+
+/* Time flow */
+
+type SuggestDateInstanceTimesParams struct {
+	DateInstanceID         uuid.UUID
+	RequestingUserID       uuid.UUID
+	ProposedScheduledTimes []time.Time
+}
+
+type SuggestDateInstanceTimesResult struct {
+	InsertedProposalCount int    `json:"inserted_proposal_count"`
+	Message               string `json:"message"`
+}
+
+type RequestMoreTimesParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+}
+
+type RequestMoreTimesResult ActionResult
+
+type ConfirmDateInstanceTimeParams struct {
+	DateInstanceID        uuid.UUID
+	RequestingUserID      uuid.UUID
+	SelectedScheduledTime time.Time
+}
+
+type ConfirmDateInstanceTimeResult struct {
+	ConfirmedScheduledTime time.Time `json:"confirmed_scheduled_time"`
+	Message                string    `json:"message"`
+}
+
+type RejectDateInstanceTimesParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	Reason           string
+}
+
+type RejectDateInstanceTimesResult struct {
+	RejectedProposalCount int    `json:"rejected_proposal_count"`
+	Message               string `json:"message"`
+}
+
+/* Venue flow */
+
+type VenueOptionsParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+}
+
+// VenueOption is a venue the initiator can pick from.
+type VenueOption struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Address       *string `json:"address,omitempty"`
+	GoogleMapsURL *string `json:"google_maps_url,omitempty"`
+	PriceLevel    *int    `json:"price_level,omitempty"`
+}
+
+type VenueOptionsResult struct {
+	Venues []VenueOption `json:"venues"`
+}
+
+type SelectVenueParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	VenueID          string
+}
+
+type SelectVenueResult struct {
+	SelectedVenueName string `json:"selected_venue_name"`
+	Message           string `json:"message"`
+}
+
+type ConfirmBookingParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	BookingStatus    string
+}
+
+type ConfirmBookingResult struct {
+	BookingStatus string `json:"booking_status"`
+	Message       string `json:"message"`
+}
+
+type RequestVenueChangeParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	Reason           string
+}
+
+type RequestVenueChangeResult ActionResult
+
+type SelectDateTypeParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	DateType         string
+}
+
+type SelectDateTypeResult struct {
+	DateType string `json:"date_type"`
+	Message  string `json:"message"`
+}
+
+type ConfirmVenueProceedParams DateInstanceAction
+type ConfirmVenueProceedResult ActionResult
+
+type GoBackVenueParams DateInstanceAction
+type GoBackVenueResult ActionResult
+
+type AcceptProposedVenueParams DateInstanceAction
+type AcceptProposedVenueResult ActionResult
+
+type RejectProposedVenueParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	Reason           string
+}
+
+type RejectProposedVenueResult ActionResult
+
+/* Venue suggestion */
+
+type SuggestVenueParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	VenueLink        string
+	VenueName        string
+	VenueArea        string
+}
+
+type SuggestVenueResult ActionResult
+
+type RespondToVenueSuggestionParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	Action           string // VenueSuggestionAccepted or VenueSuggestionShowAlternatives
+}
+
+type RespondToVenueSuggestionResult ActionResult
+
+/* Modification */
+
+type ChangeTimeParams DateInstanceAction
+type ChangeTimeResult ActionResult
+
+type ChangePlaceParams DateInstanceAction
+type ChangePlaceResult ActionResult
+
+type CancelDateParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	Reason           string
+}
+
+type CancelDateResult ActionResult
+
+type SetReminderParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	RemindAt         time.Time
+}
+
+type SetReminderResult ActionResult
+
+type ChooseNewVenueParams DateInstanceAction
+type ChooseNewVenueResult ActionResult
+
+type KeepReminderParams DateInstanceAction
+type KeepReminderResult ActionResult
+
+type ProvideOwnVenueParams DateInstanceAction
+type ProvideOwnVenueResult ActionResult
+
+type ConfirmAttendanceParams DateInstanceAction
+type ConfirmAttendanceResult ActionResult
+
+/* Feedback */
+
+type PendingFeedbackParams struct {
+	RequestingUserID uuid.UUID
+}
+
+type PendingFeedbackResult struct {
+	DateInstance *DateInstanceUI `json:"date_instance,omitempty"`
+}
+
+type SubmitDidYouMeetParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	DidMeet          string // "yes", "no", "prefer_not_to_say"
+	FeedbackText     string
+}
+
+type SubmitDidYouMeetResult ActionResult
+
+type SubmitDecisionParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	Decision         string
+}
+
+type SubmitDecisionResult ActionResult
+
+/* Logistics */
+
+type LogisticsArrivedParams DateInstanceAction
+type LogisticsArrivedResult ActionResult
+
+type LogisticsRunningLateParams struct {
+	DateInstanceID   uuid.UUID
+	RequestingUserID uuid.UUID
+	Minutes          int
+}
+
+type LogisticsRunningLateResult ActionResult
+
+type LogisticsNeedHelpParams DateInstanceAction
+type LogisticsNeedHelpResult ActionResult
+
+type LogisticsCancelInWindowParams DateInstanceAction
+type LogisticsCancelInWindowResult ActionResult
+
+/* Client payloads, decoded from ActionRequest.Payload */
+
+type PayloadSuggestTimes struct {
+	ProposedTimes []time.Time `json:"proposed_times"`
+}
+
+type PayloadConfirmTime struct {
+	SelectedTime time.Time `json:"selected_time"`
+}
+
+type PayloadRejectTimes struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type PayloadSelectVenue struct {
+	VenueID string `json:"venue_id"`
+}
+
+type PayloadConfirmBooking struct {
+	BookingStatus string `json:"booking_status"`
+}
+
+type PayloadSuggestVenue struct {
+	VenueLink string `json:"venue_link,omitempty"`
+	VenueName string `json:"venue_name,omitempty"`
+	VenueArea string `json:"venue_area,omitempty"`
+}
+
+type PayloadRequestVenueChange struct {
+	Reason string `json:"reason"`
+}
+
+type PayloadRespondVenueSuggestion struct {
+	Accept bool `json:"accept"`
+}
+
+type PayloadSelectDateType struct {
+	DateType string `json:"date_type"`
+}
+
+type PayloadRejectProposedVenue struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type PayloadSetReminder struct {
+	RemindAt string `json:"remind_at"` // RFC3339
+}
+
+type PayloadCancel struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type PayloadDidYouMeet struct {
+	DidMeet      string `json:"did_meet"`
+	FeedbackText string `json:"feedback_text,omitempty"`
+}
+
+type PayloadDecision struct {
+	Decision string `json:"decision"`
+}
+
+type PayloadRunningLate struct {
+	Minutes int `json:"minutes"`
+}
@@ -0,0 +1,31 @@
+package scheduling
+
+import (
+	"errors"
+	"time"
+)
+
+// timeNow is a variable for testing purposes
+var timeNow = time.Now
+
+type Logic struct {
+	availabilityStorer availabilityStorer
+	dateInstanceStorer dateInstanceStorer
+}
+
+func NewLogic(
+	availabilityStorer availabilityStorer,
+	dateInstanceStorer dateInstanceStorer,
+) (*Logic, error) {
+	if availabilityStorer == nil {
+		return nil, errors.New("availabilityStorer is required")
+	}
+	if dateInstanceStorer == nil {
+		return nil, errors.New("dateInstanceStorer is required")
+	}
+
+	return &Logic{
+		availabilityStorer: availabilityStorer,
+		dateInstanceStorer: dateInstanceStorer,
+	}, nil
+}
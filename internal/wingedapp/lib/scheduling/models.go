@@ -0,0 +1,224 @@
+package scheduling
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/business/sdk"
+
+	"github.com/aarondl/null/v8"
+	"github.com/google/uuid"
+)
+
+// TimeBlock is a half-open [Start, End) window of time, stored as a TSTZRANGE.
+type TimeBlock struct {
+	Start time.Time `json:"start" boil:"start_time"`
+	End   time.Time `json:"end" boil:"end_time"`
+}
+
+// Minutes returns the length of the block in whole minutes.
+func (b TimeBlock) Minutes() int {
+	if !b.End.After(b.Start) {
+		return 0
+	}
+	return int(b.End.Sub(b.Start) / time.Minute)
+}
+
+// Validate checks the block is non-empty.
+func (b TimeBlock) Validate() error {
+	if b.Start.IsZero() || b.End.IsZero() {
+		return ErrTimeBlockMissingBounds
+	}
+	if !b.End.After(b.Start) {
+		return fmt.Errorf("%w: start %s, end %s", ErrTimeBlockEndBeforeStart,
+			b.Start.Format(time.RFC3339), b.End.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Range returns the block as a postgres TSTZRANGE literal.
+func (b TimeBlock) Range() string {
+	return fmt.Sprintf("[%s,%s)",
+		b.Start.UTC().Format(time.RFC3339),
+		b.End.UTC().Format(time.RFC3339))
+}
+
+// ParseTimeBlock parses a postgres TSTZRANGE literal, e.g.
+// `["2025-01-01 10:00:00+00","2025-01-01 12:00:00+00")`.
+func ParseTimeBlock(s string) (TimeBlock, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 {
+		return TimeBlock{}, fmt.Errorf("%w: %q", ErrInvalidTimeRange, s)
+	}
+
+	parts := strings.SplitN(s[1:len(s)-1], ",", 2)
+	if len(parts) != 2 {
+		return TimeBlock{}, fmt.Errorf("%w: %q", ErrInvalidTimeRange, s)
+	}
+
+	start, err := parseRangeBound(parts[0])
+	if err != nil {
+		return TimeBlock{}, fmt.Errorf("parse range start: %w", err)
+	}
+	end, err := parseRangeBound(parts[1])
+	if err != nil {
+		return TimeBlock{}, fmt.Errorf("parse range end: %w", err)
+	}
+
+	return TimeBlock{Start: start, End: end}, nil
+}
+
+// rangeBoundLayouts are the layouts postgres (and our own Range) use for range bounds.
+var rangeBoundLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999-07",
+	"2006-01-02 15:04:05.999999-07:00",
+	"2006-01-02 15:04:05-07",
+	"2006-01-02 15:04:05-07:00",
+}
+
+func parseRangeBound(s string) (time.Time, error) {
+	s = strings.Trim(strings.TrimSpace(s), `"`)
+	for _, layout := range rangeBoundLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: unsupported bound %q", ErrInvalidTimeRange, s)
+}
+
+// TotalMinutes sums the length of all blocks in minutes.
+func TotalMinutes(blocks []TimeBlock) int {
+	total := 0
+	for _, b := range blocks {
+		total += b.Minutes()
+	}
+	return total
+}
+
+// SyncUserAvailabilityParams replaces all availability of a user with TimeBlocks.
+type SyncUserAvailabilityParams struct {
+	UserID     string      `json:"user_id"`
+	TimeBlocks []TimeBlock `json:"time_blocks"`
+}
+
+// Validate validates the sync params.
+func (p *SyncUserAvailabilityParams) Validate() error {
+	if p == nil {
+		return ErrMissingParams
+	}
+	if _, err := uuid.Parse(p.UserID); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidUserID, p.UserID)
+	}
+	for i, b := range p.TimeBlocks {
+		if err := b.Validate(); err != nil {
+			return fmt.Errorf("time block %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// SyncUserAvailabilityResult is the outcome of an availability sync.
+type SyncUserAvailabilityResult struct {
+	UserID        string      `json:"user_id"`
+	DeletedCount  int         `json:"deleted_count"`
+	InsertedCount int         `json:"inserted_count"`
+	TimeBlocks    []TimeBlock `json:"time_blocks"`
+}
+
+// InsertUserAvailability is a single availability block to persist.
+type InsertUserAvailability struct {
+	UserID    string
+	TimeBlock TimeBlock
+}
+
+// PartnerInfo is the minimal profile of the other user in a date instance.
+type PartnerInfo struct {
+	UserID    uuid.UUID `json:"user_id"`
+	FirstName string    `json:"first_name"`
+}
+
+// DateInstance is a date instance row joined with its match participants.
+type DateInstance struct {
+	ID                   uuid.UUID   `boil:"id"`
+	MatchResultRefID     uuid.UUID   `boil:"match_result_ref_id"`
+	InitiatorUserID      uuid.UUID   `boil:"initiator_user_id"`
+	ReceiverUserID       uuid.UUID   `boil:"receiver_user_id"`
+	Status               string      `boil:"status"`
+	VenueRefID           null.String `boil:"venue_ref_id"`
+	VenueName            null.String `boil:"venue_name"`
+	DateTypeCore         null.String `boil:"date_type_core"`
+	BookingStatus        null.String `boil:"booking_status"`
+	VenueProposalStatus  null.String `boil:"venue_proposal_status"`
+	ScheduledTimeUTC     null.Time   `boil:"scheduled_time_utc"`
+	InitiatorConfirmedAt null.Time   `boil:"initiator_confirmed_at"`
+	ReceiverConfirmedAt  null.Time   `boil:"receiver_confirmed_at"`
+	DecisionWindowEnd    time.Time   `boil:"decision_window_end"`
+	PendingProposals     int         `boil:"pending_proposals"`
+	RejectedProposals    int         `boil:"rejected_proposals"`
+	TotalProposals       int         `boil:"total_proposals"`
+	CreatedAt            time.Time   `boil:"created_at"`
+}
+
+// DateInstancePaginated wraps a paginated list of DateInstances.
+type DateInstancePaginated struct {
+	Data       []DateInstance
+	Pagination *sdk.Pagination
+}
+
+// QueryFilterDateInstance contains filter options for querying date instances.
+type QueryFilterDateInstance struct {
+	ID     null.String
+	UserID null.String // either participant of the underlying match
+	Status null.String
+
+	// Sorting
+	OrderBy null.String // column to order by
+	Sort    null.String // "+" for ASC, "-" for DESC
+
+	// Pagination
+	Pagination *sdk.Pagination
+}
+
+// DateInstanceUI is a date instance from the requesting user's perspective,
+// with its computed UI state.
+type DateInstanceUI struct {
+	ID                   uuid.UUID    `json:"id"`
+	MatchResultRefID     uuid.UUID    `json:"match_result_ref_id"`
+	Status               string       `json:"status"`
+	UIState              UIStateName  `json:"ui_state"`
+	Hint                 string       `json:"hint"`
+	MyRole               string       `json:"my_role"`
+	PartnerInfo          *PartnerInfo `json:"partner_info,omitempty"`
+	HasPendingProposals  bool         `json:"has_pending_proposals"`
+	AllProposalsRejected bool         `json:"all_proposals_rejected"`
+	VenueRefID           *string      `json:"venue_ref_id,omitempty"`
+	VenueName            *string      `json:"venue_name,omitempty"`
+	DateTypeCore         *string      `json:"date_type_core,omitempty"`
+	BookingStatus        *string      `json:"booking_status,omitempty"`
+	VenueProposalStatus  *string      `json:"venue_proposal_status,omitempty"`
+	ScheduledTimeUTC     *time.Time   `json:"scheduled_time_utc,omitempty"`
+	InitiatorConfirmedAt *time.Time   `json:"initiator_confirmed_at,omitempty"`
+	ReceiverConfirmedAt  *time.Time   `json:"receiver_confirmed_at,omitempty"`
+	DecisionWindowEnd    time.Time    `json:"decision_window_end"`
+	CreatedAt            time.Time    `json:"created_at"`
+}
+
+// DateInstanceUIPaginated wraps a paginated list of DateInstanceUIs.
+type DateInstanceUIPaginated struct {
+	Data       []DateInstanceUI `json:"data"`
+	Pagination *sdk.Pagination  `json:"pagination"`
+}
+
+// QueryFilterUserDateInstances contains filter options for a user's date instances.
+type QueryFilterUserDateInstances struct {
+	UserID uuid.UUID
+	Status null.String
+
+	// Sorting
+	OrderBy null.String // column to order by
+	Sort    null.String // "+" for ASC, "-" for DESC
+
+	// Pagination
+	Pagination *sdk.Pagination
+}
@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/db/repo"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/scheduling"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+// AvailabilityStore handles user_availability data access.
+// time_block is a TSTZRANGE, so reads go through lower()/upper().
+type AvailabilityStore struct {
+	l    applog.Logger
+	repo *repo.Store
+}
+
+// TimeBlocks returns all availability blocks of a user, ordered by start.
+func (s *AvailabilityStore) TimeBlocks(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	userID string,
+) ([]scheduling.TimeBlock, error) {
+	blocks := make([]scheduling.TimeBlock, 0)
+
+	uaCols := pgmodel.UserAvailabilityColumns
+
+	qMods := []qm.QueryMod{
+		qm.Select(
+			"lower(ua."+uaCols.TimeBlock+") AS start_time",
+			"upper(ua."+uaCols.TimeBlock+") AS end_time",
+		),
+		qm.From(pgmodel.TableNames.UserAvailability + " ua"),
+		qm.Where("ua."+uaCols.UserID+" = ?", userID),
+		qm.OrderBy("lower(ua." + uaCols.TimeBlock + ") ASC"),
+	}
+
+	if err := pgmodel.NewQuery(qMods...).Bind(ctx, exec, &blocks); err != nil {
+		return nil, fmt.Errorf("query user availability: %w", err)
+	}
+
+	return blocks, nil
+}
+
+// Insert inserts a single availability block.
+func (s *AvailabilityStore) Insert(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	inserter *scheduling.InsertUserAvailability,
+) error {
+	if _, err := s.repo.InsertUserAvailability(ctx, exec, &repo.InsertUserAvailability{
+		UserID:    inserter.UserID,
+		TimeBlock: inserter.TimeBlock.Range(),
+	}); err != nil {
+		return fmt.Errorf("repo insert user availability: %w", err)
+	}
+	return nil
+}
+
+// DeleteAll deletes all availability blocks of a user.
+func (s *AvailabilityStore) DeleteAll(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	userID string,
+) (int, error) {
+	n, err := s.repo.DeleteUserAvailabilities(ctx, exec, userID)
+	if err != nil {
+		return 0, fmt.Errorf("repo delete user availabilities: %w", err)
+	}
+	return int(n), nil
+}
+
+// Overlaps returns the range intersections (*) of every pair of
+// overlapping (&&) blocks between two users, ordered by start.
+func (s *AvailabilityStore) Overlaps(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	userAID, userBID string,
+) ([]scheduling.TimeBlock, error) {
+	overlaps := make([]scheduling.TimeBlock, 0)
+
+	uaTbl := pgmodel.TableNames.UserAvailability
+	uaCols := pgmodel.UserAvailabilityColumns
+
+	intersection := "(a." + uaCols.TimeBlock + " * b." + uaCols.TimeBlock + ")"
+
+	qMods := []qm.QueryMod{
+		qm.Select(
+			"lower"+intersection+" AS start_time",
+			"upper"+intersection+" AS end_time",
+		),
+		qm.From(uaTbl + " a"),
+		qm.InnerJoin(uaTbl + " b ON a." + uaCols.TimeBlock + " && b." + uaCols.TimeBlock),
+		qm.Where("a."+uaCols.UserID+" = ?", userAID),
+		qm.Where("b."+uaCols.UserID+" = ?", userBID),
+		qm.OrderBy("start_time ASC"),
+	}
+
+	if err := pgmodel.NewQuery(qMods...).Bind(ctx, exec, &overlaps); err != nil {
+		return nil, fmt.Errorf("query availability overlaps: %w", err)
+	}
+
+	return overlaps, nil
+}
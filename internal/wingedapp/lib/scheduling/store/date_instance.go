@@ -0,0 +1,207 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"wingedapp/pgtester/internal/wingedapp/db/boilhelper"
+	"wingedapp/pgtester/internal/wingedapp/db/pgmodel"
+	"wingedapp/pgtester/internal/wingedapp/db/repo"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+	"wingedapp/pgtester/internal/wingedapp/lib/scheduling"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/google/uuid"
+)
+
+// DateInstanceStore handles date instance reads for the scheduling flows.
+type DateInstanceStore struct {
+	l    applog.Logger
+	repo *repo.Store
+}
+
+// proposalCount selects the number of proposals of di, in the given statuses.
+func proposalCount(alias string, statuses ...string) string {
+	pCols := pgmodel.DateInstanceProposalColumns
+
+	in := ""
+	for i, st := range statuses {
+		if i > 0 {
+			in += ", "
+		}
+		in += "'" + st + "'"
+	}
+
+	return "(SELECT COUNT(*) FROM " + pgmodel.TableNames.DateInstanceProposal + " p" +
+		" WHERE p." + pCols.DateInstanceRefID + " = di.id" +
+		" AND p." + pCols.Status + " IN (" + in + ")) AS " + alias
+}
+
+func (s *DateInstanceStore) dateInstancesRows(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	f *scheduling.QueryFilterDateInstance,
+) ([]scheduling.DateInstance, error) {
+	var results []scheduling.DateInstance
+
+	diCols := pgmodel.DateInstanceColumns
+	mrCols := pgmodel.MatchResultColumns
+	vCols := pgmodel.VenueColumns
+
+	qMods := append(
+		qModsDateInstance(f, true),
+		qm.Select(
+			"di."+diCols.ID+" AS id",
+			"di."+diCols.MatchResultRefID+" AS match_result_ref_id",
+			"mr."+mrCols.InitiatorUserRefID+" AS initiator_user_id",
+			"mr."+mrCols.ReceiverUserRefID+" AS receiver_user_id",
+			"di."+diCols.Status+" AS status",
+			"di."+diCols.VenueRefID+" AS venue_ref_id",
+			"COALESCE(v."+vCols.DisplayName+", v."+vCols.Name+") AS venue_name",
+			"di."+diCols.DateTypeCore+" AS date_type_core",
+			"di."+diCols.BookingStatus+" AS booking_status",
+			"di."+diCols.VenueProposalStatus+" AS venue_proposal_status",
+			"di."+diCols.ScheduledTimeUtc+" AS scheduled_time_utc",
+			"di."+diCols.InitiatorConfirmedAt+" AS initiator_confirmed_at",
+			"di."+diCols.ReceiverConfirmedAt+" AS receiver_confirmed_at",
+			"di."+diCols.DecisionWindowEnd+" AS decision_window_end",
+			"di."+diCols.CreatedAt+" AS created_at",
+			proposalCount("pending_proposals", scheduling.ProposalStatusPending),
+			proposalCount("rejected_proposals", scheduling.ProposalStatusRejected),
+			proposalCount("total_proposals", // superseded proposals were replaced, so don't count
+				scheduling.ProposalStatusPending,
+				scheduling.ProposalStatusAccepted,
+				scheduling.ProposalStatusRejected,
+			),
+		),
+		qm.LeftOuterJoin(pgmodel.TableNames.Venue+" v ON v."+vCols.ID+" = di."+diCols.VenueRefID),
+	)
+
+	if err := pgmodel.NewQuery(qMods...).Bind(ctx, exec, &results); err != nil {
+		return nil, fmt.Errorf("query date instances: %w", err)
+	}
+
+	return results, nil
+}
+
+func (s *DateInstanceStore) dateInstancesCount(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	f *scheduling.QueryFilterDateInstance,
+) (int, error) {
+	qMods := append(
+		qModsDateInstance(f, false),
+		qm.Select("COUNT(*)"),
+	)
+
+	var count int
+	if err := pgmodel.NewQuery(qMods...).QueryRow(exec).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count date instances: %w", err)
+	}
+
+	return count, nil
+}
+
+// DateInstances returns date instances matching the filter, joined with their match participants.
+func (s *DateInstanceStore) DateInstances(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	f *scheduling.QueryFilterDateInstance,
+) (*scheduling.DateInstancePaginated, error) {
+	rows, err := s.dateInstancesRows(ctx, exec, f)
+	if err != nil {
+		return nil, fmt.Errorf("date instances rows: %w", err)
+	}
+
+	count := len(rows)
+	if f.Pagination != nil {
+		count, err = s.dateInstancesCount(ctx, exec, f)
+		if err != nil {
+			return nil, fmt.Errorf("date instances count: %w", err)
+		}
+	}
+
+	return &scheduling.DateInstancePaginated{
+		Data:       rows,
+		Pagination: f.Pagination.Recalculated(count),
+	}, nil
+}
+
+// PartnerInfo returns the public info of a date participant.
+func (s *DateInstanceStore) PartnerInfo(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	userID string,
+) (*scheduling.PartnerInfo, error) {
+	uCols := pgmodel.UserColumns
+
+	u, err := pgmodel.FindUser(ctx, exec, userID, uCols.ID, uCols.FirstName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user %s: %w", userID, repo.ErrNotFound)
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	id, err := uuid.Parse(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parse user id: %w", err)
+	}
+
+	return &scheduling.PartnerInfo{
+		UserID:    id,
+		FirstName: u.FirstName.String,
+	}, nil
+}
+
+// qModsDateInstance builds the FROM, joins, and filters shared by the rows and count queries.
+func qModsDateInstance(f *scheduling.QueryFilterDateInstance, paginated bool) []qm.QueryMod {
+	diCols := pgmodel.DateInstanceColumns
+	mrCols := pgmodel.MatchResultColumns
+
+	qMods := []qm.QueryMod{
+		qm.From(pgmodel.TableNames.DateInstance + " di"),
+		qm.InnerJoin(pgmodel.TableNames.MatchResult + " mr ON mr." + mrCols.ID + " = di." + diCols.MatchResultRefID),
+	}
+
+	if f.ID.Valid {
+		qMods = append(qMods, qm.Where("di."+diCols.ID+" = ?", f.ID.String))
+	}
+
+	if f.UserID.Valid {
+		qMods = append(qMods, qm.Where(
+			"(mr."+mrCols.InitiatorUserRefID+" = ? OR mr."+mrCols.ReceiverUserRefID+" = ?)",
+			f.UserID.String, f.UserID.String,
+		))
+	}
+
+	if f.Status.Valid {
+		qMods = append(qMods, qm.Where("di."+diCols.Status+" = ?", f.Status.String))
+	}
+
+	// Only apply ordering and pagination for data queries, not count queries
+	if paginated {
+		orderBy := "di." + diCols.CreatedAt + " DESC"
+		if f.OrderBy.Valid {
+			allowedColumns := map[string]string{
+				"created_at":          diCols.CreatedAt,
+				"scheduled_time_utc":  diCols.ScheduledTimeUtc,
+				"decision_window_end": diCols.DecisionWindowEnd,
+				"status":              diCols.Status,
+			}
+
+			if col, ok := allowedColumns[f.OrderBy.String]; ok {
+				orderBy = "di." + col + " " + boilhelper.SortByAscOrDesc(f.Sort)
+			}
+		}
+		qMods = append(qMods, qm.OrderBy(orderBy))
+
+		if f.Pagination != nil {
+			qMods = boilhelper.ApplyPagination(qMods, f.Pagination)
+		}
+	}
+
+	return qMods
+}
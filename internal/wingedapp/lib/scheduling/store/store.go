@@ -0,0 +1,21 @@
+package store
+
+import (
+	"wingedapp/pgtester/internal/wingedapp/db/repo"
+	"wingedapp/pgtester/internal/wingedapp/lib/applog"
+)
+
+type SchedulingStores struct {
+	AvailabilityStore *AvailabilityStore
+	DateInstanceStore *DateInstanceStore
+}
+
+// NewSchedulingStores creates a new instance of SchedulingStores with the provided logger.
+func NewSchedulingStores(l applog.Logger) *SchedulingStores {
+	r := &repo.Store{}
+
+	return &SchedulingStores{
+		AvailabilityStore: &AvailabilityStore{l, r},
+		DateInstanceStore: &DateInstanceStore{l, r},
+	}
+}
@@ -0,0 +1,350 @@
+package scheduling
+
+import (
+	"fmt"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
+
+	"github.com/google/uuid"
+)
+
+/*
+	ui_state.go houses the date instance UI state machine.
+		- ComputeUIState derives the screen a user should see from the date instance row
+		- ActionGuards / ValidateAction gate which actions are allowed per state and role
+		- GetHintForState returns the copy shown under the status line
+*/
+
+// UIStateName is the client facing state of a date instance.
+type UIStateName string
+
+const (
+	UIStateSyncingAvailability      UIStateName = "syncing_availability"
+	UIStateAwaitingTimeConfirmation UIStateName = "awaiting_time_confirmation"
+	UIStateSelectingVenue           UIStateName = "selecting_venue"
+	UIStateVenueProposedToReceiver  UIStateName = "venue_proposed_to_receiver"
+	UIStateAwaitingBooking          UIStateName = "awaiting_booking"
+	UIStateAwaitingConfirmation     UIStateName = "awaiting_confirmation"
+	UIStateDateScheduled            UIStateName = "date_scheduled"
+	UIStateLogisticsPanel           UIStateName = "logistics_panel"
+	UIStateAwaitingFeedback         UIStateName = "awaiting_feedback"
+	UIStateClosed                   UIStateName = "closed"
+)
+
+// UIStateInput is everything ComputeUIState needs, flattened from the date instance.
+type UIStateInput struct {
+	StatusName            string
+	HasProposals          bool
+	AllProposalsRejected  bool
+	HasVenue              bool
+	HasDateType           bool
+	IsWithinActiveWindow  bool
+	BookingFailed         bool
+	VenueProposalAccepted bool
+	InitiatorConfirmed    bool
+	ReceiverConfirmed     bool
+}
+
+// ComputeUIState maps a date instance onto its UI state.
+func ComputeUIState(in UIStateInput) UIStateName {
+	switch enums.DateInstanceStatus(in.StatusName) {
+	case enums.DateInstanceStatusProposed:
+		if in.HasProposals && !in.AllProposalsRejected {
+			return UIStateAwaitingTimeConfirmation
+		}
+		return UIStateSyncingAvailability
+
+	case enums.DateInstanceStatusTimeChosen:
+		// date type is picked on the venue screen, so both land here
+		return UIStateSelectingVenue
+
+	case enums.DateInstanceStatusVenueChosen:
+		if !in.HasVenue || in.BookingFailed {
+			return UIStateSelectingVenue
+		}
+		if !in.VenueProposalAccepted {
+			return UIStateVenueProposedToReceiver
+		}
+		return UIStateAwaitingBooking
+
+	case enums.DateInstanceStatusDateSet:
+		if in.IsWithinActiveWindow {
+			return UIStateLogisticsPanel
+		}
+		if in.InitiatorConfirmed && in.ReceiverConfirmed {
+			return UIStateDateScheduled
+		}
+		return UIStateAwaitingConfirmation
+
+	case enums.DateInstanceStatusCompleted:
+		return UIStateAwaitingFeedback
+	}
+
+	// Cancelled, Expired, No Show, and anything unknown
+	return UIStateClosed
+}
+
+// uiHints holds the hint per state and role.
+var uiHints = map[UIStateName]map[string]string{
+	UIStateSyncingAvailability: {
+		RoleInitiator: "We're finding times that work for both of you.",
+		RoleReceiver:  "Suggest a few times that work for you.",
+	},
+	UIStateAwaitingTimeConfirmation: {
+		RoleInitiator: "Pick one of the proposed times.",
+		RoleReceiver:  "Your times were sent, waiting for your match to pick one.",
+	},
+	UIStateSelectingVenue: {
+		RoleInitiator: "Choose a date type and a place to meet.",
+		RoleReceiver:  "Your match is choosing a place.",
+	},
+	UIStateVenueProposedToReceiver: {
+		RoleInitiator: "Waiting for your match to accept the venue.",
+		RoleReceiver:  "Accept the venue, or suggest a place yourself.",
+	},
+	UIStateAwaitingBooking: {
+		RoleInitiator: "Book the venue and confirm the reservation.",
+		RoleReceiver:  "Your match is booking the venue.",
+	},
+	UIStateAwaitingConfirmation: {
+		RoleInitiator: "Confirm you'll be there.",
+		RoleReceiver:  "Confirm you'll be there.",
+	},
+	UIStateDateScheduled: {
+		RoleInitiator: "You're both confirmed. See you there!",
+		RoleReceiver:  "You're both confirmed. See you there!",
+	},
+	UIStateLogisticsPanel: {
+		RoleInitiator: "Let your match know when you arrive.",
+		RoleReceiver:  "Let your match know when you arrive.",
+	},
+	UIStateAwaitingFeedback: {
+		RoleInitiator: "Tell us how it went.",
+		RoleReceiver:  "Tell us how it went.",
+	},
+	UIStateClosed: {
+		RoleInitiator: "This date is closed.",
+		RoleReceiver:  "This date is closed.",
+	},
+}
+
+// GetHintForState returns the hint for a state, from the given role's perspective.
+func GetHintForState(state UIStateName, role string) string {
+	if byRole, ok := uiHints[state]; ok {
+		if hint, ok := byRole[role]; ok {
+			return hint
+		}
+	}
+	return ""
+}
+
+// Actions a client can execute on a date instance.
+const (
+	// Time flow
+	ActionSuggestTimes     = "suggest_times"
+	ActionConfirmTime      = "confirm_time"
+	ActionRejectTimes      = "reject_times"
+	ActionRequestMoreTimes = "request_more_times"
+
+	// Venue flow
+	ActionSelectDateType         = "select_date_type"
+	ActionSelectVenue            = "select_venue"
+	ActionConfirmVenueProceed    = "confirm_venue_proceed"
+	ActionGoBackVenue            = "go_back_venue"
+	ActionAcceptProposedVenue    = "accept_proposed_venue"
+	ActionRejectProposedVenue    = "reject_proposed_venue"
+	ActionSuggestVenue           = "suggest_venue"
+	ActionRespondVenueSuggestion = "respond_venue_suggestion"
+	ActionRequestVenueChange     = "request_venue_change"
+	ActionConfirmBooking         = "confirm_booking"
+
+	// Booking reminder flow
+	ActionSetReminder     = "set_reminder"
+	ActionChooseNewVenue  = "choose_new_venue"
+	ActionKeepReminder    = "keep_reminder"
+	ActionProvideOwnVenue = "provide_own_venue"
+
+	// Modification
+	ActionConfirmAttendance = "confirm_attendance"
+	ActionChangeTime        = "change_time"
+	ActionChangePlace       = "change_place"
+	ActionCancel            = "cancel"
+
+	// Logistics
+	ActionArrived     = "arrived"
+	ActionRunningLate = "running_late"
+	ActionNeedHelp    = "need_help"
+	ActionCancelNow   = "cancel_now"
+
+	// Feedback
+	ActionDidMeet  = "did_meet"
+	ActionDecision = "decision"
+
+	// UI only, no server side effect
+	ActionExpandVenues      = "expand_venues"
+	ActionCollapseVenues    = "collapse_venues"
+	ActionExpandTimeSlots   = "expand_time_slots"
+	ActionCollapseTimeSlots = "collapse_time_slots"
+	ActionDismissSheet      = "dismiss_sheet"
+)
+
+// ActionGuard lists the states an action may be executed from, and by whom.
+type ActionGuard struct {
+	FromStates  []UIStateName
+	AllowedRole string // RoleInitiator, RoleReceiver, or RoleBoth
+}
+
+var (
+	upcomingDateStates = []UIStateName{UIStateAwaitingBooking, UIStateAwaitingConfirmation, UIStateDateScheduled}
+	allOpenStates      = []UIStateName{
+		UIStateSyncingAvailability, UIStateAwaitingTimeConfirmation, UIStateSelectingVenue,
+		UIStateVenueProposedToReceiver, UIStateAwaitingBooking, UIStateAwaitingConfirmation,
+		UIStateDateScheduled, UIStateLogisticsPanel, UIStateAwaitingFeedback,
+	}
+)
+
+// ActionGuards is the action -> guard table used by ValidateAction.
+var ActionGuards = map[string]ActionGuard{
+	ActionSuggestTimes:     {FromStates: []UIStateName{UIStateSyncingAvailability, UIStateAwaitingTimeConfirmation}, AllowedRole: RoleReceiver},
+	ActionRequestMoreTimes: {FromStates: []UIStateName{UIStateAwaitingTimeConfirmation}, AllowedRole: RoleReceiver},
+	ActionConfirmTime:      {FromStates: []UIStateName{UIStateAwaitingTimeConfirmation}, AllowedRole: RoleInitiator},
+	ActionRejectTimes:      {FromStates: []UIStateName{UIStateAwaitingTimeConfirmation}, AllowedRole: RoleInitiator},
+
+	ActionSelectDateType:         {FromStates: []UIStateName{UIStateSelectingVenue}, AllowedRole: RoleInitiator},
+	ActionSelectVenue:            {FromStates: []UIStateName{UIStateSelectingVenue}, AllowedRole: RoleInitiator},
+	ActionConfirmVenueProceed:    {FromStates: []UIStateName{UIStateSelectingVenue}, AllowedRole: RoleInitiator},
+	ActionGoBackVenue:            {FromStates: []UIStateName{UIStateSelectingVenue, UIStateVenueProposedToReceiver}, AllowedRole: RoleInitiator},
+	ActionRespondVenueSuggestion: {FromStates: []UIStateName{UIStateSelectingVenue, UIStateVenueProposedToReceiver}, AllowedRole: RoleInitiator},
+	ActionAcceptProposedVenue:    {FromStates: []UIStateName{UIStateVenueProposedToReceiver}, AllowedRole: RoleReceiver},
+	ActionRejectProposedVenue:    {FromStates: []UIStateName{UIStateVenueProposedToReceiver}, AllowedRole: RoleReceiver},
+	ActionSuggestVenue:           {FromStates: []UIStateName{UIStateVenueProposedToReceiver}, AllowedRole: RoleReceiver},
+	ActionRequestVenueChange:     {FromStates: upcomingDateStates, AllowedRole: RoleReceiver},
+	ActionConfirmBooking:         {FromStates: []UIStateName{UIStateAwaitingBooking}, AllowedRole: RoleInitiator},
+
+	ActionSetReminder:     {FromStates: []UIStateName{UIStateAwaitingBooking}, AllowedRole: RoleInitiator},
+	ActionChooseNewVenue:  {FromStates: []UIStateName{UIStateAwaitingBooking}, AllowedRole: RoleInitiator},
+	ActionKeepReminder:    {FromStates: []UIStateName{UIStateAwaitingBooking}, AllowedRole: RoleInitiator},
+	ActionProvideOwnVenue: {FromStates: []UIStateName{UIStateSelectingVenue, UIStateAwaitingBooking}, AllowedRole: RoleInitiator},
+
+	ActionConfirmAttendance: {FromStates: []UIStateName{UIStateAwaitingConfirmation}, AllowedRole: RoleBoth},
+	ActionChangeTime:        {FromStates: upcomingDateStates, AllowedRole: RoleBoth},
+	ActionChangePlace:       {FromStates: upcomingDateStates, AllowedRole: RoleBoth},
+	ActionCancel:            {FromStates: upcomingDateStates, AllowedRole: RoleBoth},
+
+	ActionArrived:     {FromStates: []UIStateName{UIStateLogisticsPanel}, AllowedRole: RoleBoth},
+	ActionRunningLate: {FromStates: []UIStateName{UIStateLogisticsPanel}, AllowedRole: RoleBoth},
+	ActionNeedHelp:    {FromStates: []UIStateName{UIStateLogisticsPanel}, AllowedRole: RoleBoth},
+	ActionCancelNow:   {FromStates: []UIStateName{UIStateLogisticsPanel}, AllowedRole: RoleBoth},
+
+	ActionDidMeet:  {FromStates: []UIStateName{UIStateAwaitingFeedback}, AllowedRole: RoleBoth},
+	ActionDecision: {FromStates: []UIStateName{UIStateAwaitingFeedback}, AllowedRole: RoleBoth},
+
+	ActionExpandVenues:      {FromStates: []UIStateName{UIStateSelectingVenue, UIStateVenueProposedToReceiver}, AllowedRole: RoleBoth},
+	ActionCollapseVenues:    {FromStates: []UIStateName{UIStateSelectingVenue, UIStateVenueProposedToReceiver}, AllowedRole: RoleBoth},
+	ActionExpandTimeSlots:   {FromStates: []UIStateName{UIStateAwaitingTimeConfirmation}, AllowedRole: RoleBoth},
+	ActionCollapseTimeSlots: {FromStates: []UIStateName{UIStateAwaitingTimeConfirmation}, AllowedRole: RoleBoth},
+	ActionDismissSheet:      {FromStates: allOpenStates, AllowedRole: RoleBoth},
+}
+
+// ValidateAction checks an action may be executed from state by role.
+func ValidateAction(action string, state UIStateName, role string) error {
+	guard, ok := ActionGuards[action]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownAction, action)
+	}
+
+	allowed := false
+	for _, s := range guard.FromStates {
+		if s == state {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s from %s", ErrActionNotAllowedInState, action, state)
+	}
+
+	if guard.AllowedRole != RoleBoth && guard.AllowedRole != role {
+		return fmt.Errorf("%w: %s by %s", ErrActionNotAllowedForRole, action, role)
+	}
+
+	return nil
+}
+
+// UI element types rendered by the client, in UIStateResponse.Elements.
+const (
+	UIElementTypeStatusLine     = "status_line"
+	UIElementTypeTimeSlots      = "time_slots"
+	UIElementTypeVenueOptions   = "venue_options"
+	UIElementTypeBookingStatus  = "booking_status"
+	UIElementTypeConfirmation   = "confirmation"
+	UIElementTypeLogisticsPanel = "logistics_panel"
+	UIElementTypeFeedbackForm   = "feedback_form"
+)
+
+// UIElement is a single block the client renders, in Order.
+type UIElement struct {
+	Type       string `json:"type"`
+	Order      int    `json:"order"`
+	Expandable bool   `json:"expandable,omitempty"`
+}
+
+// UIAction is a button the client renders.
+type UIAction struct {
+	Action string `json:"action"`
+	Label  string `json:"label"`
+	Style  string `json:"style"` // primary, secondary, destructive
+}
+
+// UIStatusLine is the header of the date screen.
+type UIStatusLine struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+	Icon     string `json:"icon"`
+}
+
+// UITimer is a countdown to the decision window end.
+type UITimer struct {
+	Label     string    `json:"label"`
+	EndsAt    time.Time `json:"ends_at"`
+	Style     string    `json:"style"` // normal, urgent
+	Expired   bool      `json:"expired"`
+	Remaining int       `json:"remaining_seconds"`
+}
+
+// UIStateResponse is everything the client needs to render a date instance.
+type UIStateResponse struct {
+	DateInstanceID    uuid.UUID     `json:"date_instance_id"`
+	MatchResultRefID  uuid.UUID     `json:"match_result_ref_id"`
+	UIState           UIStateName   `json:"ui_state"`
+	UIStateCode       string        `json:"ui_state_code"`
+	Hint              string        `json:"hint"`
+	MyRole            string        `json:"my_role"`
+	PartnerInfo       *PartnerInfo  `json:"partner_info,omitempty"`
+	StatusName        string        `json:"status_name"`
+	StatusLine        *UIStatusLine `json:"status_line,omitempty"`
+	Timer             *UITimer      `json:"timer,omitempty"`
+	ScheduledTimeUTC  *time.Time    `json:"scheduled_time_utc,omitempty"`
+	VenueRefID        *string       `json:"venue_ref_id,omitempty"`
+	VenueName         *string       `json:"venue_name,omitempty"`
+	DecisionWindowEnd time.Time     `json:"decision_window_end"`
+	Elements          []UIElement   `json:"elements"`
+	AvailableActions  []UIAction    `json:"available_actions"`
+	GeneratedAt       time.Time     `json:"generated_at"`
+}
+
+// ActionRequest is an action the client wants to execute, with its payload.
+type ActionRequest struct {
+	Action  string `json:"action"`
+	Payload any    `json:"payload,omitempty"`
+}
+
+// ActionResponse is the outcome of an executed action.
+type ActionResponse struct {
+	Success    bool   `json:"success"`
+	Action     string `json:"action"`
+	Message    string `json:"message,omitempty"`
+	Error      string `json:"error,omitempty"`
+	NavigateTo string `json:"navigate_to,omitempty"`
+	NextAction string `json:"next_action,omitempty"`
+}
@@ -0,0 +1,210 @@
+package scheduling_test
+
+import (
+	"testing"
+	"time"
+	"wingedapp/pgtester/internal/wingedapp/lib/enums"
+	"wingedapp/pgtester/internal/wingedapp/lib/scheduling"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCaseComputeUIState struct {
+	name  string
+	input scheduling.UIStateInput
+	want  scheduling.UIStateName
+}
+
+func TestComputeUIState(t *testing.T) {
+	testCases := []testCaseComputeUIState{
+		{
+			name:  "proposed-without-proposals-syncs-availability",
+			input: scheduling.UIStateInput{StatusName: enums.DateInstanceStatusProposed.String()},
+			want:  scheduling.UIStateSyncingAvailability,
+		},
+		{
+			name:  "proposed-with-pending-proposals-awaits-time-confirmation",
+			input: scheduling.UIStateInput{StatusName: enums.DateInstanceStatusProposed.String(), HasProposals: true},
+			want:  scheduling.UIStateAwaitingTimeConfirmation,
+		},
+		{
+			name: "proposed-with-all-proposals-rejected-syncs-availability",
+			input: scheduling.UIStateInput{
+				StatusName:           enums.DateInstanceStatusProposed.String(),
+				HasProposals:         true,
+				AllProposalsRejected: true,
+			},
+			want: scheduling.UIStateSyncingAvailability,
+		},
+		{
+			name:  "time-chosen-selects-venue",
+			input: scheduling.UIStateInput{StatusName: enums.DateInstanceStatusTimeChosen.String()},
+			want:  scheduling.UIStateSelectingVenue,
+		},
+		{
+			name:  "venue-chosen-not-accepted-proposed-to-receiver",
+			input: scheduling.UIStateInput{StatusName: enums.DateInstanceStatusVenueChosen.String(), HasVenue: true},
+			want:  scheduling.UIStateVenueProposedToReceiver,
+		},
+		{
+			name: "venue-chosen-accepted-awaits-booking",
+			input: scheduling.UIStateInput{
+				StatusName:            enums.DateInstanceStatusVenueChosen.String(),
+				HasVenue:              true,
+				VenueProposalAccepted: true,
+			},
+			want: scheduling.UIStateAwaitingBooking,
+		},
+		{
+			name: "venue-chosen-booking-failed-selects-venue-again",
+			input: scheduling.UIStateInput{
+				StatusName:            enums.DateInstanceStatusVenueChosen.String(),
+				HasVenue:              true,
+				VenueProposalAccepted: true,
+				BookingFailed:         true,
+			},
+			want: scheduling.UIStateSelectingVenue,
+		},
+		{
+			name:  "date-set-unconfirmed-awaits-confirmation",
+			input: scheduling.UIStateInput{StatusName: enums.DateInstanceStatusDateSet.String(), InitiatorConfirmed: true},
+			want:  scheduling.UIStateAwaitingConfirmation,
+		},
+		{
+			name: "date-set-both-confirmed-scheduled",
+			input: scheduling.UIStateInput{
+				StatusName:         enums.DateInstanceStatusDateSet.String(),
+				InitiatorConfirmed: true,
+				ReceiverConfirmed:  true,
+			},
+			want: scheduling.UIStateDateScheduled,
+		},
+		{
+			name: "date-set-within-active-window-shows-logistics",
+			input: scheduling.UIStateInput{
+				StatusName:           enums.DateInstanceStatusDateSet.String(),
+				IsWithinActiveWindow: true,
+			},
+			want: scheduling.UIStateLogisticsPanel,
+		},
+		{
+			name:  "completed-awaits-feedback",
+			input: scheduling.UIStateInput{StatusName: enums.DateInstanceStatusCompleted.String()},
+			want:  scheduling.UIStateAwaitingFeedback,
+		},
+		{
+			name:  "cancelled-is-closed",
+			input: scheduling.UIStateInput{StatusName: enums.DateInstanceStatusCancelled.String()},
+			want:  scheduling.UIStateClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, scheduling.ComputeUIState(tc.input))
+		})
+	}
+}
+
+func TestGetHintForState(t *testing.T) {
+	assert.NotEmpty(t, scheduling.GetHintForState(scheduling.UIStateSelectingVenue, scheduling.RoleInitiator))
+	assert.NotEqual(t,
+		scheduling.GetHintForState(scheduling.UIStateSelectingVenue, scheduling.RoleInitiator),
+		scheduling.GetHintForState(scheduling.UIStateSelectingVenue, scheduling.RoleReceiver),
+		"initiator and receiver should get different hints while selecting a venue",
+	)
+	assert.Empty(t, scheduling.GetHintForState(scheduling.UIStateSelectingVenue, "stranger"))
+}
+
+type testCaseValidateAction struct {
+	name    string
+	action  string
+	state   scheduling.UIStateName
+	role    string
+	wantErr error
+}
+
+func TestValidateAction(t *testing.T) {
+	testCases := []testCaseValidateAction{
+		{
+			name:   "initiator-confirms-time",
+			action: scheduling.ActionConfirmTime,
+			state:  scheduling.UIStateAwaitingTimeConfirmation,
+			role:   scheduling.RoleInitiator,
+		},
+		{
+			name:   "both-roles-can-cancel-scheduled-date",
+			action: scheduling.ActionCancel,
+			state:  scheduling.UIStateDateScheduled,
+			role:   scheduling.RoleReceiver,
+		},
+		{
+			name:    "receiver-cannot-confirm-time",
+			action:  scheduling.ActionConfirmTime,
+			state:   scheduling.UIStateAwaitingTimeConfirmation,
+			role:    scheduling.RoleReceiver,
+			wantErr: scheduling.ErrActionNotAllowedForRole,
+		},
+		{
+			name:    "confirm-time-not-allowed-while-selecting-venue",
+			action:  scheduling.ActionConfirmTime,
+			state:   scheduling.UIStateSelectingVenue,
+			role:    scheduling.RoleInitiator,
+			wantErr: scheduling.ErrActionNotAllowedInState,
+		},
+		{
+			name:    "unknown-action",
+			action:  "teleport",
+			state:   scheduling.UIStateSelectingVenue,
+			role:    scheduling.RoleInitiator,
+			wantErr: scheduling.ErrUnknownAction,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := scheduling.ValidateAction(tc.action, tc.state, tc.role)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestTimeBlocks(t *testing.T) {
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	block := func(startH, endH int) scheduling.TimeBlock {
+		return scheduling.TimeBlock{
+			Start: base.Add(time.Duration(startH) * time.Hour),
+			End:   base.Add(time.Duration(endH) * time.Hour),
+		}
+	}
+
+	t.Run("total-minutes", func(t *testing.T) {
+		assert.Equal(t, 0, scheduling.TotalMinutes(nil))
+		assert.Equal(t, 180, scheduling.TotalMinutes([]scheduling.TimeBlock{block(0, 1), block(2, 4)}))
+	})
+
+	t.Run("merge-overlapping-keeps-touching-apart", func(t *testing.T) {
+		merged := scheduling.MergeTimeBlocks([]scheduling.TimeBlock{block(3, 4), block(0, 2), block(1, 3), block(5, 6)})
+		assert.Equal(t, []scheduling.TimeBlock{block(0, 3), block(3, 4), block(5, 6)}, merged)
+	})
+
+	t.Run("range-round-trips-through-parse", func(t *testing.T) {
+		parsed, err := scheduling.ParseTimeBlock(block(0, 2).Range())
+		require.NoError(t, err)
+		assert.Equal(t, block(0, 2), parsed)
+
+		parsed, err = scheduling.ParseTimeBlock(`["2025-01-01 10:00:00+00","2025-01-01 12:00:00+00")`)
+		require.NoError(t, err)
+		assert.Equal(t, block(0, 2), parsed)
+	})
+
+	t.Run("validate-rejects-inverted-block", func(t *testing.T) {
+		require.ErrorIs(t, block(2, 1).Validate(), scheduling.ErrTimeBlockEndBeforeStart)
+		require.ErrorIs(t, scheduling.TimeBlock{}.Validate(), scheduling.ErrTimeBlockMissingBounds)
+	})
+}
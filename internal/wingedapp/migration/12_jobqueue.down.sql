@@ -0,0 +1,9 @@
+-- Migration 12 DOWN: Remove job queue scheduling fields
+
+DROP INDEX IF EXISTS idx_jobs_running_heartbeat;
+DROP INDEX IF EXISTS idx_jobs_pending;
+CREATE INDEX idx_jobs_pending ON jobs (created_at) WHERE status = 'pending';
+
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS run_after,
+    DROP COLUMN IF EXISTS heartbeat_at;
@@ -0,0 +1,24 @@
+-- Migration 12: Job queue scheduling and liveness fields
+-- run_after delays a job (retry backoff), heartbeat_at lets workers reclaim
+-- jobs whose worker died mid-run.
+
+--------------------------------------------------------------------------------
+-- ADD SCHEDULING FIELDS TO JOBS
+--------------------------------------------------------------------------------
+
+ALTER TABLE jobs
+    ADD COLUMN run_after    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ADD COLUMN heartbeat_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+COMMENT ON COLUMN jobs.run_after IS 'Job is not claimable before this time (retry backoff)';
+COMMENT ON COLUMN jobs.heartbeat_at IS 'Last liveness signal of the worker running the job';
+
+--------------------------------------------------------------------------------
+-- REPLACE PENDING INDEX (workers poll on run_after now)
+--------------------------------------------------------------------------------
+
+DROP INDEX IF EXISTS idx_jobs_pending;
+CREATE INDEX idx_jobs_pending ON jobs (run_after) WHERE status = 'pending';
+
+-- Index for reclaiming running jobs with a stale heartbeat
+CREATE INDEX idx_jobs_running_heartbeat ON jobs (heartbeat_at) WHERE status = 'running';
@@ -0,0 +1,4 @@
+-- Migration 13 DOWN: Remove configurable hard qualifiers
+
+ALTER TABLE match_config
+    DROP COLUMN IF EXISTS qualifiers;
@@ -0,0 +1,10 @@
+-- Migration 13: Configurable hard qualifiers
+-- The ordered list of hard qualifiers a pair must pass. Qualifiers not in the
+-- list are disabled.
+
+ALTER TABLE match_config
+    ADD COLUMN qualifiers TEXT[] NOT NULL DEFAULT ARRAY [
+        'age_window_qualifier', 'date_prefs_qualifier', 'height_qualifier', 'distance_qualifier'
+    ];
+
+COMMENT ON COLUMN match_config.qualifiers IS 'Enabled hard qualifiers, in execution order';
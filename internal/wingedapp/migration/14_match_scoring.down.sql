@@ -0,0 +1,15 @@
+-- Migration 14 DOWN: Remove soft scoring
+
+DROP INDEX IF EXISTS idx_match_result_pending_final_score;
+
+ALTER TABLE match_result
+    DROP COLUMN IF EXISTS final_score,
+    DROP COLUMN IF EXISTS ai_score,
+    DROP COLUMN IF EXISTS soft_score;
+
+ALTER TABLE match_config
+    DROP COLUMN IF EXISTS score_weight_ai,
+    DROP COLUMN IF EXISTS score_weight_traits,
+    DROP COLUMN IF EXISTS score_weight_height,
+    DROP COLUMN IF EXISTS score_weight_age,
+    DROP COLUMN IF EXISTS score_weight_distance;
@@ -0,0 +1,31 @@
+-- Migration 14: Soft scoring and weighted ranking
+-- Pairs passing the hard qualifiers get a local soft score (distance, age gap,
+-- height delta, trait similarity), which is blended with the AI score into the
+-- final score used to rank pending matches for drops.
+
+--------------------------------------------------------------------------------
+-- MATCH CONFIG: score weights
+--------------------------------------------------------------------------------
+
+ALTER TABLE match_config
+    ADD COLUMN score_weight_distance DECIMAL(5, 2) NOT NULL DEFAULT 0.25,
+    ADD COLUMN score_weight_age      DECIMAL(5, 2) NOT NULL DEFAULT 0.20,
+    ADD COLUMN score_weight_height   DECIMAL(5, 2) NOT NULL DEFAULT 0.10,
+    ADD COLUMN score_weight_traits   DECIMAL(5, 2) NOT NULL DEFAULT 0.45,
+    ADD COLUMN score_weight_ai       DECIMAL(5, 2) NOT NULL DEFAULT 0.60;
+
+COMMENT ON COLUMN match_config.score_weight_ai IS 'Share of the AI score in the final score, the soft score gets the rest (0..1)';
+
+--------------------------------------------------------------------------------
+-- MATCH RESULT: scores
+--------------------------------------------------------------------------------
+
+ALTER TABLE match_result
+    ADD COLUMN soft_score  FLOAT,
+    ADD COLUMN ai_score    FLOAT,
+    ADD COLUMN final_score FLOAT;
+
+-- Drops pick the best pending match per user
+CREATE INDEX idx_match_result_pending_final_score
+    ON match_result (final_score DESC NULLS LAST, created_at)
+    WHERE is_approved = TRUE AND is_dropped = FALSE;
@@ -0,0 +1,6 @@
+-- Migration 15 DOWN: Remove qualitative match result cache
+
+ALTER TABLE match_config
+    DROP COLUMN IF EXISTS qualitative_cache_ttl_hours;
+
+DROP TABLE IF EXISTS qualitative_match_cache;
@@ -0,0 +1,44 @@
+-- Migration 15: Qualitative match result cache
+-- Caches the qualitative matcher's result per user pair, keyed on a hash of
+-- both profiles and the matcher version, so unchanged pairs skip the AI call.
+-- A profile change changes the hash, so stale entries are never read.
+
+--------------------------------------------------------------------------------
+-- QUALITATIVE MATCH CACHE
+--------------------------------------------------------------------------------
+
+CREATE TABLE qualitative_match_cache
+(
+    id              UUID PRIMARY KEY         DEFAULT gen_random_uuid(),
+
+    -- Pair, sorted so user_a_ref_id < user_b_ref_id
+    user_a_ref_id   UUID                     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    user_b_ref_id   UUID                     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+
+    -- The matcher isn't symmetric, so each orientation has its own entry
+    romeo_ref_id    UUID                     NOT NULL,
+
+    -- Cache key
+    profile_hash    VARCHAR(64)              NOT NULL,
+    matcher_version VARCHAR(255)             NOT NULL,
+
+    result          JSONB                    NOT NULL,
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CHECK (user_a_ref_id < user_b_ref_id),
+    CHECK (romeo_ref_id IN (user_a_ref_id, user_b_ref_id)),
+    UNIQUE (user_a_ref_id, user_b_ref_id, romeo_ref_id, matcher_version)
+);
+
+COMMENT ON COLUMN qualitative_match_cache.romeo_ref_id IS 'The pair user the result was computed as Romeo';
+COMMENT ON COLUMN qualitative_match_cache.profile_hash IS 'sha256 of both profile payloads, a mismatch means a profile changed';
+
+--------------------------------------------------------------------------------
+-- MATCH CONFIG: cache TTL
+--------------------------------------------------------------------------------
+
+ALTER TABLE match_config
+    ADD COLUMN qualitative_cache_ttl_hours INTEGER NOT NULL DEFAULT 168;
+
+COMMENT ON COLUMN match_config.qualitative_cache_ttl_hours IS 'How long a cached qualitative result is reused, 0 disables the cache';
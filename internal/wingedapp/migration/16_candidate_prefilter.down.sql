@@ -0,0 +1,7 @@
+-- Migration 16 DOWN: Remove candidate prefilter
+
+DROP INDEX IF EXISTS idx_match_result_receiver;
+DROP INDEX IF EXISTS idx_match_result_initiator_receiver;
+
+ALTER TABLE match_config
+    DROP COLUMN IF EXISTS candidate_prefilter;
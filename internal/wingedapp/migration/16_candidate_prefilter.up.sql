@@ -0,0 +1,20 @@
+-- Migration 16: Candidate prefilter for ingestion
+-- Ingestion buckets users into a grid sized by the widest distance ring, and
+-- only creates match results for nearby pairs with compatible dating
+-- preferences, instead of every pair of active users.
+
+--------------------------------------------------------------------------------
+-- MATCH CONFIG: prefilter toggle
+--------------------------------------------------------------------------------
+
+ALTER TABLE match_config
+    ADD COLUMN candidate_prefilter BOOLEAN NOT NULL DEFAULT TRUE;
+
+COMMENT ON COLUMN match_config.candidate_prefilter IS 'Only pair users that can pass the distance and dating preference qualifiers';
+
+--------------------------------------------------------------------------------
+-- MATCH RESULT: existing pair lookups per user
+--------------------------------------------------------------------------------
+
+CREATE INDEX idx_match_result_initiator_receiver ON match_result (initiator_user_ref_id, receiver_user_ref_id);
+CREATE INDEX idx_match_result_receiver ON match_result (receiver_user_ref_id);
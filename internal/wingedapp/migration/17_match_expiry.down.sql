@@ -0,0 +1,7 @@
+-- Migration 17 DOWN: Remove match expiry
+
+DROP INDEX IF EXISTS idx_match_result_dropped_expires_at;
+
+ALTER TABLE match_result
+    DROP COLUMN IF EXISTS receiver_expiry_outcome,
+    DROP COLUMN IF EXISTS initiator_expiry_outcome;
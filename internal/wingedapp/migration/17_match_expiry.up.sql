@@ -0,0 +1,22 @@
+-- Migration 17: Match expiry
+-- Drops stamp expires_at with match_config.match_expiration_hours. A sweeper
+-- expires dropped matches left undecided past it, and records what each side
+-- did: ignored the match, passed, or proposed to a partner who didn't.
+
+--------------------------------------------------------------------------------
+-- MATCH RESULT: expiry outcomes
+--------------------------------------------------------------------------------
+
+ALTER TABLE match_result
+    ADD COLUMN initiator_expiry_outcome VARCHAR(16)
+        CHECK (initiator_expiry_outcome IS NULL OR initiator_expiry_outcome IN ('Ignored', 'Passed', 'Proposed')),
+    ADD COLUMN receiver_expiry_outcome  VARCHAR(16)
+        CHECK (receiver_expiry_outcome IS NULL OR receiver_expiry_outcome IN ('Ignored', 'Passed', 'Proposed'));
+
+COMMENT ON COLUMN match_result.initiator_expiry_outcome IS 'What the initiator did by expiry: Ignored (no action), Passed or Proposed';
+COMMENT ON COLUMN match_result.receiver_expiry_outcome IS 'What the receiver did by expiry: Ignored (no action), Passed or Proposed';
+
+-- The sweeper looks up dropped matches past their expiry
+CREATE INDEX idx_match_result_dropped_expires_at
+    ON match_result (expires_at)
+    WHERE is_dropped = TRUE AND is_expired = FALSE;
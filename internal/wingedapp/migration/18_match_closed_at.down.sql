@@ -0,0 +1,4 @@
+-- Migration 18 DOWN: Remove match closed at
+
+ALTER TABLE match_result
+    DROP COLUMN IF EXISTS closed_at;
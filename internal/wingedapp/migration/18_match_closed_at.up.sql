@@ -0,0 +1,17 @@
+-- Migration 18: Match closed at
+-- The closed-match re-match cooldown counts from when the match was closed.
+-- updated_at moves on every later write, so closing stamps its own time.
+
+--------------------------------------------------------------------------------
+-- MATCH RESULT: closed at
+--------------------------------------------------------------------------------
+
+ALTER TABLE match_result
+    ADD COLUMN closed_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN match_result.closed_at IS 'When match_lifecycle_status became Closed';
+
+-- Matches closed before this migration: the last update is the best guess
+UPDATE match_result
+SET closed_at = COALESCE(updated_at, created_at)
+WHERE match_lifecycle_status = 'Closed';
@@ -0,0 +1,3 @@
+-- Migration 19 DOWN: Remove match drop runs
+
+DROP TABLE IF EXISTS match_drop_run;
@@ -0,0 +1,20 @@
+-- Migration 19: Match drop runs
+-- Each timezone bucket gets its drops at match_config.drop_hours in its local
+-- time. Every drop a bucket got is recorded, so a runner that missed the exact
+-- minute still drops late, and a drop never runs twice.
+
+--------------------------------------------------------------------------------
+-- MATCH DROP RUN
+--------------------------------------------------------------------------------
+
+CREATE TABLE match_drop_run
+(
+    utc_offset SMALLINT    NOT NULL CHECK (utc_offset BETWEEN -12 AND 12),
+    drop_at    TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (utc_offset, drop_at)
+);
+
+COMMENT ON TABLE match_drop_run IS 'Drops each UTC offset bucket got, one row per bucket and drop hour';
+COMMENT ON COLUMN match_drop_run.drop_at IS 'The bucket''s drop hour the drop was for; it may have run later';
@@ -0,0 +1,5 @@
+-- Migration 20 DOWN: Remove stale chat nudges
+
+DROP INDEX IF EXISTS idx_chat_message_match_last;
+
+DROP TABLE IF EXISTS match_chat_nudge;
@@ -0,0 +1,29 @@
+-- Migration 20: Stale chat nudges
+-- A periodic job nudges the quiet user of a mutually proposed match whose
+-- chat has gone quiet for match_config.stale_chat_nudge hours, and after
+-- stale_chat_agent_setup hours offers to let their agent schedule the date.
+-- Each nudge is recorded once per quiet window, so reruns don't repeat it.
+
+--------------------------------------------------------------------------------
+-- MATCH CHAT NUDGE
+--------------------------------------------------------------------------------
+
+CREATE TABLE match_chat_nudge
+(
+    id                  UUID PRIMARY KEY     DEFAULT gen_random_uuid(),
+    match_result_id     UUID        NOT NULL REFERENCES match_result (id) ON DELETE CASCADE,
+    user_ref_id         UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    nudge_type          VARCHAR(32) NOT NULL
+        CHECK (nudge_type IN ('Nudge', 'AgentSetup')),
+    quiet_since         TIMESTAMPTZ NOT NULL,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (match_result_id, user_ref_id, nudge_type, quiet_since)
+);
+
+COMMENT ON TABLE match_chat_nudge IS 'Nudges sent to the quiet user of a stale match chat';
+COMMENT ON COLUMN match_chat_nudge.quiet_since IS 'Last message time (or chat unlock) the nudge was sent for; a new message starts a new window';
+
+-- The nudge job finds the last message of each chat
+CREATE INDEX idx_chat_message_match_last ON match_chat_message (match_result_id, created_at DESC)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,13 @@
+-- Migration 21 DOWN: Remove ingestion run records
+
+DROP TABLE IF EXISTS match_set_run;
+
+DROP INDEX IF EXISTS idx_match_result_set_ingestion;
+
+ALTER TABLE match_result
+    DROP COLUMN IF EXISTS ingested_at,
+    DROP COLUMN IF EXISTS ingestion_error,
+    DROP COLUMN IF EXISTS ingestion_status;
+
+ALTER TABLE match_set
+    DROP COLUMN IF EXISTS ingestion_status;
@@ -0,0 +1,61 @@
+-- Migration 21: Ingestion run records
+-- RunIngestionSet records each run of a match set, and the processing state of
+-- each of its pairs, so a run that died mid-batch resumes from the pairs it
+-- hadn't processed yet, and a failed pair keeps its error.
+
+--------------------------------------------------------------------------------
+-- MATCH SET: ingestion status
+--------------------------------------------------------------------------------
+
+ALTER TABLE match_set
+    ADD COLUMN ingestion_status VARCHAR(16) NOT NULL DEFAULT 'Pending'
+        CHECK (ingestion_status IN ('Pending', 'Running', 'Completed', 'Failed'));
+
+COMMENT ON COLUMN match_set.ingestion_status IS 'Status of the latest ingestion run; Failed when some pairs failed and a rerun is due';
+
+--------------------------------------------------------------------------------
+-- MATCH RESULT: ingestion state
+--------------------------------------------------------------------------------
+
+ALTER TABLE match_result
+    ADD COLUMN ingestion_status VARCHAR(16) NOT NULL DEFAULT 'Pending'
+        CHECK (ingestion_status IN ('Pending', 'Processed', 'Failed')),
+    ADD COLUMN ingestion_error  TEXT,
+    ADD COLUMN ingested_at      TIMESTAMPTZ;
+
+COMMENT ON COLUMN match_result.ingestion_error IS 'Error of the last failed attempt to process the pair';
+
+-- Pairs processed before runs were recorded are done
+UPDATE match_result
+SET ingestion_status = 'Processed',
+    ingested_at      = COALESCE(updated_at, created_at)
+WHERE qualifier_results IS NOT NULL;
+
+-- A run reads the pairs of its set
+CREATE INDEX idx_match_result_set_ingestion ON match_result (match_set_ref_id, ingestion_status);
+
+--------------------------------------------------------------------------------
+-- MATCH SET RUN
+--------------------------------------------------------------------------------
+
+CREATE TABLE match_set_run
+(
+    id               UUID PRIMARY KEY     DEFAULT gen_random_uuid(),
+    match_set_ref_id UUID        NOT NULL REFERENCES match_set (id) ON DELETE CASCADE,
+    status           VARCHAR(16) NOT NULL DEFAULT 'Running'
+        CHECK (status IN ('Running', 'Completed', 'Failed', 'Abandoned')),
+    pairs            INT         NOT NULL DEFAULT 0,
+    processed        INT         NOT NULL DEFAULT 0,
+    failed           INT         NOT NULL DEFAULT 0,
+    skipped          INT         NOT NULL DEFAULT 0,
+    last_error       TEXT,
+    started_at       TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at      TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE match_set_run IS 'One row per RunIngestionSet run of a match set';
+COMMENT ON COLUMN match_set_run.skipped IS 'Pairs already processed by an earlier run';
+COMMENT ON COLUMN match_set_run.status IS 'Abandoned when a later run found it still Running, i.e. its process died';
+
+CREATE INDEX idx_match_set_run_set ON match_set_run (match_set_ref_id, started_at DESC);
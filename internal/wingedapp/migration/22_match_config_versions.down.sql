@@ -0,0 +1,6 @@
+-- Migration 22 DOWN: Remove match config versions
+
+ALTER TABLE match_set
+    DROP COLUMN IF EXISTS match_config_version_ref_id;
+
+DROP TABLE IF EXISTS match_config_version;
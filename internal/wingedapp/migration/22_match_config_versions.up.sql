@@ -0,0 +1,83 @@
+-- Migration 22: Match config versions
+-- Every match config update is kept as an immutable version. A match set
+-- references the version it was created with, so its pairs are qualified
+-- against that config even if the live one changes mid-run, and an admin can
+-- diff two versions or roll the live config back to an earlier one.
+-- New match_config columns must be added to match_config_version as well.
+
+--------------------------------------------------------------------------------
+-- MATCH CONFIG VERSION
+--------------------------------------------------------------------------------
+
+CREATE TABLE match_config_version
+(
+    id                          UUID PRIMARY KEY       DEFAULT gen_random_uuid(),
+    version                     INTEGER       NOT NULL UNIQUE,
+    rolled_back_from            INTEGER,
+    age_range_start             INTEGER,
+    age_range_end               INTEGER,
+    age_range_woman_older_by    INTEGER       NOT NULL,
+    age_range_man_older_by      INTEGER       NOT NULL,
+    height_male_greater_by_cm   DECIMAL(5, 2) NOT NULL,
+    location_radius_km          DECIMAL(5, 2) NOT NULL,
+    location_adaptive_expansion INTEGER[]     NOT NULL,
+    match_hours                 TEXT[]        NOT NULL,
+    drop_hours                  TEXT[]        NOT NULL,
+    drop_hours_utc              TEXT[]        NOT NULL,
+    stale_chat_nudge            INTEGER       NOT NULL,
+    stale_chat_agent_setup      INTEGER       NOT NULL,
+    match_expiration_hours      INTEGER       NOT NULL,
+    match_block_declined        INTEGER       NOT NULL,
+    match_block_ignored         INTEGER       NOT NULL,
+    match_block_closed          INTEGER       NOT NULL,
+    score_range_start           DECIMAL(5, 2) NOT NULL,
+    score_range_end             DECIMAL(5, 2) NOT NULL,
+    qualifiers                  TEXT[]        NOT NULL,
+    score_weight_distance       DECIMAL(5, 2) NOT NULL,
+    score_weight_age            DECIMAL(5, 2) NOT NULL,
+    score_weight_height         DECIMAL(5, 2) NOT NULL,
+    score_weight_traits         DECIMAL(5, 2) NOT NULL,
+    score_weight_ai             DECIMAL(5, 2) NOT NULL,
+    qualitative_cache_ttl_hours INTEGER       NOT NULL,
+    candidate_prefilter         BOOLEAN       NOT NULL,
+    created_at                  TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE match_config_version IS 'Immutable snapshots of match_config, one per update';
+COMMENT ON COLUMN match_config_version.rolled_back_from IS 'The version this one restored, when it was created by a rollback';
+
+-- Version numbers come from a sequence, so concurrent config updates never
+-- pick the same number. A rolled back update leaves a gap.
+CREATE SEQUENCE match_config_version_version_seq OWNED BY match_config_version.version;
+
+ALTER TABLE match_config_version
+    ALTER COLUMN version SET DEFAULT nextval('match_config_version_version_seq');
+
+-- The config as it is now is version 1
+INSERT INTO match_config_version (age_range_start, age_range_end, age_range_woman_older_by,
+                                  age_range_man_older_by, height_male_greater_by_cm, location_radius_km,
+                                  location_adaptive_expansion, match_hours, drop_hours, drop_hours_utc,
+                                  stale_chat_nudge, stale_chat_agent_setup, match_expiration_hours,
+                                  match_block_declined, match_block_ignored, match_block_closed,
+                                  score_range_start, score_range_end, qualifiers, score_weight_distance,
+                                  score_weight_age, score_weight_height, score_weight_traits, score_weight_ai,
+                                  qualitative_cache_ttl_hours, candidate_prefilter)
+SELECT age_range_start, age_range_end, age_range_woman_older_by,
+       age_range_man_older_by, height_male_greater_by_cm, location_radius_km,
+       location_adaptive_expansion, match_hours, drop_hours, drop_hours_utc,
+       stale_chat_nudge, stale_chat_agent_setup, match_expiration_hours,
+       match_block_declined, match_block_ignored, match_block_closed,
+       score_range_start, score_range_end, qualifiers, score_weight_distance,
+       score_weight_age, score_weight_height, score_weight_traits, score_weight_ai,
+       qualitative_cache_ttl_hours, candidate_prefilter
+FROM match_config
+LIMIT 1;
+
+--------------------------------------------------------------------------------
+-- MATCH SET: config version
+--------------------------------------------------------------------------------
+
+ALTER TABLE match_set
+    ADD COLUMN match_config_version_ref_id UUID REFERENCES match_config_version (id);
+
+COMMENT ON COLUMN match_set.match_config_version_ref_id IS 'Config version the set was created with; NULL for sets created before versions';
@@ -0,0 +1,4 @@
+-- Migration 23 DOWN: Remove hard qualifier results
+
+ALTER TABLE match_result
+    DROP COLUMN IF EXISTS hard_qualifier_results;
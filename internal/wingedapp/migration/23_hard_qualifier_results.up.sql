@@ -0,0 +1,19 @@
+-- Migration 23: Hard qualifier results kept next to the qualitative result
+-- qualifier_results holds the hard qualifier results of a pair that failed
+-- them, but the qualitative result of a pair that passed, so the hard
+-- qualifier telemetry of matched pairs was lost. It's now kept for every pair.
+
+--------------------------------------------------------------------------------
+-- MATCH RESULT: hard qualifier results
+--------------------------------------------------------------------------------
+
+ALTER TABLE match_result
+    ADD COLUMN hard_qualifier_results JSONB;
+
+COMMENT ON COLUMN match_result.hard_qualifier_results IS 'Outcome and telemetry of each hard qualifier, keyed by qualifier name';
+
+-- Pairs that failed the hard qualifiers only have those results
+UPDATE match_result
+SET hard_qualifier_results = qualifier_results
+WHERE matched_qualitatively = FALSE
+  AND qualifier_results IS NOT NULL;
@@ -0,0 +1,75 @@
+-- Migration 24: RevenueCat subscription lifecycle
+-- Every RevenueCat webhook is stored as received, with the outcome of
+-- processing it, so a failed or skipped event can be replayed.
+-- The WingedX 3 and 6 month plans gain their payment action types.
+
+--------------------------------------------------------------------------------
+-- ADD WINGEDX 3 AND 6 MONTH ACTION TYPES
+--------------------------------------------------------------------------------
+
+ALTER TABLE wings_ecn_action_log
+    DROP CONSTRAINT IF EXISTS wings_ecn_action_log_action_log_type_check;
+
+ALTER TABLE wings_ecn_action_log
+    ADD CONSTRAINT wings_ecn_action_log_action_log_type_check
+    CHECK (action_log_type IN (
+        'Daily Check-In', 'Send Message',
+        'WingedX - Weekly Payment', 'WingedX - Monthly Payment',
+        'WingedX - 3 Month Payment', 'WingedX - 6 Month Payment',
+        'Winged+ - Weekly Payment', 'Winged+ - Monthly Payment',
+        'Winged+ - 3 Month Payment', 'Winged+ - 6 Month Payment',
+        'Top Up - Mini', 'Top Up - Boost', 'Top Up - Premium',
+        'Referral - Friend Signup', 'Referral - Friend Complete',
+        'Attend a Date',
+        'Streak - 7 Day Milestone', 'Streak - 30 Day Milestone'
+    ));
+
+ALTER TABLE wings_ecn_transaction
+    DROP CONSTRAINT IF EXISTS wings_ecn_transaction_action_log_type_check;
+
+ALTER TABLE wings_ecn_transaction
+    ADD CONSTRAINT wings_ecn_transaction_action_log_type_check
+    CHECK (action_log_type IN (
+        'Daily Check-In', 'Send Message',
+        'WingedX - Weekly Payment', 'WingedX - Monthly Payment',
+        'WingedX - 3 Month Payment', 'WingedX - 6 Month Payment',
+        'Winged+ - Weekly Payment', 'Winged+ - Monthly Payment',
+        'Winged+ - 3 Month Payment', 'Winged+ - 6 Month Payment',
+        'Top Up - Mini', 'Top Up - Boost', 'Top Up - Premium',
+        'Referral - Friend Signup', 'Referral - Friend Complete',
+        'Attend a Date',
+        'Streak - 7 Day Milestone', 'Streak - 30 Day Milestone'
+    ));
+
+--------------------------------------------------------------------------------
+-- REVENUECAT EVENT
+--------------------------------------------------------------------------------
+
+CREATE TABLE revenuecat_event
+(
+    id             UUID PRIMARY KEY     DEFAULT gen_random_uuid(),
+    event_id       VARCHAR(255) NOT NULL UNIQUE,
+    event_type     VARCHAR(64)  NOT NULL,
+    app_user_id    VARCHAR(255) NOT NULL DEFAULT '',
+    product_id     VARCHAR(255) NOT NULL DEFAULT '',
+    transaction_id VARCHAR(255) NOT NULL DEFAULT '',
+    environment    VARCHAR(32)  NOT NULL DEFAULT '',
+    payload        JSONB        NOT NULL,
+    status         VARCHAR(16)  NOT NULL DEFAULT 'Received'
+        CHECK (status IN ('Received', 'Processed', 'Ignored', 'Failed')),
+    action         VARCHAR(64),
+    reason         VARCHAR(255),
+    error          TEXT,
+    event_at       TIMESTAMPTZ,
+    processed_at   TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE revenuecat_event IS 'Raw RevenueCat webhooks, kept for audit and replay';
+COMMENT ON COLUMN revenuecat_event.event_id IS 'RevenueCat event id, a redelivered webhook keeps its row';
+COMMENT ON COLUMN revenuecat_event.transaction_id IS 'Store transaction of the event, a refund is matched to its purchase by it';
+COMMENT ON COLUMN revenuecat_event.status IS 'Ignored for events with nothing to do, e.g. sandbox events when they are not processed';
+
+-- Replays read events by status, in the order they happened
+CREATE INDEX idx_revenuecat_event_status ON revenuecat_event (status, event_at);
+CREATE INDEX idx_revenuecat_event_transaction ON revenuecat_event (transaction_id);
@@ -0,0 +1,4 @@
+-- Migration 25 DOWN: Remove premium sync ordering
+
+ALTER TABLE wings_ecn_user_totals
+    DROP COLUMN IF EXISTS premium_synced_at;
@@ -0,0 +1,9 @@
+-- Migration 25: Order premium changes by the store's event time
+-- RevenueCat can deliver events out of order, e.g. a RENEWAL after the
+-- EXPIRATION that followed it. A premium change from an event is applied only
+-- when the event is newer than the one the current expiry came from.
+
+ALTER TABLE wings_ecn_user_totals
+    ADD COLUMN premium_synced_at TIMESTAMPTZ DEFAULT NULL;
+
+COMMENT ON COLUMN wings_ecn_user_totals.premium_synced_at IS 'Time of the store event premium_expires_in was last set from';
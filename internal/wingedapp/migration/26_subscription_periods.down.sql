@@ -0,0 +1,9 @@
+-- Migration 26 DOWN: Remove subscription period details
+
+DROP INDEX IF EXISTS idx_wings_ecn_user_subscription_plan_action_log;
+DROP INDEX IF EXISTS idx_wings_ecn_user_subscription_plan_user;
+
+ALTER TABLE wings_ecn_user_subscription_plan
+    DROP COLUMN IF EXISTS end_reason,
+    DROP COLUMN IF EXISTS wings_granted,
+    DROP COLUMN IF EXISTS action_log_ref_id;
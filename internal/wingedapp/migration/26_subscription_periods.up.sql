@@ -0,0 +1,29 @@
+-- Migration 26: Subscription periods
+-- Every Winged+/WingedX payment records the period it buys in
+-- wings_ecn_user_subscription_plan. A plan change (upgrade or downgrade) ends
+-- the current period early, and the unused share of the wings it granted is
+-- credited towards the new plan's wings.
+
+--------------------------------------------------------------------------------
+-- PERIOD DETAILS
+--------------------------------------------------------------------------------
+
+ALTER TABLE wings_ecn_user_subscription_plan
+    ADD COLUMN action_log_ref_id UUID        DEFAULT NULL REFERENCES wings_ecn_action_log (id),
+    ADD COLUMN wings_granted     INTEGER     NOT NULL DEFAULT 0,
+    ADD COLUMN end_reason        VARCHAR(16) DEFAULT NULL
+        CHECK (end_reason IN ('Upgrade', 'Downgrade'));
+
+COMMENT ON COLUMN wings_ecn_user_subscription_plan.action_log_ref_id IS 'The payment that bought the period';
+COMMENT ON COLUMN wings_ecn_user_subscription_plan.wings_granted IS 'Wings credited for the period, after proration';
+COMMENT ON COLUMN wings_ecn_user_subscription_plan.end_reason IS 'Why the period ended before its end_date was originally due';
+
+--------------------------------------------------------------------------------
+-- INDEXES
+--------------------------------------------------------------------------------
+
+CREATE INDEX idx_wings_ecn_user_subscription_plan_user
+    ON wings_ecn_user_subscription_plan (user_id, start_date);
+
+CREATE INDEX idx_wings_ecn_user_subscription_plan_action_log
+    ON wings_ecn_user_subscription_plan (action_log_ref_id);
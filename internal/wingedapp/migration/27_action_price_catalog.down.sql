@@ -0,0 +1,41 @@
+-- Migration 27 DOWN: Remove the action price catalog
+
+DROP TABLE IF EXISTS wings_ecn_action_price;
+
+--------------------------------------------------------------------------------
+-- RESTORE ACTION TYPE CONSTRAINTS (without spend actions)
+--------------------------------------------------------------------------------
+
+ALTER TABLE wings_ecn_action_log
+    DROP CONSTRAINT IF EXISTS wings_ecn_action_log_action_log_type_check;
+
+ALTER TABLE wings_ecn_action_log
+    ADD CONSTRAINT wings_ecn_action_log_action_log_type_check
+    CHECK (action_log_type IN (
+        'Daily Check-In', 'Send Message',
+        'WingedX - Weekly Payment', 'WingedX - Monthly Payment',
+        'WingedX - 3 Month Payment', 'WingedX - 6 Month Payment',
+        'Winged+ - Weekly Payment', 'Winged+ - Monthly Payment',
+        'Winged+ - 3 Month Payment', 'Winged+ - 6 Month Payment',
+        'Top Up - Mini', 'Top Up - Boost', 'Top Up - Premium',
+        'Referral - Friend Signup', 'Referral - Friend Complete',
+        'Attend a Date',
+        'Streak - 7 Day Milestone', 'Streak - 30 Day Milestone'
+    ));
+
+ALTER TABLE wings_ecn_transaction
+    DROP CONSTRAINT IF EXISTS wings_ecn_transaction_action_log_type_check;
+
+ALTER TABLE wings_ecn_transaction
+    ADD CONSTRAINT wings_ecn_transaction_action_log_type_check
+    CHECK (action_log_type IN (
+        'Daily Check-In', 'Send Message',
+        'WingedX - Weekly Payment', 'WingedX - Monthly Payment',
+        'WingedX - 3 Month Payment', 'WingedX - 6 Month Payment',
+        'Winged+ - Weekly Payment', 'Winged+ - Monthly Payment',
+        'Winged+ - 3 Month Payment', 'Winged+ - 6 Month Payment',
+        'Top Up - Mini', 'Top Up - Boost', 'Top Up - Premium',
+        'Referral - Friend Signup', 'Referral - Friend Complete',
+        'Attend a Date',
+        'Streak - 7 Day Milestone', 'Streak - 30 Day Milestone'
+    ));
@@ -0,0 +1,86 @@
+-- Migration 27: Action price catalog
+-- What spending actions cost, in wings, priced per tier: a cost, with
+-- optional overrides for free users, Winged+ and WingedX subscribers. A price
+-- applies from its effective_from, so a price change is scheduled by
+-- inserting a row, without a deploy. The latest effective row applies.
+
+--------------------------------------------------------------------------------
+-- ADD SPEND ACTION TYPES
+--------------------------------------------------------------------------------
+
+ALTER TABLE wings_ecn_action_log
+    DROP CONSTRAINT IF EXISTS wings_ecn_action_log_action_log_type_check;
+
+ALTER TABLE wings_ecn_action_log
+    ADD CONSTRAINT wings_ecn_action_log_action_log_type_check
+    CHECK (action_log_type IN (
+        'Daily Check-In', 'Send Message',
+        'WingedX - Weekly Payment', 'WingedX - Monthly Payment',
+        'WingedX - 3 Month Payment', 'WingedX - 6 Month Payment',
+        'Winged+ - Weekly Payment', 'Winged+ - Monthly Payment',
+        'Winged+ - 3 Month Payment', 'Winged+ - 6 Month Payment',
+        'Top Up - Mini', 'Top Up - Boost', 'Top Up - Premium',
+        'Referral - Friend Signup', 'Referral - Friend Complete',
+        'Attend a Date',
+        'Streak - 7 Day Milestone', 'Streak - 30 Day Milestone',
+        'Propose Match', 'Reschedule Date', 'Venue Change', 'Priority Drop', 'Extra AI Message'
+    ));
+
+ALTER TABLE wings_ecn_transaction
+    DROP CONSTRAINT IF EXISTS wings_ecn_transaction_action_log_type_check;
+
+ALTER TABLE wings_ecn_transaction
+    ADD CONSTRAINT wings_ecn_transaction_action_log_type_check
+    CHECK (action_log_type IN (
+        'Daily Check-In', 'Send Message',
+        'WingedX - Weekly Payment', 'WingedX - Monthly Payment',
+        'WingedX - 3 Month Payment', 'WingedX - 6 Month Payment',
+        'Winged+ - Weekly Payment', 'Winged+ - Monthly Payment',
+        'Winged+ - 3 Month Payment', 'Winged+ - 6 Month Payment',
+        'Top Up - Mini', 'Top Up - Boost', 'Top Up - Premium',
+        'Referral - Friend Signup', 'Referral - Friend Complete',
+        'Attend a Date',
+        'Streak - 7 Day Milestone', 'Streak - 30 Day Milestone',
+        'Propose Match', 'Reschedule Date', 'Venue Change', 'Priority Drop', 'Extra AI Message'
+    ));
+
+--------------------------------------------------------------------------------
+-- ACTION PRICE
+--------------------------------------------------------------------------------
+
+CREATE TABLE wings_ecn_action_price
+(
+    id               UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    action_type      VARCHAR(64) NOT NULL,
+    wings_cost       INTEGER     NOT NULL CHECK (wings_cost >= 0),
+    free_cost        INTEGER     DEFAULT NULL CHECK (free_cost >= 0),
+    winged_plus_cost INTEGER     DEFAULT NULL CHECK (winged_plus_cost >= 0),
+    wingedx_cost     INTEGER     DEFAULT NULL CHECK (wingedx_cost >= 0),
+    effective_from   TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    is_active        INTEGER              DEFAULT 1,
+    created_date     TIMESTAMP            DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (action_type, effective_from)
+);
+
+COMMENT ON TABLE wings_ecn_action_price IS 'Wings cost of spending actions, the latest effective row per action applies';
+COMMENT ON COLUMN wings_ecn_action_price.wings_cost IS 'Cost for tiers without an override';
+COMMENT ON COLUMN wings_ecn_action_price.free_cost IS 'Cost for users without a subscription, overrides wings_cost';
+COMMENT ON COLUMN wings_ecn_action_price.winged_plus_cost IS 'Cost for Winged+ subscribers, overrides wings_cost';
+COMMENT ON COLUMN wings_ecn_action_price.wingedx_cost IS 'Cost for WingedX subscribers, overrides wings_cost';
+
+CREATE INDEX idx_wings_ecn_action_price_effective
+    ON wings_ecn_action_price (action_type, effective_from DESC);
+
+--------------------------------------------------------------------------------
+-- SEED PRICES
+--------------------------------------------------------------------------------
+
+-- Send Message is charged every WINGS_ECON_INCREMENT_THRESH_SEND_MESSAGE messages.
+-- WingedX spends no wings, as premium access did before the catalog.
+INSERT INTO wings_ecn_action_price (action_type, wings_cost, wingedx_cost, effective_from)
+VALUES ('Send Message', 1, 0, '2025-01-01'),
+       ('Propose Match', 1, 0, '2025-01-01'),
+       ('Reschedule Date', 1, 0, '2025-01-01'),
+       ('Venue Change', 1, 0, '2025-01-01'),
+       ('Priority Drop', 2, 0, '2025-01-01'),
+       ('Extra AI Message', 1, 0, '2025-01-01');